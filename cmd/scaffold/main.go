@@ -0,0 +1,36 @@
+// Command scaffold generates a new provider project:
+//
+//	go run github.com/cloudquery/cq-provider-sdk/cmd/scaffold -name example -module github.com/me/cq-provider-example
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cloudquery/cq-provider-sdk/scaffold"
+)
+
+func main() {
+	var (
+		opts scaffold.Options
+		dir  string
+	)
+	flag.StringVar(&opts.Name, "name", "", "name of the provider, i.e aws")
+	flag.StringVar(&opts.Module, "module", "", "go module path of the provider, defaults to github.com/cloudquery/cq-provider-<name>")
+	flag.StringVar(&dir, "dir", "", "directory to generate the provider in, defaults to cq-provider-<name>")
+	flag.BoolVar(&opts.Force, "force", false, "overwrite existing files")
+	flag.Parse()
+
+	if opts.Module == "" {
+		opts.Module = "github.com/cloudquery/cq-provider-" + opts.Name
+	}
+	if dir == "" {
+		dir = "cq-provider-" + opts.Name
+	}
+	if err := scaffold.Generate(dir, opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("provider %s generated in %s, run `go mod tidy` in it to add the SDK dependency\n", opts.Name, dir)
+}
@@ -2,6 +2,7 @@ package cqproto
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/cqproto/internal"
@@ -39,6 +40,7 @@ func (g GRPCClient) GetProviderSchema(ctx context.Context, _ *GetProviderSchemaR
 		Name:           res.GetName(),
 		Version:        res.GetVersion(),
 		ResourceTables: tablesFromProto(res.GetResourceTables()),
+		Diagnostics:    diagnosticsFromProto("", res.GetDiagnostics()),
 	}
 
 	return resp, nil
@@ -62,11 +64,14 @@ func (g GRPCClient) ConfigureProvider(ctx context.Context, request *ConfigurePro
 	res, err := g.client.ConfigureProvider(ctx, &internal.ConfigureProvider_Request{
 		CloudqueryVersion: request.CloudQueryVersion,
 		Connection: &internal.ConnectionDetails{
-			Type: internal.ConnectionType_POSTGRES,
-			Dsn:  request.Connection.DSN,
+			Type:           internal.ConnectionType_POSTGRES,
+			StorageType:    request.Connection.Type,
+			Dsn:            request.Connection.DSN,
+			ColumnPolicies: request.Connection.ColumnPolicies,
 		},
-		Config: request.Config,
-		Format: internal.ConfigFormat_YAML,
+		Config:       request.Config,
+		Format:       configFormatToProto(request.Format),
+		FeatureFlags: request.FeatureFlags,
 	})
 	if err != nil {
 		return nil, err
@@ -88,6 +93,19 @@ func (g GRPCClient) FetchResources(ctx context.Context, request *FetchResourcesR
 		MaxGoroutines:         request.MaxGoroutines,
 		Timeout:               int64(request.Timeout.Seconds()),
 		Metadata:              md,
+		ValidateSchema:        request.ValidateSchema,
+		CanaryRows:            request.CanaryRows,
+		ProgressInterval:      request.ProgressInterval.Milliseconds(),
+		Labels:                request.Labels,
+		ResumeFetchId:         request.ResumeFetchId,
+		DryRun:                request.DryRun,
+		Verify:                request.Verify,
+		ErrorPolicy:           internal.ErrorPolicy(request.ErrorPolicy),
+		TenantId:              request.TenantId,
+		FullRefresh:           request.FullRefresh,
+		TableFilters:          request.TableFilters,
+		SkipStaleCleanup:      request.SkipStaleCleanup,
+		BatchSize:             request.BatchSize,
 	})
 	if err != nil {
 		return nil, err
@@ -107,11 +125,25 @@ func (g GRPCFetchResponseStream) Recv() (*FetchResourcesResponse, error) {
 		Error:                       resp.GetError(),
 		PartialFetchFailedResources: partialFetchFailedResourcesFromProto(resp.GetPartialFetchFailedResources()),
 	}
+	if p := resp.GetProgress(); p != nil {
+		fr.Progress = &ResourceFetchProgress{
+			ResourceCount: p.GetResourceCount(),
+			Client:        p.GetClient(),
+			Elapsed:       time.Duration(p.GetElapsed()) * time.Millisecond,
+		}
+		return fr, nil
+	}
 	if resp.GetSummary() != nil {
 		fr.Summary = ResourceFetchSummary{
 			Status:        ResourceFetchStatus(resp.Summary.Status),
 			ResourceCount: resp.GetSummary().GetResourceCount(),
 			Diagnostics:   diagnosticsFromProto(resp.GetResource(), resp.GetSummary().Diagnostics),
+			APICalls:      apiCallsFromProto(resp.GetSummary().GetApiCalls()),
+			FeatureFlags:  resp.GetSummary().GetFeatureFlags(),
+			TableCounts:   resp.GetSummary().GetTableCounts(),
+			ErrorPolicy:   ErrorPolicy(resp.GetSummary().GetErrorPolicy()),
+			FailFastError: resp.GetSummary().GetFailFastError(),
+			Clients:       clientSummariesFromProto(resp.GetSummary().GetClients()),
 		}
 	}
 	return fr, nil
@@ -132,6 +164,86 @@ func (g GRPCClient) GetModuleInfo(ctx context.Context, request *GetModuleRequest
 	}, nil
 }
 
+func (g GRPCClient) GetFetchStatus(ctx context.Context, request *GetFetchStatusRequest) (*GetFetchStatusResponse, error) {
+	res, err := g.client.GetFetchStatus(ctx, &internal.GetFetchStatus_Request{
+		FetchId: request.FetchID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts map[diag.Severity]uint64
+	if len(res.GetDiagnosticCounts()) > 0 {
+		counts = make(map[diag.Severity]uint64, len(res.GetDiagnosticCounts()))
+		for s, c := range res.GetDiagnosticCounts() {
+			counts[diag.Severity(s)] = c
+		}
+	}
+	return &GetFetchStatusResponse{
+		Found:            res.GetFound(),
+		Done:             res.GetDone(),
+		RowsResolved:     res.GetRowsResolved(),
+		RowsStored:       res.GetRowsStored(),
+		BytesEstimated:   res.GetBytesEstimated(),
+		DiagnosticCounts: counts,
+		Error:            res.GetError(),
+	}, nil
+}
+
+func (g GRPCClient) GetFetches(ctx context.Context, request *GetFetchesRequest) (*GetFetchesResponse, error) {
+	res, err := g.client.GetFetches(ctx, &internal.GetFetches_Request{
+		Limit: request.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GetFetchesResponse{
+		Fetches:     fetchesFromProto(res.GetFetches()),
+		Diagnostics: diagnosticsFromProto("", res.GetDiagnostics()),
+	}, nil
+}
+
+func (g GRPCClient) GetEffectiveConfig(ctx context.Context, _ *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error) {
+	res, err := g.client.GetEffectiveConfig(ctx, &internal.GetEffectiveConfig_Request{})
+	if err != nil {
+		return nil, err
+	}
+	return &GetEffectiveConfigResponse{
+		Config:      res.GetConfig(),
+		Diagnostics: diagnosticsFromProto("", res.GetDiagnostics()),
+	}, nil
+}
+
+func (g GRPCClient) GetResource(ctx context.Context, request *GetResourceRequest) (*GetResourceResponse, error) {
+	md, err := msgpack.Marshal(request.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	res, err := g.client.GetResource(ctx, &internal.GetResource_Request{
+		Resource:    request.Resource,
+		PrimaryKeys: request.PrimaryKeys,
+		Metadata:    md,
+		Timeout:     int64(request.Timeout.Seconds()),
+		TenantId:    request.TenantId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GetResourceResponse{
+		ResourceCount: res.GetResourceCount(),
+		Diagnostics:   diagnosticsFromProto(request.Resource, res.GetDiagnostics()),
+	}, nil
+}
+
+func (g GRPCClient) CheckHealth(ctx context.Context, _ *CheckHealthRequest) (*CheckHealthResponse, error) {
+	res, err := g.client.CheckHealth(ctx, &internal.CheckHealth_Request{})
+	if err != nil {
+		return nil, err
+	}
+	return &CheckHealthResponse{
+		Diagnostics: diagnosticsFromProto("", res.GetDiagnostics()),
+	}, nil
+}
+
 func (g *GRPCServer) GetProviderSchema(ctx context.Context, _ *internal.GetProviderSchema_Request) (*internal.GetProviderSchema_Response, error) {
 	resp, err := g.Impl.GetProviderSchema(ctx, &GetProviderSchemaRequest{})
 	if err != nil {
@@ -141,6 +253,7 @@ func (g *GRPCServer) GetProviderSchema(ctx context.Context, _ *internal.GetProvi
 		Name:           resp.Name,
 		Version:        resp.Version,
 		ResourceTables: tablesToProto(resp.ResourceTables),
+		Diagnostics:    diagnosticsToProto(resp.Diagnostics),
 	}, nil
 }
 
@@ -159,10 +272,13 @@ func (g *GRPCServer) ConfigureProvider(ctx context.Context, request *internal.Co
 	resp, err := g.Impl.ConfigureProvider(ctx, &ConfigureProviderRequest{
 		CloudQueryVersion: request.GetCloudqueryVersion(),
 		Connection: ConnectionDetails{
-			Type: string(request.Connection.GetType()),
-			DSN:  request.Connection.GetDsn(),
+			Type:           connectionType(request.Connection),
+			DSN:            request.Connection.GetDsn(),
+			ColumnPolicies: request.Connection.GetColumnPolicies(),
 		},
-		Config: request.Config,
+		Config:       request.Config,
+		Format:       configFormatFromProto(request.GetFormat()),
+		FeatureFlags: request.GetFeatureFlags(),
 	})
 	if err != nil {
 		return nil, err
@@ -190,12 +306,35 @@ func (g *GRPCServer) FetchResources(request *internal.FetchResources_Request, se
 			MaxGoroutines:         request.MaxGoroutines,
 			Metadata:              md,
 			Timeout:               time.Duration(request.GetTimeout()) * time.Second,
+			ValidateSchema:        request.GetValidateSchema(),
+			CanaryRows:            request.GetCanaryRows(),
+			ProgressInterval:      time.Duration(request.GetProgressInterval()) * time.Millisecond,
+			Labels:                request.GetLabels(),
+			ResumeFetchId:         request.GetResumeFetchId(),
+			DryRun:                request.GetDryRun(),
+			Verify:                request.GetVerify(),
+			ErrorPolicy:           ErrorPolicy(request.GetErrorPolicy()),
+			TenantId:              request.GetTenantId(),
+			FullRefresh:           request.GetFullRefresh(),
+			TableFilters:          request.GetTableFilters(),
+			SkipStaleCleanup:      request.GetSkipStaleCleanup(),
+			BatchSize:             request.GetBatchSize(),
 		},
 		&GRPCFetchResourcesServer{server: server},
 	)
 }
 
 func (g GRPCFetchResourcesServer) Send(response *FetchResourcesResponse) error {
+	if p := response.Progress; p != nil {
+		return g.server.Send(&internal.FetchResources_Response{
+			Resource: response.ResourceName,
+			Progress: &internal.ResourceFetchProgress{
+				ResourceCount: p.ResourceCount,
+				Client:        p.Client,
+				Elapsed:       p.Elapsed.Milliseconds(),
+			},
+		})
+	}
 	return g.server.Send(&internal.FetchResources_Response{
 		Resource:                    response.ResourceName,
 		FinishedResources:           response.FinishedResources,
@@ -206,6 +345,12 @@ func (g GRPCFetchResourcesServer) Send(response *FetchResourcesResponse) error {
 			Status:        internal.ResourceFetchSummary_Status(response.Summary.Status),
 			ResourceCount: response.Summary.ResourceCount,
 			Diagnostics:   diagnosticsToProto(response.Summary.Diagnostics),
+			ApiCalls:      apiCallsToProto(response.Summary.APICalls),
+			FeatureFlags:  response.Summary.FeatureFlags,
+			TableCounts:   response.Summary.TableCounts,
+			ErrorPolicy:   internal.ErrorPolicy(response.Summary.ErrorPolicy),
+			FailFastError: response.Summary.FailFastError,
+			Clients:       clientSummariesToProto(response.Summary.Clients),
 		},
 	})
 }
@@ -229,6 +374,89 @@ func (g *GRPCServer) GetModuleInfo(ctx context.Context, request *internal.GetMod
 	}, nil
 }
 
+func (g *GRPCServer) GetFetchStatus(ctx context.Context, request *internal.GetFetchStatus_Request) (*internal.GetFetchStatus_Response, error) {
+	resp, err := g.Impl.GetFetchStatus(ctx, &GetFetchStatusRequest{
+		FetchID: request.GetFetchId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts map[int32]uint64
+	if len(resp.DiagnosticCounts) > 0 {
+		counts = make(map[int32]uint64, len(resp.DiagnosticCounts))
+		for s, c := range resp.DiagnosticCounts {
+			counts[int32(s)] = c
+		}
+	}
+	return &internal.GetFetchStatus_Response{
+		Found:            resp.Found,
+		Done:             resp.Done,
+		RowsResolved:     resp.RowsResolved,
+		RowsStored:       resp.RowsStored,
+		BytesEstimated:   resp.BytesEstimated,
+		DiagnosticCounts: counts,
+		Error:            resp.Error,
+	}, nil
+}
+
+func (g *GRPCServer) GetFetches(ctx context.Context, request *internal.GetFetches_Request) (*internal.GetFetches_Response, error) {
+	resp, err := g.Impl.GetFetches(ctx, &GetFetchesRequest{
+		Limit: request.GetLimit(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &internal.GetFetches_Response{
+		Fetches:     fetchesToProto(resp.Fetches),
+		Diagnostics: diagnosticsToProto(resp.Diagnostics),
+	}, nil
+}
+
+func (g *GRPCServer) GetEffectiveConfig(ctx context.Context, _ *internal.GetEffectiveConfig_Request) (*internal.GetEffectiveConfig_Response, error) {
+	resp, err := g.Impl.GetEffectiveConfig(ctx, &GetEffectiveConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &internal.GetEffectiveConfig_Response{
+		Config:      resp.Config,
+		Diagnostics: diagnosticsToProto(resp.Diagnostics),
+	}, nil
+}
+
+func (g *GRPCServer) GetResource(ctx context.Context, request *internal.GetResource_Request) (*internal.GetResource_Response, error) {
+	var md map[string]interface{}
+	if mdVal := request.GetMetadata(); mdVal != nil {
+		md = make(map[string]interface{})
+		if err := msgpack.Unmarshal(mdVal, &md); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := g.Impl.GetResource(ctx, &GetResourceRequest{
+		Resource:    request.GetResource(),
+		PrimaryKeys: request.GetPrimaryKeys(),
+		Metadata:    md,
+		Timeout:     time.Duration(request.GetTimeout()) * time.Second,
+		TenantId:    request.GetTenantId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &internal.GetResource_Response{
+		ResourceCount: resp.ResourceCount,
+		Diagnostics:   diagnosticsToProto(resp.Diagnostics),
+	}, nil
+}
+
+func (g *GRPCServer) CheckHealth(ctx context.Context, _ *internal.CheckHealth_Request) (*internal.CheckHealth_Response, error) {
+	resp, err := g.Impl.CheckHealth(ctx, &CheckHealthRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &internal.CheckHealth_Response{
+		Diagnostics: diagnosticsToProto(resp.Diagnostics),
+	}, nil
+}
+
 func tablesFromProto(in map[string]*internal.Table) map[string]*schema.Table {
 	if in == nil {
 		return nil
@@ -244,9 +472,16 @@ func tableFromProto(v *internal.Table) *schema.Table {
 	cols := make([]schema.Column, len(v.GetColumns()))
 	for i, c := range v.GetColumns() {
 		cols[i] = schema.SetColumnMeta(schema.Column{
-			Name:        c.GetName(),
-			Type:        schema.ValueType(c.GetType()),
-			Description: c.GetDescription(),
+			Name:           c.GetName(),
+			Type:           schema.ValueType(c.GetType()),
+			Description:    c.GetDescription(),
+			Deprecated:     c.GetDeprecated(),
+			Classification: schema.Classification(c.GetClassification()),
+			CreationOptions: schema.ColumnCreationOptions{
+				Unique:        c.GetCreationOptions().GetUnique(),
+				NotNull:       c.GetCreationOptions().GetNotNull(),
+				IncludeInCQID: c.GetCreationOptions().GetIncludeInCqId(),
+			},
 		}, metaFromProto(c.GetMeta()))
 	}
 	rels := make([]*schema.Table, len(v.GetRelations()))
@@ -257,15 +492,19 @@ func tableFromProto(v *internal.Table) *schema.Table {
 	var opts schema.TableCreationOptions
 	if o := v.GetOptions(); o != nil {
 		opts.PrimaryKeys = o.GetPrimaryKeys()
+		opts.Sequence = o.GetSequence()
+		opts.SoftDelete = o.GetSoftDelete()
 	}
 
 	return &schema.Table{
 		Name:        v.GetName(),
 		Description: v.GetDescription(),
+		Deprecated:  v.GetDeprecated(),
 		Columns:     cols,
 		Relations:   rels,
 		Options:     opts,
 		Serial:      v.GetSerial(),
+		Labels:      v.GetLabels(),
 	}
 }
 
@@ -281,8 +520,10 @@ func metaFromProto(m *internal.ColumnMeta) *schema.ColumnMeta {
 		}
 	}
 	return &schema.ColumnMeta{
-		Resolver:     r,
-		IgnoreExists: m.GetIgnoreExists(),
+		Resolver:      r,
+		IgnoreExists:  m.GetIgnoreExists(),
+		DefaultPath:   m.GetDefaultPath(),
+		FallbackPaths: m.GetFallbackPaths(),
 	}
 }
 
@@ -301,10 +542,17 @@ func tableToProto(in *schema.Table) *internal.Table {
 	cols := make([]*internal.Column, len(in.Columns))
 	for i, c := range in.Columns {
 		cols[i] = &internal.Column{
-			Name:        c.Name,
-			Type:        internal.ColumnType(c.Type),
-			Description: c.Description,
-			Meta:        columnMetaToProto(c.Meta()),
+			Name:           c.Name,
+			Type:           internal.ColumnType(c.Type),
+			Description:    c.Description,
+			Deprecated:     c.Deprecated,
+			Classification: string(c.Classification),
+			Meta:           columnMetaToProto(c.Meta()),
+			CreationOptions: &internal.ColumnCreationOptions{
+				Unique:        c.CreationOptions.Unique,
+				NotNull:       c.CreationOptions.NotNull,
+				IncludeInCqId: c.CreationOptions.IncludeInCQID,
+			},
 		}
 	}
 	rels := make([]*internal.Table, len(in.Relations))
@@ -314,12 +562,16 @@ func tableToProto(in *schema.Table) *internal.Table {
 	return &internal.Table{
 		Name:        in.Name,
 		Description: in.Description,
+		Deprecated:  in.Deprecated,
 		Columns:     cols,
 		Relations:   rels,
 		Options: &internal.TableCreationOptions{
 			PrimaryKeys: in.Options.PrimaryKeys,
+			Sequence:    in.Options.Sequence,
+			SoftDelete:  in.Options.SoftDelete,
 		},
 		Serial: in.Serial,
+		Labels: in.Labels,
 	}
 }
 
@@ -332,8 +584,10 @@ func columnMetaToProto(m *schema.ColumnMeta) *internal.ColumnMeta {
 		r = &internal.ResolverMeta{Name: m.Resolver.Name, Builtin: m.Resolver.Builtin}
 	}
 	return &internal.ColumnMeta{
-		Resolver:     r,
-		IgnoreExists: m.IgnoreExists,
+		Resolver:      r,
+		IgnoreExists:  m.IgnoreExists,
+		DefaultPath:   m.DefaultPath,
+		FallbackPaths: m.FallbackPaths,
 	}
 }
 
@@ -375,15 +629,27 @@ func diagnosticsToProto(in diag.Diagnostics) []*internal.Diagnostic {
 	}
 	diagnostics := make([]*internal.Diagnostic, len(in))
 	for i, p := range in {
+		// squashed diagnostics are sent with their count, the receiver squashes them again
+		var count uint64
+		if _, ok := p.(diag.Countable); ok {
+			count = diag.CountDiag(p)
+		}
+		samples := diag.SampleResourceIDs(p)
+		if count == 0 {
+			samples = nil
+		}
+		d := diag.UnsquashDiag(p)
 		diagnostics[i] = &internal.Diagnostic{
-			Type:       internal.Diagnostic_Type(p.Type()),
-			Severity:   internal.Diagnostic_Severity(p.Severity()),
-			Summary:    p.Description().Summary,
-			Detail:     p.Description().Detail,
-			Resource:   p.Description().Resource,
-			ResourceId: p.Description().ResourceID,
+			Type:       internal.Diagnostic_Type(d.Type()),
+			Severity:   internal.Diagnostic_Severity(d.Severity()),
+			Summary:    d.Description().Summary,
+			Detail:     d.Description().Detail,
+			Resource:   d.Description().Resource,
+			ResourceId: d.Description().ResourceID,
+			Count:      count,
+			Samples:    diagnosticSamplesToProto(samples),
 		}
-		if rd, ok := p.(diag.Redactable); ok {
+		if rd, ok := d.(diag.Redactable); ok {
 			if r := rd.Redacted(); r != nil {
 				diagnostics[i].Redacted = &internal.Diagnostic{
 					Type:       internal.Diagnostic_Type(r.Type()),
@@ -405,29 +671,122 @@ func diagnosticsFromProto(resourceName string, in []*internal.Diagnostic) diag.D
 	}
 	diagnostics := make(diag.Diagnostics, len(in))
 	for i, p := range in {
-		pdiag := &ProviderDiagnostic{
+		diagnostics[i] = diagnosticFromProto(resourceName, p)
+		if p.GetCount() > 0 {
+			diagnostics[i] = diag.NewSquashedDiag(diagnostics[i], p.GetCount(), diagnosticSamplesFromProto(p.GetSamples()))
+		}
+	}
+	return diagnostics
+}
+
+func diagnosticFromProto(resourceName string, p *internal.Diagnostic) diag.Diagnostic {
+	pdiag := &ProviderDiagnostic{
+		ResourceName:       resourceName,
+		ResourceId:         p.GetResourceId(),
+		DiagnosticType:     diag.Type(p.GetType()),
+		DiagnosticSeverity: diag.Severity(p.GetSeverity()),
+		Summary:            p.GetSummary(),
+		Details:            p.GetDetail(),
+	}
+	if r := p.GetRedacted(); r != nil {
+		return diag.NewRedactedDiagnostic(pdiag, &ProviderDiagnostic{
 			ResourceName:       resourceName,
-			ResourceId:         p.GetResourceId(),
-			DiagnosticType:     diag.Type(p.GetType()),
-			DiagnosticSeverity: diag.Severity(p.GetSeverity()),
-			Summary:            p.GetSummary(),
-			Details:            p.GetDetail(),
+			ResourceId:         r.GetResourceId(),
+			DiagnosticType:     diag.Type(r.GetType()),
+			DiagnosticSeverity: diag.Severity(r.GetSeverity()),
+			Summary:            r.GetSummary(),
+			Details:            r.GetDetail(),
+		})
+	}
+	return pdiag
+}
+
+func diagnosticSamplesToProto(in [][]string) []*internal.DiagnosticSample {
+	if len(in) == 0 {
+		return nil
+	}
+	samples := make([]*internal.DiagnosticSample, len(in))
+	for i, id := range in {
+		samples[i] = &internal.DiagnosticSample{ResourceId: id}
+	}
+	return samples
+}
+
+func diagnosticSamplesFromProto(in []*internal.DiagnosticSample) [][]string {
+	if len(in) == 0 {
+		return nil
+	}
+	samples := make([][]string, len(in))
+	for i, s := range in {
+		samples[i] = s.GetResourceId()
+	}
+	return samples
+}
+
+func apiCallsFromProto(in []*internal.ApiCallCount) []APICallCount {
+	if len(in) == 0 {
+		return nil
+	}
+	calls := make([]APICallCount, len(in))
+	for i, c := range in {
+		calls[i] = APICallCount{
+			Table:     c.GetTable(),
+			ClientID:  c.GetClientId(),
+			Service:   c.GetService(),
+			Operation: c.GetOperation(),
+			Count:     c.GetCount(),
 		}
-		if r := p.GetRedacted(); r != nil {
-			diagnostics[i] = diag.NewRedactedDiagnostic(pdiag, &ProviderDiagnostic{
-				ResourceName:       resourceName,
-				ResourceId:         r.GetResourceId(),
-				DiagnosticType:     diag.Type(r.GetType()),
-				DiagnosticSeverity: diag.Severity(r.GetSeverity()),
-				Summary:            r.GetSummary(),
-				Details:            r.GetDetail(),
-			})
-			continue
+	}
+	return calls
+}
+
+func apiCallsToProto(in []APICallCount) []*internal.ApiCallCount {
+	if len(in) == 0 {
+		return nil
+	}
+	calls := make([]*internal.ApiCallCount, len(in))
+	for i, c := range in {
+		calls[i] = &internal.ApiCallCount{
+			Table:     c.Table,
+			ClientId:  c.ClientID,
+			Service:   c.Service,
+			Operation: c.Operation,
+			Count:     c.Count,
+		}
+	}
+	return calls
+}
+
+func clientSummariesFromProto(in []*internal.ClientFetchSummary) []ClientFetchSummary {
+	if len(in) == 0 {
+		return nil
+	}
+	clients := make([]ClientFetchSummary, len(in))
+	for i, c := range in {
+		clients[i] = ClientFetchSummary{
+			ClientID:      c.GetClientId(),
+			Status:        ResourceFetchStatus(c.GetStatus()),
+			Started:       c.GetStarted(),
+			ResourceCount: c.GetResourceCount(),
 		}
+	}
+	return clients
+}
 
-		diagnostics[i] = pdiag
+func clientSummariesToProto(in []ClientFetchSummary) []*internal.ClientFetchSummary {
+	if len(in) == 0 {
+		return nil
 	}
-	return diagnostics
+	clients := make([]*internal.ClientFetchSummary, len(in))
+	for i, c := range in {
+		clients[i] = &internal.ClientFetchSummary{
+			ClientId:      c.ClientID,
+			Status:        internal.ResourceFetchSummary_Status(c.Status),
+			Started:       c.Started,
+			ResourceCount: c.ResourceCount,
+		}
+	}
+	return clients
 }
 
 func moduleInfoFromProto(in map[uint32]*internal.GetModuleInfo_Response_ModuleInfo) map[uint32]ModuleInfo {
@@ -463,3 +822,81 @@ func moduleInfoToProto(in map[uint32]ModuleInfo) map[uint32]*internal.GetModuleI
 	}
 	return ret
 }
+
+func fetchesFromProto(in []*internal.GetFetches_Fetch) []FetchHistory {
+	if in == nil {
+		return nil
+	}
+	out := make([]FetchHistory, len(in))
+	for i, f := range in {
+		resources := make([]FetchHistoryResource, len(f.GetResources()))
+		for j, r := range f.GetResources() {
+			resources[j] = FetchHistoryResource{
+				ResourceName:  r.GetResource(),
+				Status:        ResourceFetchStatus(r.GetStatus()),
+				ResourceCount: r.GetResourceCount(),
+				ErrorCount:    r.GetErrorCount(),
+			}
+		}
+		out[i] = FetchHistory{
+			FetchID:         f.GetFetchId(),
+			ProviderVersion: f.GetProviderVersion(),
+			Start:           time.UnixMilli(f.GetStart()),
+			Finish:          time.UnixMilli(f.GetFinish()),
+			Error:           f.GetError(),
+			Resources:       resources,
+		}
+	}
+	return out
+}
+
+func fetchesToProto(in []FetchHistory) []*internal.GetFetches_Fetch {
+	if in == nil {
+		return nil
+	}
+	out := make([]*internal.GetFetches_Fetch, len(in))
+	for i, f := range in {
+		resources := make([]*internal.GetFetches_Resource, len(f.Resources))
+		for j, r := range f.Resources {
+			resources[j] = &internal.GetFetches_Resource{
+				Resource:      r.ResourceName,
+				Status:        internal.ResourceFetchSummary_Status(r.Status),
+				ResourceCount: r.ResourceCount,
+				ErrorCount:    r.ErrorCount,
+			}
+		}
+		out[i] = &internal.GetFetches_Fetch{
+			FetchId:         f.FetchID,
+			ProviderVersion: f.ProviderVersion,
+			Start:           f.Start.UnixMilli(),
+			Finish:          f.Finish.UnixMilli(),
+			Error:           f.Error,
+			Resources:       resources,
+		}
+	}
+	return out
+}
+
+// connectionType returns the storage type of the connection, falling back to the connection type enum for clients
+// that don't send a storage type
+func connectionType(c *internal.ConnectionDetails) string {
+	if t := c.GetStorageType(); t != "" {
+		return t
+	}
+	return strings.ToLower(c.GetType().String())
+}
+
+func configFormatToProto(f ConfigFormat) internal.ConfigFormat {
+	if f == ConfigFormatHCL {
+		return internal.ConfigFormat_HCL
+	}
+	return internal.ConfigFormat_YAML
+}
+
+// configFormatFromProto converts the format of the config, older clients set YAML or don't set it
+func configFormatFromProto(f internal.ConfigFormat) ConfigFormat {
+	if f == internal.ConfigFormat_HCL {
+		return ConfigFormatHCL
+	}
+	return ConfigFormatYAML
+}
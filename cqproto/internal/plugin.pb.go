@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ErrorPolicy defines how errors of a fetch are handled
+type ErrorPolicy int32
+
+const (
+	// errors are reported and the fetch continues, resources are fetched as much as possible
+	ErrorPolicy_BEST_EFFORT ErrorPolicy = 0
+	// the fetch is canceled on the first error
+	ErrorPolicy_FAIL_FAST ErrorPolicy = 1
+)
+
+// Enum value maps for ErrorPolicy.
+var (
+	ErrorPolicy_name = map[int32]string{
+		0: "BEST_EFFORT",
+		1: "FAIL_FAST",
+	}
+	ErrorPolicy_value = map[string]int32{
+		"BEST_EFFORT": 0,
+		"FAIL_FAST":   1,
+	}
+)
+
+func (x ErrorPolicy) Enum() *ErrorPolicy {
+	p := new(ErrorPolicy)
+	*p = x
+	return p
+}
+
+func (x ErrorPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_plugin_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorPolicy) Type() protoreflect.EnumType {
+	return &file_internal_plugin_proto_enumTypes[0]
+}
+
+func (x ErrorPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorPolicy.Descriptor instead.
+func (ErrorPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{0}
+}
+
 // ConfigFormat is deprecated
 type ConfigFormat int32
 
@@ -27,6 +76,7 @@ const (
 	// Deprecated: Do not use.
 	ConfigFormat_Invalid ConfigFormat = 0
 	ConfigFormat_YAML    ConfigFormat = 1
+	ConfigFormat_HCL     ConfigFormat = 2
 )
 
 // Enum value maps for ConfigFormat.
@@ -34,10 +84,12 @@ var (
 	ConfigFormat_name = map[int32]string{
 		0: "Invalid",
 		1: "YAML",
+		2: "HCL",
 	}
 	ConfigFormat_value = map[string]int32{
 		"Invalid": 0,
 		"YAML":    1,
+		"HCL":     2,
 	}
 )
 
@@ -52,11 +104,11 @@ func (x ConfigFormat) String() string {
 }
 
 func (ConfigFormat) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[0].Descriptor()
+	return file_internal_plugin_proto_enumTypes[1].Descriptor()
 }
 
 func (ConfigFormat) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[0]
+	return &file_internal_plugin_proto_enumTypes[1]
 }
 
 func (x ConfigFormat) Number() protoreflect.EnumNumber {
@@ -65,7 +117,7 @@ func (x ConfigFormat) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConfigFormat.Descriptor instead.
 func (ConfigFormat) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{0}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{1}
 }
 
 type ColumnType int32
@@ -91,6 +143,9 @@ const (
 	ColumnType_CIDR_ARRAY     ColumnType = 17
 	ColumnType_MAC_ADDR       ColumnType = 18
 	ColumnType_MAC_ADDR_ARRAY ColumnType = 19
+	ColumnType_TIMESTAMPTZ    ColumnType = 20
+	ColumnType_DECIMAL        ColumnType = 21
+	ColumnType_HSTORE         ColumnType = 22
 )
 
 // Enum value maps for ColumnType.
@@ -116,6 +171,9 @@ var (
 		17: "CIDR_ARRAY",
 		18: "MAC_ADDR",
 		19: "MAC_ADDR_ARRAY",
+		20: "TIMESTAMPTZ",
+		21: "DECIMAL",
+		22: "HSTORE",
 	}
 	ColumnType_value = map[string]int32{
 		"INVALID":        0,
@@ -138,6 +196,9 @@ var (
 		"CIDR_ARRAY":     17,
 		"MAC_ADDR":       18,
 		"MAC_ADDR_ARRAY": 19,
+		"TIMESTAMPTZ":    20,
+		"DECIMAL":        21,
+		"HSTORE":         22,
 	}
 )
 
@@ -152,11 +213,11 @@ func (x ColumnType) String() string {
 }
 
 func (ColumnType) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[1].Descriptor()
+	return file_internal_plugin_proto_enumTypes[2].Descriptor()
 }
 
 func (ColumnType) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[1]
+	return &file_internal_plugin_proto_enumTypes[2]
 }
 
 func (x ColumnType) Number() protoreflect.EnumNumber {
@@ -165,7 +226,7 @@ func (x ColumnType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ColumnType.Descriptor instead.
 func (ColumnType) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{1}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{2}
 }
 
 type ConnectionType int32
@@ -195,11 +256,11 @@ func (x ConnectionType) String() string {
 }
 
 func (ConnectionType) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[2].Descriptor()
+	return file_internal_plugin_proto_enumTypes[3].Descriptor()
 }
 
 func (ConnectionType) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[2]
+	return &file_internal_plugin_proto_enumTypes[3]
 }
 
 func (x ConnectionType) Number() protoreflect.EnumNumber {
@@ -208,7 +269,7 @@ func (x ConnectionType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConnectionType.Descriptor instead.
 func (ConnectionType) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{2}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{3}
 }
 
 // Execution status of the resource fetch execution
@@ -252,11 +313,11 @@ func (x ResourceFetchSummary_Status) String() string {
 }
 
 func (ResourceFetchSummary_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[3].Descriptor()
+	return file_internal_plugin_proto_enumTypes[4].Descriptor()
 }
 
 func (ResourceFetchSummary_Status) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[3]
+	return &file_internal_plugin_proto_enumTypes[4]
 }
 
 func (x ResourceFetchSummary_Status) Number() protoreflect.EnumNumber {
@@ -265,7 +326,7 @@ func (x ResourceFetchSummary_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ResourceFetchSummary_Status.Descriptor instead.
 func (ResourceFetchSummary_Status) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{2, 0}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{3, 0}
 }
 
 type Diagnostic_Type int32
@@ -313,11 +374,11 @@ func (x Diagnostic_Type) String() string {
 }
 
 func (Diagnostic_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[4].Descriptor()
+	return file_internal_plugin_proto_enumTypes[5].Descriptor()
 }
 
 func (Diagnostic_Type) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[4]
+	return &file_internal_plugin_proto_enumTypes[5]
 }
 
 func (x Diagnostic_Type) Number() protoreflect.EnumNumber {
@@ -326,7 +387,7 @@ func (x Diagnostic_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Diagnostic_Type.Descriptor instead.
 func (Diagnostic_Type) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{4, 0}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type Diagnostic_Severity int32
@@ -365,11 +426,11 @@ func (x Diagnostic_Severity) String() string {
 }
 
 func (Diagnostic_Severity) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_plugin_proto_enumTypes[5].Descriptor()
+	return file_internal_plugin_proto_enumTypes[6].Descriptor()
 }
 
 func (Diagnostic_Severity) Type() protoreflect.EnumType {
-	return &file_internal_plugin_proto_enumTypes[5]
+	return &file_internal_plugin_proto_enumTypes[6]
 }
 
 func (x Diagnostic_Severity) Number() protoreflect.EnumNumber {
@@ -378,7 +439,7 @@ func (x Diagnostic_Severity) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Diagnostic_Severity.Descriptor instead.
 func (Diagnostic_Severity) EnumDescriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{4, 1}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 1}
 }
 
 type ConfigureProvider struct {
@@ -457,6 +518,73 @@ func (*FetchResources) Descriptor() ([]byte, []int) {
 	return file_internal_plugin_proto_rawDescGZIP(), []int{1}
 }
 
+// ResourceFetchProgress is a progress update of a running resource fetch
+type ResourceFetchProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Amount of resources resolved so far, including relations
+	ResourceCount uint64 `protobuf:"varint,1,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	// id of the multiplexed client that last resolved resources
+	Client string `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	// time since the resource fetch started, in milliseconds
+	Elapsed int64 `protobuf:"varint,3,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+}
+
+func (x *ResourceFetchProgress) Reset() {
+	*x = ResourceFetchProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceFetchProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceFetchProgress) ProtoMessage() {}
+
+func (x *ResourceFetchProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceFetchProgress.ProtoReflect.Descriptor instead.
+func (*ResourceFetchProgress) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResourceFetchProgress) GetResourceCount() uint64 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *ResourceFetchProgress) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *ResourceFetchProgress) GetElapsed() int64 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
 // ResourceFetchSummary includes a summarized report of a fetched resource, such as total amount of resources collected,
 // status of the fetch and any diagnostics found while executing fetch on it.
 type ResourceFetchSummary struct {
@@ -470,12 +598,24 @@ type ResourceFetchSummary struct {
 	ResourceCount uint64 `protobuf:"varint,2,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
 	// list of diagnostic returned from a resource fetch
 	Diagnostics []*Diagnostic `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// API calls made while fetching the resource, per table and client
+	ApiCalls []*ApiCallCount `protobuf:"bytes,4,rep,name=api_calls,json=apiCalls,proto3" json:"api_calls,omitempty"`
+	// Feature flags the provider was configured with
+	FeatureFlags map[string]bool `protobuf:"bytes,5,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// amount of resources resolved per table of the resource, set by dry run fetches
+	TableCounts map[string]uint64 `protobuf:"bytes,6,rep,name=table_counts,json=tableCounts,proto3" json:"table_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// error policy of the fetch
+	ErrorPolicy ErrorPolicy `protobuf:"varint,7,opt,name=error_policy,json=errorPolicy,proto3,enum=proto.ErrorPolicy" json:"error_policy,omitempty"`
+	// summary of the error that canceled a fail fast fetch, if the fetch was canceled before the summary was sent
+	FailFastError string `protobuf:"bytes,8,opt,name=fail_fast_error,json=failFastError,proto3" json:"fail_fast_error,omitempty"`
+	// completion state of the resource's multiplexed clients, set if the fetch was canceled
+	Clients []*ClientFetchSummary `protobuf:"bytes,9,rep,name=clients,proto3" json:"clients,omitempty"`
 }
 
 func (x *ResourceFetchSummary) Reset() {
 	*x = ResourceFetchSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[2]
+		mi := &file_internal_plugin_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -488,7 +628,7 @@ func (x *ResourceFetchSummary) String() string {
 func (*ResourceFetchSummary) ProtoMessage() {}
 
 func (x *ResourceFetchSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[2]
+	mi := &file_internal_plugin_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +641,7 @@ func (x *ResourceFetchSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceFetchSummary.ProtoReflect.Descriptor instead.
 func (*ResourceFetchSummary) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{2}
+	return file_internal_plugin_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ResourceFetchSummary) GetStatus() ResourceFetchSummary_Status {
@@ -525,38 +665,80 @@ func (x *ResourceFetchSummary) GetDiagnostics() []*Diagnostic {
 	return nil
 }
 
-type PartialFetchFailedResource struct {
+func (x *ResourceFetchSummary) GetApiCalls() []*ApiCallCount {
+	if x != nil {
+		return x.ApiCalls
+	}
+	return nil
+}
+
+func (x *ResourceFetchSummary) GetFeatureFlags() map[string]bool {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+func (x *ResourceFetchSummary) GetTableCounts() map[string]uint64 {
+	if x != nil {
+		return x.TableCounts
+	}
+	return nil
+}
+
+func (x *ResourceFetchSummary) GetErrorPolicy() ErrorPolicy {
+	if x != nil {
+		return x.ErrorPolicy
+	}
+	return ErrorPolicy_BEST_EFFORT
+}
+
+func (x *ResourceFetchSummary) GetFailFastError() string {
+	if x != nil {
+		return x.FailFastError
+	}
+	return ""
+}
+
+func (x *ResourceFetchSummary) GetClients() []*ClientFetchSummary {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+// ClientFetchSummary is the completion state of a multiplexed client of a canceled resource fetch
+type ClientFetchSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// table name of the failed resource fetch
-	TableName string `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	// root/parent table name
-	RootTableName string `protobuf:"bytes,2,opt,name=root_table_name,json=rootTableName,proto3" json:"root_table_name,omitempty"`
-	// root/parent primary key values
-	RootPrimaryKeyValues []string `protobuf:"bytes,3,rep,name=root_primary_key_values,json=rootPrimaryKeyValues,proto3" json:"root_primary_key_values,omitempty"`
-	// error message for this resource fetch failure
-	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// COMPLETE or PARTIAL if the client finished resolving, CANCELED otherwise
+	Status ResourceFetchSummary_Status `protobuf:"varint,2,opt,name=status,proto3,enum=proto.ResourceFetchSummary_Status" json:"status,omitempty"`
+	// false if the fetch was canceled before the client started resolving
+	Started bool `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	// amount of resources resolved by the client, including relations
+	ResourceCount uint64 `protobuf:"varint,4,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
 }
 
-func (x *PartialFetchFailedResource) Reset() {
-	*x = PartialFetchFailedResource{}
+func (x *ClientFetchSummary) Reset() {
+	*x = ClientFetchSummary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[3]
+		mi := &file_internal_plugin_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PartialFetchFailedResource) String() string {
+func (x *ClientFetchSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PartialFetchFailedResource) ProtoMessage() {}
+func (*ClientFetchSummary) ProtoMessage() {}
 
-func (x *PartialFetchFailedResource) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[3]
+func (x *ClientFetchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,70 +749,69 @@ func (x *PartialFetchFailedResource) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PartialFetchFailedResource.ProtoReflect.Descriptor instead.
-func (*PartialFetchFailedResource) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ClientFetchSummary.ProtoReflect.Descriptor instead.
+func (*ClientFetchSummary) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *PartialFetchFailedResource) GetTableName() string {
+func (x *ClientFetchSummary) GetClientId() string {
 	if x != nil {
-		return x.TableName
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *PartialFetchFailedResource) GetRootTableName() string {
+func (x *ClientFetchSummary) GetStatus() ResourceFetchSummary_Status {
 	if x != nil {
-		return x.RootTableName
+		return x.Status
 	}
-	return ""
+	return ResourceFetchSummary_COMPLETE
 }
 
-func (x *PartialFetchFailedResource) GetRootPrimaryKeyValues() []string {
+func (x *ClientFetchSummary) GetStarted() bool {
 	if x != nil {
-		return x.RootPrimaryKeyValues
+		return x.Started
 	}
-	return nil
+	return false
 }
 
-func (x *PartialFetchFailedResource) GetError() string {
+func (x *ClientFetchSummary) GetResourceCount() uint64 {
 	if x != nil {
-		return x.Error
+		return x.ResourceCount
 	}
-	return ""
+	return 0
 }
 
-type Diagnostic struct {
+// ApiCallCount is the amount of calls made to a single API operation by a client while resolving a table
+type ApiCallCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type       Diagnostic_Type     `protobuf:"varint,1,opt,name=type,proto3,enum=proto.Diagnostic_Type" json:"type,omitempty"`
-	Severity   Diagnostic_Severity `protobuf:"varint,2,opt,name=severity,proto3,enum=proto.Diagnostic_Severity" json:"severity,omitempty"`
-	Summary    string              `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
-	Detail     string              `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
-	Resource   string              `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
-	Redacted   *Diagnostic         `protobuf:"bytes,6,opt,name=redacted,proto3" json:"redacted,omitempty"`
-	ResourceId []string            `protobuf:"bytes,7,rep,name=resourceId,proto3" json:"resourceId,omitempty"`
+	Table     string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	ClientId  string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Service   string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Operation string `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	Count     uint64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *Diagnostic) Reset() {
-	*x = Diagnostic{}
+func (x *ApiCallCount) Reset() {
+	*x = ApiCallCount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[4]
+		mi := &file_internal_plugin_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Diagnostic) String() string {
+func (x *ApiCallCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Diagnostic) ProtoMessage() {}
+func (*ApiCallCount) ProtoMessage() {}
 
-func (x *Diagnostic) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[4]
+func (x *ApiCallCount) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,83 +822,78 @@ func (x *Diagnostic) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
-func (*Diagnostic) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *Diagnostic) GetType() Diagnostic_Type {
-	if x != nil {
-		return x.Type
-	}
-	return Diagnostic_UNKNOWN
-}
-
-func (x *Diagnostic) GetSeverity() Diagnostic_Severity {
-	if x != nil {
-		return x.Severity
-	}
-	return Diagnostic_INVALID
+// Deprecated: Use ApiCallCount.ProtoReflect.Descriptor instead.
+func (*ApiCallCount) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Diagnostic) GetSummary() string {
+func (x *ApiCallCount) GetTable() string {
 	if x != nil {
-		return x.Summary
+		return x.Table
 	}
 	return ""
 }
 
-func (x *Diagnostic) GetDetail() string {
+func (x *ApiCallCount) GetClientId() string {
 	if x != nil {
-		return x.Detail
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *Diagnostic) GetResource() string {
+func (x *ApiCallCount) GetService() string {
 	if x != nil {
-		return x.Resource
+		return x.Service
 	}
 	return ""
 }
 
-func (x *Diagnostic) GetRedacted() *Diagnostic {
+func (x *ApiCallCount) GetOperation() string {
 	if x != nil {
-		return x.Redacted
+		return x.Operation
 	}
-	return nil
+	return ""
 }
 
-func (x *Diagnostic) GetResourceId() []string {
+func (x *ApiCallCount) GetCount() uint64 {
 	if x != nil {
-		return x.ResourceId
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type GetProviderSchema struct {
+type PartialFetchFailedResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// table name of the failed resource fetch
+	TableName string `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	// root/parent table name
+	RootTableName string `protobuf:"bytes,2,opt,name=root_table_name,json=rootTableName,proto3" json:"root_table_name,omitempty"`
+	// root/parent primary key values
+	RootPrimaryKeyValues []string `protobuf:"bytes,3,rep,name=root_primary_key_values,json=rootPrimaryKeyValues,proto3" json:"root_primary_key_values,omitempty"`
+	// error message for this resource fetch failure
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *GetProviderSchema) Reset() {
-	*x = GetProviderSchema{}
+func (x *PartialFetchFailedResource) Reset() {
+	*x = PartialFetchFailedResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[5]
+		mi := &file_internal_plugin_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetProviderSchema) String() string {
+func (x *PartialFetchFailedResource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProviderSchema) ProtoMessage() {}
+func (*PartialFetchFailedResource) ProtoMessage() {}
 
-func (x *GetProviderSchema) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[5]
+func (x *PartialFetchFailedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,10 +904,237 @@ func (x *GetProviderSchema) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProviderSchema.ProtoReflect.Descriptor instead.
-func (*GetProviderSchema) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{5}
-}
+// Deprecated: Use PartialFetchFailedResource.ProtoReflect.Descriptor instead.
+func (*PartialFetchFailedResource) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PartialFetchFailedResource) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *PartialFetchFailedResource) GetRootTableName() string {
+	if x != nil {
+		return x.RootTableName
+	}
+	return ""
+}
+
+func (x *PartialFetchFailedResource) GetRootPrimaryKeyValues() []string {
+	if x != nil {
+		return x.RootPrimaryKeyValues
+	}
+	return nil
+}
+
+func (x *PartialFetchFailedResource) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Diagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       Diagnostic_Type     `protobuf:"varint,1,opt,name=type,proto3,enum=proto.Diagnostic_Type" json:"type,omitempty"`
+	Severity   Diagnostic_Severity `protobuf:"varint,2,opt,name=severity,proto3,enum=proto.Diagnostic_Severity" json:"severity,omitempty"`
+	Summary    string              `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	Detail     string              `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	Resource   string              `protobuf:"bytes,5,opt,name=resource,proto3" json:"resource,omitempty"`
+	Redacted   *Diagnostic         `protobuf:"bytes,6,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	ResourceId []string            `protobuf:"bytes,7,rep,name=resourceId,proto3" json:"resourceId,omitempty"`
+	// number of identical diagnostics squashed into this one, 0 if it isn't squashed
+	Count uint64 `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	// resource ids of some of the squashed diagnostics
+	Samples []*DiagnosticSample `protobuf:"bytes,9,rep,name=samples,proto3" json:"samples,omitempty"`
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Diagnostic) GetType() Diagnostic_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Diagnostic_UNKNOWN
+}
+
+func (x *Diagnostic) GetSeverity() Diagnostic_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Diagnostic_INVALID
+}
+
+func (x *Diagnostic) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetRedacted() *Diagnostic {
+	if x != nil {
+		return x.Redacted
+	}
+	return nil
+}
+
+func (x *Diagnostic) GetResourceId() []string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return nil
+}
+
+func (x *Diagnostic) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetSamples() []*DiagnosticSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+// DiagnosticSample is the resource id of one of the diagnostics of a squashed diagnostic
+type DiagnosticSample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceId []string `protobuf:"bytes,1,rep,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+}
+
+func (x *DiagnosticSample) Reset() {
+	*x = DiagnosticSample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiagnosticSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticSample) ProtoMessage() {}
+
+func (x *DiagnosticSample) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticSample.ProtoReflect.Descriptor instead.
+func (*DiagnosticSample) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DiagnosticSample) GetResourceId() []string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return nil
+}
+
+type GetProviderSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetProviderSchema) Reset() {
+	*x = GetProviderSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetProviderSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProviderSchema) ProtoMessage() {}
+
+func (x *GetProviderSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderSchema.ProtoReflect.Descriptor instead.
+func (*GetProviderSchema) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{9}
+}
 
 type GetProviderConfig struct {
 	state         protoimpl.MessageState
@@ -742,7 +1145,7 @@ type GetProviderConfig struct {
 func (x *GetProviderConfig) Reset() {
 	*x = GetProviderConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[6]
+		mi := &file_internal_plugin_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -755,7 +1158,45 @@ func (x *GetProviderConfig) String() string {
 func (*GetProviderConfig) ProtoMessage() {}
 
 func (x *GetProviderConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[6]
+	mi := &file_internal_plugin_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProviderConfig.ProtoReflect.Descriptor instead.
+func (*GetProviderConfig) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{10}
+}
+
+type GetModuleInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetModuleInfo) Reset() {
+	*x = GetModuleInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetModuleInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModuleInfo) ProtoMessage() {}
+
+func (x *GetModuleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,37 +1204,1208 @@ func (x *GetProviderConfig) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModuleInfo.ProtoReflect.Descriptor instead.
+func (*GetModuleInfo) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{11}
+}
+
+type GetFetchStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetFetchStatus) Reset() {
+	*x = GetFetchStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFetchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetchStatus) ProtoMessage() {}
+
+func (x *GetFetchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFetchStatus.ProtoReflect.Descriptor instead.
+func (*GetFetchStatus) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{12}
+}
+
+type GetFetches struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetFetches) Reset() {
+	*x = GetFetches{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFetches) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetches) ProtoMessage() {}
+
+func (x *GetFetches) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFetches.ProtoReflect.Descriptor instead.
+func (*GetFetches) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{13}
+}
+
+type GetEffectiveConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetEffectiveConfig) Reset() {
+	*x = GetEffectiveConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEffectiveConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveConfig) ProtoMessage() {}
+
+func (x *GetEffectiveConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveConfig.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfig) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{14}
+}
+
+type GetResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetResource) Reset() {
+	*x = GetResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResource) ProtoMessage() {}
+
+func (x *GetResource) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResource.ProtoReflect.Descriptor instead.
+func (*GetResource) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{15}
+}
+
+type CheckHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CheckHealth) Reset() {
+	*x = CheckHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHealth) ProtoMessage() {}
+
+func (x *CheckHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHealth.ProtoReflect.Descriptor instead.
+func (*CheckHealth) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{16}
+}
+
+// Table is the definition of how a table is defined in a provider
+type Table struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Columns     []*Column             `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	Relations   []*Table              `protobuf:"bytes,4,rep,name=relations,proto3" json:"relations,omitempty"`
+	Options     *TableCreationOptions `protobuf:"bytes,5,opt,name=options,proto3,oneof" json:"options,omitempty"`
+	Serial      string                `protobuf:"bytes,6,opt,name=serial,proto3" json:"serial,omitempty"`
+	Labels      map[string]string     `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the reason the table is deprecated, if it is
+	Deprecated string `protobuf:"bytes,8,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Table) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Table) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Table) GetColumns() []*Column {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Table) GetRelations() []*Table {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *Table) GetOptions() *TableCreationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Table) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *Table) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Table) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
+type Column struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type            ColumnType             `protobuf:"varint,3,opt,name=type,proto3,enum=proto.ColumnType" json:"type,omitempty"`
+	Meta            *ColumnMeta            `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"`
+	CreationOptions *ColumnCreationOptions `protobuf:"bytes,5,opt,name=creation_options,json=creationOptions,proto3" json:"creation_options,omitempty"`
+	// the reason the column is deprecated, if it is
+	Deprecated string `protobuf:"bytes,6,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// the kind of data the column holds, if classified
+	Classification string `protobuf:"bytes,7,opt,name=classification,proto3" json:"classification,omitempty"`
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Column) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Column) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Column) GetType() ColumnType {
+	if x != nil {
+		return x.Type
+	}
+	return ColumnType_INVALID
+}
+
+func (x *Column) GetMeta() *ColumnMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Column) GetCreationOptions() *ColumnCreationOptions {
+	if x != nil {
+		return x.CreationOptions
+	}
+	return nil
+}
+
+func (x *Column) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
+func (x *Column) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+type ColumnCreationOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Unique        bool `protobuf:"varint,1,opt,name=unique,proto3" json:"unique,omitempty"`
+	NotNull       bool `protobuf:"varint,2,opt,name=not_null,json=notNull,proto3" json:"not_null,omitempty"`
+	IncludeInCqId bool `protobuf:"varint,3,opt,name=include_in_cq_id,json=includeInCqId,proto3" json:"include_in_cq_id,omitempty"`
+}
+
+func (x *ColumnCreationOptions) Reset() {
+	*x = ColumnCreationOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnCreationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnCreationOptions) ProtoMessage() {}
+
+func (x *ColumnCreationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnCreationOptions.ProtoReflect.Descriptor instead.
+func (*ColumnCreationOptions) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ColumnCreationOptions) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *ColumnCreationOptions) GetNotNull() bool {
+	if x != nil {
+		return x.NotNull
+	}
+	return false
+}
+
+func (x *ColumnCreationOptions) GetIncludeInCqId() bool {
+	if x != nil {
+		return x.IncludeInCqId
+	}
+	return false
+}
+
+type ColumnMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resolver     *ResolverMeta `protobuf:"bytes,1,opt,name=resolver,proto3" json:"resolver,omitempty"`
+	IgnoreExists bool          `protobuf:"varint,2,opt,name=IgnoreExists,proto3" json:"IgnoreExists,omitempty"`
+	// path in the resource's item the column is resolved from, if it has no resolver
+	DefaultPath string `protobuf:"bytes,3,opt,name=default_path,json=defaultPath,proto3" json:"default_path,omitempty"`
+	// paths in the resource's item tried in order when the column resolves to nil
+	FallbackPaths []string `protobuf:"bytes,4,rep,name=fallback_paths,json=fallbackPaths,proto3" json:"fallback_paths,omitempty"`
+}
+
+func (x *ColumnMeta) Reset() {
+	*x = ColumnMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnMeta) ProtoMessage() {}
+
+func (x *ColumnMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnMeta.ProtoReflect.Descriptor instead.
+func (*ColumnMeta) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ColumnMeta) GetResolver() *ResolverMeta {
+	if x != nil {
+		return x.Resolver
+	}
+	return nil
+}
+
+func (x *ColumnMeta) GetIgnoreExists() bool {
+	if x != nil {
+		return x.IgnoreExists
+	}
+	return false
+}
+
+func (x *ColumnMeta) GetDefaultPath() string {
+	if x != nil {
+		return x.DefaultPath
+	}
+	return ""
+}
+
+func (x *ColumnMeta) GetFallbackPaths() []string {
+	if x != nil {
+		return x.FallbackPaths
+	}
+	return nil
+}
+
+type ResolverMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Builtin bool   `protobuf:"varint,2,opt,name=builtin,proto3" json:"builtin,omitempty"`
+}
+
+func (x *ResolverMeta) Reset() {
+	*x = ResolverMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolverMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolverMeta) ProtoMessage() {}
+
+func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolverMeta.ProtoReflect.Descriptor instead.
+func (*ResolverMeta) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResolverMeta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResolverMeta) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+type TableCreationOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrimaryKeys []string `protobuf:"bytes,1,rep,name=PrimaryKeys,proto3" json:"PrimaryKeys,omitempty"`
+	Sequence    bool     `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	SoftDelete  bool     `protobuf:"varint,3,opt,name=soft_delete,json=softDelete,proto3" json:"soft_delete,omitempty"`
+}
+
+func (x *TableCreationOptions) Reset() {
+	*x = TableCreationOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableCreationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableCreationOptions) ProtoMessage() {}
+
+func (x *TableCreationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableCreationOptions.ProtoReflect.Descriptor instead.
+func (*TableCreationOptions) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TableCreationOptions) GetPrimaryKeys() []string {
+	if x != nil {
+		return x.PrimaryKeys
+	}
+	return nil
+}
+
+func (x *TableCreationOptions) GetSequence() bool {
+	if x != nil {
+		return x.Sequence
+	}
+	return false
+}
+
+func (x *TableCreationOptions) GetSoftDelete() bool {
+	if x != nil {
+		return x.SoftDelete
+	}
+	return false
+}
+
+type ConnectionDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type ConnectionType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.ConnectionType" json:"type,omitempty"`
+	Dsn  string         `protobuf:"bytes,2,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	// policy tag to action (omit/mask) applied to restricted columns written to this connection
+	ColumnPolicies map[string]string `protobuf:"bytes,3,rep,name=column_policies,json=columnPolicies,proto3" json:"column_policies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// registered storage type of the connection, i.e postgres. Takes precedence over type when set
+	StorageType string `protobuf:"bytes,4,opt,name=storage_type,json=storageType,proto3" json:"storage_type,omitempty"`
+}
+
+func (x *ConnectionDetails) Reset() {
+	*x = ConnectionDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionDetails) ProtoMessage() {}
+
+func (x *ConnectionDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionDetails.ProtoReflect.Descriptor instead.
+func (*ConnectionDetails) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ConnectionDetails) GetType() ConnectionType {
+	if x != nil {
+		return x.Type
+	}
+	return ConnectionType_POSTGRES
+}
+
+func (x *ConnectionDetails) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *ConnectionDetails) GetColumnPolicies() map[string]string {
+	if x != nil {
+		return x.ColumnPolicies
+	}
+	return nil
+}
+
+func (x *ConnectionDetails) GetStorageType() string {
+	if x != nil {
+		return x.StorageType
+	}
+	return ""
+}
+
+type ConfigureProvider_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of cloudquery executing this plugin
+	CloudqueryVersion string `protobuf:"bytes,1,opt,name=cloudquery_version,json=cloudqueryVersion,proto3" json:"cloudquery_version,omitempty"`
+	// Connection details for connecting to the database
+	Connection *ConnectionDetails `protobuf:"bytes,2,opt,name=connection,proto3" json:"connection,omitempty"`
+	// Holds information such as credentials, regions, accounts, etc'
+	Config []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	// Format of the config, YAML if not set
+	Format ConfigFormat `protobuf:"varint,6,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
+	// Experimental SDK behaviors enabled or disabled for this run, by flag name
+	FeatureFlags map[string]bool `protobuf:"bytes,7,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *ConfigureProvider_Request) Reset() {
+	*x = ConfigureProvider_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigureProvider_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigureProvider_Request) ProtoMessage() {}
+
+func (x *ConfigureProvider_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigureProvider_Request.ProtoReflect.Descriptor instead.
+func (*ConfigureProvider_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ConfigureProvider_Request) GetCloudqueryVersion() string {
+	if x != nil {
+		return x.CloudqueryVersion
+	}
+	return ""
+}
+
+func (x *ConfigureProvider_Request) GetConnection() *ConnectionDetails {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+func (x *ConfigureProvider_Request) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ConfigureProvider_Request) GetFormat() ConfigFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ConfigFormat_Invalid
+}
+
+func (x *ConfigureProvider_Request) GetFeatureFlags() map[string]bool {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+type ConfigureProvider_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error       string        `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Diagnostics []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+}
+
+func (x *ConfigureProvider_Response) Reset() {
+	*x = ConfigureProvider_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigureProvider_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigureProvider_Response) ProtoMessage() {}
+
+func (x *ConfigureProvider_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigureProvider_Response.ProtoReflect.Descriptor instead.
+func (*ConfigureProvider_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ConfigureProvider_Response) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ConfigureProvider_Response) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+type FetchResources_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// list of resources provider is requested to fetch
+	Resources []string `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	// trigger to enable partial fetching
+	PartialFetchingEnabled bool `protobuf:"varint,2,opt,name=partial_fetching_enabled,json=partialFetchingEnabled,proto3" json:"partial_fetching_enabled,omitempty"`
+	// if value is more than 0 it enables throttling for concurrent fetch
+	ParallelFetchingLimit uint64 `protobuf:"varint,3,opt,name=parallel_fetching_limit,json=parallelFetchingLimit,proto3" json:"parallel_fetching_limit,omitempty"`
+	// approximate maximum goroutines spawned during fetch
+	MaxGoroutines uint64 `protobuf:"varint,4,opt,name=max_goroutines,json=maxGoroutines,proto3" json:"max_goroutines,omitempty"`
+	// Msgpack encoded: Metadata for fetch, used to pass information to cq* resolvers
+	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// timeout for each parent resource resolve call
+	Timeout int64 `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// validate requested resources against the database schema before fetching
+	ValidateSchema bool `protobuf:"varint,7,opt,name=validate_schema,json=validateSchema,proto3" json:"validate_schema,omitempty"`
+	// if more than 0, resources are first fetched with a sample of up to this many rows per table, and only resources
+	// whose sample succeeded are fully fetched
+	CanaryRows uint64 `protobuf:"varint,8,opt,name=canary_rows,json=canaryRows,proto3" json:"canary_rows,omitempty"`
+	// if more than 0, the interval in milliseconds at which progress updates of running resources are sent
+	ProgressInterval int64 `protobuf:"varint,9,opt,name=progress_interval,json=progressInterval,proto3" json:"progress_interval,omitempty"`
+	// if set, only the requested resources whose table has all these labels are fetched
+	Labels map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// if set, continues the interrupted fetch with this id from its checkpoints
+	ResumeFetchId string `protobuf:"bytes,11,opt,name=resume_fetch_id,json=resumeFetchId,proto3" json:"resume_fetch_id,omitempty"`
+	// if set, resolvers are executed but the resources aren't written to the database
+	DryRun bool `protobuf:"varint,12,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// if set, resolved resources are compared to the stored data instead of written, and drifted tables are reported
+	Verify bool `protobuf:"varint,13,opt,name=verify,proto3" json:"verify,omitempty"`
+	// how errors of the fetch are handled, best effort fetches keep fetching on errors
+	ErrorPolicy ErrorPolicy `protobuf:"varint,14,opt,name=error_policy,json=errorPolicy,proto3,enum=proto.ErrorPolicy" json:"error_policy,omitempty"`
+	// if set, the fetched resources are owned by this tenant, requires a multi tenant provider
+	TenantId string `protobuf:"bytes,15,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// if set, incremental tables are fully fetched, ignoring the cursors of the previous fetches
+	FullRefresh bool `protobuf:"varint,16,opt,name=full_refresh,json=fullRefresh,proto3" json:"full_refresh,omitempty"`
+	// filter expressions limiting the resources fetched, by table name
+	TableFilters map[string]string `protobuf:"bytes,17,rep,name=table_filters,json=tableFilters,proto3" json:"table_filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// if set, stale data isn't removed, the stale rows are counted instead
+	SkipStaleCleanup bool `protobuf:"varint,18,opt,name=skip_stale_cleanup,json=skipStaleCleanup,proto3" json:"skip_stale_cleanup,omitempty"`
+	// if more than 0, the maximum amount of resources resolved and inserted together, 500 if not set
+	BatchSize uint64 `protobuf:"varint,19,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+}
+
+func (x *FetchResources_Request) Reset() {
+	*x = FetchResources_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchResources_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResources_Request) ProtoMessage() {}
+
+func (x *FetchResources_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResources_Request.ProtoReflect.Descriptor instead.
+func (*FetchResources_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *FetchResources_Request) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *FetchResources_Request) GetPartialFetchingEnabled() bool {
+	if x != nil {
+		return x.PartialFetchingEnabled
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetParallelFetchingLimit() uint64 {
+	if x != nil {
+		return x.ParallelFetchingLimit
+	}
+	return 0
+}
+
+func (x *FetchResources_Request) GetMaxGoroutines() uint64 {
+	if x != nil {
+		return x.MaxGoroutines
+	}
+	return 0
+}
+
+func (x *FetchResources_Request) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FetchResources_Request) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *FetchResources_Request) GetValidateSchema() bool {
+	if x != nil {
+		return x.ValidateSchema
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetCanaryRows() uint64 {
+	if x != nil {
+		return x.CanaryRows
+	}
+	return 0
+}
+
+func (x *FetchResources_Request) GetProgressInterval() int64 {
+	if x != nil {
+		return x.ProgressInterval
+	}
+	return 0
+}
+
+func (x *FetchResources_Request) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *FetchResources_Request) GetResumeFetchId() string {
+	if x != nil {
+		return x.ResumeFetchId
+	}
+	return ""
+}
+
+func (x *FetchResources_Request) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetVerify() bool {
+	if x != nil {
+		return x.Verify
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetErrorPolicy() ErrorPolicy {
+	if x != nil {
+		return x.ErrorPolicy
+	}
+	return ErrorPolicy_BEST_EFFORT
+}
+
+func (x *FetchResources_Request) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *FetchResources_Request) GetFullRefresh() bool {
+	if x != nil {
+		return x.FullRefresh
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetTableFilters() map[string]string {
+	if x != nil {
+		return x.TableFilters
+	}
+	return nil
+}
+
+func (x *FetchResources_Request) GetSkipStaleCleanup() bool {
+	if x != nil {
+		return x.SkipStaleCleanup
+	}
+	return false
+}
+
+func (x *FetchResources_Request) GetBatchSize() uint64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type FetchResources_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// map of resources that have finished fetching
+	FinishedResources map[string]bool `protobuf:"bytes,1,rep,name=finished_resources,json=finishedResources,proto3" json:"finished_resources,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Amount of resources collected so far
+	ResourceCount uint64 `protobuf:"varint,2,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	// Error value if any, if returned the stream will be canceled
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// list of resources where the fetching failed
+	PartialFetchFailedResources []*PartialFetchFailedResource `protobuf:"bytes,4,rep,name=partial_fetch_failed_resources,json=partialFetchFailedResources,proto3" json:"partial_fetch_failed_resources,omitempty"`
+	// fetch summary of resource that finished
+	Summary *ResourceFetchSummary `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	// name of resource that finished
+	Resource string `protobuf:"bytes,6,opt,name=resource,proto3" json:"resource,omitempty"`
+	// if set, the response is a progress update of the resource, which is still running
+	Progress *ResourceFetchProgress `protobuf:"bytes,7,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (x *FetchResources_Response) Reset() {
+	*x = FetchResources_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchResources_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResources_Response) ProtoMessage() {}
+
+func (x *FetchResources_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResources_Response.ProtoReflect.Descriptor instead.
+func (*FetchResources_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *FetchResources_Response) GetFinishedResources() map[string]bool {
+	if x != nil {
+		return x.FinishedResources
+	}
+	return nil
+}
+
+func (x *FetchResources_Response) GetResourceCount() uint64 {
+	if x != nil {
+		return x.ResourceCount
+	}
+	return 0
+}
+
+func (x *FetchResources_Response) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FetchResources_Response) GetPartialFetchFailedResources() []*PartialFetchFailedResource {
+	if x != nil {
+		return x.PartialFetchFailedResources
+	}
+	return nil
+}
+
+func (x *FetchResources_Response) GetSummary() *ResourceFetchSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *FetchResources_Response) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
 }
 
-// Deprecated: Use GetProviderConfig.ProtoReflect.Descriptor instead.
-func (*GetProviderConfig) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{6}
+func (x *FetchResources_Response) GetProgress() *ResourceFetchProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
 }
 
-type GetModuleInfo struct {
+type GetProviderSchema_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GetModuleInfo) Reset() {
-	*x = GetModuleInfo{}
+func (x *GetProviderSchema_Request) Reset() {
+	*x = GetProviderSchema_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[7]
+		mi := &file_internal_plugin_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetModuleInfo) String() string {
+func (x *GetProviderSchema_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleInfo) ProtoMessage() {}
+func (*GetProviderSchema_Request) ProtoMessage() {}
 
-func (x *GetModuleInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[7]
+func (x *GetProviderSchema_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -804,42 +2416,39 @@ func (x *GetModuleInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleInfo.ProtoReflect.Descriptor instead.
-func (*GetModuleInfo) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetProviderSchema_Request.ProtoReflect.Descriptor instead.
+func (*GetProviderSchema_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{9, 0}
 }
 
-// Table is the definition of how a table is defined in a provider
-type Table struct {
+type GetProviderSchema_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Columns     []*Column             `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
-	Relations   []*Table              `protobuf:"bytes,4,rep,name=relations,proto3" json:"relations,omitempty"`
-	Options     *TableCreationOptions `protobuf:"bytes,5,opt,name=options,proto3,oneof" json:"options,omitempty"`
-	Serial      string                `protobuf:"bytes,6,opt,name=serial,proto3" json:"serial,omitempty"`
+	Name           string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version        string            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	ResourceTables map[string]*Table `protobuf:"bytes,3,rep,name=resource_tables,json=resourceTables,proto3" json:"resource_tables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Diagnostics    []*Diagnostic     `protobuf:"bytes,6,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *Table) Reset() {
-	*x = Table{}
+func (x *GetProviderSchema_Response) Reset() {
+	*x = GetProviderSchema_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[8]
+		mi := &file_internal_plugin_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Table) String() string {
+func (x *GetProviderSchema_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Table) ProtoMessage() {}
+func (*GetProviderSchema_Response) ProtoMessage() {}
 
-func (x *Table) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[8]
+func (x *GetProviderSchema_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,81 +2459,65 @@ func (x *Table) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Table.ProtoReflect.Descriptor instead.
-func (*Table) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetProviderSchema_Response.ProtoReflect.Descriptor instead.
+func (*GetProviderSchema_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{9, 1}
 }
 
-func (x *Table) GetName() string {
+func (x *GetProviderSchema_Response) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Table) GetDescription() string {
+func (x *GetProviderSchema_Response) GetVersion() string {
 	if x != nil {
-		return x.Description
+		return x.Version
 	}
 	return ""
 }
 
-func (x *Table) GetColumns() []*Column {
-	if x != nil {
-		return x.Columns
-	}
-	return nil
-}
-
-func (x *Table) GetRelations() []*Table {
+func (x *GetProviderSchema_Response) GetResourceTables() map[string]*Table {
 	if x != nil {
-		return x.Relations
+		return x.ResourceTables
 	}
 	return nil
 }
 
-func (x *Table) GetOptions() *TableCreationOptions {
+func (x *GetProviderSchema_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
-		return x.Options
+		return x.Diagnostics
 	}
 	return nil
 }
 
-func (x *Table) GetSerial() string {
-	if x != nil {
-		return x.Serial
-	}
-	return ""
-}
-
-type Column struct {
+type GetProviderConfig_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description string      `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Type        ColumnType  `protobuf:"varint,3,opt,name=type,proto3,enum=proto.ColumnType" json:"type,omitempty"`
-	Meta        *ColumnMeta `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"`
+	// Deprecated. Format of the config, always ConfigYAML
+	Format ConfigFormat `protobuf:"varint,1,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
 }
 
-func (x *Column) Reset() {
-	*x = Column{}
+func (x *GetProviderConfig_Request) Reset() {
+	*x = GetProviderConfig_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[9]
+		mi := &file_internal_plugin_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Column) String() string {
+func (x *GetProviderConfig_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Column) ProtoMessage() {}
+func (*GetProviderConfig_Request) ProtoMessage() {}
 
-func (x *Column) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[9]
+func (x *GetProviderConfig_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -935,65 +2528,49 @@ func (x *Column) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Column.ProtoReflect.Descriptor instead.
-func (*Column) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Column) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Column) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *Column) GetType() ColumnType {
-	if x != nil {
-		return x.Type
-	}
-	return ColumnType_INVALID
+// Deprecated: Use GetProviderConfig_Request.ProtoReflect.Descriptor instead.
+func (*GetProviderConfig_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{10, 0}
 }
 
-func (x *Column) GetMeta() *ColumnMeta {
+func (x *GetProviderConfig_Request) GetFormat() ConfigFormat {
 	if x != nil {
-		return x.Meta
+		return x.Format
 	}
-	return nil
+	return ConfigFormat_Invalid
 }
 
-type ColumnMeta struct {
+type GetProviderConfig_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Resolver     *ResolverMeta `protobuf:"bytes,1,opt,name=resolver,proto3" json:"resolver,omitempty"`
-	IgnoreExists bool          `protobuf:"varint,2,opt,name=IgnoreExists,proto3" json:"IgnoreExists,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Config  []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	// Deprecated. Format of the config, always ConfigYAML
+	//
+	// Deprecated: Do not use.
+	Format ConfigFormat `protobuf:"varint,4,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
 }
 
-func (x *ColumnMeta) Reset() {
-	*x = ColumnMeta{}
+func (x *GetProviderConfig_Response) Reset() {
+	*x = GetProviderConfig_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[10]
+		mi := &file_internal_plugin_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ColumnMeta) String() string {
+func (x *GetProviderConfig_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColumnMeta) ProtoMessage() {}
+func (*GetProviderConfig_Response) ProtoMessage() {}
 
-func (x *ColumnMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[10]
+func (x *GetProviderConfig_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,51 +2581,66 @@ func (x *ColumnMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColumnMeta.ProtoReflect.Descriptor instead.
-func (*ColumnMeta) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetProviderConfig_Response.ProtoReflect.Descriptor instead.
+func (*GetProviderConfig_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{10, 1}
 }
 
-func (x *ColumnMeta) GetResolver() *ResolverMeta {
+func (x *GetProviderConfig_Response) GetName() string {
 	if x != nil {
-		return x.Resolver
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetProviderConfig_Response) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetProviderConfig_Response) GetConfig() []byte {
+	if x != nil {
+		return x.Config
 	}
 	return nil
 }
 
-func (x *ColumnMeta) GetIgnoreExists() bool {
+// Deprecated: Do not use.
+func (x *GetProviderConfig_Response) GetFormat() ConfigFormat {
 	if x != nil {
-		return x.IgnoreExists
+		return x.Format
 	}
-	return false
+	return ConfigFormat_Invalid
 }
 
-type ResolverMeta struct {
+type GetModuleInfo_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Builtin bool   `protobuf:"varint,2,opt,name=builtin,proto3" json:"builtin,omitempty"`
+	Module            string   `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	PreferredVersions []uint32 `protobuf:"varint,2,rep,packed,name=preferred_versions,json=preferredVersions,proto3" json:"preferred_versions,omitempty"`
 }
 
-func (x *ResolverMeta) Reset() {
-	*x = ResolverMeta{}
+func (x *GetModuleInfo_Request) Reset() {
+	*x = GetModuleInfo_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[11]
+		mi := &file_internal_plugin_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResolverMeta) String() string {
+func (x *GetModuleInfo_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolverMeta) ProtoMessage() {}
+func (*GetModuleInfo_Request) ProtoMessage() {}
 
-func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[11]
+func (x *GetModuleInfo_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,50 +2651,52 @@ func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolverMeta.ProtoReflect.Descriptor instead.
-func (*ResolverMeta) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetModuleInfo_Request.ProtoReflect.Descriptor instead.
+func (*GetModuleInfo_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{11, 0}
 }
 
-func (x *ResolverMeta) GetName() string {
+func (x *GetModuleInfo_Request) GetModule() string {
 	if x != nil {
-		return x.Name
+		return x.Module
 	}
 	return ""
 }
 
-func (x *ResolverMeta) GetBuiltin() bool {
+func (x *GetModuleInfo_Request) GetPreferredVersions() []uint32 {
 	if x != nil {
-		return x.Builtin
+		return x.PreferredVersions
 	}
-	return false
+	return nil
 }
 
-type TableCreationOptions struct {
+type GetModuleInfo_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PrimaryKeys []string `protobuf:"bytes,1,rep,name=PrimaryKeys,proto3" json:"PrimaryKeys,omitempty"`
+	Data              map[uint32]*GetModuleInfo_Response_ModuleInfo `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AvailableVersions []uint32                                      `protobuf:"varint,2,rep,packed,name=available_versions,json=availableVersions,proto3" json:"available_versions,omitempty"`
+	Diagnostics       []*Diagnostic                                 `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *TableCreationOptions) Reset() {
-	*x = TableCreationOptions{}
+func (x *GetModuleInfo_Response) Reset() {
+	*x = GetModuleInfo_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[12]
+		mi := &file_internal_plugin_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TableCreationOptions) String() string {
+func (x *GetModuleInfo_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TableCreationOptions) ProtoMessage() {}
+func (*GetModuleInfo_Response) ProtoMessage() {}
 
-func (x *TableCreationOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[12]
+func (x *GetModuleInfo_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,44 +2707,58 @@ func (x *TableCreationOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TableCreationOptions.ProtoReflect.Descriptor instead.
-func (*TableCreationOptions) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetModuleInfo_Response.ProtoReflect.Descriptor instead.
+func (*GetModuleInfo_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{11, 1}
 }
 
-func (x *TableCreationOptions) GetPrimaryKeys() []string {
+func (x *GetModuleInfo_Response) GetData() map[uint32]*GetModuleInfo_Response_ModuleInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetModuleInfo_Response) GetAvailableVersions() []uint32 {
+	if x != nil {
+		return x.AvailableVersions
+	}
+	return nil
+}
+
+func (x *GetModuleInfo_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
-		return x.PrimaryKeys
+		return x.Diagnostics
 	}
 	return nil
 }
 
-type ConnectionDetails struct {
+type GetModuleInfo_Response_ModuleInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type ConnectionType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.ConnectionType" json:"type,omitempty"`
-	Dsn  string         `protobuf:"bytes,2,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	Files  []*GetModuleInfo_Response_ModuleInfo_ModuleFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Extras map[string]string                               `protobuf:"bytes,2,rep,name=extras,proto3" json:"extras,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ConnectionDetails) Reset() {
-	*x = ConnectionDetails{}
+func (x *GetModuleInfo_Response_ModuleInfo) Reset() {
+	*x = GetModuleInfo_Response_ModuleInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[13]
+		mi := &file_internal_plugin_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConnectionDetails) String() string {
+func (x *GetModuleInfo_Response_ModuleInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionDetails) ProtoMessage() {}
+func (*GetModuleInfo_Response_ModuleInfo) ProtoMessage() {}
 
-func (x *ConnectionDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[13]
+func (x *GetModuleInfo_Response_ModuleInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1161,59 +2769,51 @@ func (x *ConnectionDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionDetails.ProtoReflect.Descriptor instead.
-func (*ConnectionDetails) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetModuleInfo_Response_ModuleInfo.ProtoReflect.Descriptor instead.
+func (*GetModuleInfo_Response_ModuleInfo) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{11, 1, 1}
 }
 
-func (x *ConnectionDetails) GetType() ConnectionType {
+func (x *GetModuleInfo_Response_ModuleInfo) GetFiles() []*GetModuleInfo_Response_ModuleInfo_ModuleFile {
 	if x != nil {
-		return x.Type
+		return x.Files
 	}
-	return ConnectionType_POSTGRES
+	return nil
 }
 
-func (x *ConnectionDetails) GetDsn() string {
+func (x *GetModuleInfo_Response_ModuleInfo) GetExtras() map[string]string {
 	if x != nil {
-		return x.Dsn
+		return x.Extras
 	}
-	return ""
+	return nil
 }
 
-type ConfigureProvider_Request struct {
+type GetModuleInfo_Response_ModuleInfo_ModuleFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Version of cloudquery executing this plugin
-	CloudqueryVersion string `protobuf:"bytes,1,opt,name=cloudquery_version,json=cloudqueryVersion,proto3" json:"cloudquery_version,omitempty"`
-	// Connection details for connecting to the database
-	Connection *ConnectionDetails `protobuf:"bytes,2,opt,name=connection,proto3" json:"connection,omitempty"`
-	// Holds information such as credentials, regions, accounts, etc'
-	Config []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
-	// Deprecated. Format of the config, always ConfigYAML
-	//
-	// Deprecated: Do not use.
-	Format ConfigFormat `protobuf:"varint,6,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Contents []byte `protobuf:"bytes,2,opt,name=contents,proto3" json:"contents,omitempty"`
 }
 
-func (x *ConfigureProvider_Request) Reset() {
-	*x = ConfigureProvider_Request{}
+func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) Reset() {
+	*x = GetModuleInfo_Response_ModuleInfo_ModuleFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[14]
+		mi := &file_internal_plugin_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConfigureProvider_Request) String() string {
+func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigureProvider_Request) ProtoMessage() {}
+func (*GetModuleInfo_Response_ModuleInfo_ModuleFile) ProtoMessage() {}
 
-func (x *ConfigureProvider_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[14]
+func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1224,66 +2824,51 @@ func (x *ConfigureProvider_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigureProvider_Request.ProtoReflect.Descriptor instead.
-func (*ConfigureProvider_Request) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{0, 0}
+// Deprecated: Use GetModuleInfo_Response_ModuleInfo_ModuleFile.ProtoReflect.Descriptor instead.
+func (*GetModuleInfo_Response_ModuleInfo_ModuleFile) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{11, 1, 1, 1}
 }
 
-func (x *ConfigureProvider_Request) GetCloudqueryVersion() string {
+func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) GetName() string {
 	if x != nil {
-		return x.CloudqueryVersion
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ConfigureProvider_Request) GetConnection() *ConnectionDetails {
-	if x != nil {
-		return x.Connection
-	}
-	return nil
-}
-
-func (x *ConfigureProvider_Request) GetConfig() []byte {
+func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) GetContents() []byte {
 	if x != nil {
-		return x.Config
+		return x.Contents
 	}
 	return nil
 }
 
-// Deprecated: Do not use.
-func (x *ConfigureProvider_Request) GetFormat() ConfigFormat {
-	if x != nil {
-		return x.Format
-	}
-	return ConfigFormat_Invalid
-}
-
-type ConfigureProvider_Response struct {
+type GetFetchStatus_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Error       string        `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Diagnostics []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// id of the fetch, as passed in the cq_fetch_id metadata of FetchResources. Empty for a fetch without an id
+	FetchId string `protobuf:"bytes,1,opt,name=fetch_id,json=fetchId,proto3" json:"fetch_id,omitempty"`
 }
 
-func (x *ConfigureProvider_Response) Reset() {
-	*x = ConfigureProvider_Response{}
+func (x *GetFetchStatus_Request) Reset() {
+	*x = GetFetchStatus_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[15]
+		mi := &file_internal_plugin_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConfigureProvider_Response) String() string {
+func (x *GetFetchStatus_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigureProvider_Response) ProtoMessage() {}
+func (*GetFetchStatus_Request) ProtoMessage() {}
 
-func (x *ConfigureProvider_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[15]
+func (x *GetFetchStatus_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,61 +2879,56 @@ func (x *ConfigureProvider_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigureProvider_Response.ProtoReflect.Descriptor instead.
-func (*ConfigureProvider_Response) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{0, 1}
+// Deprecated: Use GetFetchStatus_Request.ProtoReflect.Descriptor instead.
+func (*GetFetchStatus_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{12, 0}
 }
 
-func (x *ConfigureProvider_Response) GetError() string {
+func (x *GetFetchStatus_Request) GetFetchId() string {
 	if x != nil {
-		return x.Error
+		return x.FetchId
 	}
 	return ""
 }
 
-func (x *ConfigureProvider_Response) GetDiagnostics() []*Diagnostic {
-	if x != nil {
-		return x.Diagnostics
-	}
-	return nil
-}
-
-type FetchResources_Request struct {
+type GetFetchStatus_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// list of resources provider is requested to fetch
-	Resources []string `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
-	// trigger to enable partial fetching
-	PartialFetchingEnabled bool `protobuf:"varint,2,opt,name=partial_fetching_enabled,json=partialFetchingEnabled,proto3" json:"partial_fetching_enabled,omitempty"`
-	// if value is more than 0 it enables throttling for concurrent fetch
-	ParallelFetchingLimit uint64 `protobuf:"varint,3,opt,name=parallel_fetching_limit,json=parallelFetchingLimit,proto3" json:"parallel_fetching_limit,omitempty"`
-	// approximate maximum goroutines spawned during fetch
-	MaxGoroutines uint64 `protobuf:"varint,4,opt,name=max_goroutines,json=maxGoroutines,proto3" json:"max_goroutines,omitempty"`
-	// Msgpack encoded: Metadata for fetch, used to pass information to cq* resolvers
-	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// timeout for each parent resource resolve call
-	Timeout int64 `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
-}
-
-func (x *FetchResources_Request) Reset() {
-	*x = FetchResources_Request{}
+	// false if the provider has no record of the requested fetch
+	Found bool `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	// true if the fetch finished
+	Done bool `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	// Amount of resources resolved so far, including relations
+	RowsResolved uint64 `protobuf:"varint,3,opt,name=rows_resolved,json=rowsResolved,proto3" json:"rows_resolved,omitempty"`
+	// Amount of resources written to the database so far
+	RowsStored uint64 `protobuf:"varint,4,opt,name=rows_stored,json=rowsStored,proto3" json:"rows_stored,omitempty"`
+	// Estimated amount of bytes of the resolved resources' values
+	BytesEstimated uint64 `protobuf:"varint,5,opt,name=bytes_estimated,json=bytesEstimated,proto3" json:"bytes_estimated,omitempty"`
+	// Amount of diagnostics reported so far, by Diagnostic.Severity
+	DiagnosticCounts map[int32]uint64 `protobuf:"bytes,6,rep,name=diagnostic_counts,json=diagnosticCounts,proto3" json:"diagnostic_counts,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Error the fetch ended with, if any
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *GetFetchStatus_Response) Reset() {
+	*x = GetFetchStatus_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[16]
+		mi := &file_internal_plugin_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FetchResources_Request) String() string {
+func (x *GetFetchStatus_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchResources_Request) ProtoMessage() {}
+func (*GetFetchStatus_Response) ProtoMessage() {}
 
-func (x *FetchResources_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[16]
+func (x *GetFetchStatus_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,89 +2939,86 @@ func (x *FetchResources_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchResources_Request.ProtoReflect.Descriptor instead.
-func (*FetchResources_Request) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use GetFetchStatus_Response.ProtoReflect.Descriptor instead.
+func (*GetFetchStatus_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{12, 1}
 }
 
-func (x *FetchResources_Request) GetResources() []string {
+func (x *GetFetchStatus_Response) GetFound() bool {
 	if x != nil {
-		return x.Resources
+		return x.Found
 	}
-	return nil
+	return false
 }
 
-func (x *FetchResources_Request) GetPartialFetchingEnabled() bool {
+func (x *GetFetchStatus_Response) GetDone() bool {
 	if x != nil {
-		return x.PartialFetchingEnabled
+		return x.Done
 	}
 	return false
 }
 
-func (x *FetchResources_Request) GetParallelFetchingLimit() uint64 {
+func (x *GetFetchStatus_Response) GetRowsResolved() uint64 {
 	if x != nil {
-		return x.ParallelFetchingLimit
+		return x.RowsResolved
 	}
 	return 0
 }
 
-func (x *FetchResources_Request) GetMaxGoroutines() uint64 {
+func (x *GetFetchStatus_Response) GetRowsStored() uint64 {
 	if x != nil {
-		return x.MaxGoroutines
+		return x.RowsStored
 	}
 	return 0
 }
 
-func (x *FetchResources_Request) GetMetadata() []byte {
+func (x *GetFetchStatus_Response) GetBytesEstimated() uint64 {
 	if x != nil {
-		return x.Metadata
+		return x.BytesEstimated
+	}
+	return 0
+}
+
+func (x *GetFetchStatus_Response) GetDiagnosticCounts() map[int32]uint64 {
+	if x != nil {
+		return x.DiagnosticCounts
 	}
 	return nil
 }
 
-func (x *FetchResources_Request) GetTimeout() int64 {
+func (x *GetFetchStatus_Response) GetError() string {
 	if x != nil {
-		return x.Timeout
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-type FetchResources_Response struct {
+type GetFetches_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// map of resources that have finished fetching
-	FinishedResources map[string]bool `protobuf:"bytes,1,rep,name=finished_resources,json=finishedResources,proto3" json:"finished_resources,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	// Amount of resources collected so far
-	ResourceCount uint64 `protobuf:"varint,2,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
-	// Error value if any, if returned the stream will be canceled
-	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	// list of resources where the fetching failed
-	PartialFetchFailedResources []*PartialFetchFailedResource `protobuf:"bytes,4,rep,name=partial_fetch_failed_resources,json=partialFetchFailedResources,proto3" json:"partial_fetch_failed_resources,omitempty"`
-	// fetch summary of resource that finished
-	Summary *ResourceFetchSummary `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
-	// name of resource that finished
-	Resource string `protobuf:"bytes,6,opt,name=resource,proto3" json:"resource,omitempty"`
+	// maximum amount of fetches to return, latest first. If 0 all persisted fetches are returned
+	Limit uint64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *FetchResources_Response) Reset() {
-	*x = FetchResources_Response{}
+func (x *GetFetches_Request) Reset() {
+	*x = GetFetches_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[17]
+		mi := &file_internal_plugin_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FetchResources_Response) String() string {
+func (x *GetFetches_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchResources_Response) ProtoMessage() {}
+func (*GetFetches_Request) ProtoMessage() {}
 
-func (x *FetchResources_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[17]
+func (x *GetFetches_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1452,118 +3029,195 @@ func (x *FetchResources_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchResources_Response.ProtoReflect.Descriptor instead.
-func (*FetchResources_Response) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{1, 1}
+// Deprecated: Use GetFetches_Request.ProtoReflect.Descriptor instead.
+func (*GetFetches_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{13, 0}
 }
 
-func (x *FetchResources_Response) GetFinishedResources() map[string]bool {
+func (x *GetFetches_Request) GetLimit() uint64 {
 	if x != nil {
-		return x.FinishedResources
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *FetchResources_Response) GetResourceCount() uint64 {
-	if x != nil {
-		return x.ResourceCount
-	}
-	return 0
+type GetFetches_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fetches     []*GetFetches_Fetch `protobuf:"bytes,1,rep,name=fetches,proto3" json:"fetches,omitempty"`
+	Diagnostics []*Diagnostic       `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *FetchResources_Response) GetError() string {
-	if x != nil {
-		return x.Error
+func (x *GetFetches_Response) Reset() {
+	*x = GetFetches_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_plugin_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *FetchResources_Response) GetPartialFetchFailedResources() []*PartialFetchFailedResource {
-	if x != nil {
-		return x.PartialFetchFailedResources
+func (x *GetFetches_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetches_Response) ProtoMessage() {}
+
+func (x *GetFetches_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *FetchResources_Response) GetSummary() *ResourceFetchSummary {
+// Deprecated: Use GetFetches_Response.ProtoReflect.Descriptor instead.
+func (*GetFetches_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{13, 1}
+}
+
+func (x *GetFetches_Response) GetFetches() []*GetFetches_Fetch {
 	if x != nil {
-		return x.Summary
+		return x.Fetches
 	}
 	return nil
 }
 
-func (x *FetchResources_Response) GetResource() string {
+func (x *GetFetches_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
-		return x.Resource
+		return x.Diagnostics
 	}
-	return ""
+	return nil
 }
 
-type GetProviderSchema_Request struct {
+// Fetch is a persisted fetch of the provider
+type GetFetches_Fetch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// id of the fetch, as passed in the cq_fetch_id metadata of FetchResources
+	FetchId string `protobuf:"bytes,1,opt,name=fetch_id,json=fetchId,proto3" json:"fetch_id,omitempty"`
+	// version of the provider that ran the fetch
+	ProviderVersion string `protobuf:"bytes,2,opt,name=provider_version,json=providerVersion,proto3" json:"provider_version,omitempty"`
+	// start and finish time of the fetch, in unix milliseconds
+	Start  int64 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	Finish int64 `protobuf:"varint,4,opt,name=finish,proto3" json:"finish,omitempty"`
+	// error the fetch ended with, if any
+	Error     string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Resources []*GetFetches_Resource `protobuf:"bytes,6,rep,name=resources,proto3" json:"resources,omitempty"`
 }
 
-func (x *GetProviderSchema_Request) Reset() {
-	*x = GetProviderSchema_Request{}
+func (x *GetFetches_Fetch) Reset() {
+	*x = GetFetches_Fetch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[19]
+		mi := &file_internal_plugin_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetProviderSchema_Request) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetFetches_Fetch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetches_Fetch) ProtoMessage() {}
+
+func (x *GetFetches_Fetch) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFetches_Fetch.ProtoReflect.Descriptor instead.
+func (*GetFetches_Fetch) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{13, 2}
+}
+
+func (x *GetFetches_Fetch) GetFetchId() string {
+	if x != nil {
+		return x.FetchId
+	}
+	return ""
+}
+
+func (x *GetFetches_Fetch) GetProviderVersion() string {
+	if x != nil {
+		return x.ProviderVersion
+	}
+	return ""
+}
+
+func (x *GetFetches_Fetch) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
 }
 
-func (*GetProviderSchema_Request) ProtoMessage() {}
+func (x *GetFetches_Fetch) GetFinish() int64 {
+	if x != nil {
+		return x.Finish
+	}
+	return 0
+}
 
-func (x *GetProviderSchema_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[19]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetFetches_Fetch) GetError() string {
+	if x != nil {
+		return x.Error
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetProviderSchema_Request.ProtoReflect.Descriptor instead.
-func (*GetProviderSchema_Request) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{5, 0}
+func (x *GetFetches_Fetch) GetResources() []*GetFetches_Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
 }
 
-type GetProviderSchema_Response struct {
+// Resource is the status of a resource fetched by a persisted fetch
+type GetFetches_Resource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name           string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version        string            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	ResourceTables map[string]*Table `protobuf:"bytes,3,rep,name=resource_tables,json=resourceTables,proto3" json:"resource_tables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resource      string                      `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Status        ResourceFetchSummary_Status `protobuf:"varint,2,opt,name=status,proto3,enum=proto.ResourceFetchSummary_Status" json:"status,omitempty"`
+	ResourceCount uint64                      `protobuf:"varint,3,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	// amount of diagnostics of error or panic severity reported while fetching the resource
+	ErrorCount uint64 `protobuf:"varint,4,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
 }
 
-func (x *GetProviderSchema_Response) Reset() {
-	*x = GetProviderSchema_Response{}
+func (x *GetFetches_Resource) Reset() {
+	*x = GetFetches_Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[20]
+		mi := &file_internal_plugin_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetProviderSchema_Response) String() string {
+func (x *GetFetches_Resource) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProviderSchema_Response) ProtoMessage() {}
+func (*GetFetches_Resource) ProtoMessage() {}
 
-func (x *GetProviderSchema_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[20]
+func (x *GetFetches_Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1574,58 +3228,62 @@ func (x *GetProviderSchema_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProviderSchema_Response.ProtoReflect.Descriptor instead.
-func (*GetProviderSchema_Response) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{5, 1}
+// Deprecated: Use GetFetches_Resource.ProtoReflect.Descriptor instead.
+func (*GetFetches_Resource) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{13, 3}
 }
 
-func (x *GetProviderSchema_Response) GetName() string {
+func (x *GetFetches_Resource) GetResource() string {
 	if x != nil {
-		return x.Name
+		return x.Resource
 	}
 	return ""
 }
 
-func (x *GetProviderSchema_Response) GetVersion() string {
+func (x *GetFetches_Resource) GetStatus() ResourceFetchSummary_Status {
 	if x != nil {
-		return x.Version
+		return x.Status
 	}
-	return ""
+	return ResourceFetchSummary_COMPLETE
 }
 
-func (x *GetProviderSchema_Response) GetResourceTables() map[string]*Table {
+func (x *GetFetches_Resource) GetResourceCount() uint64 {
 	if x != nil {
-		return x.ResourceTables
+		return x.ResourceCount
 	}
-	return nil
+	return 0
 }
 
-type GetProviderConfig_Request struct {
+func (x *GetFetches_Resource) GetErrorCount() uint64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+type GetEffectiveConfig_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// Deprecated. Format of the config, always ConfigYAML
-	Format ConfigFormat `protobuf:"varint,1,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
 }
 
-func (x *GetProviderConfig_Request) Reset() {
-	*x = GetProviderConfig_Request{}
+func (x *GetEffectiveConfig_Request) Reset() {
+	*x = GetEffectiveConfig_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[22]
+		mi := &file_internal_plugin_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetProviderConfig_Request) String() string {
+func (x *GetEffectiveConfig_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProviderConfig_Request) ProtoMessage() {}
+func (*GetEffectiveConfig_Request) ProtoMessage() {}
 
-func (x *GetProviderConfig_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[22]
+func (x *GetEffectiveConfig_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1636,49 +3294,38 @@ func (x *GetProviderConfig_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProviderConfig_Request.ProtoReflect.Descriptor instead.
-func (*GetProviderConfig_Request) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{6, 0}
-}
-
-func (x *GetProviderConfig_Request) GetFormat() ConfigFormat {
-	if x != nil {
-		return x.Format
-	}
-	return ConfigFormat_Invalid
+// Deprecated: Use GetEffectiveConfig_Request.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfig_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{14, 0}
 }
 
-type GetProviderConfig_Response struct {
+type GetEffectiveConfig_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Config  []byte `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
-	// Deprecated. Format of the config, always ConfigYAML
-	//
-	// Deprecated: Do not use.
-	Format ConfigFormat `protobuf:"varint,4,opt,name=format,proto3,enum=proto.ConfigFormat" json:"format,omitempty"`
+	// effective configuration, in YAML
+	Config      []byte        `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Diagnostics []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *GetProviderConfig_Response) Reset() {
-	*x = GetProviderConfig_Response{}
+func (x *GetEffectiveConfig_Response) Reset() {
+	*x = GetEffectiveConfig_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[23]
+		mi := &file_internal_plugin_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetProviderConfig_Response) String() string {
+func (x *GetEffectiveConfig_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProviderConfig_Response) ProtoMessage() {}
+func (*GetEffectiveConfig_Response) ProtoMessage() {}
 
-func (x *GetProviderConfig_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[23]
+func (x *GetEffectiveConfig_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,66 +3336,59 @@ func (x *GetProviderConfig_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProviderConfig_Response.ProtoReflect.Descriptor instead.
-func (*GetProviderConfig_Response) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{6, 1}
-}
-
-func (x *GetProviderConfig_Response) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *GetProviderConfig_Response) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
+// Deprecated: Use GetEffectiveConfig_Response.ProtoReflect.Descriptor instead.
+func (*GetEffectiveConfig_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{14, 1}
 }
 
-func (x *GetProviderConfig_Response) GetConfig() []byte {
+func (x *GetEffectiveConfig_Response) GetConfig() []byte {
 	if x != nil {
 		return x.Config
 	}
 	return nil
 }
 
-// Deprecated: Do not use.
-func (x *GetProviderConfig_Response) GetFormat() ConfigFormat {
+func (x *GetEffectiveConfig_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
-		return x.Format
+		return x.Diagnostics
 	}
-	return ConfigFormat_Invalid
+	return nil
 }
 
-type GetModuleInfo_Request struct {
+type GetResource_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Module            string   `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
-	PreferredVersions []uint32 `protobuf:"varint,2,rep,packed,name=preferred_versions,json=preferredVersions,proto3" json:"preferred_versions,omitempty"`
+	// resource to fetch the single resource of
+	Resource string `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	// values of the primary keys of the resource, by column name
+	PrimaryKeys map[string]string `protobuf:"bytes,2,rep,name=primary_keys,json=primaryKeys,proto3" json:"primary_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Msgpack encoded: Metadata for fetch, used to pass information to cq* resolvers
+	Metadata []byte `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// timeout of the resolve call
+	Timeout int64 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// if set, the resource is owned by this tenant, requires a multi tenant provider
+	TenantId string `protobuf:"bytes,5,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
 }
 
-func (x *GetModuleInfo_Request) Reset() {
-	*x = GetModuleInfo_Request{}
+func (x *GetResource_Request) Reset() {
+	*x = GetResource_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[24]
+		mi := &file_internal_plugin_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetModuleInfo_Request) String() string {
+func (x *GetResource_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleInfo_Request) ProtoMessage() {}
+func (*GetResource_Request) ProtoMessage() {}
 
-func (x *GetModuleInfo_Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[24]
+func (x *GetResource_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1759,52 +3399,73 @@ func (x *GetModuleInfo_Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleInfo_Request.ProtoReflect.Descriptor instead.
-func (*GetModuleInfo_Request) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 0}
+// Deprecated: Use GetResource_Request.ProtoReflect.Descriptor instead.
+func (*GetResource_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *GetModuleInfo_Request) GetModule() string {
+func (x *GetResource_Request) GetResource() string {
 	if x != nil {
-		return x.Module
+		return x.Resource
 	}
 	return ""
 }
 
-func (x *GetModuleInfo_Request) GetPreferredVersions() []uint32 {
+func (x *GetResource_Request) GetPrimaryKeys() map[string]string {
 	if x != nil {
-		return x.PreferredVersions
+		return x.PrimaryKeys
 	}
 	return nil
 }
 
-type GetModuleInfo_Response struct {
+func (x *GetResource_Request) GetMetadata() []byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetResource_Request) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *GetResource_Request) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type GetResource_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data              map[uint32]*GetModuleInfo_Response_ModuleInfo `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	AvailableVersions []uint32                                      `protobuf:"varint,2,rep,packed,name=available_versions,json=availableVersions,proto3" json:"available_versions,omitempty"`
-	Diagnostics       []*Diagnostic                                 `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// amount of resources fetched, 0 if the resource wasn't found
+	ResourceCount uint64        `protobuf:"varint,1,opt,name=resource_count,json=resourceCount,proto3" json:"resource_count,omitempty"`
+	Diagnostics   []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *GetModuleInfo_Response) Reset() {
-	*x = GetModuleInfo_Response{}
+func (x *GetResource_Response) Reset() {
+	*x = GetResource_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[25]
+		mi := &file_internal_plugin_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetModuleInfo_Response) String() string {
+func (x *GetResource_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleInfo_Response) ProtoMessage() {}
+func (*GetResource_Response) ProtoMessage() {}
 
-func (x *GetModuleInfo_Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[25]
+func (x *GetResource_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,58 +3476,48 @@ func (x *GetModuleInfo_Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleInfo_Response.ProtoReflect.Descriptor instead.
-func (*GetModuleInfo_Response) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 1}
-}
-
-func (x *GetModuleInfo_Response) GetData() map[uint32]*GetModuleInfo_Response_ModuleInfo {
-	if x != nil {
-		return x.Data
-	}
-	return nil
+// Deprecated: Use GetResource_Response.ProtoReflect.Descriptor instead.
+func (*GetResource_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{15, 1}
 }
 
-func (x *GetModuleInfo_Response) GetAvailableVersions() []uint32 {
+func (x *GetResource_Response) GetResourceCount() uint64 {
 	if x != nil {
-		return x.AvailableVersions
+		return x.ResourceCount
 	}
-	return nil
+	return 0
 }
 
-func (x *GetModuleInfo_Response) GetDiagnostics() []*Diagnostic {
+func (x *GetResource_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
 		return x.Diagnostics
 	}
 	return nil
 }
 
-type GetModuleInfo_Response_ModuleInfo struct {
+type CheckHealth_Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Files  []*GetModuleInfo_Response_ModuleInfo_ModuleFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	Extras map[string]string                               `protobuf:"bytes,2,rep,name=extras,proto3" json:"extras,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *GetModuleInfo_Response_ModuleInfo) Reset() {
-	*x = GetModuleInfo_Response_ModuleInfo{}
+func (x *CheckHealth_Request) Reset() {
+	*x = CheckHealth_Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[27]
+		mi := &file_internal_plugin_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetModuleInfo_Response_ModuleInfo) String() string {
+func (x *CheckHealth_Request) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleInfo_Response_ModuleInfo) ProtoMessage() {}
+func (*CheckHealth_Request) ProtoMessage() {}
 
-func (x *GetModuleInfo_Response_ModuleInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[27]
+func (x *CheckHealth_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1877,51 +3528,37 @@ func (x *GetModuleInfo_Response_ModuleInfo) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleInfo_Response_ModuleInfo.ProtoReflect.Descriptor instead.
-func (*GetModuleInfo_Response_ModuleInfo) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 1, 1}
-}
-
-func (x *GetModuleInfo_Response_ModuleInfo) GetFiles() []*GetModuleInfo_Response_ModuleInfo_ModuleFile {
-	if x != nil {
-		return x.Files
-	}
-	return nil
-}
-
-func (x *GetModuleInfo_Response_ModuleInfo) GetExtras() map[string]string {
-	if x != nil {
-		return x.Extras
-	}
-	return nil
+// Deprecated: Use CheckHealth_Request.ProtoReflect.Descriptor instead.
+func (*CheckHealth_Request) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{16, 0}
 }
 
-type GetModuleInfo_Response_ModuleInfo_ModuleFile struct {
+type CheckHealth_Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Contents []byte `protobuf:"bytes,2,opt,name=contents,proto3" json:"contents,omitempty"`
+	// diagnostics of the failed checks, the provider is healthy if none is an error
+	Diagnostics []*Diagnostic `protobuf:"bytes,1,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
 }
 
-func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) Reset() {
-	*x = GetModuleInfo_Response_ModuleInfo_ModuleFile{}
+func (x *CheckHealth_Response) Reset() {
+	*x = CheckHealth_Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_plugin_proto_msgTypes[29]
+		mi := &file_internal_plugin_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) String() string {
+func (x *CheckHealth_Response) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleInfo_Response_ModuleInfo_ModuleFile) ProtoMessage() {}
+func (*CheckHealth_Response) ProtoMessage() {}
 
-func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_plugin_proto_msgTypes[29]
+func (x *CheckHealth_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_plugin_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1932,21 +3569,14 @@ func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) ProtoReflect() protorefle
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleInfo_Response_ModuleInfo_ModuleFile.ProtoReflect.Descriptor instead.
-func (*GetModuleInfo_Response_ModuleInfo_ModuleFile) Descriptor() ([]byte, []int) {
-	return file_internal_plugin_proto_rawDescGZIP(), []int{7, 1, 1, 1}
-}
-
-func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use CheckHealth_Response.ProtoReflect.Descriptor instead.
+func (*CheckHealth_Response) Descriptor() ([]byte, []int) {
+	return file_internal_plugin_proto_rawDescGZIP(), []int{16, 1}
 }
 
-func (x *GetModuleInfo_Response_ModuleInfo_ModuleFile) GetContents() []byte {
+func (x *CheckHealth_Response) GetDiagnostics() []*Diagnostic {
 	if x != nil {
-		return x.Contents
+		return x.Diagnostics
 	}
 	return nil
 }
@@ -1955,9 +3585,9 @@ var File_internal_plugin_proto protoreflect.FileDescriptor
 
 var file_internal_plugin_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb4,
-	0x02, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x1a, 0xc7, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xca,
+	0x03, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x1a, 0xdd, 0x02, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x76,
 	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6c,
 	0x6f, 0x75, 0x64, 0x71, 0x75, 0x65, 0x72, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
@@ -1966,279 +3596,577 @@ var file_internal_plugin_proto_rawDesc = []byte{
 	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x0a, 0x63,
 	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x67, 0x12, 0x2b, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
 	0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x1a, 0x55,
-	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69,
-	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
-	0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xba, 0x05, 0x0a, 0x0e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0xf6, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65,
-	0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x17,
-	0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x69, 0x6e,
-	0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x70,
-	0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x4c,
-	0x69, 0x6d, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x67, 0x6f, 0x72, 0x6f,
-	0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61,
-	0x78, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x1a, 0xae, 0x03, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64,
-	0x0a, 0x12, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x11, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x66, 0x0a, 0x1e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x74,
-	0x63, 0x68, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x46, 0x61,
-	0x69, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x1b, 0x70, 0x61,
-	0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x73, 0x75, 0x6d,
-	0x6d, 0x61, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x57,
+	0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46,
+	0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x46, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04,
+	0x08, 0x05, 0x10, 0x06, 0x1a, 0x55, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x9d, 0x0b, 0x0a, 0x0e,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x1a, 0x9f,
+	0x07, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x5f, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x15, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61,
+	0x78, 0x5f, 0x67, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x6f, 0x77,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x41,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x35, 0x0a, 0x0c, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x75, 0x6c, 0x6c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x12, 0x54, 0x0a, 0x0d, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x6b, 0x69, 0x70, 0x5f,
+	0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x43, 0x6c,
+	0x65, 0x61, 0x6e, 0x75, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x3f, 0x0a, 0x11, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0xe8, 0x03, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a,
+	0x12, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x11, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x66, 0x0a, 0x1e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x1b, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x44, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x70, 0x0a, 0x15, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x22, 0xd9, 0x05,
+	0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x30,
+	0x0a, 0x09, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x69, 0x43, 0x61, 0x6c,
+	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x08, 0x61, 0x70, 0x69, 0x43, 0x61, 0x6c, 0x6c, 0x73,
+	0x12, 0x52, 0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46,
+	0x6c, 0x61, 0x67, 0x73, 0x12, 0x4f, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68,
-	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x44, 0x0a, 0x16,
-	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0xed, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33,
-	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67,
-	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
-	0x69, 0x63, 0x73, 0x22, 0x3d, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0c, 0x0a,
-	0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x46,
-	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x52, 0x54, 0x49,
-	0x41, 0x4c, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44,
-	0x10, 0x03, 0x22, 0xb0, 0x01, 0x0a, 0x1a, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65,
-	0x74, 0x63, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x26, 0x0a, 0x0f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x6f, 0x6f, 0x74, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x17, 0x72, 0x6f, 0x6f, 0x74,
-	0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x72, 0x6f, 0x6f, 0x74, 0x50,
-	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xaf, 0x03, 0x0a, 0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
-	0x73, 0x74, 0x69, 0x63, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e,
-	0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e,
-	0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08,
-	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d,
-	0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74,
-	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x08, 0x72, 0x65, 0x64,
-	0x61, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x49, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x64, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45,
-	0x53, 0x4f, 0x4c, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x43,
-	0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c,
-	0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x10,
-	0x04, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x10, 0x05, 0x12, 0x0c, 0x0a,
-	0x08, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x06, 0x22, 0x3a, 0x0a, 0x08, 0x53,
-	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c,
-	0x49, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12,
-	0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
-	0x50, 0x41, 0x4e, 0x49, 0x43, 0x10, 0x03, 0x22, 0x96, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50,
-	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x09, 0x0a,
-	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0xf5, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x5e, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x73, 0x1a, 0x4f, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06,
-	0x22, 0xcf, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x36, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2b, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x1a, 0x81,
-	0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x22, 0x93, 0x05, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x50, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65,
-	0x72, 0x72, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0d, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xaf, 0x04, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64,
-	0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x11, 0x61, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61,
-	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
-	0x74, 0x69, 0x63, 0x73, 0x1a, 0x61, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x3e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f,
-	0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x9e, 0x02, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x75,
-	0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x49, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
-	0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e,
-	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65,
-	0x73, 0x12, 0x4c, 0x0a, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64,
-	0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x45, 0x78, 0x74, 0x72,
-	0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x1a,
-	0x39, 0x0a, 0x0b, 0x45, 0x78, 0x74, 0x72, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0f,
+	0x66, 0x61, 0x69, 0x6c, 0x5f, 0x66, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x69, 0x6c, 0x46, 0x61, 0x73, 0x74, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0a, 0x4d, 0x6f,
-	0x64, 0x75, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xf2, 0x01, 0x0a, 0x05, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x73, 0x12, 0x2a, 0x0a, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a,
-	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x07, 0x6f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72,
-	0x69, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x8c, 0x01,
-	0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0x61, 0x0a, 0x0a,
-	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2f, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x74,
-	0x61, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x49,
-	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0c, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22,
-	0x3c, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x22, 0x38, 0x0a,
-	0x14, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
-	0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x50, 0x72, 0x69, 0x6d,
-	0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x50, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x73, 0x6e, 0x2a, 0x29, 0x0a, 0x0c, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0f, 0x0a, 0x07, 0x49, 0x6e, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x10, 0x00, 0x1a, 0x02, 0x08, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x59, 0x41,
-	0x4d, 0x4c, 0x10, 0x01, 0x2a, 0x97, 0x02, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x4d,
-	0x41, 0x4c, 0x4c, 0x49, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e, 0x54, 0x10,
-	0x03, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x47, 0x49, 0x4e, 0x54, 0x10, 0x04, 0x12, 0x09, 0x0a,
-	0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
-	0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x0e,
-	0x0a, 0x0a, 0x42, 0x59, 0x54, 0x45, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x08, 0x12, 0x10,
-	0x0a, 0x0c, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x09,
-	0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x54, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x0a, 0x12,
-	0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x0b, 0x12, 0x08,
-	0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x55, 0x49, 0x44,
-	0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x0d, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x45, 0x54,
-	0x10, 0x0e, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e, 0x45, 0x54, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59,
-	0x10, 0x0f, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x10, 0x10, 0x12, 0x0e, 0x0a, 0x0a,
-	0x43, 0x49, 0x44, 0x52, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08,
-	0x4d, 0x41, 0x43, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x10, 0x12, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x41,
-	0x43, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x13, 0x2a, 0x1e,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0c, 0x0a, 0x08, 0x50, 0x4f, 0x53, 0x54, 0x47, 0x52, 0x45, 0x53, 0x10, 0x00, 0x32, 0xb9,
-	0x03, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x11, 0x47,
-	0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3d, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x43,
+	0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x22, 0xae, 0x01, 0x0a, 0x12, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x3a, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x0c, 0x41,
+	0x70, 0x69, 0x43, 0x61, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb0, 0x01, 0x0a,
+	0x1a, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x6f,
+	0x6f, 0x74, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x6f, 0x6f, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x35, 0x0a, 0x17, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x14, 0x72, 0x6f, 0x6f, 0x74, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
+	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
+	0xf8, 0x03, 0x0a, 0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x2a,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x2d, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x08, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x48, 0x52, 0x4f,
+	0x54, 0x54, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41,
+	0x53, 0x45, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x10, 0x05,
+	0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x06, 0x22, 0x3a,
+	0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e,
+	0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12,
+	0x09, 0x0a, 0x05, 0x50, 0x41, 0x4e, 0x49, 0x43, 0x10, 0x03, 0x22, 0x33, 0x0a, 0x10, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22,
+	0xcb, 0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0xaa, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x5e, 0x0a, 0x0f, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x0b, 0x64,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x1a, 0x4f, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0xcf, 0x01,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x1a, 0x36, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x1a, 0x81, 0x01, 0x0a, 0x08,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2f,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22,
+	0x93, 0x05, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x1a, 0x50, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
+	0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0xaf, 0x04, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2d, 0x0a,
+	0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x1a, 0x61, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x3e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x9e, 0x02, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x49, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x4c,
+	0x0a, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x65, 0x78, 0x74, 0x72, 0x61, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
+	0x45, 0x78, 0x74, 0x72, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x9a, 0x03, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x24, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x49, 0x64, 0x1a, 0xe1,
+	0x02, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x6f,
+	0x77, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x6f,
+	0x77, 0x73, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x72, 0x6f, 0x77, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x45, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x61, 0x0a, 0x11, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x43, 0x0a,
+	0x15, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x9c, 0x04, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x1a, 0x1f, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x1a, 0x72, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x1a, 0xcb, 0x01, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x12, 0x19, 0x0a, 0x08, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x66, 0x65, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x70,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x1a, 0xaa, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3a, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x78, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x57, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x80, 0x03, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x88, 0x02, 0x0a, 0x07,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72,
+	0x79, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x66, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x59,
+	0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x1a, 0x09, 0x0a,
+	0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3f, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0xff, 0x02, 0x0a, 0x05, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a,
+	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x07,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x12, 0x30, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9d, 0x02, 0x0a, 0x06,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x10, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x15, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x6e, 0x6f, 0x74, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x6e, 0x6f, 0x74, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x27, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x71, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x49, 0x6e, 0x43, 0x71, 0x49, 0x64,
+	0x22, 0xab, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4d, 0x65, 0x74, 0x61, 0x12,
+	0x2f, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72,
+	0x12, 0x22, 0x0a, 0x0c, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x45, 0x78,
+	0x69, 0x73, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x73, 0x22, 0x3c,
+	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x22, 0x75, 0x0a, 0x14,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x6f, 0x66, 0x74, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x22, 0x8d, 0x02, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x64, 0x73, 0x6e, 0x12, 0x55, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x1a, 0x41, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x2a, 0x2d, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x45, 0x46, 0x46, 0x4f, 0x52,
+	0x54, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x46, 0x41, 0x53, 0x54,
+	0x10, 0x01, 0x2a, 0x32, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x0f, 0x0a, 0x07, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0x00, 0x1a,
+	0x02, 0x08, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x59, 0x41, 0x4d, 0x4c, 0x10, 0x01, 0x12, 0x07, 0x0a,
+	0x03, 0x48, 0x43, 0x4c, 0x10, 0x02, 0x2a, 0xc1, 0x02, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x49, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x4e,
+	0x54, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x47, 0x49, 0x4e, 0x54, 0x10, 0x04, 0x12,
+	0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x55,
+	0x49, 0x44, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x07,
+	0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x59, 0x54, 0x45, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x08,
+	0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59,
+	0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x54, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10,
+	0x0a, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x0b,
+	0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x55,
+	0x49, 0x44, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x0d, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e,
+	0x45, 0x54, 0x10, 0x0e, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e, 0x45, 0x54, 0x5f, 0x41, 0x52, 0x52,
+	0x41, 0x59, 0x10, 0x0f, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x49, 0x44, 0x52, 0x10, 0x10, 0x12, 0x0e,
+	0x0a, 0x0a, 0x43, 0x49, 0x44, 0x52, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x11, 0x12, 0x0c,
+	0x0a, 0x08, 0x4d, 0x41, 0x43, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x10, 0x12, 0x12, 0x12, 0x0a, 0x0e,
+	0x4d, 0x41, 0x43, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x5f, 0x41, 0x52, 0x52, 0x41, 0x59, 0x10, 0x13,
+	0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x54, 0x5a, 0x10,
+	0x14, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x43, 0x49, 0x4d, 0x41, 0x4c, 0x10, 0x15, 0x12, 0x0a,
+	0x0a, 0x06, 0x48, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x16, 0x2a, 0x1e, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08,
+	0x50, 0x4f, 0x53, 0x54, 0x47, 0x52, 0x45, 0x53, 0x10, 0x00, 0x32, 0xbc, 0x06, 0x0a, 0x08, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x20, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x58, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x58, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x43,
 	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d,
-	0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66,
-	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x45, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x46, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b, 0x5a, 0x09, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2253,89 +4181,156 @@ func file_internal_plugin_proto_rawDescGZIP() []byte {
 	return file_internal_plugin_proto_rawDescData
 }
 
-var file_internal_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_internal_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_internal_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_internal_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_internal_plugin_proto_goTypes = []interface{}{
-	(ConfigFormat)(0),                         // 0: proto.ConfigFormat
-	(ColumnType)(0),                           // 1: proto.ColumnType
-	(ConnectionType)(0),                       // 2: proto.ConnectionType
-	(ResourceFetchSummary_Status)(0),          // 3: proto.ResourceFetchSummary.Status
-	(Diagnostic_Type)(0),                      // 4: proto.Diagnostic.Type
-	(Diagnostic_Severity)(0),                  // 5: proto.Diagnostic.Severity
-	(*ConfigureProvider)(nil),                 // 6: proto.ConfigureProvider
-	(*FetchResources)(nil),                    // 7: proto.FetchResources
-	(*ResourceFetchSummary)(nil),              // 8: proto.ResourceFetchSummary
-	(*PartialFetchFailedResource)(nil),        // 9: proto.PartialFetchFailedResource
-	(*Diagnostic)(nil),                        // 10: proto.Diagnostic
-	(*GetProviderSchema)(nil),                 // 11: proto.GetProviderSchema
-	(*GetProviderConfig)(nil),                 // 12: proto.GetProviderConfig
-	(*GetModuleInfo)(nil),                     // 13: proto.GetModuleInfo
-	(*Table)(nil),                             // 14: proto.Table
-	(*Column)(nil),                            // 15: proto.Column
-	(*ColumnMeta)(nil),                        // 16: proto.ColumnMeta
-	(*ResolverMeta)(nil),                      // 17: proto.ResolverMeta
-	(*TableCreationOptions)(nil),              // 18: proto.TableCreationOptions
-	(*ConnectionDetails)(nil),                 // 19: proto.ConnectionDetails
-	(*ConfigureProvider_Request)(nil),         // 20: proto.ConfigureProvider.Request
-	(*ConfigureProvider_Response)(nil),        // 21: proto.ConfigureProvider.Response
-	(*FetchResources_Request)(nil),            // 22: proto.FetchResources.Request
-	(*FetchResources_Response)(nil),           // 23: proto.FetchResources.Response
-	nil,                                       // 24: proto.FetchResources.Response.FinishedResourcesEntry
-	(*GetProviderSchema_Request)(nil),         // 25: proto.GetProviderSchema.Request
-	(*GetProviderSchema_Response)(nil),        // 26: proto.GetProviderSchema.Response
-	nil,                                       // 27: proto.GetProviderSchema.Response.ResourceTablesEntry
-	(*GetProviderConfig_Request)(nil),         // 28: proto.GetProviderConfig.Request
-	(*GetProviderConfig_Response)(nil),        // 29: proto.GetProviderConfig.Response
-	(*GetModuleInfo_Request)(nil),             // 30: proto.GetModuleInfo.Request
-	(*GetModuleInfo_Response)(nil),            // 31: proto.GetModuleInfo.Response
-	nil,                                       // 32: proto.GetModuleInfo.Response.DataEntry
-	(*GetModuleInfo_Response_ModuleInfo)(nil), // 33: proto.GetModuleInfo.Response.ModuleInfo
-	nil, // 34: proto.GetModuleInfo.Response.ModuleInfo.ExtrasEntry
-	(*GetModuleInfo_Response_ModuleInfo_ModuleFile)(nil), // 35: proto.GetModuleInfo.Response.ModuleInfo.ModuleFile
+	(ErrorPolicy)(0),                          // 0: proto.ErrorPolicy
+	(ConfigFormat)(0),                         // 1: proto.ConfigFormat
+	(ColumnType)(0),                           // 2: proto.ColumnType
+	(ConnectionType)(0),                       // 3: proto.ConnectionType
+	(ResourceFetchSummary_Status)(0),          // 4: proto.ResourceFetchSummary.Status
+	(Diagnostic_Type)(0),                      // 5: proto.Diagnostic.Type
+	(Diagnostic_Severity)(0),                  // 6: proto.Diagnostic.Severity
+	(*ConfigureProvider)(nil),                 // 7: proto.ConfigureProvider
+	(*FetchResources)(nil),                    // 8: proto.FetchResources
+	(*ResourceFetchProgress)(nil),             // 9: proto.ResourceFetchProgress
+	(*ResourceFetchSummary)(nil),              // 10: proto.ResourceFetchSummary
+	(*ClientFetchSummary)(nil),                // 11: proto.ClientFetchSummary
+	(*ApiCallCount)(nil),                      // 12: proto.ApiCallCount
+	(*PartialFetchFailedResource)(nil),        // 13: proto.PartialFetchFailedResource
+	(*Diagnostic)(nil),                        // 14: proto.Diagnostic
+	(*DiagnosticSample)(nil),                  // 15: proto.DiagnosticSample
+	(*GetProviderSchema)(nil),                 // 16: proto.GetProviderSchema
+	(*GetProviderConfig)(nil),                 // 17: proto.GetProviderConfig
+	(*GetModuleInfo)(nil),                     // 18: proto.GetModuleInfo
+	(*GetFetchStatus)(nil),                    // 19: proto.GetFetchStatus
+	(*GetFetches)(nil),                        // 20: proto.GetFetches
+	(*GetEffectiveConfig)(nil),                // 21: proto.GetEffectiveConfig
+	(*GetResource)(nil),                       // 22: proto.GetResource
+	(*CheckHealth)(nil),                       // 23: proto.CheckHealth
+	(*Table)(nil),                             // 24: proto.Table
+	(*Column)(nil),                            // 25: proto.Column
+	(*ColumnCreationOptions)(nil),             // 26: proto.ColumnCreationOptions
+	(*ColumnMeta)(nil),                        // 27: proto.ColumnMeta
+	(*ResolverMeta)(nil),                      // 28: proto.ResolverMeta
+	(*TableCreationOptions)(nil),              // 29: proto.TableCreationOptions
+	(*ConnectionDetails)(nil),                 // 30: proto.ConnectionDetails
+	(*ConfigureProvider_Request)(nil),         // 31: proto.ConfigureProvider.Request
+	(*ConfigureProvider_Response)(nil),        // 32: proto.ConfigureProvider.Response
+	nil,                                       // 33: proto.ConfigureProvider.Request.FeatureFlagsEntry
+	(*FetchResources_Request)(nil),            // 34: proto.FetchResources.Request
+	(*FetchResources_Response)(nil),           // 35: proto.FetchResources.Response
+	nil,                                       // 36: proto.FetchResources.Request.LabelsEntry
+	nil,                                       // 37: proto.FetchResources.Request.TableFiltersEntry
+	nil,                                       // 38: proto.FetchResources.Response.FinishedResourcesEntry
+	nil,                                       // 39: proto.ResourceFetchSummary.FeatureFlagsEntry
+	nil,                                       // 40: proto.ResourceFetchSummary.TableCountsEntry
+	(*GetProviderSchema_Request)(nil),         // 41: proto.GetProviderSchema.Request
+	(*GetProviderSchema_Response)(nil),        // 42: proto.GetProviderSchema.Response
+	nil,                                       // 43: proto.GetProviderSchema.Response.ResourceTablesEntry
+	(*GetProviderConfig_Request)(nil),         // 44: proto.GetProviderConfig.Request
+	(*GetProviderConfig_Response)(nil),        // 45: proto.GetProviderConfig.Response
+	(*GetModuleInfo_Request)(nil),             // 46: proto.GetModuleInfo.Request
+	(*GetModuleInfo_Response)(nil),            // 47: proto.GetModuleInfo.Response
+	nil,                                       // 48: proto.GetModuleInfo.Response.DataEntry
+	(*GetModuleInfo_Response_ModuleInfo)(nil), // 49: proto.GetModuleInfo.Response.ModuleInfo
+	nil, // 50: proto.GetModuleInfo.Response.ModuleInfo.ExtrasEntry
+	(*GetModuleInfo_Response_ModuleInfo_ModuleFile)(nil), // 51: proto.GetModuleInfo.Response.ModuleInfo.ModuleFile
+	(*GetFetchStatus_Request)(nil),                       // 52: proto.GetFetchStatus.Request
+	(*GetFetchStatus_Response)(nil),                      // 53: proto.GetFetchStatus.Response
+	nil,                                                  // 54: proto.GetFetchStatus.Response.DiagnosticCountsEntry
+	(*GetFetches_Request)(nil),                           // 55: proto.GetFetches.Request
+	(*GetFetches_Response)(nil),                          // 56: proto.GetFetches.Response
+	(*GetFetches_Fetch)(nil),                             // 57: proto.GetFetches.Fetch
+	(*GetFetches_Resource)(nil),                          // 58: proto.GetFetches.Resource
+	(*GetEffectiveConfig_Request)(nil),                   // 59: proto.GetEffectiveConfig.Request
+	(*GetEffectiveConfig_Response)(nil),                  // 60: proto.GetEffectiveConfig.Response
+	(*GetResource_Request)(nil),                          // 61: proto.GetResource.Request
+	(*GetResource_Response)(nil),                         // 62: proto.GetResource.Response
+	nil,                                                  // 63: proto.GetResource.Request.PrimaryKeysEntry
+	(*CheckHealth_Request)(nil),                          // 64: proto.CheckHealth.Request
+	(*CheckHealth_Response)(nil),                         // 65: proto.CheckHealth.Response
+	nil,                                                  // 66: proto.Table.LabelsEntry
+	nil,                                                  // 67: proto.ConnectionDetails.ColumnPoliciesEntry
 }
 var file_internal_plugin_proto_depIdxs = []int32{
-	3,  // 0: proto.ResourceFetchSummary.status:type_name -> proto.ResourceFetchSummary.Status
-	10, // 1: proto.ResourceFetchSummary.diagnostics:type_name -> proto.Diagnostic
-	4,  // 2: proto.Diagnostic.type:type_name -> proto.Diagnostic.Type
-	5,  // 3: proto.Diagnostic.severity:type_name -> proto.Diagnostic.Severity
-	10, // 4: proto.Diagnostic.redacted:type_name -> proto.Diagnostic
-	15, // 5: proto.Table.columns:type_name -> proto.Column
-	14, // 6: proto.Table.relations:type_name -> proto.Table
-	18, // 7: proto.Table.options:type_name -> proto.TableCreationOptions
-	1,  // 8: proto.Column.type:type_name -> proto.ColumnType
-	16, // 9: proto.Column.meta:type_name -> proto.ColumnMeta
-	17, // 10: proto.ColumnMeta.resolver:type_name -> proto.ResolverMeta
-	2,  // 11: proto.ConnectionDetails.type:type_name -> proto.ConnectionType
-	19, // 12: proto.ConfigureProvider.Request.connection:type_name -> proto.ConnectionDetails
-	0,  // 13: proto.ConfigureProvider.Request.format:type_name -> proto.ConfigFormat
-	10, // 14: proto.ConfigureProvider.Response.diagnostics:type_name -> proto.Diagnostic
-	24, // 15: proto.FetchResources.Response.finished_resources:type_name -> proto.FetchResources.Response.FinishedResourcesEntry
-	9,  // 16: proto.FetchResources.Response.partial_fetch_failed_resources:type_name -> proto.PartialFetchFailedResource
-	8,  // 17: proto.FetchResources.Response.summary:type_name -> proto.ResourceFetchSummary
-	27, // 18: proto.GetProviderSchema.Response.resource_tables:type_name -> proto.GetProviderSchema.Response.ResourceTablesEntry
-	14, // 19: proto.GetProviderSchema.Response.ResourceTablesEntry.value:type_name -> proto.Table
-	0,  // 20: proto.GetProviderConfig.Request.format:type_name -> proto.ConfigFormat
-	0,  // 21: proto.GetProviderConfig.Response.format:type_name -> proto.ConfigFormat
-	32, // 22: proto.GetModuleInfo.Response.data:type_name -> proto.GetModuleInfo.Response.DataEntry
-	10, // 23: proto.GetModuleInfo.Response.diagnostics:type_name -> proto.Diagnostic
-	33, // 24: proto.GetModuleInfo.Response.DataEntry.value:type_name -> proto.GetModuleInfo.Response.ModuleInfo
-	35, // 25: proto.GetModuleInfo.Response.ModuleInfo.files:type_name -> proto.GetModuleInfo.Response.ModuleInfo.ModuleFile
-	34, // 26: proto.GetModuleInfo.Response.ModuleInfo.extras:type_name -> proto.GetModuleInfo.Response.ModuleInfo.ExtrasEntry
-	25, // 27: proto.Provider.GetProviderSchema:input_type -> proto.GetProviderSchema.Request
-	28, // 28: proto.Provider.GetProviderConfig:input_type -> proto.GetProviderConfig.Request
-	20, // 29: proto.Provider.ConfigureProvider:input_type -> proto.ConfigureProvider.Request
-	22, // 30: proto.Provider.FetchResources:input_type -> proto.FetchResources.Request
-	30, // 31: proto.Provider.GetModuleInfo:input_type -> proto.GetModuleInfo.Request
-	26, // 32: proto.Provider.GetProviderSchema:output_type -> proto.GetProviderSchema.Response
-	29, // 33: proto.Provider.GetProviderConfig:output_type -> proto.GetProviderConfig.Response
-	21, // 34: proto.Provider.ConfigureProvider:output_type -> proto.ConfigureProvider.Response
-	23, // 35: proto.Provider.FetchResources:output_type -> proto.FetchResources.Response
-	31, // 36: proto.Provider.GetModuleInfo:output_type -> proto.GetModuleInfo.Response
-	32, // [32:37] is the sub-list for method output_type
-	27, // [27:32] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	4,  // 0: proto.ResourceFetchSummary.status:type_name -> proto.ResourceFetchSummary.Status
+	14, // 1: proto.ResourceFetchSummary.diagnostics:type_name -> proto.Diagnostic
+	12, // 2: proto.ResourceFetchSummary.api_calls:type_name -> proto.ApiCallCount
+	39, // 3: proto.ResourceFetchSummary.feature_flags:type_name -> proto.ResourceFetchSummary.FeatureFlagsEntry
+	40, // 4: proto.ResourceFetchSummary.table_counts:type_name -> proto.ResourceFetchSummary.TableCountsEntry
+	0,  // 5: proto.ResourceFetchSummary.error_policy:type_name -> proto.ErrorPolicy
+	11, // 6: proto.ResourceFetchSummary.clients:type_name -> proto.ClientFetchSummary
+	4,  // 7: proto.ClientFetchSummary.status:type_name -> proto.ResourceFetchSummary.Status
+	5,  // 8: proto.Diagnostic.type:type_name -> proto.Diagnostic.Type
+	6,  // 9: proto.Diagnostic.severity:type_name -> proto.Diagnostic.Severity
+	14, // 10: proto.Diagnostic.redacted:type_name -> proto.Diagnostic
+	15, // 11: proto.Diagnostic.samples:type_name -> proto.DiagnosticSample
+	25, // 12: proto.Table.columns:type_name -> proto.Column
+	24, // 13: proto.Table.relations:type_name -> proto.Table
+	29, // 14: proto.Table.options:type_name -> proto.TableCreationOptions
+	66, // 15: proto.Table.labels:type_name -> proto.Table.LabelsEntry
+	2,  // 16: proto.Column.type:type_name -> proto.ColumnType
+	27, // 17: proto.Column.meta:type_name -> proto.ColumnMeta
+	26, // 18: proto.Column.creation_options:type_name -> proto.ColumnCreationOptions
+	28, // 19: proto.ColumnMeta.resolver:type_name -> proto.ResolverMeta
+	3,  // 20: proto.ConnectionDetails.type:type_name -> proto.ConnectionType
+	67, // 21: proto.ConnectionDetails.column_policies:type_name -> proto.ConnectionDetails.ColumnPoliciesEntry
+	30, // 22: proto.ConfigureProvider.Request.connection:type_name -> proto.ConnectionDetails
+	1,  // 23: proto.ConfigureProvider.Request.format:type_name -> proto.ConfigFormat
+	33, // 24: proto.ConfigureProvider.Request.feature_flags:type_name -> proto.ConfigureProvider.Request.FeatureFlagsEntry
+	14, // 25: proto.ConfigureProvider.Response.diagnostics:type_name -> proto.Diagnostic
+	36, // 26: proto.FetchResources.Request.labels:type_name -> proto.FetchResources.Request.LabelsEntry
+	0,  // 27: proto.FetchResources.Request.error_policy:type_name -> proto.ErrorPolicy
+	37, // 28: proto.FetchResources.Request.table_filters:type_name -> proto.FetchResources.Request.TableFiltersEntry
+	38, // 29: proto.FetchResources.Response.finished_resources:type_name -> proto.FetchResources.Response.FinishedResourcesEntry
+	13, // 30: proto.FetchResources.Response.partial_fetch_failed_resources:type_name -> proto.PartialFetchFailedResource
+	10, // 31: proto.FetchResources.Response.summary:type_name -> proto.ResourceFetchSummary
+	9,  // 32: proto.FetchResources.Response.progress:type_name -> proto.ResourceFetchProgress
+	43, // 33: proto.GetProviderSchema.Response.resource_tables:type_name -> proto.GetProviderSchema.Response.ResourceTablesEntry
+	14, // 34: proto.GetProviderSchema.Response.diagnostics:type_name -> proto.Diagnostic
+	24, // 35: proto.GetProviderSchema.Response.ResourceTablesEntry.value:type_name -> proto.Table
+	1,  // 36: proto.GetProviderConfig.Request.format:type_name -> proto.ConfigFormat
+	1,  // 37: proto.GetProviderConfig.Response.format:type_name -> proto.ConfigFormat
+	48, // 38: proto.GetModuleInfo.Response.data:type_name -> proto.GetModuleInfo.Response.DataEntry
+	14, // 39: proto.GetModuleInfo.Response.diagnostics:type_name -> proto.Diagnostic
+	49, // 40: proto.GetModuleInfo.Response.DataEntry.value:type_name -> proto.GetModuleInfo.Response.ModuleInfo
+	51, // 41: proto.GetModuleInfo.Response.ModuleInfo.files:type_name -> proto.GetModuleInfo.Response.ModuleInfo.ModuleFile
+	50, // 42: proto.GetModuleInfo.Response.ModuleInfo.extras:type_name -> proto.GetModuleInfo.Response.ModuleInfo.ExtrasEntry
+	54, // 43: proto.GetFetchStatus.Response.diagnostic_counts:type_name -> proto.GetFetchStatus.Response.DiagnosticCountsEntry
+	57, // 44: proto.GetFetches.Response.fetches:type_name -> proto.GetFetches.Fetch
+	14, // 45: proto.GetFetches.Response.diagnostics:type_name -> proto.Diagnostic
+	58, // 46: proto.GetFetches.Fetch.resources:type_name -> proto.GetFetches.Resource
+	4,  // 47: proto.GetFetches.Resource.status:type_name -> proto.ResourceFetchSummary.Status
+	14, // 48: proto.GetEffectiveConfig.Response.diagnostics:type_name -> proto.Diagnostic
+	63, // 49: proto.GetResource.Request.primary_keys:type_name -> proto.GetResource.Request.PrimaryKeysEntry
+	14, // 50: proto.GetResource.Response.diagnostics:type_name -> proto.Diagnostic
+	14, // 51: proto.CheckHealth.Response.diagnostics:type_name -> proto.Diagnostic
+	41, // 52: proto.Provider.GetProviderSchema:input_type -> proto.GetProviderSchema.Request
+	44, // 53: proto.Provider.GetProviderConfig:input_type -> proto.GetProviderConfig.Request
+	31, // 54: proto.Provider.ConfigureProvider:input_type -> proto.ConfigureProvider.Request
+	34, // 55: proto.Provider.FetchResources:input_type -> proto.FetchResources.Request
+	46, // 56: proto.Provider.GetModuleInfo:input_type -> proto.GetModuleInfo.Request
+	52, // 57: proto.Provider.GetFetchStatus:input_type -> proto.GetFetchStatus.Request
+	55, // 58: proto.Provider.GetFetches:input_type -> proto.GetFetches.Request
+	59, // 59: proto.Provider.GetEffectiveConfig:input_type -> proto.GetEffectiveConfig.Request
+	61, // 60: proto.Provider.GetResource:input_type -> proto.GetResource.Request
+	64, // 61: proto.Provider.CheckHealth:input_type -> proto.CheckHealth.Request
+	42, // 62: proto.Provider.GetProviderSchema:output_type -> proto.GetProviderSchema.Response
+	45, // 63: proto.Provider.GetProviderConfig:output_type -> proto.GetProviderConfig.Response
+	32, // 64: proto.Provider.ConfigureProvider:output_type -> proto.ConfigureProvider.Response
+	35, // 65: proto.Provider.FetchResources:output_type -> proto.FetchResources.Response
+	47, // 66: proto.Provider.GetModuleInfo:output_type -> proto.GetModuleInfo.Response
+	53, // 67: proto.Provider.GetFetchStatus:output_type -> proto.GetFetchStatus.Response
+	56, // 68: proto.Provider.GetFetches:output_type -> proto.GetFetches.Response
+	60, // 69: proto.Provider.GetEffectiveConfig:output_type -> proto.GetEffectiveConfig.Response
+	62, // 70: proto.Provider.GetResource:output_type -> proto.GetResource.Response
+	65, // 71: proto.Provider.CheckHealth:output_type -> proto.CheckHealth.Response
+	62, // [62:72] is the sub-list for method output_type
+	52, // [52:62] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_internal_plugin_proto_init() }
@@ -2356,8 +4351,32 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchResources); i {
+		file_internal_plugin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchResources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceFetchProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceFetchSummary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2368,8 +4387,8 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceFetchSummary); i {
+		file_internal_plugin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientFetchSummary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2380,7 +4399,19 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApiCallCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PartialFetchFailedResource); i {
 			case 0:
 				return &v.state
@@ -2392,7 +4423,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Diagnostic); i {
 			case 0:
 				return &v.state
@@ -2404,7 +4435,19 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiagnosticSample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderSchema); i {
 			case 0:
 				return &v.state
@@ -2416,7 +4459,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderConfig); i {
 			case 0:
 				return &v.state
@@ -2428,7 +4471,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetModuleInfo); i {
 			case 0:
 				return &v.state
@@ -2440,7 +4483,67 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetchStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetches); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEffectiveConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Table); i {
 			case 0:
 				return &v.state
@@ -2452,7 +4555,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Column); i {
 			case 0:
 				return &v.state
@@ -2464,7 +4567,19 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColumnCreationOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ColumnMeta); i {
 			case 0:
 				return &v.state
@@ -2476,7 +4591,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResolverMeta); i {
 			case 0:
 				return &v.state
@@ -2488,7 +4603,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TableCreationOptions); i {
 			case 0:
 				return &v.state
@@ -2500,7 +4615,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectionDetails); i {
 			case 0:
 				return &v.state
@@ -2512,7 +4627,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConfigureProvider_Request); i {
 			case 0:
 				return &v.state
@@ -2524,7 +4639,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConfigureProvider_Response); i {
 			case 0:
 				return &v.state
@@ -2536,7 +4651,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FetchResources_Request); i {
 			case 0:
 				return &v.state
@@ -2548,7 +4663,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FetchResources_Response); i {
 			case 0:
 				return &v.state
@@ -2560,7 +4675,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderSchema_Request); i {
 			case 0:
 				return &v.state
@@ -2572,7 +4687,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderSchema_Response); i {
 			case 0:
 				return &v.state
@@ -2584,7 +4699,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderConfig_Request); i {
 			case 0:
 				return &v.state
@@ -2596,7 +4711,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetProviderConfig_Response); i {
 			case 0:
 				return &v.state
@@ -2608,7 +4723,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetModuleInfo_Request); i {
 			case 0:
 				return &v.state
@@ -2620,7 +4735,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetModuleInfo_Response); i {
 			case 0:
 				return &v.state
@@ -2632,7 +4747,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetModuleInfo_Response_ModuleInfo); i {
 			case 0:
 				return &v.state
@@ -2644,7 +4759,7 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
-		file_internal_plugin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_plugin_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetModuleInfo_Response_ModuleInfo_ModuleFile); i {
 			case 0:
 				return &v.state
@@ -2656,15 +4771,159 @@ func file_internal_plugin_proto_init() {
 				return nil
 			}
 		}
+		file_internal_plugin_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetchStatus_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetchStatus_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetches_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetches_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetches_Fetch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetches_Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEffectiveConfig_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEffectiveConfig_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResource_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResource_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealth_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_plugin_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckHealth_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_internal_plugin_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_internal_plugin_proto_msgTypes[17].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_plugin_proto_rawDesc,
-			NumEnums:      6,
-			NumMessages:   30,
+			NumEnums:      7,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
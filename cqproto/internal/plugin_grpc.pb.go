@@ -28,6 +28,16 @@ type ProviderClient interface {
 	FetchResources(ctx context.Context, in *FetchResources_Request, opts ...grpc.CallOption) (Provider_FetchResourcesClient, error)
 	// Gets info about specific module config embedded inside provider
 	GetModuleInfo(ctx context.Context, in *GetModuleInfo_Request, opts ...grpc.CallOption) (*GetModuleInfo_Response, error)
+	// Gets the counters of a running or recently finished fetch
+	GetFetchStatus(ctx context.Context, in *GetFetchStatus_Request, opts ...grpc.CallOption) (*GetFetchStatus_Response, error)
+	// Gets the history of the provider's latest fetches, as persisted in the database
+	GetFetches(ctx context.Context, in *GetFetches_Request, opts ...grpc.CallOption) (*GetFetches_Response, error)
+	// Gets the configuration the provider was configured with, after defaults are applied and with sensitive values redacted
+	GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfig_Request, opts ...grpc.CallOption) (*GetEffectiveConfig_Response, error)
+	// Fetches a single resource by the values of its primary keys, and stores it
+	GetResource(ctx context.Context, in *GetResource_Request, opts ...grpc.CallOption) (*GetResource_Response, error)
+	// Verifies the configured provider's database connectivity, credentials and connectivity to its APIs
+	CheckHealth(ctx context.Context, in *CheckHealth_Request, opts ...grpc.CallOption) (*CheckHealth_Response, error)
 }
 
 type providerClient struct {
@@ -106,6 +116,51 @@ func (c *providerClient) GetModuleInfo(ctx context.Context, in *GetModuleInfo_Re
 	return out, nil
 }
 
+func (c *providerClient) GetFetchStatus(ctx context.Context, in *GetFetchStatus_Request, opts ...grpc.CallOption) (*GetFetchStatus_Response, error) {
+	out := new(GetFetchStatus_Response)
+	err := c.cc.Invoke(ctx, "/proto.Provider/GetFetchStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetFetches(ctx context.Context, in *GetFetches_Request, opts ...grpc.CallOption) (*GetFetches_Response, error) {
+	out := new(GetFetches_Response)
+	err := c.cc.Invoke(ctx, "/proto.Provider/GetFetches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetEffectiveConfig(ctx context.Context, in *GetEffectiveConfig_Request, opts ...grpc.CallOption) (*GetEffectiveConfig_Response, error) {
+	out := new(GetEffectiveConfig_Response)
+	err := c.cc.Invoke(ctx, "/proto.Provider/GetEffectiveConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) GetResource(ctx context.Context, in *GetResource_Request, opts ...grpc.CallOption) (*GetResource_Response, error) {
+	out := new(GetResource_Response)
+	err := c.cc.Invoke(ctx, "/proto.Provider/GetResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerClient) CheckHealth(ctx context.Context, in *CheckHealth_Request, opts ...grpc.CallOption) (*CheckHealth_Response, error) {
+	out := new(CheckHealth_Response)
+	err := c.cc.Invoke(ctx, "/proto.Provider/CheckHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProviderServer is the server API for Provider service.
 // All implementations must embed UnimplementedProviderServer
 // for forward compatibility
@@ -120,6 +175,16 @@ type ProviderServer interface {
 	FetchResources(*FetchResources_Request, Provider_FetchResourcesServer) error
 	// Gets info about specific module config embedded inside provider
 	GetModuleInfo(context.Context, *GetModuleInfo_Request) (*GetModuleInfo_Response, error)
+	// Gets the counters of a running or recently finished fetch
+	GetFetchStatus(context.Context, *GetFetchStatus_Request) (*GetFetchStatus_Response, error)
+	// Gets the history of the provider's latest fetches, as persisted in the database
+	GetFetches(context.Context, *GetFetches_Request) (*GetFetches_Response, error)
+	// Gets the configuration the provider was configured with, after defaults are applied and with sensitive values redacted
+	GetEffectiveConfig(context.Context, *GetEffectiveConfig_Request) (*GetEffectiveConfig_Response, error)
+	// Fetches a single resource by the values of its primary keys, and stores it
+	GetResource(context.Context, *GetResource_Request) (*GetResource_Response, error)
+	// Verifies the configured provider's database connectivity, credentials and connectivity to its APIs
+	CheckHealth(context.Context, *CheckHealth_Request) (*CheckHealth_Response, error)
 	mustEmbedUnimplementedProviderServer()
 }
 
@@ -142,6 +207,21 @@ func (UnimplementedProviderServer) FetchResources(*FetchResources_Request, Provi
 func (UnimplementedProviderServer) GetModuleInfo(context.Context, *GetModuleInfo_Request) (*GetModuleInfo_Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetModuleInfo not implemented")
 }
+func (UnimplementedProviderServer) GetFetchStatus(context.Context, *GetFetchStatus_Request) (*GetFetchStatus_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFetchStatus not implemented")
+}
+func (UnimplementedProviderServer) GetFetches(context.Context, *GetFetches_Request) (*GetFetches_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFetches not implemented")
+}
+func (UnimplementedProviderServer) GetEffectiveConfig(context.Context, *GetEffectiveConfig_Request) (*GetEffectiveConfig_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveConfig not implemented")
+}
+func (UnimplementedProviderServer) GetResource(context.Context, *GetResource_Request) (*GetResource_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedProviderServer) CheckHealth(context.Context, *CheckHealth_Request) (*CheckHealth_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckHealth not implemented")
+}
 func (UnimplementedProviderServer) mustEmbedUnimplementedProviderServer() {}
 
 // UnsafeProviderServer may be embedded to opt out of forward compatibility for this service.
@@ -248,6 +328,96 @@ func _Provider_GetModuleInfo_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Provider_GetFetchStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFetchStatus_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetFetchStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Provider/GetFetchStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetFetchStatus(ctx, req.(*GetFetchStatus_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetFetches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFetches_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetFetches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Provider/GetFetches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetFetches(ctx, req.(*GetFetches_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetEffectiveConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEffectiveConfig_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetEffectiveConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Provider/GetEffectiveConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetEffectiveConfig(ctx, req.(*GetEffectiveConfig_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResource_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Provider/GetResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).GetResource(ctx, req.(*GetResource_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Provider_CheckHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckHealth_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServer).CheckHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Provider/CheckHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServer).CheckHealth(ctx, req.(*CheckHealth_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Provider_ServiceDesc is the grpc.ServiceDesc for Provider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -271,6 +441,26 @@ var Provider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetModuleInfo",
 			Handler:    _Provider_GetModuleInfo_Handler,
 		},
+		{
+			MethodName: "GetFetchStatus",
+			Handler:    _Provider_GetFetchStatus_Handler,
+		},
+		{
+			MethodName: "GetFetches",
+			Handler:    _Provider_GetFetches_Handler,
+		},
+		{
+			MethodName: "GetEffectiveConfig",
+			Handler:    _Provider_GetEffectiveConfig_Handler,
+		},
+		{
+			MethodName: "GetResource",
+			Handler:    _Provider_GetResource_Handler,
+		},
+		{
+			MethodName: "CheckHealth",
+			Handler:    _Provider_CheckHealth_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -29,6 +29,21 @@ type CQProvider interface {
 
 	// Gets info about specific module config embedded inside provider
 	GetModuleInfo(context.Context, *GetModuleRequest) (*GetModuleResponse, error)
+
+	// GetFetchStatus is called to get the counters of a running or recently finished fetch, i.e to display its progress
+	GetFetchStatus(context.Context, *GetFetchStatusRequest) (*GetFetchStatusResponse, error)
+
+	// GetFetches is called to get the history of the provider's latest fetches, as persisted in the database
+	GetFetches(context.Context, *GetFetchesRequest) (*GetFetchesResponse, error)
+
+	// GetEffectiveConfig is called to get the configuration the provider was configured with, i.e to debug it
+	GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error)
+
+	// GetResource is called to fetch a single resource by the values of its primary keys, i.e to refresh it
+	GetResource(context.Context, *GetResourceRequest) (*GetResourceResponse, error)
+
+	// CheckHealth is called to verify the configured provider can fetch resources, i.e before a long running fetch
+	CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error)
 }
 
 type CQProviderServer interface {
@@ -49,6 +64,21 @@ type CQProviderServer interface {
 
 	// Gets info about specific module config embedded inside provider
 	GetModuleInfo(context.Context, *GetModuleRequest) (*GetModuleResponse, error)
+
+	// GetFetchStatus is called to get the counters of a running or recently finished fetch, i.e to display its progress
+	GetFetchStatus(context.Context, *GetFetchStatusRequest) (*GetFetchStatusResponse, error)
+
+	// GetFetches is called to get the history of the provider's latest fetches, as persisted in the database
+	GetFetches(context.Context, *GetFetchesRequest) (*GetFetchesResponse, error)
+
+	// GetEffectiveConfig is called to get the configuration the provider was configured with, i.e to debug it
+	GetEffectiveConfig(context.Context, *GetEffectiveConfigRequest) (*GetEffectiveConfigResponse, error)
+
+	// GetResource is called to fetch a single resource by the values of its primary keys, i.e to refresh it
+	GetResource(context.Context, *GetResourceRequest) (*GetResourceResponse, error)
+
+	// CheckHealth is called to verify the configured provider can fetch resources, i.e before a long running fetch
+	CheckHealth(context.Context, *CheckHealthRequest) (*CheckHealthResponse, error)
 }
 
 // GetProviderSchemaRequest represents a CloudQuery RPC request for provider's schemas
@@ -61,6 +91,8 @@ type GetProviderSchemaResponse struct {
 	Version string
 	// ResourceTables is a map of tables this provider creates
 	ResourceTables map[string]*schema.Table
+	// Diagnostics are warnings about breaking changes of the schema since the provider's previous version
+	Diagnostics diag.Diagnostics
 }
 
 // GetProviderConfigRequest represents a CloudQuery RPC request for provider's config
@@ -80,7 +112,10 @@ type ConfigureProviderRequest struct {
 	Connection ConnectionDetails
 	// Config is the configuration the user supplied for the provider
 	Config []byte
-	Format internal.ConfigFormat // Deprecated
+	// Format of Config, ConfigFormatYAML if not set
+	Format ConfigFormat
+	// FeatureFlags enable or disable experimental SDK behaviors for this run, by flag name
+	FeatureFlags map[string]bool
 }
 
 type ConfigureProviderResponse struct {
@@ -103,6 +138,47 @@ type FetchResourcesRequest struct {
 	Timeout time.Duration
 	// Metadata for the fetch
 	Metadata map[string]interface{}
+	// ValidateSchema if true validates the requested resources against the database schema before fetching
+	ValidateSchema bool
+	// CanaryRows if more than 0, resources are first fetched with a sample of up to CanaryRows rows per table, which
+	// are validated but not stored. Only resources whose sample had no errors are fully fetched, the others are reported
+	// as failed with the sample's diagnostics. Use with ValidateSchema to also verify the database schema beforehand.
+	CanaryRows uint64
+	// ProgressInterval if more than 0, progress updates of running resources are sent at this interval, see
+	// FetchResourcesResponse.Progress
+	ProgressInterval time.Duration
+	// Labels if set, only the requested resources whose table has all the labels are fetched, see schema.Table.Labels
+	Labels map[string]string
+	// ResumeFetchId if set, continues the interrupted fetch with this id from its checkpoints instead of fetching every
+	// table from scratch. The fetch keeps the id of the resumed fetch. Requires a provider with checkpoints enabled.
+	ResumeFetchId string
+	// DryRun if true executes the table and column resolvers without writing the resources to the database, which isn't
+	// connected to. The amount of resources resolved per table is reported in ResourceFetchSummary.TableCounts.
+	// Can't be used with ValidateSchema, ResumeFetchId or Verify.
+	DryRun bool
+	// Verify if true compares the resolved resources to the rows stored by previous fetches instead of writing them,
+	// the tables whose stored data drifted are reported as warnings. Nothing is written to the database, including the
+	// fetch history. Can't be used with DryRun.
+	Verify bool
+	// ErrorPolicy defines how errors of the fetch are handled, ErrorPolicyFailFast cancels the whole fetch on the first
+	// error. Defaults to ErrorPolicyBestEffort.
+	ErrorPolicy ErrorPolicy
+	// TenantId if set, the fetched resources are owned by the tenant, see schema.TenantDialect. Their stale data is
+	// removed per tenant. Requires a multi tenant provider.
+	TenantId string
+	// FullRefresh if true fully fetches schema.Table.Incremental tables, ignoring the cursors stored by previous fetches.
+	// The cursors are replaced once the tables are fetched.
+	FullRefresh bool
+	// TableFilters if set, limit the resources fetched of tables by filter expressions, by table name, i.e
+	// "aws_ec2_instances": "region in ('us-east-1')". See schema.ParseFetchFilter. Resolvers can push filters down to the
+	// APIs, and only the resources matching them are stored. Stale data of filtered tables isn't removed.
+	TableFilters map[string]string
+	// SkipStaleCleanup if true keeps the stale data of the fetched tables, the stale rows are counted and reported in
+	// the diagnostics of the resources' summaries instead, i.e to investigate which rows a fetch would remove.
+	SkipStaleCleanup bool
+	// BatchSize if more than 0, is the maximum amount of resources resolved and inserted together, bounding the memory
+	// of large tables. See execution.DefaultBatchSize.
+	BatchSize uint64
 }
 
 // FetchResourcesStream represents a CloudQuery RPC stream of fetch updates from the provider
@@ -128,6 +204,19 @@ type FetchResourcesResponse struct {
 	PartialFetchFailedResources []*FailedResourceFetch
 	// fetch summary of resource that finished execution
 	Summary ResourceFetchSummary
+	// Progress if set, the response is a progress update of ResourceName, which is still running. Other fields
+	// aren't set in progress updates.
+	Progress *ResourceFetchProgress
+}
+
+// ResourceFetchProgress is a progress update of a running resource fetch
+type ResourceFetchProgress struct {
+	// ResourceCount is the amount of resources resolved so far, including relations
+	ResourceCount uint64
+	// Client is the id of the multiplexed client that last resolved resources
+	Client string
+	// Elapsed is the time since the resource fetch started
+	Elapsed time.Duration
 }
 
 // GetModuleRequest represents a CloudQuery RPC request of provider's module info for specific provider
@@ -143,6 +232,109 @@ type GetModuleResponse struct {
 	Diagnostics       diag.Diagnostics
 }
 
+// GetFetchStatusRequest represents a CloudQuery RPC request of a fetch's counters
+type GetFetchStatusRequest struct {
+	// FetchID is the id of the fetch, as passed in the schema.FetchIdMetaKey metadata of FetchResourcesRequest.
+	// Empty for a fetch without an id
+	FetchID string
+}
+
+// GetFetchStatusResponse represents a CloudQuery RPC response of a fetch's counters
+type GetFetchStatusResponse struct {
+	// Found is false if the provider has no record of the requested fetch
+	Found bool
+	// Done is true if the fetch finished
+	Done bool
+	// RowsResolved is the amount of resources resolved so far, including relations
+	RowsResolved uint64
+	// RowsStored is the amount of resources written to the database so far
+	RowsStored uint64
+	// BytesEstimated is the estimated amount of bytes of the resolved resources' values
+	BytesEstimated uint64
+	// DiagnosticCounts is the amount of diagnostics reported so far, by severity
+	DiagnosticCounts map[diag.Severity]uint64
+	// Error the fetch ended with, if any
+	Error string
+}
+
+// GetFetchesRequest represents a CloudQuery RPC request of the provider's fetch history
+type GetFetchesRequest struct {
+	// Limit is the maximum amount of fetches to return, latest first. If 0 all persisted fetches are returned
+	Limit uint64
+}
+
+// GetFetchesResponse represents a CloudQuery RPC response of the provider's fetch history
+type GetFetchesResponse struct {
+	// Fetches are the persisted fetches of the provider, latest first
+	Fetches     []FetchHistory
+	Diagnostics diag.Diagnostics
+}
+
+// FetchHistory is a persisted fetch of the provider
+type FetchHistory struct {
+	// FetchID is the id of the fetch, as passed in the schema.FetchIdMetaKey metadata of FetchResourcesRequest
+	FetchID string
+	// ProviderVersion is the version of the provider that ran the fetch
+	ProviderVersion string
+	Start           time.Time
+	Finish          time.Time
+	// Error the fetch ended with, if any
+	Error string
+	// Resources are the statuses of the fetched resources
+	Resources []FetchHistoryResource
+}
+
+// FetchHistoryResource is the status of a resource fetched by a persisted fetch
+type FetchHistoryResource struct {
+	ResourceName  string
+	Status        ResourceFetchStatus
+	ResourceCount uint64
+	// ErrorCount is the amount of diagnostics of error or panic severity reported while fetching the resource
+	ErrorCount uint64
+}
+
+// GetEffectiveConfigRequest represents a CloudQuery RPC request of the provider's effective configuration
+type GetEffectiveConfigRequest struct{}
+
+// GetEffectiveConfigResponse represents a CloudQuery RPC response of the provider's effective configuration
+type GetEffectiveConfigResponse struct {
+	// Config is the configuration the provider was configured with in YAML, after defaults are applied and with
+	// sensitive values redacted
+	Config      []byte
+	Diagnostics diag.Diagnostics
+}
+
+// GetResourceRequest represents a CloudQuery RPC request of a single resource
+type GetResourceRequest struct {
+	// Resource is the resource whose table has the single resource, the table must have a schema.Table.GetResolver
+	Resource string
+	// PrimaryKeys are the values of the primary keys of the resource, by column name
+	PrimaryKeys map[string]string
+	// Metadata for the fetch, as in FetchResourcesRequest.Metadata
+	Metadata map[string]interface{}
+	// Timeout of the resolve call
+	Timeout time.Duration
+	// TenantId if set, the resource is owned by the tenant, as in FetchResourcesRequest.TenantId
+	TenantId string
+}
+
+// GetResourceResponse represents a CloudQuery RPC response of a single resource
+type GetResourceResponse struct {
+	// ResourceCount is the amount of resources fetched and stored, 0 if the resource wasn't found. Multiplexed tables may
+	// fetch the resource with more than one client.
+	ResourceCount uint64
+	Diagnostics   diag.Diagnostics
+}
+
+// CheckHealthRequest represents a CloudQuery RPC request of the provider's health
+type CheckHealthRequest struct{}
+
+// CheckHealthResponse represents a CloudQuery RPC response of the provider's health, the provider is healthy if none of
+// the diagnostics is an error
+type CheckHealthResponse struct {
+	Diagnostics diag.Diagnostics
+}
+
 // ModuleInfo is info about a module
 type ModuleInfo struct {
 	Files  []*ModuleFile
@@ -158,6 +350,46 @@ type ModuleFile struct {
 // ResourceFetchStatus defines execution status of the resource fetch execution
 type ResourceFetchStatus int
 
+// ConfigFormat is the format of the configuration of a ConfigureProviderRequest
+type ConfigFormat int
+
+const (
+	// ConfigFormatYAML configurations are decoded by the YAML tags of the provider's config
+	ConfigFormatYAML ConfigFormat = iota
+	// ConfigFormatHCL configurations are decoded by the HCL tags of the provider's config, as written by older
+	// versions of CloudQuery
+	ConfigFormatHCL
+)
+
+func (f ConfigFormat) String() string {
+	switch f {
+	case ConfigFormatYAML:
+		return "yaml"
+	case ConfigFormatHCL:
+		return "hcl"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrorPolicy defines how errors of a fetch are handled
+type ErrorPolicy int
+
+const (
+	// ErrorPolicyBestEffort reports errors and keeps fetching, resources are fetched as much as possible
+	ErrorPolicyBestEffort ErrorPolicy = iota
+	// ErrorPolicyFailFast cancels the whole fetch on the first diagnostic of error severity, i.e for CI
+	ErrorPolicyFailFast
+)
+
+func (p ErrorPolicy) String() string {
+	name, ok := internal.ErrorPolicy_name[int32(p)]
+	if !ok {
+		return "UNKNOWN"
+	}
+	return name
+}
+
 // ResourceFetchSummary includes a summarized report of a fetched resource, such as total amount of resources collected,
 // status of the fetch and any diagnostics found while executing fetch on it.
 type ResourceFetchSummary struct {
@@ -168,6 +400,40 @@ type ResourceFetchSummary struct {
 	// Diagnostics of failed resource fetch, the diagnostic provides insights such as severity, summary and
 	// details on how to solve this issue
 	Diagnostics diag.Diagnostics
+	// APICalls made while fetching the resource, per table and client
+	APICalls []APICallCount
+	// FeatureFlags the provider was configured with
+	FeatureFlags map[string]bool
+	// TableCounts is the amount of resources resolved per table of the resource, set by dry run fetches
+	TableCounts map[string]uint64
+	// ErrorPolicy of the fetch
+	ErrorPolicy ErrorPolicy
+	// FailFastError is the summary of the error that canceled the fetch, set by fail fast fetches once they are canceled
+	FailFastError string
+	// Clients is the completion state of the resource's multiplexed clients, set if the fetch was canceled so the
+	// clients whose resources weren't fully fetched are known
+	Clients []ClientFetchSummary
+}
+
+// ClientFetchSummary is the completion state of a multiplexed client of a canceled resource fetch
+type ClientFetchSummary struct {
+	ClientID string
+	// Status is ResourceFetchComplete or ResourceFetchPartial if the client finished resolving, ResourceFetchCanceled
+	// otherwise
+	Status ResourceFetchStatus
+	// Started is false if the fetch was canceled before the client started resolving
+	Started bool
+	// ResourceCount is the amount of resources resolved by the client, including relations
+	ResourceCount uint64
+}
+
+// APICallCount is the amount of calls made to a single API operation by a client while resolving a table
+type APICallCount struct {
+	Table     string
+	ClientID  string
+	Service   string
+	Operation string
+	Count     uint64
 }
 
 type FailedResourceFetch struct {
@@ -182,8 +448,12 @@ type FailedResourceFetch struct {
 }
 
 type ConnectionDetails struct {
+	// Type is the storage type of the connection, as registered with database.RegisterStorage. Defaults to postgres.
 	Type string
 	DSN  string
+	// ColumnPolicies maps a column policy tag to the action ("omit" or "mask") applied to restricted columns
+	// written to this connection, see schema.ColumnPolicies
+	ColumnPolicies map[string]string
 }
 
 type ProviderDiagnostic struct {
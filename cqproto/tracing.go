@@ -0,0 +1,30 @@
+package cqproto
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// tracePropagator propagates the trace context of the calls of hosts to providers in the gRPC metadata
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// GRPCServerOptions instrument the gRPC server of a provider with OpenTelemetry spans of the tracer provider, which
+// continue the traces propagated by clients dialed with GRPCDialOptions
+func GRPCServerOptions(tp trace.TracerProvider) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(otelgrpc.WithTracerProvider(tp), otelgrpc.WithPropagators(tracePropagator))),
+		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor(otelgrpc.WithTracerProvider(tp), otelgrpc.WithPropagators(tracePropagator))),
+	}
+}
+
+// GRPCDialOptions instrument the gRPC client of a host with OpenTelemetry spans of the tracer provider, propagating the
+// trace context of calls to the provider so its spans are part of the host's traces. Hosts set them in the
+// plugin.ClientConfig.GRPCDialOptions of the provider.
+func GRPCDialOptions(tp trace.TracerProvider) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(otelgrpc.WithTracerProvider(tp), otelgrpc.WithPropagators(tracePropagator))),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(otelgrpc.WithTracerProvider(tp), otelgrpc.WithPropagators(tracePropagator))),
+	}
+}
@@ -0,0 +1,271 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+const (
+	// backupFormat identifies the streams written by Backup
+	backupFormat = "cq-backup"
+	// backupFormatVersion is the version of the stream's framing, increased on incompatible changes
+	backupFormatVersion = 1
+	// DefaultBackupChunkSize is the maximum size of the COPY data frames of a backup, if BackupOptions.ChunkSize isn't set
+	DefaultBackupChunkSize = 4 << 20
+)
+
+// BackupHeader is the first frame of a backup, describing its content
+type BackupHeader struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	// SchemaVersion is the version of the provider's schema the data was backed up from, see BackupOptions.SchemaVersion
+	SchemaVersion string `json:"schema_version,omitempty"`
+	// Tables backed up, parents before their relations
+	Tables    []string  `json:"tables"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// backupFrame precedes each COPY data chunk of a table, and marks the end of a table's data
+type backupFrame struct {
+	Table string `json:"table"`
+	// Size of the chunk following the frame
+	Size int `json:"size,omitempty"`
+	// End is true once all the data of the table was written, Size is then the table's total data size
+	End bool `json:"end,omitempty"`
+}
+
+// BackupProgress is reported after each chunk of table data is written or restored
+type BackupProgress struct {
+	// Table the chunk belongs to
+	Table string
+	// Bytes of the table's COPY data written or restored so far
+	Bytes int64
+	// Done is true once the table is complete
+	Done bool
+}
+
+// BackupOptions configure Backup, zero values use the defaults
+type BackupOptions struct {
+	// SchemaVersion is stamped in the backup, i.e the provider's version or latest migration, so Restore can verify the
+	// backup matches the target database
+	SchemaVersion string
+	// ChunkSize is the maximum size of the COPY data frames, DefaultBackupChunkSize if not set
+	ChunkSize int
+	// Progress is called after each chunk, if set
+	Progress func(BackupProgress)
+}
+
+// RestoreOptions configure Restore, zero values use the defaults
+type RestoreOptions struct {
+	// SchemaVersion if set must match the SchemaVersion the backup was stamped with
+	SchemaVersion string
+	// SkipTables aren't restored, i.e the tables completed by a previous Restore of the same backup that was interrupted
+	SkipTables map[string]bool
+	// Progress is called after each chunk, if set
+	Progress func(BackupProgress)
+}
+
+// Backup streams the data of tables and their relations to w with COPY, in chunks framed so Restore can load them in
+// another database with the same schema. The storage must support RawCopyTo, i.e postgres.
+func Backup(ctx context.Context, storage execution.Storage, tables []*schema.Table, w io.Writer, opts BackupOptions) error {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultBackupChunkSize
+	}
+	d := storage.Dialect()
+	var names []string
+	for _, t := range tables {
+		names = append(names, t.TableNames()...)
+	}
+	bw := bufio.NewWriter(w)
+	if err := writeBackupJSON(bw, BackupHeader{
+		Format:        backupFormat,
+		Version:       backupFormatVersion,
+		SchemaVersion: opts.SchemaVersion,
+		Tables:        names,
+		CreatedAt:     time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+	for _, name := range names {
+		cw := &chunkWriter{w: bw, table: name, size: opts.ChunkSize, progress: opts.Progress}
+		if err := storage.RawCopyTo(ctx, cw, fmt.Sprintf("COPY %s TO STDOUT", d.QuoteIdentifier(name))); err != nil {
+			return fmt.Errorf("failed to back up table %s: %w", name, err)
+		}
+		if err := cw.close(); err != nil {
+			return fmt.Errorf("failed to back up table %s: %w", name, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// Restore loads a stream written by Backup into the storage's tables with COPY, in the order they were backed up.
+// The tables must exist, i.e created by the provider's migrations, and shouldn't have conflicting rows. The storage must
+// support RawCopyFrom, i.e postgres. The header of the restored backup is returned.
+func Restore(ctx context.Context, storage execution.Storage, r io.Reader, opts RestoreOptions) (*BackupHeader, error) {
+	br := bufio.NewReader(r)
+	var header BackupHeader
+	if err := readBackupJSON(br, &header); err != nil {
+		return nil, fmt.Errorf("failed to read backup header: %w", err)
+	}
+	if header.Format != backupFormat || header.Version != backupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format %s version %d", header.Format, header.Version)
+	}
+	if opts.SchemaVersion != "" && opts.SchemaVersion != header.SchemaVersion {
+		return nil, fmt.Errorf("backup schema version %s doesn't match %s", header.SchemaVersion, opts.SchemaVersion)
+	}
+	d := storage.Dialect()
+	for _, name := range header.Tables {
+		if err := restoreTable(ctx, storage, d, br, name, opts); err != nil {
+			return &header, fmt.Errorf("failed to restore table %s: %w", name, err)
+		}
+	}
+	return &header, nil
+}
+
+// restoreTable copies the chunks of a table's data into it, or discards them if the table is skipped
+func restoreTable(ctx context.Context, storage execution.Storage, d schema.Dialect, br *bufio.Reader, name string, opts RestoreOptions) error {
+	skip := opts.SkipTables[name]
+	pr, pw := io.Pipe()
+	copyErr := make(chan error, 1)
+	if skip {
+		go func() {
+			_, err := io.Copy(io.Discard, pr)
+			copyErr <- err
+		}()
+	} else {
+		go func() {
+			err := storage.RawCopyFrom(ctx, pr, fmt.Sprintf("COPY %s FROM STDIN", d.QuoteIdentifier(name)))
+			// unblock the chunks written after a failed copy
+			_ = pr.CloseWithError(err)
+			copyErr <- err
+		}()
+	}
+	var restored int64
+	for {
+		var f backupFrame
+		if err := readBackupJSON(br, &f); err != nil {
+			_ = pw.CloseWithError(err)
+			<-copyErr
+			return err
+		}
+		if f.Table != name {
+			err := fmt.Errorf("unexpected data of table %s", f.Table)
+			_ = pw.CloseWithError(err)
+			<-copyErr
+			return err
+		}
+		if f.End {
+			break
+		}
+		if _, err := io.CopyN(pw, br, int64(f.Size)); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			_ = pw.CloseWithError(err)
+			if cerr := <-copyErr; cerr != nil {
+				return cerr
+			}
+			return err
+		}
+		restored += int64(f.Size)
+		if opts.Progress != nil && !skip {
+			opts.Progress(BackupProgress{Table: name, Bytes: restored})
+		}
+	}
+	_ = pw.Close()
+	if err := <-copyErr; err != nil {
+		return err
+	}
+	if opts.Progress != nil && !skip {
+		opts.Progress(BackupProgress{Table: name, Bytes: restored, Done: true})
+	}
+	return nil
+}
+
+// chunkWriter frames the COPY data of a table written to it in chunks of up to size bytes
+type chunkWriter struct {
+	w        *bufio.Writer
+	table    string
+	size     int
+	buf      []byte
+	written  int64
+	progress func(BackupProgress)
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := c.size - len(c.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		c.buf = append(c.buf, p[:free]...)
+		p = p[free:]
+		if len(c.buf) == c.size {
+			if err := c.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (c *chunkWriter) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	if err := writeBackupJSON(c.w, backupFrame{Table: c.table, Size: len(c.buf)}); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(c.buf); err != nil {
+		return err
+	}
+	c.written += int64(len(c.buf))
+	c.buf = c.buf[:0]
+	if c.progress != nil {
+		c.progress(BackupProgress{Table: c.table, Bytes: c.written})
+	}
+	return nil
+}
+
+// close flushes the last chunk and marks the end of the table's data
+func (c *chunkWriter) close() error {
+	if err := c.flush(); err != nil {
+		return err
+	}
+	if err := writeBackupJSON(c.w, backupFrame{Table: c.table, Size: int(c.written), End: true}); err != nil {
+		return err
+	}
+	if c.progress != nil {
+		c.progress(BackupProgress{Table: c.table, Bytes: c.written, Done: true})
+	}
+	return nil
+}
+
+// writeBackupJSON writes v as a single JSON line
+func writeBackupJSON(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func readBackupJSON(r *bufio.Reader, v interface{}) error {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return json.Unmarshal(line, v)
+}
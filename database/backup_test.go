@@ -0,0 +1,245 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/database/memory"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyStorage keeps the COPY data of each table, so backups can be restored into another copyStorage
+type copyStorage struct {
+	*memory.Storage
+	mu      sync.Mutex
+	data    map[string][]byte
+	copyErr error
+}
+
+func newCopyStorage(data map[string][]byte) *copyStorage {
+	if data == nil {
+		data = make(map[string][]byte)
+	}
+	return &copyStorage{Storage: memory.New(), data: data}
+}
+
+// copyTable returns the unquoted table name of a COPY statement
+func copyTable(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.Trim(fields[1], `"`)
+}
+
+func (s *copyStorage) RawCopyTo(_ context.Context, w io.Writer, sql string) error {
+	s.mu.Lock()
+	data := s.data[copyTable(sql)]
+	s.mu.Unlock()
+	// written in small pieces, as COPY sends a message per row
+	for len(data) > 0 {
+		n := 3
+		if n > len(data) {
+			n = len(data)
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func (s *copyStorage) RawCopyFrom(_ context.Context, r io.Reader, sql string) error {
+	if s.copyErr != nil {
+		return s.copyErr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[copyTable(sql)] = data
+	return nil
+}
+
+var backupTable = &schema.Table{
+	Name: "backup_parents",
+	Relations: []*schema.Table{
+		{Name: "backup_children"},
+		{Name: "backup_empty"},
+	},
+}
+
+func backupTestData() map[string][]byte {
+	return map[string][]byte{
+		"backup_parents":  []byte("1\tfirst\n2\tsecond\n3\tthird\n"),
+		"backup_children": []byte("1\t1\ta\n2\t1\tb\n"),
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	source := newCopyStorage(backupTestData())
+	var backupProgress []BackupProgress
+	var buf bytes.Buffer
+	err := Backup(ctx, source, []*schema.Table{backupTable}, &buf, BackupOptions{
+		SchemaVersion: "v1",
+		ChunkSize:     8,
+		Progress:      func(p BackupProgress) { backupProgress = append(backupProgress, p) },
+	})
+	require.NoError(t, err)
+
+	target := newCopyStorage(nil)
+	var restoreProgress []BackupProgress
+	header, err := Restore(ctx, target, bytes.NewReader(buf.Bytes()), RestoreOptions{
+		SchemaVersion: "v1",
+		Progress:      func(p BackupProgress) { restoreProgress = append(restoreProgress, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, backupFormat, header.Format)
+	assert.Equal(t, backupFormatVersion, header.Version)
+	assert.Equal(t, "v1", header.SchemaVersion)
+	assert.Equal(t, []string{"backup_parents", "backup_children", "backup_empty"}, header.Tables)
+	assert.False(t, header.CreatedAt.IsZero())
+
+	expected := backupTestData()
+	expected["backup_empty"] = []byte{}
+	assert.Equal(t, expected, target.data)
+
+	// 25 bytes of parents in chunks of 8, 12 bytes of children and no data of the empty table
+	expectedProgress := []BackupProgress{
+		{Table: "backup_parents", Bytes: 8},
+		{Table: "backup_parents", Bytes: 16},
+		{Table: "backup_parents", Bytes: 24},
+		{Table: "backup_parents", Bytes: 25},
+		{Table: "backup_parents", Bytes: 25, Done: true},
+		{Table: "backup_children", Bytes: 8},
+		{Table: "backup_children", Bytes: 12},
+		{Table: "backup_children", Bytes: 12, Done: true},
+		{Table: "backup_empty", Done: true},
+	}
+	assert.Equal(t, expectedProgress, backupProgress)
+	assert.Equal(t, expectedProgress, restoreProgress)
+}
+
+func TestBackup_CopyError(t *testing.T) {
+	source := &failingCopyToStorage{Storage: memory.New()}
+	err := Backup(context.Background(), source, []*schema.Table{backupTable}, io.Discard, BackupOptions{})
+	assert.EqualError(t, err, "failed to back up table backup_parents: copy failed")
+}
+
+type failingCopyToStorage struct {
+	*memory.Storage
+}
+
+func (failingCopyToStorage) RawCopyTo(context.Context, io.Writer, string) error {
+	return errors.New("copy failed")
+}
+
+func TestRestore_SkipTables(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	require.NoError(t, Backup(ctx, newCopyStorage(backupTestData()), []*schema.Table{backupTable}, &buf, BackupOptions{ChunkSize: 4}))
+
+	target := newCopyStorage(nil)
+	var progress []BackupProgress
+	_, err := Restore(ctx, target, &buf, RestoreOptions{
+		SkipTables: map[string]bool{"backup_parents": true},
+		Progress: func(p BackupProgress) {
+			if p.Done {
+				progress = append(progress, p)
+			}
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"backup_children": []byte("1\t1\ta\n2\t1\tb\n"),
+		"backup_empty":    {},
+	}, target.data)
+	assert.Equal(t, []BackupProgress{
+		{Table: "backup_children", Bytes: 12, Done: true},
+		{Table: "backup_empty", Done: true},
+	}, progress)
+}
+
+func TestRestore_Errors(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	require.NoError(t, Backup(ctx, newCopyStorage(backupTestData()), []*schema.Table{backupTable}, &buf, BackupOptions{SchemaVersion: "v1", ChunkSize: 8}))
+	backup := buf.String()
+	lines := strings.SplitAfter(backup, "\n")
+
+	tests := []struct {
+		name    string
+		backup  string
+		opts    RestoreOptions
+		copyErr error
+		err     string
+	}{
+		{
+			name:   "empty",
+			backup: "",
+			err:    "failed to read backup header: unexpected EOF",
+		},
+		{
+			name:   "unsupported format",
+			backup: `{"format":"pg_dump","version":1,"tables":[]}` + "\n",
+			err:    "unsupported backup format pg_dump version 1",
+		},
+		{
+			name:   "unsupported version",
+			backup: fmt.Sprintf(`{"format":%q,"version":%d,"tables":[]}`, backupFormat, backupFormatVersion+1) + "\n",
+			err:    fmt.Sprintf("unsupported backup format %s version %d", backupFormat, backupFormatVersion+1),
+		},
+		{
+			name:   "schema version mismatch",
+			backup: backup,
+			opts:   RestoreOptions{SchemaVersion: "v2"},
+			err:    "backup schema version v1 doesn't match v2",
+		},
+		{
+			name:   "truncated frame",
+			backup: lines[0] + lines[1][:5],
+			err:    "failed to restore table backup_parents: unexpected EOF",
+		},
+		{
+			name:   "missing chunk",
+			backup: lines[0] + lines[1],
+			err:    "failed to restore table backup_parents: unexpected EOF",
+		},
+		{
+			name:   "truncated chunk",
+			backup: lines[0] + lines[1] + "1\tf",
+			err:    "failed to restore table backup_parents: unexpected EOF",
+		},
+		{
+			name:   "unexpected table",
+			backup: lines[0] + `{"table":"backup_children","size":1}` + "\n",
+			err:    "failed to restore table backup_parents: unexpected data of table backup_children",
+		},
+		{
+			name:    "copy error",
+			backup:  backup,
+			copyErr: errors.New("relation \"backup_parents\" does not exist"),
+			err:     "failed to restore table backup_parents: relation \"backup_parents\" does not exist",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			target := newCopyStorage(nil)
+			target.copyErr = tc.copyErr
+			_, err := Restore(ctx, target, strings.NewReader(tc.backup), tc.opts)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudquery/cq-provider-sdk/provider/execution"
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 // DB encapsulates a schema.Storage and the (auto-detected) dialect it was configured with
@@ -39,6 +40,31 @@ func New(ctx context.Context, logger hclog.Logger, dsn string) (*DB, error) {
 	}, nil
 }
 
+// NewFromPool creates a new DB using an existing pool of the given dialect, for providers embedded in a host process
+// that manages its own connections. The pool isn't closed when the DB is closed, see postgres.NewPgDatabaseFromPool.
+func NewFromPool(logger hclog.Logger, pool *pgxpool.Pool, dType schema.DialectType) (*DB, error) {
+	dialect, err := schema.GetDialect(dType)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{
+		Storage:     postgres.NewPgDatabaseFromPool(logger, pool, dialect),
+		dialectType: dType,
+	}, nil
+}
+
+// WithDialect returns a DB sharing the connection, writing resources with dialect d, see execution.DialectStorage
+func (d *DB) WithDialect(dialect schema.Dialect) execution.Storage {
+	ds, ok := d.Storage.(execution.DialectStorage)
+	if !ok {
+		return d
+	}
+	return &DB{
+		Storage:     ds.WithDialect(dialect),
+		dialectType: d.dialectType,
+	}
+}
+
 // DialectType returns the dialect type the DB was configured with
 func (d *DB) DialectType() schema.DialectType {
 	return d.dialectType
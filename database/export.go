@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// ExportOptions configure ExportCSV, zero values use the defaults
+type ExportOptions struct {
+	// FetchID if set exports only the rows written by the fetch, see schema.Meta.FetchId
+	FetchID string
+	// SkipHeader doesn't write the column names as the first record
+	SkipHeader bool
+	// Comma is the field delimiter, ',' if not set
+	Comma rune
+}
+
+// ExportCSV writes the rows of table t to w as CSV, one record per row with the columns of the storage's dialect,
+// returning the number of rows written. Values are formatted by the column's type so they read well in spreadsheets:
+// timestamps as RFC 3339 in UTC, arrays and hstores as JSON arrays and objects, byte arrays as base64 and NULLs as
+// empty fields. Relations aren't exported, export each of t.TableNames() to export them. The storage must support
+// postgres statements.
+func ExportCSV(ctx context.Context, storage execution.Storage, t *schema.Table, w io.Writer, opts ExportOptions) (int, error) {
+	d := storage.Dialect()
+	columns := d.Columns(t)
+	names := columns.Names()
+	selects := make([]string, len(columns))
+	for i, c := range columns {
+		selects[i] = exportColumnExpr(d.QuoteIdentifier(c.Name), c.Type)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selects, ", "), d.QuoteIdentifier(t.Name))
+	var args []interface{}
+	if opts.FetchID != "" {
+		query += " WHERE cq_meta->>'fetch_id' = $1"
+		args = append(args, opts.FetchID)
+	}
+	rows, err := storage.Query(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export table %s: %w", t.Name, err)
+	}
+	defer rows.Close()
+
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+	if !opts.SkipHeader {
+		if err := cw.Write(names); err != nil {
+			return 0, err
+		}
+	}
+	values := make([]*string, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, fmt.Errorf("failed to export table %s: %w", t.Name, err)
+		}
+		for i, v := range values {
+			record[i] = ""
+			if v != nil {
+				record[i] = *v
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to export table %s: %w", t.Name, err)
+	}
+	cw.Flush()
+	return count, cw.Error()
+}
+
+// exportColumnExpr selects the quoted column as text formatted by its type, see ExportCSV
+func exportColumnExpr(column string, t schema.ValueType) string {
+	switch t {
+	case schema.TypeTimestamp:
+		return fmt.Sprintf(`to_char(%s, 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`, column)
+	case schema.TypeTimestampTZ:
+		return fmt.Sprintf(`to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`, column)
+	case schema.TypeStringArray, schema.TypeIntArray, schema.TypeUUIDArray, schema.TypeInetArray, schema.TypeCIDRArray, schema.TypeMacAddrArray:
+		return fmt.Sprintf("array_to_json(%s)::text", column)
+	case schema.TypeHStore:
+		return fmt.Sprintf("hstore_to_json(%s)::text", column)
+	case schema.TypeByteArray:
+		return fmt.Sprintf("replace(encode(%s, 'base64'), E'\\n', '')", column)
+	default:
+		return column + "::text"
+	}
+}
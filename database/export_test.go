@@ -0,0 +1,174 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/database/memory"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queryStorage returns its rows for any query, recording the query and its arguments
+type queryStorage struct {
+	*memory.Storage
+	rows     [][]*string
+	queryErr error
+	rowsErr  error
+	query    string
+	args     []interface{}
+	result   *textRows
+}
+
+func (s *queryStorage) Query(_ context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	s.query, s.args = query, args
+	if s.queryErr != nil {
+		return nil, s.queryErr
+	}
+	s.result = &textRows{rows: s.rows, err: s.rowsErr, i: -1}
+	return s.result, nil
+}
+
+// textRows are the rows of queryStorage, with every column selected as text
+type textRows struct {
+	pgx.Rows
+	rows   [][]*string
+	err    error
+	i      int
+	closed bool
+}
+
+func (r *textRows) Next() bool {
+	r.i++
+	return r.i < len(r.rows)
+}
+
+func (r *textRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.rows[r.i]) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r.rows[r.i]), len(dest))
+	}
+	for i, v := range r.rows[r.i] {
+		*dest[i].(**string) = v
+	}
+	return nil
+}
+
+func (r *textRows) Err() error {
+	return r.err
+}
+
+func (r *textRows) Close() {
+	r.closed = true
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+var exportTable = &schema.Table{
+	Name: "export_items",
+	Columns: []schema.Column{
+		{Name: "name", Type: schema.TypeString},
+		{Name: "created_at", Type: schema.TypeTimestamp},
+		{Name: "tags", Type: schema.TypeStringArray},
+	},
+}
+
+func TestExportCSV(t *testing.T) {
+	storage := &queryStorage{
+		Storage: memory.New(),
+		rows: [][]*string{
+			{strPtr("9f4c7830-4d2e-4b2c-90b3-6c2b8e1d4a11"), strPtr(`{"fetch_id":"f1"}`), strPtr("first, second"), strPtr("2022-05-01T10:00:00.000000Z"), strPtr(`["a","b"]`)},
+			{strPtr("1d9aa2f4-0cbb-4aa4-8d8f-2a3e0ff0f3b2"), nil, strPtr(`say "hi"`), nil, nil},
+		},
+	}
+	var buf bytes.Buffer
+	n, err := ExportCSV(context.Background(), storage, exportTable, &buf, ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `cq_id,cq_meta,name,created_at,tags
+9f4c7830-4d2e-4b2c-90b3-6c2b8e1d4a11,"{""fetch_id"":""f1""}","first, second",2022-05-01T10:00:00.000000Z,"[""a"",""b""]"
+1d9aa2f4-0cbb-4aa4-8d8f-2a3e0ff0f3b2,,"say ""hi""",,
+`, buf.String())
+	assert.Equal(t, `SELECT "cq_id"::text, "cq_meta"::text, "name"::text, to_char("created_at", 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'), array_to_json("tags")::text FROM "export_items"`, storage.query)
+	assert.Empty(t, storage.args)
+	assert.True(t, storage.result.closed)
+}
+
+func TestExportCSV_Options(t *testing.T) {
+	storage := &queryStorage{
+		Storage: memory.New(),
+		rows:    [][]*string{{strPtr("id"), nil, strPtr("a;b"), nil, nil}},
+	}
+	var buf bytes.Buffer
+	n, err := ExportCSV(context.Background(), storage, exportTable, &buf, ExportOptions{FetchID: "f1", SkipHeader: true, Comma: ';'})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "id;;\"a;b\";;\n", buf.String())
+	assert.Contains(t, storage.query, ` FROM "export_items" WHERE cq_meta->>'fetch_id' = $1`)
+	assert.Equal(t, []interface{}{"f1"}, storage.args)
+}
+
+func TestExportCSV_Errors(t *testing.T) {
+	row := []*string{strPtr("id"), nil, nil, nil, nil}
+	tests := []struct {
+		name    string
+		storage *queryStorage
+		count   int
+		err     string
+	}{
+		{
+			name:    "query",
+			storage: &queryStorage{queryErr: errors.New("relation \"export_items\" does not exist")},
+			err:     "failed to export table export_items: relation \"export_items\" does not exist",
+		},
+		{
+			name:    "scan",
+			storage: &queryStorage{rows: [][]*string{row, {strPtr("id")}}},
+			count:   1,
+			err:     "failed to export table export_items: expected 1 destinations, got 5",
+		},
+		{
+			name:    "rows",
+			storage: &queryStorage{rows: [][]*string{row}, rowsErr: errors.New("connection reset")},
+			count:   1,
+			err:     "failed to export table export_items: connection reset",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.storage.Storage = memory.New()
+			n, err := ExportCSV(context.Background(), tc.storage, exportTable, &bytes.Buffer{}, ExportOptions{})
+			assert.EqualError(t, err, tc.err)
+			assert.Equal(t, tc.count, n)
+		})
+	}
+}
+
+func TestExportColumnExpr(t *testing.T) {
+	tests := []struct {
+		typ      schema.ValueType
+		expected string
+	}{
+		{schema.TypeString, `"c"::text`},
+		{schema.TypeBigInt, `"c"::text`},
+		{schema.TypeJSON, `"c"::text`},
+		{schema.TypeTimestamp, `to_char("c", 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`},
+		{schema.TypeTimestampTZ, `to_char("c" AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')`},
+		{schema.TypeStringArray, `array_to_json("c")::text`},
+		{schema.TypeIntArray, `array_to_json("c")::text`},
+		{schema.TypeInetArray, `array_to_json("c")::text`},
+		{schema.TypeHStore, `hstore_to_json("c")::text`},
+		{schema.TypeByteArray, `replace(encode("c", 'base64'), E'\n', '')`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.typ.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, exportColumnExpr(`"c"`, tc.typ))
+		})
+	}
+}
@@ -0,0 +1,480 @@
+// Package filestore implements an execution.Storage that streams fetched resources to files instead of a database,
+// in a directory per table:
+//
+//	db, err := filestore.New("out", filestore.Options{Compression: filestore.CompressionGzip, BatchSize: 10000})
+//	... fetch with db as storage ...
+//	db.Close() // out/aws_ec2_instances/aws_ec2_instances-0000.csv.gz, ...
+//	if err := db.Err(); err != nil { ... }
+package filestore
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// ErrNotSupported is returned by the SQL methods of Storage
+var ErrNotSupported = errors.New("not supported by file storage")
+
+// Format is the file format resources are written in
+type Format string
+
+// Compression is the compression applied to written files
+type Compression string
+
+const (
+	// FormatCSV writes a CSV file with a header row of the table's column names
+	FormatCSV Format = "csv"
+	// FormatParquet writes a Parquet file with a column per table column. Rows are buffered in row groups, so files are
+	// only readable once closed, when a batch is completed or the storage is closed.
+	FormatParquet Format = "parquet"
+
+	// CompressionNone writes uncompressed files
+	CompressionNone Compression = ""
+	// CompressionGzip writes gzip compressed files, CSV files with a .gz suffix and Parquet files with gzip compressed
+	// pages
+	CompressionGzip Compression = "gzip"
+)
+
+// Options configure the files written by Storage
+type Options struct {
+	// Format of the written files, defaults to FormatCSV
+	Format Format
+	// Compression of the written files, defaults to CompressionNone
+	Compression Compression
+	// BatchSize is the maximum number of rows written to a single file, once reached the table continues in a new file.
+	// If zero, all the rows of a table are written to a single file.
+	BatchSize int
+}
+
+// Storage writes resources to files under a directory per table. Rows are appended as they are stored, Delete and
+// RemoveStaleData have no effect on written rows.
+type Storage struct {
+	mu      sync.Mutex
+	dir     string
+	opts    Options
+	dialect schema.Dialect
+	tables  map[string]*tableWriter
+	err     error
+}
+
+var _ execution.Storage = (*Storage)(nil)
+
+// New creates a Storage writing to dir, which is created if it doesn't exist
+func New(dir string, opts Options) (*Storage, error) {
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+	if opts.Format != FormatCSV && opts.Format != FormatParquet {
+		return nil, fmt.Errorf("unsupported file format %q", opts.Format)
+	}
+	if opts.Compression != CompressionNone && opts.Compression != CompressionGzip {
+		return nil, fmt.Errorf("unsupported file compression %q", opts.Compression)
+	}
+	if opts.BatchSize < 0 {
+		return nil, fmt.Errorf("invalid batch size %d", opts.BatchSize)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Storage{
+		dir:     dir,
+		opts:    opts,
+		dialect: schema.PostgresDialect{},
+		tables:  make(map[string]*tableWriter),
+	}, nil
+}
+
+// NewFromDSN creates a Storage from a DSN of the form file:///path/to/dir?format=parquet&compression=gzip&batch_size=1000
+func NewFromDSN(dsn string) (*Storage, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("invalid file storage dsn scheme %q", u.Scheme)
+	}
+	dir := u.Opaque
+	if dir == "" {
+		dir = u.Host + u.Path
+	}
+	if dir == "" {
+		return nil, errors.New("file storage dsn has no directory")
+	}
+	q := u.Query()
+	opts := Options{
+		Format:      Format(q.Get("format")),
+		Compression: Compression(q.Get("compression")),
+	}
+	if v := q.Get("batch_size"); v != "" {
+		if opts.BatchSize, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid batch_size %q: %w", v, err)
+		}
+	}
+	return New(dir, opts)
+}
+
+func (s *Storage) Insert(ctx context.Context, t *schema.Table, resources schema.Resources, shouldCascade bool) error {
+	for _, r := range resources {
+		if r.TableName() != t.Name {
+			return fmt.Errorf("resource table expected %s got %s", t.Name, r.TableName())
+		}
+	}
+	return s.CopyFrom(ctx, resources, shouldCascade)
+}
+
+func (s *Storage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.tables[resources.TableName()]
+	if !ok {
+		w = &tableWriter{
+			dir:     filepath.Join(s.dir, resources.TableName()),
+			name:    resources.TableName(),
+			columns: resources.Columns(),
+			opts:    s.opts,
+		}
+		s.tables[resources.TableName()] = w
+	}
+	return w.write(resources)
+}
+
+func (*Storage) Delete(context.Context, *schema.Table, []interface{}) error {
+	return nil
+}
+
+func (*Storage) RemoveStaleData(context.Context, *schema.Table, execution.StaleFilter, []interface{}) error {
+	return nil
+}
+
+func (s *Storage) Dialect() schema.Dialect {
+	return s.dialect
+}
+
+// Close flushes and closes the files being written. The first error of closing them is returned by Err, as Close
+// of execution.Storage has no result.
+func (s *Storage) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, w := range s.tables {
+		if err := w.close(); err != nil && s.err == nil {
+			s.err = fmt.Errorf("failed to close file of table %s: %w", w.name, err)
+		}
+	}
+}
+
+// Err returns the first error of Close, files of tables written before it may be incomplete
+func (s *Storage) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Files returns the paths of the files written for the table, in write order
+func (s *Storage) Files(table string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.tables[table]
+	if !ok {
+		return nil
+	}
+	files := make([]string, len(w.files))
+	copy(files, w.files)
+	return files
+}
+
+func (*Storage) Exec(context.Context, string, ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (*Storage) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Storage) Begin(context.Context) (execution.TXQueryExecer, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Storage) RawCopyTo(context.Context, io.Writer, string) error {
+	return ErrNotSupported
+}
+
+func (*Storage) RawCopyFrom(context.Context, io.Reader, string) error {
+	return ErrNotSupported
+}
+
+// tableWriter writes the rows of a table, starting a new file every BatchSize rows
+type tableWriter struct {
+	dir     string
+	name    string
+	columns schema.ColumnList
+	opts    Options
+	files   []string
+
+	file *os.File
+	enc  encoder
+	rows int
+}
+
+// encoder writes the rows of a file in its format, nil fields are NULL
+type encoder interface {
+	write(record []*string) error
+	// flush writes the buffered rows to the file, if the format allows it before the file is closed
+	flush() error
+	// close flushes the rows and writes the end of the file, without closing it
+	close() error
+}
+
+func (w *tableWriter) write(resources schema.Resources) error {
+	record := make([]*string, len(w.columns))
+	for _, r := range resources {
+		if w.file == nil || (w.opts.BatchSize > 0 && w.rows >= w.opts.BatchSize) {
+			if err := w.next(); err != nil {
+				return err
+			}
+		}
+		for i, c := range w.columns {
+			v, err := w.formatField(c, r.Get(c.Name))
+			if err != nil {
+				return fmt.Errorf("column %s: %w", c.Name, err)
+			}
+			record[i] = v
+		}
+		if err := w.enc.write(record); err != nil {
+			return err
+		}
+		w.rows++
+	}
+	// flush, so rows are streamed to the file as they are stored
+	return w.enc.flush()
+}
+
+func (w *tableWriter) formatField(c schema.Column, v interface{}) (*string, error) {
+	if w.opts.Format == FormatParquet {
+		return formatParquetValue(c.Type, v)
+	}
+	s, err := formatValue(v)
+	return &s, err
+}
+
+// next closes the current file and starts a new one
+func (w *tableWriter) next() error {
+	if err := w.close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%04d.%s", w.name, len(w.files), w.opts.Format)
+	if w.opts.Format == FormatCSV && w.opts.Compression == CompressionGzip {
+		name += ".gz"
+	}
+	path := filepath.Join(w.dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w.file, w.files, w.rows = f, append(w.files, path), 0
+	if w.opts.Format == FormatParquet {
+		w.enc, err = newParquetEncoder(f, w.columns, w.opts.Compression)
+	} else {
+		w.enc, err = newCSVEncoder(f, w.columns.Names(), w.opts.Compression)
+	}
+	if err != nil {
+		w.enc = nil
+		_ = w.close()
+		return err
+	}
+	return nil
+}
+
+func (w *tableWriter) close() error {
+	if w.file == nil {
+		return nil
+	}
+	var err error
+	if w.enc != nil {
+		err = w.enc.close()
+	}
+	if fErr := w.file.Close(); err == nil {
+		err = fErr
+	}
+	w.file, w.enc = nil, nil
+	return err
+}
+
+// csvEncoder writes a CSV file with a header row of the column names, NULL fields are empty
+type csvEncoder struct {
+	gz     *gzip.Writer
+	csv    *csv.Writer
+	fields []string
+}
+
+func newCSVEncoder(out io.Writer, columns []string, compression Compression) (*csvEncoder, error) {
+	e := &csvEncoder{fields: make([]string, len(columns))}
+	if compression == CompressionGzip {
+		e.gz = gzip.NewWriter(out)
+		out = e.gz
+	}
+	e.csv = csv.NewWriter(out)
+	return e, e.csv.Write(columns)
+}
+
+func (e *csvEncoder) write(record []*string) error {
+	for i, v := range record {
+		e.fields[i] = ""
+		if v != nil {
+			e.fields[i] = *v
+		}
+	}
+	return e.csv.Write(e.fields)
+}
+
+func (e *csvEncoder) flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *csvEncoder) close() error {
+	err := e.flush()
+	if e.gz != nil {
+		if gzErr := e.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	return err
+}
+
+// parquetEncoder writes a Parquet file of optional columns. The writer buffers rows in row groups, which are written
+// as they fill up and on close.
+type parquetEncoder struct {
+	w *writer.CSVWriter
+}
+
+func newParquetEncoder(out io.Writer, columns schema.ColumnList, compression Compression) (*parquetEncoder, error) {
+	md := make([]string, len(columns))
+	for i, c := range columns {
+		md[i] = fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", c.Name, parquetType(c.Type))
+	}
+	w, err := writer.NewCSVWriterFromWriter(md, out, 1)
+	if err != nil {
+		return nil, err
+	}
+	w.CompressionType = parquet.CompressionCodec_UNCOMPRESSED
+	if compression == CompressionGzip {
+		w.CompressionType = parquet.CompressionCodec_GZIP
+	}
+	return &parquetEncoder{w: w}, nil
+}
+
+func (e *parquetEncoder) write(record []*string) error {
+	return e.w.WriteString(record)
+}
+
+func (*parquetEncoder) flush() error {
+	return nil
+}
+
+func (e *parquetEncoder) close() error {
+	return e.w.WriteStop()
+}
+
+// parquetType returns the parquet type of a column type, in the metadata form of the parquet writer. Timestamps are
+// written as microseconds since the epoch, and types without a parquet equivalent as strings in their CSV form.
+func parquetType(t schema.ValueType) string {
+	switch t {
+	case schema.TypeBool:
+		return "type=BOOLEAN"
+	case schema.TypeSmallInt, schema.TypeInt, schema.TypeBigInt:
+		return "type=INT64"
+	case schema.TypeFloat:
+		return "type=DOUBLE"
+	case schema.TypeTimestamp, schema.TypeTimestampTZ:
+		return "type=INT64, convertedtype=TIMESTAMP_MICROS"
+	default:
+		return "type=BYTE_ARRAY, convertedtype=UTF8"
+	}
+}
+
+// formatParquetValue formats a resource value in the string form the parquet writer parses into the column's
+// parquet type, nil for NULL values
+func formatParquetValue(t schema.ValueType, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if rv := reflect.ValueOf(v); (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map || rv.Kind() == reflect.Ptr) && rv.IsNil() {
+		return nil, nil
+	}
+	if t == schema.TypeTimestamp || t == schema.TypeTimestampTZ {
+		switch val := v.(type) {
+		case time.Time:
+			s := strconv.FormatInt(val.UnixMicro(), 10)
+			return &s, nil
+		case *time.Time:
+			s := strconv.FormatInt(val.UnixMicro(), 10)
+			return &s, nil
+		}
+	}
+	s, err := formatValue(v)
+	return &s, err
+}
+
+// formatValue formats a resource value as a CSV field, JSON columns are written as is, and lists as JSON arrays
+func formatValue(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return val, nil
+	case *string:
+		if val == nil {
+			return "", nil
+		}
+		return *val, nil
+	case []byte:
+		return string(val), nil
+	case time.Time:
+		return val.Format(time.RFC3339Nano), nil
+	case *time.Time:
+		if val == nil {
+			return "", nil
+		}
+		return val.Format(time.RFC3339Nano), nil
+	case uuid.UUID:
+		return val.String(), nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(val), nil
+	case fmt.Stringer:
+		return val.String(), nil
+	default:
+		if rv := reflect.ValueOf(val); (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map || rv.Kind() == reflect.Ptr) && rv.IsNil() {
+			return "", nil
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
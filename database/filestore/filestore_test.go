@@ -0,0 +1,170 @@
+package filestore
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"golang.org/x/sync/semaphore"
+)
+
+type testClient struct{}
+
+func (testClient) Logger() hclog.Logger {
+	return hclog.NewNullLogger()
+}
+
+type testItem struct {
+	Id   int
+	Name *string
+	Tags []string
+}
+
+var testTable = &schema.Table{
+	Name: "test_items",
+	Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		name := "first"
+		res <- []testItem{{Id: 1, Name: &name, Tags: []string{"a", "b"}}, {Id: 2}, {Id: 3}}
+		return nil
+	},
+	Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+	Columns: []schema.Column{
+		{Name: "id", Type: schema.TypeBigInt},
+		{Name: "name", Type: schema.TypeString},
+		{Name: "tags", Type: schema.TypeStringArray},
+	},
+}
+
+func fetch(t *testing.T, db *Storage) {
+	exec := execution.NewTableExecutor("test", db, hclog.NewNullLogger(), testTable, nil, nil, semaphore.NewWeighted(10), time.Minute)
+	count, diags := exec.Resolve(context.Background(), testClient{})
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.EqualValues(t, 3, count)
+}
+
+func readCSV(t *testing.T, path string, gzipped bool) [][]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var r io.Reader = f
+	if gzipped {
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = gz
+	}
+	records, err := csv.NewReader(r).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestStorage(t *testing.T) {
+	dir := t.TempDir()
+	db, err := New(dir, Options{})
+	require.NoError(t, err)
+	fetch(t, db)
+	db.Close()
+
+	files := db.Files("test_items")
+	require.Equal(t, []string{filepath.Join(dir, "test_items", "test_items-0000.csv")}, files)
+	records := readCSV(t, files[0], false)
+	require.Len(t, records, 4)
+	header := records[0]
+	assert.Subset(t, header, []string{"cq_id", "cq_meta", "id", "name", "tags"})
+	assert.Equal(t, map[string]string{"id": "1", "name": "first", "tags": `["a","b"]`}, fields(header, records[1], "id", "name", "tags"))
+	assert.Equal(t, map[string]string{"id": "2", "name": "", "tags": ""}, fields(header, records[2], "id", "name", "tags"))
+}
+
+func fields(header, record []string, columns ...string) map[string]string {
+	m := make(map[string]string, len(columns))
+	for i, h := range header {
+		for _, c := range columns {
+			if h == c {
+				m[c] = record[i]
+			}
+		}
+	}
+	return m
+}
+
+func TestStorage_BatchSizeAndCompression(t *testing.T) {
+	db, err := NewFromDSN("file://" + t.TempDir() + "?compression=gzip&batch_size=2")
+	require.NoError(t, err)
+	fetch(t, db)
+	db.Close()
+
+	files := db.Files("test_items")
+	require.Len(t, files, 2)
+	assert.Equal(t, "test_items-0001.csv.gz", filepath.Base(files[1]))
+	assert.Len(t, readCSV(t, files[0], true), 3)
+	assert.Len(t, readCSV(t, files[1], true), 2)
+}
+
+func TestNewFromDSN(t *testing.T) {
+	_, err := NewFromDSN("postgres://localhost")
+	assert.Error(t, err)
+	_, err = NewFromDSN("file://" + t.TempDir() + "?format=orc")
+	assert.EqualError(t, err, `unsupported file format "orc"`)
+	_, err = NewFromDSN("file://" + t.TempDir() + "?batch_size=many")
+	assert.Error(t, err)
+}
+
+// readParquet returns the values of the columns of the parquet file, by column name
+func readParquet(t *testing.T, path string, columns ...string) map[string][]interface{} {
+	f, err := local.NewLocalFileReader(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := reader.NewParquetColumnReader(f, 1)
+	require.NoError(t, err)
+	defer r.ReadStop()
+	values := make(map[string][]interface{}, len(columns))
+	for _, c := range columns {
+		v, _, _, err := r.ReadColumnByPath("parquet_go_root\x01"+c, r.GetNumRows())
+		require.NoError(t, err)
+		values[c] = v
+	}
+	return values
+}
+
+func TestStorage_Parquet(t *testing.T) {
+	for _, compression := range []Compression{CompressionNone, CompressionGzip} {
+		t.Run(string(compression), func(t *testing.T) {
+			db, err := New(t.TempDir(), Options{Format: FormatParquet, Compression: compression, BatchSize: 2})
+			require.NoError(t, err)
+			fetch(t, db)
+			db.Close()
+			require.NoError(t, db.Err())
+
+			files := db.Files("test_items")
+			require.Len(t, files, 2)
+			assert.Equal(t, "test_items-0000.parquet", filepath.Base(files[0]))
+			assert.Equal(t, map[string][]interface{}{
+				"id":   {int64(1), int64(2)},
+				"name": {"first", nil},
+				"tags": {`["a","b"]`, nil},
+			}, readParquet(t, files[0], "id", "name", "tags"))
+			assert.Equal(t, map[string][]interface{}{"id": {int64(3)}}, readParquet(t, files[1], "id"))
+		})
+	}
+}
+
+func TestStorage_CloseError(t *testing.T) {
+	db, err := New(t.TempDir(), Options{Compression: CompressionGzip})
+	require.NoError(t, err)
+	fetch(t, db)
+	// closed under the storage, so writing the rest of the gzip stream on Close fails
+	require.NoError(t, db.tables["test_items"].file.Close())
+	db.Close()
+	assert.ErrorContains(t, db.Err(), "failed to close file of table test_items")
+}
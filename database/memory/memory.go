@@ -0,0 +1,298 @@
+// Package memory implements an in-memory execution.Storage for provider unit tests. Stored resources can be asserted
+// with a small query API, without SQL or a database:
+//
+//	db := memory.New()
+//	... fetch with db as storage ...
+//	n := db.Table("aws_ec2_instances").Where("region", "us-east-1").Count()
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+// ErrNotSupported is returned by the SQL methods of Storage
+var ErrNotSupported = errors.New("not supported by memory storage")
+
+// Row is a stored resource, mapping column name to value
+type Row map[string]interface{}
+
+// Storage keeps resources in memory, rows are replaced by their cq_id
+type Storage struct {
+	dialect schema.Dialect
+	*tableRows
+}
+
+// tableRows are the stored rows of each table, shared by a Storage and the copies returned by its WithDialect
+type tableRows struct {
+	mu     sync.RWMutex
+	tables map[string]*table
+}
+
+// table holds the rows of a table in insertion order, indexed by their cq_id so rows are replaced in constant time
+type table struct {
+	rows []Row
+	byID map[interface{}]int
+}
+
+var _ execution.DialectStorage = (*Storage)(nil)
+var _ execution.StaleCounter = (*Storage)(nil)
+
+// New creates an empty Storage using the postgres dialect
+func New() *Storage {
+	return NewWithDialect(schema.PostgresDialect{})
+}
+
+// NewWithDialect creates an empty Storage using the given dialect, which determines the internal columns stored
+func NewWithDialect(d schema.Dialect) *Storage {
+	return &Storage{
+		dialect:   d,
+		tableRows: &tableRows{tables: make(map[string]*table)},
+	}
+}
+
+// WithDialect returns a copy of the storage using the given dialect, which determines the internal columns stored. The
+// copy stores its rows with the storage's, so they're queried by either.
+func (s *Storage) WithDialect(d schema.Dialect) execution.Storage {
+	return &Storage{dialect: d, tableRows: s.tableRows}
+}
+
+func (s *Storage) Insert(_ context.Context, t *schema.Table, resources schema.Resources, _ bool) error {
+	for _, r := range resources {
+		if r.TableName() != t.Name {
+			return fmt.Errorf("resource table expected %s got %s", t.Name, r.TableName())
+		}
+	}
+	s.store(resources)
+	return nil
+}
+
+func (s *Storage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	s.store(resources)
+	return nil
+}
+
+func (s *Storage) Delete(_ context.Context, t *schema.Table, kvFilters []interface{}) error {
+	if len(kvFilters)%2 != 0 {
+		return fmt.Errorf("number of args to delete should be even. Got %d", len(kvFilters))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.table(t.Name).remove(func(r Row) bool {
+		return matchFilters(r, kvFilters)
+	})
+	return nil
+}
+
+func (s *Storage) RemoveStaleData(_ context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) error {
+	if len(kvFilters)%2 != 0 {
+		return fmt.Errorf("expected even number of k,v delete filters received %s", kvFilters)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stale := staleRow(t, filter, kvFilters)
+	if !t.Options.SoftDelete {
+		s.table(t.Name).remove(stale)
+		return nil
+	}
+	now := time.Now().UTC()
+	for _, r := range s.rows(t.Name) {
+		if stale(r) {
+			r[schema.DeletedAtColumnName] = now
+		}
+	}
+	return nil
+}
+
+// CountStaleData counts the rows RemoveStaleData removes, or marks deleted
+func (s *Storage) CountStaleData(_ context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) (uint64, error) {
+	if len(kvFilters)%2 != 0 {
+		return 0, fmt.Errorf("expected even number of k,v delete filters received %s", kvFilters)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stale := staleRow(t, filter, kvFilters)
+	var count uint64
+	for _, r := range s.rows(t.Name) {
+		if stale(r) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// staleRow returns whether a row of the table is stale, rows of soft delete tables marked before aren't stale again
+func staleRow(t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) func(Row) bool {
+	return func(r Row) bool {
+		if !matchFilters(r, kvFilters) {
+			return false
+		}
+		if t.Options.SoftDelete && r[schema.DeletedAtColumnName] != nil {
+			return false
+		}
+		var meta schema.Meta
+		if b, ok := r["cq_meta"].([]byte); ok {
+			_ = json.Unmarshal(b, &meta)
+		}
+		if filter.FetchId != "" {
+			return meta.FetchId != filter.FetchId
+		}
+		return meta.LastUpdate.Unix() < filter.LastUpdateBefore.Unix()
+	}
+}
+
+func (s *Storage) Dialect() schema.Dialect {
+	return s.dialect
+}
+
+func (*Storage) Close() {}
+
+func (*Storage) Exec(context.Context, string, ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (*Storage) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Storage) Begin(context.Context) (execution.TXQueryExecer, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Storage) RawCopyTo(context.Context, io.Writer, string) error {
+	return ErrNotSupported
+}
+
+func (*Storage) RawCopyFrom(context.Context, io.Reader, string) error {
+	return ErrNotSupported
+}
+
+// Tables returns the names of the tables that have rows, sorted
+func (s *Storage) Tables() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.tables))
+	for name, t := range s.tables {
+		if len(t.rows) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Table returns a Query over a copy of the current rows of the table, modifying the returned rows doesn't modify the
+// stored ones
+func (s *Storage) Table(name string) *Query {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t := s.tables[name]
+	if t == nil {
+		return &Query{rows: []Row{}}
+	}
+	rows := make([]Row, len(t.rows))
+	for i, r := range t.rows {
+		row := make(Row, len(r))
+		for k, v := range r {
+			row[k] = v
+		}
+		rows[i] = row
+	}
+	return &Query{rows: rows}
+}
+
+func (s *Storage) store(resources schema.Resources) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range resources {
+		row := make(Row, len(resources.ColumnNames()))
+		for _, c := range resources.ColumnNames() {
+			row[c] = r.Get(c)
+		}
+		s.table(r.TableName()).put(row)
+	}
+}
+
+// rows returns the stored rows of the named table
+func (s *tableRows) rows(name string) []Row {
+	if t, ok := s.tables[name]; ok {
+		return t.rows
+	}
+	return nil
+}
+
+// table returns the named table, creating it if it doesn't exist, s.mu must be held for writing
+func (s *tableRows) table(name string) *table {
+	t, ok := s.tables[name]
+	if !ok {
+		t = &table{byID: make(map[interface{}]int)}
+		s.tables[name] = t
+	}
+	return t
+}
+
+// put replaces the row with the same cq_id, or appends it. Rows without a cq_id are always appended.
+func (t *table) put(row Row) {
+	id, ok := rowID(row)
+	if !ok {
+		t.rows = append(t.rows, row)
+		return
+	}
+	if i, ok := t.byID[id]; ok {
+		t.rows[i] = row
+		return
+	}
+	t.byID[id] = len(t.rows)
+	t.rows = append(t.rows, row)
+}
+
+// remove removes the rows for which f returns true, keeping the order of the others
+func (t *table) remove(f func(Row) bool) {
+	kept := t.rows[:0]
+	for _, r := range t.rows {
+		if !f(r) {
+			kept = append(kept, r)
+		}
+	}
+	// the removed rows are cleared, so they can be collected
+	for i := len(kept); i < len(t.rows); i++ {
+		t.rows[i] = nil
+	}
+	t.rows = kept
+	t.byID = make(map[interface{}]int, len(kept))
+	for i, r := range kept {
+		if id, ok := rowID(r); ok {
+			t.byID[id] = i
+		}
+	}
+}
+
+// rowID returns the cq_id of the row, if it has one that can be indexed
+func rowID(r Row) (interface{}, bool) {
+	id := r["cq_id"]
+	if id == nil || !reflect.TypeOf(id).Comparable() {
+		return nil, false
+	}
+	return id, true
+}
+
+func matchFilters(r Row, kvFilters []interface{}) bool {
+	for i := 0; i < len(kvFilters); i += 2 {
+		if !equalValues(r[cast.ToString(kvFilters[i])], kvFilters[i+1]) {
+			return false
+		}
+	}
+	return true
+}
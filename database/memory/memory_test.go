@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+type testClient struct{}
+
+func (testClient) Logger() hclog.Logger {
+	return hclog.NewNullLogger()
+}
+
+type testItem struct {
+	Id     int
+	Region string
+	Name   *string
+}
+
+var testTable = &schema.Table{
+	Name: "test_items",
+	Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		name := "first"
+		res <- []testItem{{Id: 1, Region: "us-east-1", Name: &name}, {Id: 2, Region: "us-east-1"}, {Id: 3, Region: "eu-west-1"}}
+		return nil
+	},
+	Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+	Columns: []schema.Column{
+		{Name: "id", Type: schema.TypeBigInt},
+		{Name: "region", Type: schema.TypeString},
+		{Name: "name", Type: schema.TypeString},
+	},
+}
+
+func fetch(t *testing.T, db *Storage, metadata map[string]interface{}) {
+	exec := execution.NewTableExecutor("test", db, hclog.NewNullLogger(), testTable, metadata, nil, semaphore.NewWeighted(10), time.Minute)
+	count, diags := exec.Resolve(context.Background(), testClient{})
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.EqualValues(t, 3, count)
+}
+
+func TestStorage(t *testing.T) {
+	db := New()
+	fetch(t, db, nil)
+
+	assert.Equal(t, []string{"test_items"}, db.Tables())
+	assert.Equal(t, 3, db.Table("test_items").Count())
+	assert.Equal(t, 2, db.Table("test_items").Where("region", "us-east-1").Count())
+	assert.Equal(t, 1, db.Table("test_items").Where("id", 3).Count())
+	assert.Equal(t, 1, db.Table("test_items").Where("region", "us-east-1").WhereNotNull("name").Count())
+	assert.Equal(t, []interface{}{"first"}, db.Table("test_items").Where("id", 1).Values("name"))
+	assert.Equal(t, map[string]int{"us-east-1": 2, "eu-west-1": 1}, db.Table("test_items").CountBy("region"))
+	assert.Equal(t, []string{"eu-west-1", "us-east-1"}, db.Table("test_items").Distinct("region"))
+	assert.Equal(t, 0, db.Table("missing").Count())
+
+	// fetching again replaces rows by their cq_id
+	fetch(t, db, nil)
+	assert.Equal(t, 3, db.Table("test_items").Count())
+}
+
+func TestStorage_RemoveStaleData(t *testing.T) {
+	db := New()
+	fetch(t, db, map[string]interface{}{schema.FetchIdMetaKey: "first"})
+	require.NoError(t, db.RemoveStaleData(context.Background(), testTable, execution.StaleFilter{FetchId: "first"}, nil))
+	assert.Equal(t, 3, db.Table("test_items").Count())
+
+	require.NoError(t, db.RemoveStaleData(context.Background(), testTable, execution.StaleFilter{FetchId: "second"}, []interface{}{"region", "eu-west-1"}))
+	assert.Equal(t, []string{"us-east-1"}, db.Table("test_items").Distinct("region"))
+
+	require.NoError(t, db.RemoveStaleData(context.Background(), testTable, execution.StaleFilter{LastUpdateBefore: time.Now().Add(time.Minute)}, nil))
+	assert.Equal(t, 0, db.Table("test_items").Count())
+}
+
+func TestStorage_RemoveStaleDataSoftDelete(t *testing.T) {
+	table := *testTable
+	table.Options.SoftDelete = true
+	db := New()
+	exec := execution.NewTableExecutor("test", db, hclog.NewNullLogger(), &table, map[string]interface{}{schema.FetchIdMetaKey: "first"}, nil, semaphore.NewWeighted(10), time.Minute)
+	_, diags := exec.Resolve(context.Background(), testClient{})
+	require.False(t, diags.HasErrors(), diags.Error())
+	assert.Equal(t, 0, db.Table("test_items").WhereNotNull(schema.DeletedAtColumnName).Count())
+
+	require.NoError(t, db.RemoveStaleData(context.Background(), &table, execution.StaleFilter{FetchId: "second"}, []interface{}{"region", "eu-west-1"}))
+	assert.Equal(t, 3, db.Table("test_items").Count())
+	deleted := db.Table("test_items").WhereNotNull(schema.DeletedAtColumnName)
+	assert.Equal(t, []interface{}{3}, deleted.Values("id"))
+	deletedAt := deleted.Values(schema.DeletedAtColumnName)
+
+	// marked resources keep the time they were found deleted
+	require.NoError(t, db.RemoveStaleData(context.Background(), &table, execution.StaleFilter{FetchId: "second"}, nil))
+	assert.Equal(t, 3, db.Table("test_items").WhereNotNull(schema.DeletedAtColumnName).Count())
+	assert.Equal(t, deletedAt, db.Table("test_items").Where("id", 3).Values(schema.DeletedAtColumnName))
+}
+
+func TestStorage_Delete(t *testing.T) {
+	db := New()
+	fetch(t, db, nil)
+	require.NoError(t, db.Delete(context.Background(), testTable, []interface{}{"id", 2}))
+	assert.ElementsMatch(t, []interface{}{1, 3}, db.Table("test_items").Values("id"))
+	assert.Error(t, db.Delete(context.Background(), testTable, []interface{}{"id"}))
+
+	// rows are still replaced by their cq_id once others are deleted
+	fetch(t, db, nil)
+	assert.ElementsMatch(t, []interface{}{1, 2, 3}, db.Table("test_items").Values("id"))
+}
+
+func TestStorage_TableCopiesRows(t *testing.T) {
+	db := New()
+	fetch(t, db, nil)
+	rows := db.Table("test_items").Where("id", 1).Rows()
+	require.Len(t, rows, 1)
+	rows[0]["region"] = "eu-west-1"
+	delete(rows[0], "name")
+	assert.Equal(t, []interface{}{"us-east-1"}, db.Table("test_items").Where("id", 1).Values("region"))
+	assert.Equal(t, []interface{}{"first"}, db.Table("test_items").Where("id", 1).Values("name"))
+}
+
+func TestStorage_WithDialect(t *testing.T) {
+	db := New()
+	tenant, ok := db.WithDialect(schema.NewTenantDialect(db.Dialect())).(*Storage)
+	require.True(t, ok)
+	assert.False(t, schema.IsTenantDialect(db.Dialect()))
+	assert.True(t, schema.IsTenantDialect(tenant.Dialect()))
+
+	// rows stored by the copy are stored in the storage
+	fetch(t, db, nil)
+	fetch(t, tenant, map[string]interface{}{schema.TenantIdMetaKey: "first"})
+	assert.Equal(t, 6, db.Table("test_items").Count())
+	assert.Equal(t, 3, db.Table("test_items").Where(schema.TenantIdColumnName, "first").Count())
+}
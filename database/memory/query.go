@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Query filters and aggregates the rows of a table. Filters narrow the query in place and return it for chaining.
+type Query struct {
+	rows []Row
+}
+
+// Where keeps the rows in which column equals value. Pointers are dereferenced and values of different types are
+// compared by their string form, so Where("id", 1) matches an int64 column.
+func (q *Query) Where(column string, value interface{}) *Query {
+	return q.Filter(func(r Row) bool {
+		return equalValues(r[column], value)
+	})
+}
+
+// WhereNotNull keeps the rows in which column is set
+func (q *Query) WhereNotNull(column string) *Query {
+	return q.Filter(func(r Row) bool {
+		return !isNil(r[column])
+	})
+}
+
+// Filter keeps the rows for which f returns true
+func (q *Query) Filter(f func(Row) bool) *Query {
+	kept := make([]Row, 0, len(q.rows))
+	for _, r := range q.rows {
+		if f(r) {
+			kept = append(kept, r)
+		}
+	}
+	q.rows = kept
+	return q
+}
+
+// Rows returns the rows matching the query
+func (q *Query) Rows() []Row {
+	return q.rows
+}
+
+// Count returns the number of rows matching the query
+func (q *Query) Count() int {
+	return len(q.rows)
+}
+
+// Values returns the values of column in the rows matching the query
+func (q *Query) Values(column string) []interface{} {
+	ret := make([]interface{}, len(q.rows))
+	for i, r := range q.rows {
+		ret[i] = deref(r[column])
+	}
+	return ret
+}
+
+// CountBy returns the number of rows matching the query per value of column, by the value's string form
+func (q *Query) CountBy(column string) map[string]int {
+	ret := make(map[string]int)
+	for _, r := range q.rows {
+		ret[fmt.Sprint(deref(r[column]))]++
+	}
+	return ret
+}
+
+// Distinct returns the distinct values of column, by their string form, sorted
+func (q *Query) Distinct(column string) []string {
+	counts := q.CountBy(column)
+	ret := make([]string, 0, len(counts))
+	for v := range counts {
+		ret = append(ret, v)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func equalValues(a, b interface{}) bool {
+	a, b = deref(a), deref(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	return reflect.TypeOf(a) != reflect.TypeOf(b) && fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func isNil(v interface{}) bool {
+	return deref(v) == nil
+}
+
+// deref returns the value pointed by v, or nil for nil values and pointers
+func deref(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+	}
+	return rv.Interface()
+}
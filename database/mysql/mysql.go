@@ -0,0 +1,248 @@
+// Package mysql implements an execution.Storage writing resources to MySQL or MariaDB, using schema.MySQLDialect.
+//
+// The package uses database/sql and doesn't depend on a driver, the host process registers one under DriverName, i.e
+// by importing github.com/go-sql-driver/mysql:
+//
+//	import _ "github.com/go-sql-driver/mysql"
+//
+//	db, err := mysql.New(ctx, logger, "user:pass@tcp(localhost:3306)/cloudquery?parseTime=true")
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+// DriverName is the database/sql driver used to connect
+const DriverName = "mysql"
+
+// ErrNotSupported is returned by the postgres specific methods of Database
+var ErrNotSupported = errors.New("not supported by mysql storage")
+
+// Database writes resources to a MySQL database. Queries returning rows and copies aren't supported, since
+// execution.Storage exposes them as pgx types.
+type Database struct {
+	db  *sql.DB
+	log hclog.Logger
+	sd  schema.Dialect
+}
+
+var _ execution.Storage = (*Database)(nil)
+var _ execution.StaleCounter = (*Database)(nil)
+
+// New connects to the MySQL database of the given DSN, in the format of the registered driver
+func New(ctx context.Context, logger hclog.Logger, dsn string) (*Database, error) {
+	db, err := sql.Open(DriverName, strings.TrimPrefix(dsn, "mysql://"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mysql database, make sure a driver is registered as %q: %w", DriverName, err)
+	}
+	return &Database{db: db, log: logger, sd: schema.MySQLDialect{}}, nil
+}
+
+// Insert inserts all resources to given table, table and resources are assumed from same table.
+func (d *Database) Insert(ctx context.Context, t *schema.Table, resources schema.Resources, shouldCascade bool) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	for _, res := range resources {
+		if res.TableName() != t.Name {
+			return fmt.Errorf("resource table expected %s got %s", t.Name, res.TableName())
+		}
+	}
+	upsert := t.InsertMode == schema.InsertModeUpsert
+	if err := d.insert(ctx, resources, shouldCascade && !upsert, upsert); err != nil {
+		return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("failed to insert to table %q", t.Name))
+	}
+	return nil
+}
+
+// CopyFrom inserts all resources, MySQL has no copy protocol
+func (d *Database) CopyFrom(ctx context.Context, resources schema.Resources, shouldCascade bool) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	return d.insert(ctx, resources, shouldCascade, false)
+}
+
+func (d *Database) insert(ctx context.Context, resources schema.Resources, shouldCascade, upsert bool) error {
+	q, args, err := d.insertQuery(resources, upsert)
+	if err != nil {
+		return err
+	}
+	tx, err := d.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return err
+	}
+	if shouldCascade {
+		if err := d.deleteByCQId(ctx, tx, resources); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		_ = tx.Rollback()
+		d.log.Debug("insert failed", "table", resources.TableName(), "error", err)
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertQuery returns the statement inserting all resources in a single query, and its arguments encoded by the dialect
+func (d *Database) insertQuery(resources schema.Resources, upsert bool) (string, []interface{}, error) {
+	// It is safe to assume that all resources have the same columns
+	cols := resources.ColumnNames()
+	args := make([]interface{}, 0, len(cols)*len(resources))
+	for _, res := range resources {
+		// use GetResourceValues instead of Resource.Values since values require encoding for the driver
+		values, err := d.sd.GetResourceValues(res)
+		if err != nil {
+			return "", nil, fmt.Errorf("table %s insert failed %w", res.TableName(), err)
+		}
+		if len(values) != len(cols) {
+			return "", nil, fmt.Errorf("expected %d values got %d", len(cols), len(values))
+		}
+		args = append(args, values...)
+	}
+	row := "(" + strings.TrimSuffix(strings.Repeat("?,", len(cols)), ",") + ")"
+	q := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", d.sd.QuoteIdentifier(resources.TableName()),
+		strings.Join(schema.QuoteIdentifiers(d.sd, cols), ","), strings.TrimSuffix(strings.Repeat(row+",", len(resources)), ","))
+	if upsert {
+		updates := make([]string, len(cols))
+		for i, c := range cols {
+			updates[i] = fmt.Sprintf("%[1]s = VALUES(%[1]s)", d.sd.QuoteIdentifier(c))
+		}
+		q += " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ",")
+	}
+	return q, args, nil
+}
+
+func (d *Database) deleteByCQId(ctx context.Context, tx *sql.Tx, resources schema.Resources) error {
+	ids := resources.GetIds()
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id.String()
+	}
+	q := fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", d.sd.QuoteIdentifier(resources.TableName()), d.sd.QuoteIdentifier("cq_id"),
+		strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","))
+	_, err := tx.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (d *Database) Delete(ctx context.Context, t *schema.Table, kvFilters []interface{}) error {
+	where, args, err := d.kvFilters(kvFilters)
+	if err != nil {
+		return err
+	}
+	q := "DELETE FROM " + d.sd.QuoteIdentifier(t.Name)
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	_, err = d.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+func (d *Database) RemoveStaleData(ctx context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) error {
+	where, args, err := d.staleConditions(t, filter, kvFilters)
+	if err != nil {
+		return err
+	}
+	q := "DELETE FROM " + d.sd.QuoteIdentifier(t.Name) + " WHERE " + where
+	if t.Options.SoftDelete {
+		q = "UPDATE " + d.sd.QuoteIdentifier(t.Name) + " SET " + d.sd.QuoteIdentifier(schema.DeletedAtColumnName) + " = ? WHERE " + where
+		args = append([]interface{}{time.Now().UTC().Format("2006-01-02 15:04:05")}, args...)
+	}
+	_, err = d.db.ExecContext(ctx, q, args...)
+	return err
+}
+
+// CountStaleData counts the rows RemoveStaleData removes, or marks deleted
+func (d *Database) CountStaleData(ctx context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) (uint64, error) {
+	where, args, err := d.staleConditions(t, filter, kvFilters)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	if err := d.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+d.sd.QuoteIdentifier(t.Name)+" WHERE "+where, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// staleConditions returns the condition of the stale rows of the table and its arguments. Stale rows of soft delete
+// tables marked before keep the time they were found deleted, they aren't stale again.
+func (d *Database) staleConditions(t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) (string, []interface{}, error) {
+	where, args, err := d.kvFilters(kvFilters)
+	if err != nil {
+		return "", nil, err
+	}
+	meta := d.sd.QuoteIdentifier("cq_meta")
+	if filter.FetchId != "" {
+		where = append([]string{"NOT (JSON_UNQUOTE(JSON_EXTRACT(" + meta + ", '$.fetch_id')) <=> ?)"}, where...)
+		args = append([]interface{}{filter.FetchId}, args...)
+	} else {
+		// last_updated is a RFC3339 UTC timestamp, MySQL doesn't parse its time zone suffix
+		where = append([]string{"STR_TO_DATE(LEFT(JSON_UNQUOTE(JSON_EXTRACT(" + meta + ", '$.last_updated')), 19), '%Y-%m-%dT%H:%i:%s') < ?"}, where...)
+		args = append([]interface{}{filter.LastUpdateBefore.UTC().Format("2006-01-02 15:04:05")}, args...)
+	}
+	if t.Options.SoftDelete {
+		where = append(where, d.sd.QuoteIdentifier(schema.DeletedAtColumnName)+" IS NULL")
+	}
+	return strings.Join(where, " AND "), args, nil
+}
+
+func (d *Database) kvFilters(kvFilters []interface{}) ([]string, []interface{}, error) {
+	if len(kvFilters)%2 != 0 {
+		return nil, nil, fmt.Errorf("number of args to delete should be even. Got %d", len(kvFilters))
+	}
+	where := make([]string, 0, len(kvFilters)/2)
+	args := make([]interface{}, 0, len(kvFilters)/2)
+	for i := 0; i < len(kvFilters); i += 2 {
+		where = append(where, d.sd.QuoteIdentifier(cast.ToString(kvFilters[i]))+" = ?")
+		args = append(args, kvFilters[i+1])
+	}
+	return where, args, nil
+}
+
+// Exec allows executions of mysql queries with given args returning error of execution
+func (d *Database) Exec(ctx context.Context, query string, args ...interface{}) error {
+	_, err := d.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (*Database) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Database) Begin(context.Context) (execution.TXQueryExecer, error) {
+	return nil, ErrNotSupported
+}
+
+func (*Database) RawCopyTo(context.Context, io.Writer, string) error {
+	return ErrNotSupported
+}
+
+func (*Database) RawCopyFrom(context.Context, io.Reader, string) error {
+	return ErrNotSupported
+}
+
+func (d *Database) Dialect() schema.Dialect {
+	return d.sd
+}
+
+func (d *Database) Close() {
+	if err := d.db.Close(); err != nil {
+		d.log.Warn("failed to close mysql database", "error", err)
+	}
+}
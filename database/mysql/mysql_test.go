@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTable = &schema.Table{
+	Name: "mysql_items",
+	Columns: []schema.Column{
+		{Name: "name", Type: schema.TypeString},
+		{Name: "tags", Type: schema.TypeHStore},
+		{Name: "names", Type: schema.TypeStringArray},
+	},
+}
+
+func newTestDatabase() *Database {
+	return &Database{sd: schema.MySQLDialect{}}
+}
+
+func TestDatabase_InsertQuery(t *testing.T) {
+	d := newTestDatabase()
+	resources := make(schema.Resources, 2)
+	for i, name := range []string{"first", "second"} {
+		r := schema.NewResourceData(d.sd, testTable, nil, nil, nil, time.Now())
+		require.NoError(t, r.Set("name", name))
+		require.NoError(t, r.Set("tags", map[string]string{"env": name}))
+		require.NoError(t, r.Set("names", []string{"a", name}))
+		resources[i] = r
+	}
+
+	q, args, err := d.insertQuery(resources, false)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `mysql_items` (`cq_id`,`cq_meta`,`name`,`tags`,`names`) VALUES (?,?,?,?,?),(?,?,?,?,?)", q)
+	require.Len(t, args, 10)
+	// the values are encoded by the dialect, so the driver gets JSON text instead of maps, slices and hstores
+	assert.Equal(t, []interface{}{"first", `{"env":"first"}`, `["a","first"]`}, args[2:5])
+	assert.Equal(t, []interface{}{"second", `{"env":"second"}`, `["a","second"]`}, args[7:10])
+
+	q, _, err = d.insertQuery(resources[:1], true)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `mysql_items` (`cq_id`,`cq_meta`,`name`,`tags`,`names`) VALUES (?,?,?,?,?)"+
+		" ON DUPLICATE KEY UPDATE `cq_id` = VALUES(`cq_id`),`cq_meta` = VALUES(`cq_meta`),`name` = VALUES(`name`),"+
+		"`tags` = VALUES(`tags`),`names` = VALUES(`names`)", q)
+}
+
+func TestDatabase_InsertQueryInvalidValue(t *testing.T) {
+	d := newTestDatabase()
+	r := schema.NewResourceData(d.sd, testTable, nil, nil, nil, time.Now())
+	require.NoError(t, r.Set("name", 1))
+	_, _, err := d.insertQuery(schema.Resources{r}, false)
+	assert.ErrorContains(t, err, "table mysql_items insert failed")
+}
+
+func TestDatabase_StaleConditions(t *testing.T) {
+	d := newTestDatabase()
+	updated := time.Date(2022, 5, 1, 10, 0, 0, 0, time.FixedZone("test", 3600))
+	softDelete := &schema.Table{Name: "soft_items", Options: schema.TableCreationOptions{SoftDelete: true}}
+	tests := []struct {
+		name      string
+		table     *schema.Table
+		filter    execution.StaleFilter
+		kvFilters []interface{}
+		where     string
+		args      []interface{}
+	}{
+		{
+			name:  "last updated",
+			table: testTable,
+			filter: execution.StaleFilter{
+				LastUpdateBefore: updated,
+			},
+			where: "STR_TO_DATE(LEFT(JSON_UNQUOTE(JSON_EXTRACT(`cq_meta`, '$.last_updated')), 19), '%Y-%m-%dT%H:%i:%s') < ?",
+			args:  []interface{}{"2022-05-01 09:00:00"},
+		},
+		{
+			name:      "fetch id",
+			table:     testTable,
+			filter:    execution.StaleFilter{FetchId: "f1"},
+			kvFilters: []interface{}{"account_id", "123"},
+			where:     "NOT (JSON_UNQUOTE(JSON_EXTRACT(`cq_meta`, '$.fetch_id')) <=> ?) AND `account_id` = ?",
+			args:      []interface{}{"f1", "123"},
+		},
+		{
+			name:   "soft delete",
+			table:  softDelete,
+			filter: execution.StaleFilter{FetchId: "f1"},
+			where:  "NOT (JSON_UNQUOTE(JSON_EXTRACT(`cq_meta`, '$.fetch_id')) <=> ?) AND `" + schema.DeletedAtColumnName + "` IS NULL",
+			args:   []interface{}{"f1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			where, args, err := d.staleConditions(tc.table, tc.filter, tc.kvFilters)
+			require.NoError(t, err)
+			assert.Equal(t, tc.where, where)
+			assert.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestDatabase_KVFilters(t *testing.T) {
+	d := newTestDatabase()
+	where, args, err := d.kvFilters([]interface{}{"account_id", "123", "re`gion", "us-east-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"`account_id` = ?", "`re``gion` = ?"}, where)
+	assert.Equal(t, []interface{}{"123", "us-east-1"}, args)
+
+	_, _, err = d.kvFilters([]interface{}{"account_id"})
+	assert.EqualError(t, err, "number of args to delete should be even. Got 1")
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strconv"
+	"sync"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -22,16 +22,22 @@ import (
 )
 
 type PgDatabase struct {
-	pool *pgxpool.Pool
-	log  hclog.Logger
-	sd   schema.Dialect
+	pool    *pgxpool.Pool
+	log     hclog.Logger
+	sd      schema.Dialect
+	inserts *insertStatements
+	// staged keeps the names of the staging tables already created, see schema.Table.StagedInsert
+	staged *sync.Map
+	// borrowed is true if the pool is owned by the caller of NewPgDatabaseFromPool, and isn't closed by Close
+	borrowed bool
 }
 
 type PgTx struct {
 	pgx.Tx
 }
 
-var _ execution.Storage = (*PgDatabase)(nil)
+var _ execution.DialectStorage = (*PgDatabase)(nil)
+var _ execution.StaleCounter = (*PgDatabase)(nil)
 
 func NewPgDatabase(ctx context.Context, logger hclog.Logger, dsn string, sd schema.Dialect) (*PgDatabase, error) {
 	pool, err := Connect(ctx, dsn)
@@ -39,12 +45,28 @@ func NewPgDatabase(ctx context.Context, logger hclog.Logger, dsn string, sd sche
 		return nil, err
 	}
 	return &PgDatabase{
-		pool: pool,
-		log:  logger,
-		sd:   sd,
+		pool:    pool,
+		log:     logger,
+		sd:      sd,
+		inserts: newInsertStatements(),
+		staged:  &sync.Map{},
 	}, nil
 }
 
+// NewPgDatabaseFromPool creates a PgDatabase using an existing pool, i.e of a host process embedding the provider. The
+// pool is owned by the caller and isn't closed by Close. Pools not created with Connect should register the uuid type
+// the same way, so UUID values are encoded as expected.
+func NewPgDatabaseFromPool(logger hclog.Logger, pool *pgxpool.Pool, sd schema.Dialect) *PgDatabase {
+	return &PgDatabase{
+		pool:     pool,
+		log:      logger,
+		sd:       sd,
+		inserts:  newInsertStatements(),
+		staged:   &sync.Map{},
+		borrowed: true,
+	}
+}
+
 // Insert inserts all resources to given table, table and resources are assumed from same table.
 func (p PgDatabase) Insert(ctx context.Context, t *schema.Table, resources schema.Resources, shouldCascade bool) error {
 	if len(resources) == 0 {
@@ -52,9 +74,8 @@ func (p PgDatabase) Insert(ctx context.Context, t *schema.Table, resources schem
 	}
 
 	// It is safe to assume that all resources have the same columns
-	cols := quoteColumns(resources.ColumnNames())
-	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	sqlStmt := psql.Insert(t.Name).Columns(cols...)
+	cols := resources.ColumnNames()
+	args := make([]interface{}, 0, len(cols)*len(resources))
 	for _, res := range resources {
 		if res.TableName() != t.Name {
 			return fmt.Errorf("resource table expected %s got %s", t.Name, res.TableName())
@@ -63,15 +84,20 @@ func (p PgDatabase) Insert(ctx context.Context, t *schema.Table, resources schem
 		if err != nil {
 			return fmt.Errorf("table %s insert failed %w", t.Name, err)
 		}
-		sqlStmt = sqlStmt.Values(values...)
+		if len(values) != len(cols) {
+			return diag.NewBaseError(fmt.Errorf("expected %d values got %d", len(cols), len(values)), diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("bad insert SQL statement created"))
+		}
+		args = append(args, values...)
 	}
-
-	s, args, err := sqlStmt.ToSql()
-	if err != nil {
-		return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("bad insert SQL statement created"), diag.WithDetails("SQL statement %q is invalid", s))
+	var conflict []string
+	if t.InsertMode == schema.InsertModeUpsert {
+		// existing resources are updated in place, so they aren't deleted with their relations
+		conflict = p.sd.PrimaryKeys(t)
+		shouldCascade = false
 	}
+	s := p.inserts.get(p.sd, t.Name, cols, len(resources), conflict)
 
-	err = p.pool.BeginTxFunc(ctx, pgx.TxOptions{
+	err := p.pool.BeginTxFunc(ctx, pgx.TxOptions{
 		IsoLevel:       pgx.ReadCommitted,
 		AccessMode:     pgx.ReadWrite,
 		DeferrableMode: pgx.Deferrable,
@@ -97,6 +123,9 @@ func (p PgDatabase) Insert(ctx context.Context, t *schema.Table, resources schem
 		if pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
 			p.log.Debug("insert integrity violation error", "constraint", pgErr.ConstraintName, "errMsg", pgErr.Message)
 		}
+		if uErr := userColumnError(t.Name, cols, pgErr); uErr != nil {
+			return uErr
+		}
 		return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("failed to insert to table %q", t.Name), diag.WithDetails("%s", pgErr.Message))
 	}
 	return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name))
@@ -131,9 +160,30 @@ func (p PgDatabase) CopyFrom(ctx context.Context, resources schema.Resources, sh
 		}
 		return nil
 	})
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		if uErr := userColumnError(resources.TableName(), resources.ColumnNames(), pgErr); uErr != nil {
+			return uErr
+		}
+	}
 	return err
 }
 
+// userColumnError returns a diagnostic of writes to table that failed on a NOT NULL column which isn't one of the
+// written columns, i.e a column users added to the table, which is left NULL by the SDK. Other errors return nil.
+func userColumnError(table string, cols []string, pgErr *pgconn.PgError) error {
+	if pgErr.Code != pgerrcode.NotNullViolation || pgErr.ColumnName == "" {
+		return nil
+	}
+	for _, c := range cols {
+		if c == pgErr.ColumnName {
+			return nil
+		}
+	}
+	return diag.NewBaseError(pgErr, diag.USER, diag.WithResourceName(table),
+		diag.WithSummary("column %q of table %q isn't defined by the provider and doesn't allow NULL values", pgErr.ColumnName, table),
+		diag.WithDetails("columns added to provider tables are left NULL, make the column nullable or give it a default value"))
+}
+
 // Exec allows executions of postgres queries with given args returning error of execution
 func (p PgDatabase) Exec(ctx context.Context, query string, args ...interface{}) error {
 	_, err := p.pool.Exec(ctx, query, args...)
@@ -158,9 +208,9 @@ func (p PgDatabase) Delete(ctx context.Context, t *schema.Table, kvFilters []int
 		return fmt.Errorf("number of args to delete should be even. Got %d", nc)
 	}
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
-	ds := psql.Delete(t.Name)
+	ds := psql.Delete(p.sd.QuoteIdentifier(t.Name))
 	for i := 0; i < nc; i += 2 {
-		ds = ds.Where(sq.Eq{kvFilters[i].(string): kvFilters[i+1]})
+		ds = ds.Where(sq.Eq{p.sd.QuoteIdentifier(cast.ToString(kvFilters[i])): kvFilters[i+1]})
 	}
 	sql, args, err := ds.ToSql()
 	if err != nil {
@@ -171,15 +221,23 @@ func (p PgDatabase) Delete(ctx context.Context, t *schema.Table, kvFilters []int
 	return err
 }
 
-func (p PgDatabase) RemoveStaleData(ctx context.Context, t *schema.Table, executionStart time.Time, kvFilters []interface{}) error {
-	q := goqu.Delete(t.Name).WithDialect("postgres").Where(goqu.L(`extract(epoch from (cq_meta->>'last_updated')::timestamp)`).Lt(executionStart.Unix()))
-	if len(kvFilters)%2 != 0 {
-		return fmt.Errorf("expected even number of k,v delete filters received %s", kvFilters)
+// RemoveStaleData deletes the stale rows of the table, or marks them deleted if the table has
+// schema.TableCreationOptions.SoftDelete
+func (p PgDatabase) RemoveStaleData(ctx context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) error {
+	where, err := staleConditions(t, filter, kvFilters)
+	if err != nil {
+		return err
 	}
-	for i := 0; i < len(kvFilters); i += 2 {
-		q = q.Where(goqu.Ex{cast.ToString(kvFilters[i]): goqu.Op{"eq": kvFilters[i+1]}})
+	var (
+		sql  string
+		args []interface{}
+	)
+	if t.Options.SoftDelete {
+		sql, args, err = goqu.Update(t.Name).WithDialect("postgres").Set(goqu.Record{schema.DeletedAtColumnName: time.Now().UTC()}).
+			Where(where...).Prepared(true).ToSQL()
+	} else {
+		sql, args, err = goqu.Delete(t.Name).WithDialect("postgres").Where(where...).Prepared(true).ToSQL()
 	}
-	sql, args, err := q.Prepared(true).ToSQL()
 	if err != nil {
 		return fmt.Errorf("failed building query: %w", err)
 	}
@@ -187,7 +245,48 @@ func (p PgDatabase) RemoveStaleData(ctx context.Context, t *schema.Table, execut
 	return err
 }
 
+// CountStaleData counts the rows RemoveStaleData removes, or marks deleted
+func (p PgDatabase) CountStaleData(ctx context.Context, t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) (uint64, error) {
+	where, err := staleConditions(t, filter, kvFilters)
+	if err != nil {
+		return 0, err
+	}
+	sql, args, err := goqu.From(t.Name).WithDialect("postgres").Select(goqu.COUNT(goqu.Star())).Where(where...).Prepared(true).ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("failed building query: %w", err)
+	}
+	var count uint64
+	if err := p.pool.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// staleConditions returns the conditions of the stale rows of the table, see RemoveStaleData. Stale rows of soft delete
+// tables marked before keep the time they were found deleted, they aren't stale again.
+func staleConditions(t *schema.Table, filter execution.StaleFilter, kvFilters []interface{}) ([]goqu.Expression, error) {
+	if len(kvFilters)%2 != 0 {
+		return nil, fmt.Errorf("expected even number of k,v delete filters received %s", kvFilters)
+	}
+	where := make([]goqu.Expression, 0, len(kvFilters)/2+2)
+	if filter.FetchId != "" {
+		where = append(where, goqu.L(`cq_meta->>'fetch_id' IS DISTINCT FROM ?`, filter.FetchId))
+	} else {
+		where = append(where, goqu.L(`extract(epoch from (cq_meta->>'last_updated')::timestamp)`).Lt(filter.LastUpdateBefore.Unix()))
+	}
+	for i := 0; i < len(kvFilters); i += 2 {
+		where = append(where, goqu.Ex{cast.ToString(kvFilters[i]): goqu.Op{"eq": kvFilters[i+1]}})
+	}
+	if t.Options.SoftDelete {
+		where = append(where, goqu.C(schema.DeletedAtColumnName).IsNull())
+	}
+	return where, nil
+}
+
 func (p PgDatabase) Close() {
+	if p.borrowed {
+		return
+	}
 	p.pool.Close()
 }
 
@@ -214,6 +313,14 @@ func (p PgDatabase) Dialect() schema.Dialect {
 	return p.sd
 }
 
+// WithDialect returns a PgDatabase sharing the connection pool, writing resources with dialect d. Closing either
+// closes the pool.
+func (p PgDatabase) WithDialect(d schema.Dialect) execution.Storage {
+	p.sd = d
+	p.inserts = newInsertStatements()
+	return &p
+}
+
 func (p PgDatabase) Begin(ctx context.Context) (execution.TXQueryExecer, error) {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
@@ -235,14 +342,6 @@ func (p PgTx) Begin(ctx context.Context) (execution.TXQueryExecer, error) {
 	return &PgTx{v}, nil
 }
 
-func quoteColumns(columns []string) []string {
-	ret := make([]string, len(columns))
-	for i, v := range columns {
-		ret[i] = strconv.Quote(v)
-	}
-	return ret
-}
-
 func deleteResourceByCQId(ctx context.Context, tx pgx.Tx, resources schema.Resources) error {
 	q := goqu.Dialect("postgres").Delete(resources.TableName()).Where(goqu.Ex{"cq_id": resources.GetIds()})
 	sql, args, err := q.Prepared(true).ToSQL()
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/cast"
+)
+
+var _ execution.StagingStorage = (*PgDatabase)(nil)
+
+// Stage copies resources to the staging table of t, creating the staging tables of t and its relations if needed
+func (p PgDatabase) Stage(ctx context.Context, t *schema.Table, resources schema.Resources) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	if err := p.createStagingTables(ctx, t); err != nil {
+		return err
+	}
+	copied, err := p.pool.CopyFrom(
+		ctx, pgx.Identifier{schema.StagingTableName(t.Name)}, resources.ColumnNames(),
+		pgx.CopyFromSlice(len(resources), func(i int) ([]interface{}, error) {
+			return p.sd.GetResourceValues(resources[i])
+		}))
+	if err != nil {
+		return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("failed to stage resources of table %q", t.Name))
+	}
+	if copied != int64(len(resources)) {
+		return fmt.Errorf("not all resources staged %d != %d to %s", copied, len(resources), t.Name)
+	}
+	return nil
+}
+
+// SwapStaged replaces the rows of t selected by kvFilters with its staged rows, and the rows of its relations with the
+// staged relations of the staged rows, in a single transaction. The swapped rows are removed from the staging tables.
+func (p PgDatabase) SwapStaged(ctx context.Context, t *schema.Table, kvFilters []interface{}) error {
+	where, args, err := p.stagingFilter(kvFilters)
+	if err != nil {
+		return err
+	}
+	if err := p.createStagingTables(ctx, t); err != nil {
+		return err
+	}
+	return p.pool.BeginTxFunc(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	}, func(tx pgx.Tx) error {
+		// existing relations are removed by their foreign keys' ON DELETE CASCADE
+		if _, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s%s", p.sd.QuoteIdentifier(t.Name), where), args...); err != nil {
+			return err
+		}
+		if err := p.swapStagedTable(ctx, tx, t, where, args); err != nil {
+			return err
+		}
+		return p.discardStagedTable(ctx, tx, t, where, args)
+	})
+}
+
+// DiscardStaged removes the staged rows of t selected by kvFilters, and the staged relations of those rows
+func (p PgDatabase) DiscardStaged(ctx context.Context, t *schema.Table, kvFilters []interface{}) error {
+	where, args, err := p.stagingFilter(kvFilters)
+	if err != nil {
+		return err
+	}
+	if err := p.createStagingTables(ctx, t); err != nil {
+		return err
+	}
+	return p.pool.BeginTxFunc(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	}, func(tx pgx.Tx) error {
+		return p.discardStagedTable(ctx, tx, t, where, args)
+	})
+}
+
+// createStagingTables creates the staging tables of t and its relations, which have the columns, defaults and indexes
+// of their tables but no foreign keys, as staged relations are swapped after their parents
+func (p PgDatabase) createStagingTables(ctx context.Context, t *schema.Table) error {
+	name := schema.StagingTableName(t.Name)
+	if _, ok := p.staged.Load(name); !ok {
+		sql := fmt.Sprintf("CREATE UNLOGGED TABLE IF NOT EXISTS %s (LIKE %s INCLUDING ALL)", p.sd.QuoteIdentifier(name), p.sd.QuoteIdentifier(t.Name))
+		if _, err := p.pool.Exec(ctx, sql); err != nil {
+			return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("failed to create staging table of table %q", t.Name))
+		}
+		p.staged.Store(name, struct{}{})
+	}
+	for _, rel := range t.Relations {
+		if err := p.createStagingTables(ctx, rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swapStagedTable inserts the staged rows of t selected by where, and recursively the staged relations of those rows
+func (p PgDatabase) swapStagedTable(ctx context.Context, tx pgx.Tx, t *schema.Table, where string, args []interface{}) error {
+	cols := p.sd.Columns(t).Names()
+	quoted := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = p.sd.QuoteIdentifier(c)
+	}
+	colList := strings.Join(quoted, ", ")
+	sql := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s%s", p.sd.QuoteIdentifier(t.Name), colList, colList, p.sd.QuoteIdentifier(schema.StagingTableName(t.Name)), where)
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(t.Name), diag.WithSummary("failed to swap staged rows of table %q", t.Name))
+	}
+	for _, rel := range t.Relations {
+		relWhere, err := p.relationFilter(t, rel, where)
+		if err != nil {
+			return err
+		}
+		if err := p.swapStagedTable(ctx, tx, rel, relWhere, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardStagedTable deletes the staged rows of t selected by where, after the staged relations of those rows
+func (p PgDatabase) discardStagedTable(ctx context.Context, tx pgx.Tx, t *schema.Table, where string, args []interface{}) error {
+	for _, rel := range t.Relations {
+		relWhere, err := p.relationFilter(t, rel, where)
+		if err != nil {
+			return err
+		}
+		if err := p.discardStagedTable(ctx, tx, rel, relWhere, args); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s%s", p.sd.QuoteIdentifier(schema.StagingTableName(t.Name)), where), args...)
+	return err
+}
+
+// relationFilter returns the WHERE clause selecting the staged rows of rel whose parents are the staged rows of parent
+// selected by where
+func (p PgDatabase) relationFilter(parent, rel *schema.Table, where string) (string, error) {
+	pc := rel.ParentIdColumn()
+	if pc == nil {
+		return "", fmt.Errorf("relation %s of table %s has no column resolved by schema.ParentIdResolver", rel.Name, parent.Name)
+	}
+	return fmt.Sprintf(" WHERE %s IN (SELECT %s FROM %s%s)", p.sd.QuoteIdentifier(pc.Name), p.sd.QuoteIdentifier("cq_id"),
+		p.sd.QuoteIdentifier(schema.StagingTableName(parent.Name)), where), nil
+}
+
+// stagingFilter returns the WHERE clause of the k,v filters and its arguments, or an empty clause without filters
+func (p PgDatabase) stagingFilter(kvFilters []interface{}) (string, []interface{}, error) {
+	if len(kvFilters)%2 != 0 {
+		return "", nil, fmt.Errorf("expected even number of k,v delete filters received %s", kvFilters)
+	}
+	if len(kvFilters) == 0 {
+		return "", nil, nil
+	}
+	conds := make([]string, 0, len(kvFilters)/2)
+	args := make([]interface{}, 0, len(kvFilters)/2)
+	for i := 0; i < len(kvFilters); i += 2 {
+		args = append(args, kvFilters[i+1])
+		conds = append(conds, p.sd.QuoteIdentifier(cast.ToString(kvFilters[i]))+" = $"+strconv.Itoa(len(args)))
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args, nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// maxCachedStatements bounds the number of cached insert statements, the cache is reset when it's reached
+const maxCachedStatements = 1024
+
+// insertStatements caches insert statements by table, columns and number of rows. pgx prepares each distinct statement
+// once per connection in its statement cache, so reusing the same SQL also reuses the prepared statement, which matters
+// for the per resource fallback inserts.
+type insertStatements struct {
+	mu    sync.RWMutex
+	cache map[string]string
+}
+
+func newInsertStatements() *insertStatements {
+	return &insertStatements{cache: make(map[string]string)}
+}
+
+// get returns the insert statement of rows into table with the given columns, with positional placeholders. If conflict
+// columns are given, the statement is an upsert updating the other columns of existing rows.
+func (s *insertStatements) get(d schema.Dialect, table string, columns []string, rows int, conflict []string) string {
+	key := table + "\x00" + strings.Join(columns, ",") + "\x00" + strconv.Itoa(rows) + "\x00" + strings.Join(conflict, ",")
+	s.mu.RLock()
+	sql, ok := s.cache[key]
+	s.mu.RUnlock()
+	if ok {
+		return sql
+	}
+	sql = buildInsert(d, table, columns, rows)
+	if len(conflict) > 0 {
+		sql += buildOnConflict(d, columns, conflict)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= maxCachedStatements {
+		s.cache = make(map[string]string)
+	}
+	s.cache[key] = sql
+	return sql
+}
+
+func buildInsert(d schema.Dialect, table string, columns []string, rows int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", d.QuoteIdentifier(table), strings.Join(schema.QuoteIdentifiers(d, columns), ","))
+	n := 1
+	for r := 0; r < rows; r++ {
+		if r > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		for c := range columns {
+			if c > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString("$" + strconv.Itoa(n))
+			n++
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// buildOnConflict returns the ON CONFLICT clause updating the columns that aren't conflict columns
+func buildOnConflict(d schema.Dialect, columns, conflict []string) string {
+	isConflict := make(map[string]bool, len(conflict))
+	for _, c := range conflict {
+		isConflict[c] = true
+	}
+	updates := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if isConflict[c] {
+			continue
+		}
+		updates = append(updates, d.QuoteIdentifier(c)+" = EXCLUDED."+d.QuoteIdentifier(c))
+	}
+	clause := " ON CONFLICT (" + strings.Join(schema.QuoteIdentifiers(d, conflict), ",") + ") DO "
+	if len(updates) == 0 {
+		return clause + "NOTHING"
+	}
+	return clause + "UPDATE SET " + strings.Join(updates, ",")
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/database/filestore"
+	"github.com/cloudquery/cq-provider-sdk/database/memory"
+	"github.com/cloudquery/cq-provider-sdk/database/mysql"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// PostgresStorage is the default storage type, connecting to postgres or timescaledb by the DSN, see New
+	PostgresStorage = "postgres"
+	// MemoryStorage keeps fetched resources in memory for the lifetime of the process. Storages opened with the same DSN
+	// share their rows, so resources of a fetch can be read by the following calls. Useful for local development and tests.
+	MemoryStorage = "memory"
+	// FileStorage writes fetched resources to files, the DSN is parsed by filestore.NewFromDSN
+	FileStorage = "file"
+	// MySQLStorage writes fetched resources to MySQL or MariaDB, the host must register a database/sql driver, see mysql.New
+	MySQLStorage = "mysql"
+)
+
+// StorageCreator creates a storage connected to dsn
+type StorageCreator func(ctx context.Context, logger hclog.Logger, dsn string) (execution.Storage, error)
+
+var (
+	storagesMu sync.RWMutex
+	storages   = map[string]StorageCreator{
+		PostgresStorage: func(ctx context.Context, logger hclog.Logger, dsn string) (execution.Storage, error) {
+			return New(ctx, logger, dsn)
+		},
+		MemoryStorage: func(_ context.Context, _ hclog.Logger, dsn string) (execution.Storage, error) {
+			return memoryStorage(dsn), nil
+		},
+		FileStorage: func(_ context.Context, _ hclog.Logger, dsn string) (execution.Storage, error) {
+			return filestore.NewFromDSN(dsn)
+		},
+		MySQLStorage: func(ctx context.Context, logger hclog.Logger, dsn string) (execution.Storage, error) {
+			return mysql.New(ctx, logger, dsn)
+		},
+	}
+)
+
+var (
+	memoryStoragesMu sync.Mutex
+	// memoryStorages are the storages of MemoryStorage by DSN
+	memoryStorages = make(map[string]*memory.Storage)
+)
+
+// memoryStorage returns the memory storage of the DSN, created by the first call
+func memoryStorage(dsn string) *memory.Storage {
+	memoryStoragesMu.Lock()
+	defer memoryStoragesMu.Unlock()
+	s, ok := memoryStorages[dsn]
+	if !ok {
+		s = memory.New()
+		memoryStorages[dsn] = s
+	}
+	return s
+}
+
+// RegisterStorage makes a storage type available to Open, so providers or the CLI can add destinations other than
+// postgres. Storage types are case-insensitive. RegisterStorage panics if the type is already registered.
+func RegisterStorage(storageType string, creator StorageCreator) {
+	storagesMu.Lock()
+	defer storagesMu.Unlock()
+	storageType = strings.ToLower(storageType)
+	if creator == nil {
+		panic("database: RegisterStorage creator is nil")
+	}
+	if _, ok := storages[storageType]; ok {
+		panic("database: RegisterStorage called twice for storage type " + storageType)
+	}
+	storages[storageType] = creator
+}
+
+// Storages returns the registered storage types, sorted
+func Storages() []string {
+	storagesMu.RLock()
+	defer storagesMu.RUnlock()
+	types := make([]string, 0, len(storages))
+	for t := range storages {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsRegisteredStorage returns true if storageType can be opened by Open. An empty type is the default postgres storage.
+func IsRegisteredStorage(storageType string) bool {
+	_, ok := storageCreator(storageType)
+	return ok
+}
+
+// Open creates a storage of the given registered type connected to dsn. An empty type opens the default postgres storage.
+func Open(ctx context.Context, logger hclog.Logger, storageType, dsn string) (execution.Storage, error) {
+	creator, ok := storageCreator(storageType)
+	if !ok {
+		return nil, fmt.Errorf("unknown storage type %q, registered types are %s", storageType, strings.Join(Storages(), ", "))
+	}
+	return creator(ctx, logger, dsn)
+}
+
+func storageCreator(storageType string) (StorageCreator, bool) {
+	if storageType == "" {
+		storageType = PostgresStorage
+	}
+	storagesMu.RLock()
+	defer storagesMu.RUnlock()
+	creator, ok := storages[strings.ToLower(storageType)]
+	return creator, ok
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen_MemoryStorageSharedByDSN(t *testing.T) {
+	ctx := context.Background()
+	first, err := Open(ctx, hclog.NewNullLogger(), MemoryStorage, "memory://registry-test")
+	require.NoError(t, err)
+	first.Close()
+	second, err := Open(ctx, hclog.NewNullLogger(), "MEMORY", "memory://registry-test")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	other, err := Open(ctx, hclog.NewNullLogger(), MemoryStorage, "memory://registry-test-other")
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+}
+
+func TestOpen_UnknownStorage(t *testing.T) {
+	_, err := Open(context.Background(), hclog.NewNullLogger(), "cassandra", "")
+	assert.ErrorContains(t, err, `unknown storage type "cassandra", registered types are `)
+	assert.True(t, IsRegisteredStorage(""))
+	assert.False(t, IsRegisteredStorage("cassandra"))
+}
@@ -0,0 +1,33 @@
+// Package httpclient provides composable http.RoundTripper middlewares that providers can wrap their API clients with,
+// so retries, throttling, logging and metrics behave the same across providers.
+package httpclient
+
+import "net/http"
+
+// Middleware wraps a http.RoundTripper adding behavior to each request
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Wrap wraps rt with the given middlewares. The first middleware is the outermost, i.e it sees the request first.
+// If rt is nil http.DefaultTransport is used.
+func Wrap(rt http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// NewClient returns a new http.Client using http.DefaultTransport wrapped with the given middlewares
+func NewClient(middlewares ...Middleware) *http.Client {
+	return &http.Client{Transport: Wrap(http.DefaultTransport, middlewares...)}
+}
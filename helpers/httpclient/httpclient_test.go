@@ -0,0 +1,127 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingStats struct {
+	calls []string
+}
+
+func (r *recordingStats) IncAPICall(service, operation string) {
+	r.calls = append(r.calls, service+"."+operation)
+}
+
+func TestWrapOrder(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	rt := Wrap(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}), mw("first"), mw("second"))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "transport"}, order)
+}
+
+func TestRetry(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(Retry(RetryOptions{MaxAttempts: 3, MinBackoff: time.Hour}))
+	resp, err := c.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&attempts))
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(Retry(RetryOptions{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+	resp, err := c.Post(srv.URL, "text/plain", strings.NewReader("body"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&attempts))
+}
+
+func TestRetryAfter(t *testing.T) {
+	d, ok := retryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	_, ok = retryAfter("")
+	assert.False(t, ok)
+
+	d, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+}
+
+func TestRateLimitPerHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	c := NewClient(RateLimitPerHost(1, 1))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	// the second request has to wait for a second, exceeding the context deadline
+	_, err = c.Do(req)
+	assert.Error(t, err)
+}
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := &recordingStats{}
+	c := NewClient(Metrics(func(req *http.Request) (string, string) {
+		return "test", req.Method + " " + req.URL.Path
+	}))
+	req, err := http.NewRequestWithContext(schema.WithClientStats(context.Background(), s), http.MethodGet, srv.URL+"/items", nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{"test.GET /items"}, s.calls)
+}
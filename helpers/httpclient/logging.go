@@ -0,0 +1,24 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Logging logs every request and its outcome at trace level
+func Logging(logger hclog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Trace("http request failed", "method", req.Method, "host", req.URL.Host, "path", req.URL.Path, "duration", time.Since(start), "error", err)
+				return resp, err
+			}
+			logger.Trace("http request", "method", req.Method, "host", req.URL.Host, "path", req.URL.Path, "status", resp.StatusCode, "duration", time.Since(start))
+			return resp, err
+		})
+	}
+}
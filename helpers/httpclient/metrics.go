@@ -0,0 +1,42 @@
+package httpclient
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/cloudquery/cq-provider-sdk/stats"
+	segmentStats "github.com/segmentio/stats/v4"
+)
+
+// OperationNamer returns the service and operation names an API request is recorded under
+type OperationNamer func(req *http.Request) (service, operation string)
+
+// HostOperationNamer records requests by their host and method
+func HostOperationNamer(req *http.Request) (string, string) {
+	return req.URL.Host, req.Method
+}
+
+// Metrics records each request as an API call on the schema.ClientStats attached to the request context, so calls are
+// aggregated per table and client by the executor, and observes the request duration. If namer is nil HostOperationNamer is used.
+func Metrics(namer OperationNamer) Middleware {
+	if namer == nil {
+		namer = HostOperationNamer
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			service, operation := namer(req)
+			schema.IncAPICall(req.Context(), service, operation)
+			tags := []segmentStats.Tag{{Name: "service", Value: service}, {Name: "operation", Value: operation}}
+			clock := stats.NewClockWithObserve("http.request", tags...)
+			resp, err := next.RoundTrip(req)
+			clock.Stop()
+			status := "error"
+			if err == nil {
+				status = strconv.Itoa(resp.StatusCode)
+			}
+			segmentStats.Incr("http.response", append(tags, segmentStats.Tag{Name: "status", Value: status})...)
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitPerHost limits the requests sent to each host to rps requests per second, allowing bursts of up to burst requests.
+// Requests block until they are allowed to proceed or their context is done.
+func RateLimitPerHost(rps float64, burst int) Middleware {
+	var (
+		mu       sync.Mutex
+		limiters = make(map[string]*rate.Limiter)
+	)
+	limiterFor := func(host string) *rate.Limiter {
+		mu.Lock()
+		defer mu.Unlock()
+		l, ok := limiters[host]
+		if !ok {
+			l = rate.NewLimiter(rate.Limit(rps), burst)
+			limiters[host] = l
+		}
+		return l
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiterFor(req.URL.Host).Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
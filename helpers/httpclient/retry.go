@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+)
+
+// RetryOptions configures the Retry middleware
+type RetryOptions struct {
+	// MaxAttempts is the maximum amount of attempts for each request, including the first one. Defaults to 3.
+	MaxAttempts int
+	// MinBackoff is the backoff before the first retry, doubled on every retry. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff between retries, and the wait requested by a Retry-After header. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+	// ShouldRetry decides if the request should be retried based on the response or error of the last attempt.
+	// Defaults to DefaultShouldRetry.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// DefaultShouldRetry retries on connection errors, 429 Too Many Requests and 502, 503 and 504 responses
+func DefaultShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Retry retries failed requests with exponential backoff. If the response carries a Retry-After header it is honored,
+// capped by MaxBackoff. Requests with a body are only retried if their GetBody is set.
+func Retry(opts RetryOptions) Middleware {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 30 * time.Second
+	}
+	if opts.ShouldRetry == nil {
+		opts.ShouldRetry = DefaultShouldRetry
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			backoff := opts.MinBackoff
+			for attempt := 1; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if attempt >= opts.MaxAttempts || !opts.ShouldRetry(resp, err) || (req.Body != nil && req.GetBody == nil) {
+					return resp, err
+				}
+				wait := backoff
+				if resp != nil {
+					if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+						wait = d
+					}
+					// drain the body so the connection can be reused
+					_, _ = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				if wait > opts.MaxBackoff {
+					wait = opts.MaxBackoff
+				}
+				if err := helpers.Sleep(req.Context(), wait); err != nil {
+					return nil, err
+				}
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(req.Context())
+					req.Body = body
+				}
+				backoff *= 2
+			}
+		})
+	}
+}
+
+// retryAfter parses the value of a Retry-After header, either in seconds or as an HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
@@ -8,6 +8,10 @@ func InterfaceSlice(slice interface{}) []interface{} {
 	if slice == nil {
 		return nil
 	}
+	// slices sent by execution.Send are already boxed, they aren't converted with reflection
+	if s, ok := slice.([]interface{}); ok {
+		return s
+	}
 	s := reflect.ValueOf(slice)
 	// Keep the distinction between nil and empty slice input
 	if s.Kind() == reflect.Ptr && s.Elem().Kind() == reflect.Slice && s.Elem().IsNil() {
@@ -18,6 +18,7 @@ func TestInterfaceSlice(t *testing.T) {
 		{Name: "empty", Value: []interface{}{}, Want: []interface{}{}},
 		{Name: "empty_string_array", Value: []string{}, Want: []interface{}{}},
 		{Name: "string_ptr_array", Value: []*string{&someStringPtr}, Want: []interface{}{&someStringPtr}},
+		{Name: "boxed", Value: []interface{}{"a", 1}, Want: []interface{}{"a", 1}},
 		//{Name: "string_array_ptr", Value: &[]string{"a"}, Want: []interface{}{"a"}}, // TODO: support this?
 	}
 	for _, tc := range cases {
@@ -2,20 +2,65 @@ package logging
 
 import (
 	"os"
+	"strings"
 
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
 	"github.com/hashicorp/go-hclog"
 )
 
-// New creates a new hclog logger
+// EnvLogFormat is the environment variable selecting the format of the logs, "json" or "text"
+const EnvLogFormat = "CQ_LOG_FORMAT"
+
+// Format is the format of the logs
+type Format string
+
+const (
+	// FormatText logs human readable lines
+	FormatText Format = "text"
+	// FormatJSON logs a JSON object per line, with the arguments of the log as fields
+	FormatJSON Format = "json"
+)
+
+// Keys of the fields logged by the SDK, so logs of all providers can be queried by the same fields
+const (
+	TableKey         = "table"
+	ClientIDKey      = "client_id"
+	ResourceCountKey = "resource_count"
+	DiagnosticsKey   = "diagnostics"
+)
+
+// FormatFromEnv returns the format set by the CQ_LOG_FORMAT environment variable, or def if it isn't set or invalid
+func FormatFromEnv(def Format) Format {
+	switch f := Format(strings.ToLower(os.Getenv(EnvLogFormat))); f {
+	case FormatText, FormatJSON:
+		return f
+	default:
+		return def
+	}
+}
+
+// New creates a new hclog logger. JSON logs are enabled by options.JSONFormat or by CQ_LOG_FORMAT=json.
 func New(options *hclog.LoggerOptions) hclog.Logger {
+	if options == nil {
+		options = &hclog.LoggerOptions{}
+	}
 	if options.Level == hclog.NoLevel {
-		if options == nil {
-			options = &hclog.LoggerOptions{}
-		}
 		options.Level = hclog.Info
 	}
 	if options.Output == nil {
 		options.Output = os.Stderr
 	}
+	if FormatFromEnv(FormatText) == FormatJSON {
+		options.JSONFormat = true
+	}
 	return hclog.New(options)
 }
+
+// DiagnosticSummaries returns the summaries of diags, logged under DiagnosticsKey
+func DiagnosticSummaries(diags diag.Diagnostics) []string {
+	summaries := make([]string, len(diags))
+	for i, d := range diags {
+		summaries[i] = d.Description().Summary
+	}
+	return summaries
+}
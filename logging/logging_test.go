@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFromEnv(t *testing.T) {
+	tests := []struct {
+		env      string
+		def      Format
+		expected Format
+	}{
+		{env: "", def: FormatText, expected: FormatText},
+		{env: "", def: FormatJSON, expected: FormatJSON},
+		{env: "json", def: FormatText, expected: FormatJSON},
+		{env: "JSON", def: FormatText, expected: FormatJSON},
+		{env: "text", def: FormatJSON, expected: FormatText},
+		{env: "yaml", def: FormatJSON, expected: FormatJSON},
+	}
+	for _, tc := range tests {
+		t.Run(tc.env+"_"+string(tc.def), func(t *testing.T) {
+			t.Setenv(EnvLogFormat, tc.env)
+			assert.Equal(t, tc.expected, FormatFromEnv(tc.def))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		t.Setenv(EnvLogFormat, "")
+		var buf bytes.Buffer
+		New(&hclog.LoggerOptions{Output: &buf}).Info("fetched", TableKey, "test_table")
+		assert.True(t, strings.HasSuffix(buf.String(), "[INFO]  fetched: table=test_table\n"), buf.String())
+	})
+	t.Run("json from env", func(t *testing.T) {
+		t.Setenv(EnvLogFormat, "json")
+		var buf bytes.Buffer
+		New(&hclog.LoggerOptions{Output: &buf}).Info("fetched", TableKey, "test_table", ClientIDKey, "account")
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "fetched", line["@message"])
+		assert.Equal(t, "test_table", line[TableKey])
+		assert.Equal(t, "account", line[ClientIDKey])
+	})
+	t.Run("json option", func(t *testing.T) {
+		t.Setenv(EnvLogFormat, "text")
+		var buf bytes.Buffer
+		New(&hclog.LoggerOptions{Output: &buf, JSONFormat: true}).Info("fetched")
+		assert.True(t, json.Valid(buf.Bytes()), buf.String())
+	})
+	t.Run("default level", func(t *testing.T) {
+		t.Setenv(EnvLogFormat, "")
+		var buf bytes.Buffer
+		logger := New(&hclog.LoggerOptions{Output: &buf})
+		logger.Debug("hidden")
+		assert.Empty(t, buf.String())
+		assert.True(t, logger.IsInfo())
+	})
+}
+
+func TestDiagnosticSummaries(t *testing.T) {
+	diags := diag.Diagnostics{}.
+		Add(diag.FromError(errors.New("access denied"), diag.ACCESS, diag.WithSummary("failed to fetch table"))).
+		Add(diag.FromError(errors.New("throttled"), diag.THROTTLE))
+	assert.Equal(t, []string{"failed to fetch table: access denied", "throttled"}, DiagnosticSummaries(diags))
+	assert.Empty(t, DiagnosticSummaries(nil))
+}
@@ -0,0 +1,28 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// BackfillStatement returns the statement that sets the value of the column, by its schema.Column.Backfill expression,
+// in rows where it's NULL. Returns an empty string if the column has no backfill.
+func BackfillStatement(t *schema.Table, c schema.Column) string {
+	if c.Backfill == "" {
+		return ""
+	}
+	return fmt.Sprintf("UPDATE %[1]s SET %[2]s = (%[3]s) WHERE %[2]s IS NULL;", schema.QuoteIdentifier(t.Name), schema.QuoteIdentifier(c.Name), c.Backfill)
+}
+
+// BackfillRowsQuery returns the query selecting the rows where the column is NULL, to set its value by its
+// schema.Column.BackfillFunc. Rows are identified by their cq_id, and selected ordered by it in pages of up to limit
+// rows with a cq_id greater than $1, so rows the function leaves NULL aren't selected again.
+func BackfillRowsQuery(t *schema.Table, c schema.Column, limit int) string {
+	return fmt.Sprintf(`SELECT * FROM %[1]s WHERE %[2]s IS NULL AND "cq_id" > $1 ORDER BY "cq_id" LIMIT %[3]d;`, schema.QuoteIdentifier(t.Name), schema.QuoteIdentifier(c.Name), limit)
+}
+
+// BackfillUpdateStatement returns the statement setting the column's value to $2 in the row with the cq_id $1
+func BackfillUpdateStatement(t *schema.Table, c schema.Column) string {
+	return fmt.Sprintf(`UPDATE %s SET %s = $2 WHERE "cq_id" = $1;`, schema.QuoteIdentifier(t.Name), schema.QuoteIdentifier(c.Name))
+}
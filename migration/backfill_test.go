@@ -0,0 +1,24 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillStatement(t *testing.T) {
+	table := &schema.Table{Name: "test_table"}
+	assert.Empty(t, BackfillStatement(table, schema.Column{Name: "name", Type: schema.TypeString}))
+	assert.Equal(t,
+		`UPDATE "test_table" SET "account_id" = (split_part(arn, ':', 5)) WHERE "account_id" IS NULL;`,
+		BackfillStatement(table, schema.Column{Name: "account_id", Type: schema.TypeString, Backfill: "split_part(arn, ':', 5)"}),
+	)
+}
+
+func TestBackfillFuncStatements(t *testing.T) {
+	table := &schema.Table{Name: "test_table"}
+	c := schema.Column{Name: "account_id", Type: schema.TypeString}
+	assert.Equal(t, `SELECT * FROM "test_table" WHERE "account_id" IS NULL AND "cq_id" > $1 ORDER BY "cq_id" LIMIT 100;`, BackfillRowsQuery(table, c, 100))
+	assert.Equal(t, `UPDATE "test_table" SET "account_id" = $2 WHERE "cq_id" = $1;`, BackfillUpdateStatement(table, c))
+}
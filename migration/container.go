@@ -0,0 +1,71 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultPostgresImage is the image used by StartPostgresContainer if none is given
+const DefaultPostgresImage = "postgres:13"
+
+const (
+	containerPassword     = "pass"
+	containerReadyTimeout = time.Minute
+)
+
+// StartPostgresContainer starts an ephemeral postgres docker container, using the docker CLI, and waits for it to accept
+// connections. It returns the DSN of the database and a function that removes the container.
+func StartPostgresContainer(ctx context.Context, image string) (string, func(), error) {
+	if image == "" {
+		image = DefaultPostgresImage
+	}
+	out, err := docker(ctx, "run", "-d", "--rm", "-e", "POSTGRES_PASSWORD="+containerPassword, "-p", "127.0.0.1::5432", image)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to start postgres container: %w", err)
+	}
+	id := strings.TrimSpace(out)
+	stop := func() {
+		_, _ = docker(context.Background(), "rm", "-f", id)
+	}
+
+	port, err := docker(ctx, "port", id, "5432/tcp")
+	if err != nil {
+		stop()
+		return "", nil, fmt.Errorf("failed to read postgres container port: %w", err)
+	}
+	// docker port may list several bindings, i.e 127.0.0.1:49153
+	addr := strings.TrimSpace(strings.SplitN(port, "\n", 2)[0])
+	dsn := fmt.Sprintf("postgres://postgres:%s@%s/postgres?sslmode=disable", containerPassword, addr)
+
+	ctx, cancel := context.WithTimeout(ctx, containerReadyTimeout)
+	defer cancel()
+	for {
+		conn, err := pgx.Connect(ctx, dsn)
+		if err == nil {
+			conn.Close(ctx)
+			return dsn, stop, nil
+		}
+		select {
+		case <-ctx.Done():
+			stop()
+			return "", nil, fmt.Errorf("postgres container isn't ready: %w", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
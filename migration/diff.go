@@ -0,0 +1,141 @@
+package migration
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// DiffSchemas compares the tables of two versions of a provider, by resource name, and returns the changes from old
+// to new: added, dropped and renamed tables and columns, columns whose type changed and tables whose primary keys
+// changed. Relations are compared by table name along with their parents. Renames are detected by the Table.RenamedFrom
+// and Column.RenamedFrom of the new tables, as in UpgradeTable. Column types are reported as their postgres types.
+// Changes are sorted by table and column name.
+func DiffSchemas(old, new map[string]*schema.Table) []schema.SchemaChange {
+	oldTables, newTables := flatTables(old), flatTables(new)
+	var changes []schema.SchemaChange
+	// renamedTables are the old names of renamed tables, so they aren't dropped
+	renamedTables := make(map[string]bool)
+	for name, t := range newTables {
+		ot, ok := oldTables[name]
+		if !ok && t.RenamedFrom != "" && newTables[t.RenamedFrom] == nil {
+			if ot, ok = oldTables[t.RenamedFrom]; ok {
+				renamedTables[t.RenamedFrom] = true
+				changes = append(changes, schema.SchemaChange{Kind: schema.TableRenamed, Table: name, Previous: t.RenamedFrom})
+			}
+		}
+		if !ok {
+			changes = append(changes, schema.SchemaChange{Kind: schema.TableAdded, Table: name})
+			continue
+		}
+		changes = append(changes, diffTable(ot, t)...)
+	}
+	for name := range oldTables {
+		if newTables[name] == nil && !renamedTables[name] {
+			changes = append(changes, schema.SchemaChange{Kind: schema.TableDropped, Table: name})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Table != changes[j].Table {
+			return changes[i].Table < changes[j].Table
+		}
+		return changes[i].Column < changes[j].Column
+	})
+	return changes
+}
+
+// diffTable returns the changes of the columns and primary keys of a table that exists in both versions
+func diffTable(old, new *schema.Table) []schema.SchemaChange {
+	var changes []schema.SchemaChange
+	dialect := schema.PostgresDialect{}
+	if o, n := strings.Join(dialect.PrimaryKeys(old), ", "), strings.Join(dialect.PrimaryKeys(new), ", "); o != n {
+		changes = append(changes, schema.SchemaChange{Kind: schema.PrimaryKeysChanged, Table: new.Name, Previous: o, Current: n})
+	}
+	// renamed are the old names of renamed columns, so they aren't dropped
+	renamed := make(map[string]bool)
+	for _, c := range new.Columns {
+		oc := old.Column(c.Name)
+		if oc == nil {
+			for _, from := range c.RenamedFrom {
+				if new.Column(from) != nil {
+					continue
+				}
+				if oc = old.Column(from); oc != nil {
+					renamed[from] = true
+					changes = append(changes, schema.SchemaChange{Kind: schema.ColumnRenamed, Table: new.Name, Column: c.Name, Previous: from})
+					break
+				}
+			}
+		}
+		if oc == nil {
+			changes = append(changes, schema.SchemaChange{Kind: schema.ColumnAdded, Table: new.Name, Column: c.Name, Current: dialect.DBTypeFromType(c.Type)})
+			continue
+		}
+		if o, n := dialect.DBTypeFromType(oc.Type), dialect.DBTypeFromType(c.Type); o != n {
+			changes = append(changes, schema.SchemaChange{Kind: schema.ColumnTypeChanged, Table: new.Name, Column: c.Name, Previous: o, Current: n})
+		}
+	}
+	for _, oc := range old.Columns {
+		if new.Column(oc.Name) == nil && !renamed[oc.Name] {
+			changes = append(changes, schema.SchemaChange{Kind: schema.ColumnDropped, Table: new.Name, Column: oc.Name})
+		}
+	}
+	return changes
+}
+
+// RenderSchemaChanges writes the changes returned by DiffSchemas as a changelog, the breaking changes first. Nothing is
+// written if there are no changes.
+func RenderSchemaChanges(w io.Writer, changes []schema.SchemaChange) error {
+	var breaking, other []string
+	for _, c := range changes {
+		if c.Breaking() {
+			breaking = append(breaking, c.String())
+		} else {
+			other = append(other, c.String())
+		}
+	}
+	sections := []struct {
+		title   string
+		changes []string
+	}{{"Breaking changes", breaking}, {"Changes", other}}
+	first := true
+	for _, s := range sections {
+		if len(s.changes) == 0 {
+			continue
+		}
+		if !first {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := fmt.Fprintf(w, "%s:\n", s.title); err != nil {
+			return err
+		}
+		for _, c := range s.changes {
+			if _, err := fmt.Fprintf(w, "  - %s\n", c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// flatTables returns the tables and their relations by table name
+func flatTables(tables map[string]*schema.Table) map[string]*schema.Table {
+	ret := make(map[string]*schema.Table)
+	var add func(t *schema.Table)
+	add = func(t *schema.Table) {
+		ret[t.Name] = t
+		for _, r := range t.Relations {
+			add(r)
+		}
+	}
+	for _, t := range tables {
+		add(t)
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+package migration
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	old := map[string]*schema.Table{
+		"items": {
+			Name: "test_items",
+			Columns: []schema.Column{
+				{Name: "id", Type: schema.TypeString},
+				{Name: "acc", Type: schema.TypeString},
+				{Name: "size", Type: schema.TypeInt},
+				{Name: "legacy", Type: schema.TypeString},
+			},
+			Relations: []*schema.Table{{Name: "test_item_tags", Columns: []schema.Column{{Name: "key", Type: schema.TypeString}}}},
+		},
+		"dropped": {Name: "test_dropped"},
+		"renamed": {Name: "test_old_name"},
+	}
+	new := map[string]*schema.Table{
+		"items": {
+			Name: "test_items",
+			Columns: []schema.Column{
+				{Name: "id", Type: schema.TypeString},
+				{Name: "account_id", Type: schema.TypeString, RenamedFrom: []string{"account", "acc"}},
+				{Name: "size", Type: schema.TypeBigInt},
+				{Name: "region", Type: schema.TypeString},
+			},
+			Options:   schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+			Relations: []*schema.Table{{Name: "test_item_tags", Columns: []schema.Column{{Name: "key", Type: schema.TypeString}}}},
+		},
+		"renamed": {Name: "test_new_name", RenamedFrom: "test_old_name"},
+		"added":   {Name: "test_added"},
+	}
+	changes := DiffSchemas(old, new)
+	assert.Equal(t, []schema.SchemaChange{
+		{Kind: schema.TableAdded, Table: "test_added"},
+		{Kind: schema.TableDropped, Table: "test_dropped"},
+		{Kind: schema.PrimaryKeysChanged, Table: "test_items", Previous: "cq_id", Current: "id"},
+		{Kind: schema.ColumnRenamed, Table: "test_items", Column: "account_id", Previous: "acc"},
+		{Kind: schema.ColumnDropped, Table: "test_items", Column: "legacy"},
+		{Kind: schema.ColumnAdded, Table: "test_items", Column: "region", Current: "text"},
+		{Kind: schema.ColumnTypeChanged, Table: "test_items", Column: "size", Previous: "integer", Current: "bigint"},
+		{Kind: schema.TableRenamed, Table: "test_new_name", Previous: "test_old_name"},
+	}, changes)
+	assert.Empty(t, DiffSchemas(new, new))
+
+	var b bytes.Buffer
+	assert.NoError(t, RenderSchemaChanges(&b, changes))
+	assert.Equal(t, `Breaking changes:
+  - table test_dropped was dropped
+  - primary keys of table test_items changed from (cq_id) to (id)
+  - column account_id of table test_items was renamed from acc
+  - column legacy of table test_items was dropped
+  - type of column size of table test_items changed from integer to bigint
+  - table test_new_name was renamed from test_old_name
+
+Changes:
+  - table test_added was added
+  - column region of type text was added to table test_items
+`, b.String())
+}
@@ -0,0 +1,15 @@
+package migrator
+
+import (
+	"context"
+
+	"github.com/cloudquery/cq-provider-sdk/migration"
+)
+
+// DefaultPostgresImage is the image used by StartPostgresContainer if none is given
+const DefaultPostgresImage = migration.DefaultPostgresImage
+
+// StartPostgresContainer starts an ephemeral postgres docker container, see migration.StartPostgresContainer
+func StartPostgresContainer(ctx context.Context, image string) (string, func(), error) {
+	return migration.StartPostgresContainer(ctx, image)
+}
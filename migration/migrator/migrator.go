@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"path"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/cloudquery/cq-provider-sdk/database/dsn"
+	"github.com/cloudquery/cq-provider-sdk/migration"
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/golang-migrate/migrate/v4"
 	mpg "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-version"
 	"github.com/jackc/pgx/v4"
@@ -30,13 +31,24 @@ type Migrator struct {
 	log         hclog.Logger
 	m           *migrate.Migrate
 	driver      source.Driver
+	// migrationsTable is the name of the table holding the migration version
+	migrationsTable string
 	// maps between semantic version to the timestamp it was created at
 	versionMapper map[string]uint
 	versions      version.Collection
+	// backfillTables are backfilled by UpgradeProvider, if set by WithBackfill
+	backfillTables map[string]*schema.Table
 }
 
 type Option func(*Migrator)
 
+// WithBackfill backfills the columns of the tables once UpgradeProvider applies migrations, see Migrator.Backfill
+func WithBackfill(tableSchema map[string]*schema.Table) Option {
+	return func(m *Migrator) {
+		m.backfillTables = tableSchema
+	}
+}
+
 const (
 	Latest  = "latest"
 	Initial = "initial"
@@ -44,6 +56,8 @@ const (
 
 	migrationsEmbeddedDirectoryPath = "migrations"
 	dropTableSQL                    = "DROP TABLE IF EXISTS %s CASCADE"
+	// backfillPageSize is the amount of rows read at once to backfill a column by its schema.Column.BackfillFunc
+	backfillPageSize = 1000
 )
 
 // ReadMigrationFiles reads the given embed.FS for the migration files and returns a map of dialect directories vs. filenames vs. data
@@ -93,6 +107,10 @@ func ReadMigrationFiles(log hclog.Logger, migrationFiles embed.FS) (map[string]m
 }
 
 func New(log hclog.Logger, dt schema.DialectType, migrationFiles map[string]map[string][]byte, dsnURI, providerName string, opts ...Option) (*Migrator, error) {
+	return newMigrator(log, dt, migrationFiles, dsnURI, providerName, fmt.Sprintf("%s_schema_migrations", providerName), opts...)
+}
+
+func newMigrator(log hclog.Logger, dt schema.DialectType, migrationFiles map[string]map[string][]byte, dsnURI, providerName, migrationsTable string, opts ...Option) (*Migrator, error) {
 	versionMapper := make(map[string]uint)
 	versions := make(version.Collection, 0)
 	mm := afero.NewMemMapFs()
@@ -128,9 +146,9 @@ func New(log hclog.Logger, dt schema.DialectType, migrationFiles map[string]map[
 		return nil, err
 	}
 	if u.RawQuery != "" {
-		u.RawQuery += fmt.Sprintf("&x-migrations-table=%s_schema_migrations", providerName)
+		u.RawQuery += fmt.Sprintf("&x-migrations-table=%s", migrationsTable)
 	} else {
-		u.RawQuery += fmt.Sprintf("x-migrations-table=%s_schema_migrations", providerName)
+		u.RawQuery += fmt.Sprintf("x-migrations-table=%s", migrationsTable)
 	}
 	m, err := migrate.NewWithSourceInstance(providerName, driver, u.String())
 	if err != nil {
@@ -138,14 +156,15 @@ func New(log hclog.Logger, dt schema.DialectType, migrationFiles map[string]map[
 	}
 
 	mg := &Migrator{
-		log:           log,
-		provider:      providerName,
-		dsn:           dsnURI,
-		migratorUrl:   u,
-		m:             m,
-		driver:        driver,
-		versionMapper: versionMapper,
-		versions:      versions,
+		log:             log,
+		provider:        providerName,
+		dsn:             dsnURI,
+		migratorUrl:     u,
+		m:               m,
+		driver:          driver,
+		migrationsTable: migrationsTable,
+		versionMapper:   versionMapper,
+		versions:        versions,
 	}
 	for _, o := range opts {
 		o(mg)
@@ -162,9 +181,12 @@ func (m *Migrator) Close() error {
 	return dbErr
 }
 
+// UpgradeProvider applies the migrations up to the given version. If WithBackfill is set, the tables are backfilled
+// once migrations are applied, they aren't if there was no migration to apply or the version isn't the latest one, as
+// the backfilled columns are those of the latest version.
 func (m *Migrator) UpgradeProvider(version string) (retErr error) {
 	if version == Latest {
-		return m.m.Up()
+		return m.backfillAfter(m.m.Up())
 	}
 
 	mv, err := m.FindLatestMigration(version)
@@ -172,7 +194,21 @@ func (m *Migrator) UpgradeProvider(version string) (retErr error) {
 		return fmt.Errorf("version %s upgrade doesn't exist", version)
 	}
 	m.log.Debug("upgrading provider version", "version", version, "migrator_version", mv)
-	return m.m.Migrate(mv)
+	if latest, _ := m.FindLatestMigration(Latest); mv != latest {
+		return m.m.Migrate(mv)
+	}
+	return m.backfillAfter(m.m.Migrate(mv))
+}
+
+// backfillAfter backfills the tables set by WithBackfill if the migration succeeded, returning its error otherwise
+func (m *Migrator) backfillAfter(migrateErr error) error {
+	if migrateErr != nil || m.backfillTables == nil {
+		return migrateErr
+	}
+	if _, err := m.Backfill(context.Background(), m.backfillTables); err != nil {
+		return fmt.Errorf("failed to backfill: %w", err)
+	}
+	return nil
 }
 
 func (m *Migrator) DowngradeProvider(version string) (retErr error) {
@@ -199,7 +235,7 @@ func (m *Migrator) DropProvider(ctx context.Context, tableSchema map[string]*sch
 	}
 	defer conn.Close(ctx)
 
-	q := fmt.Sprintf(dropTableSQL, strconv.Quote(fmt.Sprintf("%s_schema_migrations", m.provider)))
+	q := fmt.Sprintf(dropTableSQL, schema.QuoteIdentifier(m.migrationsTable))
 	if _, err := conn.Exec(ctx, q); err != nil {
 		return err
 	}
@@ -223,6 +259,43 @@ func (m *Migrator) DropProvider(ctx context.Context, tableSchema map[string]*sch
 	return nil
 }
 
+// BackfillResult is the number of rows updated by the backfill of a column
+type BackfillResult struct {
+	Table  string
+	Column string
+	Rows   int64
+}
+
+// Backfill sets the values of columns declaring schema.Column.Backfill or BackfillFunc in rows where they are NULL, i.e
+// rows stored before an upgrade added the column. It should be called after UpgradeProvider, unless the migrator was
+// created WithBackfill, and returns the rows updated per column.
+func (m *Migrator) Backfill(ctx context.Context, tableSchema map[string]*schema.Table) ([]BackfillResult, error) {
+	conn, err := pgx.Connect(ctx, m.dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	names := make([]string, 0, len(tableSchema))
+	for name := range tableSchema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []BackfillResult
+	for _, name := range names {
+		r, err := backfillTable(ctx, conn, tableSchema[name])
+		if err != nil {
+			return results, err
+		}
+		for _, b := range r {
+			m.log.Info("backfilled column", "table", b.Table, "column", b.Column, "rows", b.Rows, "provider", m.provider)
+		}
+		results = append(results, r...)
+	}
+	return results, nil
+}
+
 func (m *Migrator) Version() (string, bool, error) {
 	ver, dirty, err := m.m.Version()
 	for k, v := range m.versionMapper {
@@ -280,7 +353,7 @@ func (m *Migrator) FindLatestMigration(requestedVersion string) (uint, error) {
 }
 
 func dropTables(ctx context.Context, conn *pgx.Conn, table *schema.Table) error {
-	if _, err := conn.Exec(ctx, fmt.Sprintf(dropTableSQL, strconv.Quote(table.Name))); err != nil {
+	if _, err := conn.Exec(ctx, fmt.Sprintf(dropTableSQL, schema.QuoteIdentifier(table.Name))); err != nil {
 		return err
 	}
 	for _, rel := range table.Relations {
@@ -291,6 +364,106 @@ func dropTables(ctx context.Context, conn *pgx.Conn, table *schema.Table) error
 	return nil
 }
 
+func backfillTable(ctx context.Context, conn *pgx.Conn, table *schema.Table) ([]BackfillResult, error) {
+	var results []BackfillResult
+	for _, c := range table.Columns {
+		var rows int64
+		switch {
+		case c.Backfill != "":
+			tag, err := conn.Exec(ctx, migration.BackfillStatement(table, c))
+			if err != nil {
+				return results, fmt.Errorf("failed to backfill column %s.%s: %w", table.Name, c.Name, err)
+			}
+			rows = tag.RowsAffected()
+		case c.BackfillFunc != nil:
+			n, err := backfillColumnFunc(ctx, conn, table, c)
+			if err != nil {
+				return results, fmt.Errorf("failed to backfill column %s.%s: %w", table.Name, c.Name, err)
+			}
+			rows = n
+		default:
+			continue
+		}
+		results = append(results, BackfillResult{Table: table.Name, Column: c.Name, Rows: rows})
+	}
+	for _, rel := range table.Relations {
+		r, err := backfillTable(ctx, conn, rel)
+		results = append(results, r...)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// backfillColumnFunc sets the column's value by its BackfillFunc in the rows where it's NULL, reading them in pages and
+// updating each page in a batch. Returns the amount of rows updated.
+func backfillColumnFunc(ctx context.Context, conn *pgx.Conn, table *schema.Table, c schema.Column) (int64, error) {
+	var (
+		updated int64
+		last    interface{} = uuid.Nil
+		update              = migration.BackfillUpdateStatement(table, c)
+	)
+	for {
+		rows, err := conn.Query(ctx, migration.BackfillRowsQuery(table, c, backfillPageSize), last)
+		if err != nil {
+			return updated, err
+		}
+		batch := &pgx.Batch{}
+		read := 0
+		for rows.Next() {
+			values, err := rows.Values()
+			if err != nil {
+				rows.Close()
+				return updated, err
+			}
+			row := make(map[string]interface{}, len(values))
+			for i, fd := range rows.FieldDescriptions() {
+				row[string(fd.Name)] = values[i]
+			}
+			read++
+			last = row["cq_id"]
+			v, err := c.BackfillFunc(ctx, row)
+			if err != nil {
+				rows.Close()
+				return updated, err
+			}
+			if v != nil {
+				batch.Queue(update, last, v)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return updated, err
+		}
+		if batch.Len() > 0 {
+			n, err := execBatch(ctx, conn, batch)
+			updated += n
+			if err != nil {
+				return updated, err
+			}
+		}
+		if read < backfillPageSize {
+			return updated, nil
+		}
+	}
+}
+
+// execBatch sends the batch of statements, returning the amount of rows they affected
+func execBatch(ctx context.Context, conn *pgx.Conn, batch *pgx.Batch) (int64, error) {
+	br := conn.SendBatch(ctx, batch)
+	var affected int64
+	for i := 0; i < batch.Len(); i++ {
+		tag, err := br.Exec()
+		if err != nil {
+			_ = br.Close()
+			return affected, err
+		}
+		affected += tag.RowsAffected()
+	}
+	return affected, br.Close()
+}
+
 func convertMigrateError(dsnURI string, err error) error {
 	if err == nil {
 		return err
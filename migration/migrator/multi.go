@@ -0,0 +1,199 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/hashicorp/go-hclog"
+)
+
+// MultiMigrator runs the migrations of several dialects of a provider against the same database. Each dialect keeps
+// its own version table, and all methods are serialized, so a single MultiMigrator can be shared between goroutines.
+type MultiMigrator struct {
+	mu        sync.Mutex
+	dialects  []schema.DialectType
+	migrators map[schema.DialectType]*Migrator
+}
+
+// NewMulti creates a MultiMigrator for the given dialects, which are upgraded in the given order and downgraded in
+// reverse order. The migration files of all dialects must define the same set of versions.
+//
+// The postgres dialect keeps the version table used by New, other dialects use <provider>_<dialect>_schema_migrations.
+func NewMulti(log hclog.Logger, dialects []schema.DialectType, migrationFiles map[string]map[string][]byte, dsnURI, providerName string, opts ...Option) (*MultiMigrator, error) {
+	if err := validateDialectMigrations(dialects, migrationFiles); err != nil {
+		return nil, err
+	}
+	mm := &MultiMigrator{
+		dialects:  dialects,
+		migrators: make(map[schema.DialectType]*Migrator, len(dialects)),
+	}
+	for _, dt := range dialects {
+		m, err := newMigrator(log.With("dialect", dt), dt, migrationFiles, dsnURI, providerName, dialectMigrationsTable(providerName, dt), opts...)
+		if err != nil {
+			_ = mm.Close()
+			return nil, fmt.Errorf("dialect %s: %w", dt, err)
+		}
+		mm.migrators[dt] = m
+	}
+	return mm, nil
+}
+
+// Migrator returns the migrator of the given dialect, or nil if it isn't managed by the MultiMigrator
+func (mm *MultiMigrator) Migrator(dt schema.DialectType) *Migrator {
+	return mm.migrators[dt]
+}
+
+// UpgradeProvider upgrades all dialects to the given version, in dialect order
+func (mm *MultiMigrator) UpgradeProvider(version string) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for _, dt := range mm.dialects {
+		if err := mm.migrators[dt].UpgradeProvider(version); err != nil {
+			return fmt.Errorf("dialect %s: %w", dt, err)
+		}
+	}
+	return nil
+}
+
+// DowngradeProvider downgrades all dialects to the given version, in reverse dialect order
+func (mm *MultiMigrator) DowngradeProvider(version string) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for i := len(mm.dialects) - 1; i >= 0; i-- {
+		dt := mm.dialects[i]
+		if err := mm.migrators[dt].DowngradeProvider(version); err != nil {
+			return fmt.Errorf("dialect %s: %w", dt, err)
+		}
+	}
+	return nil
+}
+
+// DropProvider drops the provider's tables and the version tables of all dialects
+func (mm *MultiMigrator) DropProvider(ctx context.Context, tableSchema map[string]*schema.Table) error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for i := len(mm.dialects) - 1; i >= 0; i-- {
+		dt := mm.dialects[i]
+		if err := mm.migrators[dt].DropProvider(ctx, tableSchema); err != nil {
+			return fmt.Errorf("dialect %s: %w", dt, err)
+		}
+	}
+	return nil
+}
+
+// Versions returns the current version of each dialect, v0.0.0 for dialects that weren't migrated yet
+func (mm *MultiMigrator) Versions() (map[schema.DialectType]string, error) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	versions := make(map[schema.DialectType]string, len(mm.dialects))
+	for _, dt := range mm.dialects {
+		v, dirty, err := mm.migrators[dt].Version()
+		if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+			return nil, fmt.Errorf("dialect %s: %w", dt, err)
+		}
+		if dirty {
+			return nil, fmt.Errorf("dialect %s: version %s is dirty", dt, v)
+		}
+		versions[dt] = v
+	}
+	return versions, nil
+}
+
+// Close closes the migrators of all dialects
+func (mm *MultiMigrator) Close() error {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	var retErr error
+	for _, m := range mm.migrators {
+		if err := m.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}
+	return retErr
+}
+
+func dialectMigrationsTable(providerName string, dt schema.DialectType) string {
+	if dt == schema.Postgres {
+		return fmt.Sprintf("%s_schema_migrations", providerName)
+	}
+	return fmt.Sprintf("%s_%s_schema_migrations", providerName, dt)
+}
+
+// validateDialectMigrations checks that all dialects have migration files, and that they define the same versions
+func validateDialectMigrations(dialects []schema.DialectType, migrationFiles map[string]map[string][]byte) error {
+	if len(dialects) == 0 {
+		return fmt.Errorf("no dialects given")
+	}
+	var (
+		base        []string
+		baseDialect schema.DialectType
+	)
+	seen := make(map[schema.DialectType]bool, len(dialects))
+	for _, dt := range dialects {
+		if seen[dt] {
+			return fmt.Errorf("dialect %s given more than once", dt)
+		}
+		seen[dt] = true
+		files, ok := migrationFiles[dt.MigrationDirectory()]
+		if !ok || len(files) == 0 {
+			return fmt.Errorf("no migration files for dialect %s", dt)
+		}
+		versions, err := migrationVersions(files)
+		if err != nil {
+			return fmt.Errorf("dialect %s: %w", dt, err)
+		}
+		if base == nil {
+			base, baseDialect = versions, dt
+			continue
+		}
+		if strings.Join(versions, ",") != strings.Join(base, ",") {
+			return fmt.Errorf("migration versions of dialect %s %v don't match dialect %s %v", dt, versions, baseDialect, base)
+		}
+	}
+	return nil
+}
+
+// migrationVersions returns the sorted versions defined by migration files, each version must have an up and down file
+func migrationVersions(files map[string][]byte) ([]string, error) {
+	up, down := make(map[string]bool), make(map[string]bool)
+	for k := range files {
+		var name string
+		switch {
+		case strings.HasSuffix(k, ".up.sql"):
+			name = strings.TrimSuffix(k, ".up.sql")
+		case strings.HasSuffix(k, ".down.sql"):
+			name = strings.TrimSuffix(k, ".down.sql")
+		default:
+			return nil, fmt.Errorf("invalid migration filename %q: should be in format <int>_v<version>.up|down.sql", k)
+		}
+		raw := strings.Split(name, "_")
+		if len(raw) == 1 {
+			return nil, fmt.Errorf("invalid migration filename %q: should be in format <int>_v<version>.up|down.sql", k)
+		}
+		if strings.HasSuffix(k, ".up.sql") {
+			up[raw[1]] = true
+		} else {
+			down[raw[1]] = true
+		}
+	}
+	versions := make([]string, 0, len(up))
+	for v := range up {
+		if !down[v] {
+			return nil, fmt.Errorf("version %s has no down migration", v)
+		}
+		versions = append(versions, v)
+	}
+	for v := range down {
+		if !up[v] {
+			return nil, fmt.Errorf("version %s has no up migration", v)
+		}
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
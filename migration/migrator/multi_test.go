@@ -0,0 +1,33 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDialectMigrations(t *testing.T) {
+	tsdb := map[string][]byte{}
+	for k, v := range simpleMigrations["postgres"] {
+		tsdb[k] = v
+	}
+	files := map[string]map[string][]byte{"postgres": simpleMigrations["postgres"], "timescale": tsdb}
+	assert.NoError(t, validateDialectMigrations([]schema.DialectType{schema.Postgres, schema.TSDB}, files))
+
+	assert.EqualError(t, validateDialectMigrations(nil, files), "no dialects given")
+	assert.EqualError(t, validateDialectMigrations([]schema.DialectType{schema.Postgres, schema.Postgres}, files), "dialect postgres given more than once")
+	assert.EqualError(t, validateDialectMigrations([]schema.DialectType{schema.Postgres, schema.TSDB}, simpleMigrations), "no migration files for dialect timescale")
+
+	delete(tsdb, "5_v0.0.4.down.sql")
+	assert.EqualError(t, validateDialectMigrations([]schema.DialectType{schema.Postgres, schema.TSDB}, files), "dialect timescale: version v0.0.4 has no down migration")
+
+	delete(tsdb, "5_v0.0.4.up.sql")
+	assert.EqualError(t, validateDialectMigrations([]schema.DialectType{schema.Postgres, schema.TSDB}, files),
+		"migration versions of dialect timescale [v0.0.1 v0.0.2 v0.0.2-beta v0.0.3] don't match dialect postgres [v0.0.1 v0.0.2 v0.0.2-beta v0.0.3 v0.0.4]")
+}
+
+func TestDialectMigrationsTable(t *testing.T) {
+	assert.Equal(t, "test_schema_migrations", dialectMigrationsTable("test", schema.Postgres))
+	assert.Equal(t, "test_timescale_schema_migrations", dialectMigrationsTable("test", schema.TSDB))
+}
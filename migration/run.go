@@ -0,0 +1,202 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultMigrationsDir is the migrations directory of a provider, relative to its root, used by Run if none is given
+const DefaultMigrationsDir = "resources/provider/migrations"
+
+const runMigrationHeader = "-- Autogenerated by migration.Run for %s\n\n"
+
+// RunOptions configure Run
+type RunOptions struct {
+	// Tables are the tables of the provider, i.e provider.Provider.ResourceMap
+	Tables map[string]*schema.Table
+	// Version of the provider the migration upgrades to, i.e v0.10.0
+	Version string
+	// Dialect of the generated migrations, schema.Postgres if not set. Only postgres migrations can be generated, the
+	// timescale migrations are derived from them, see TSDB.
+	Dialect schema.DialectType
+	// Dir is the migrations directory, laid out as expected by migrator.ReadMigrationFiles, DefaultMigrationsDir if not set
+	Dir string
+	// DSN of an empty postgres database the migrations are generated with. If not set an ephemeral postgres docker
+	// container is started.
+	DSN string
+	// Image of the postgres container started if DSN isn't set, DefaultPostgresImage if not set
+	Image string
+	// TSDB also writes the timescale migrations derived from the postgres migrations, see WriteTSDBMigrations
+	TSDB bool
+	// History if set, partitions the tables the timescale migrations create for history mode, so they're set up as the
+	// provider is upgraded instead of by every fetch. Providers set it to their provider.Provider.HistoryConfig.
+	// Requires TSDB.
+	History *execution.HistoryConfig
+	// Now is the time the migration files are named after, time.Now if not set
+	Now func() time.Time
+}
+
+// Run generates the postgres migration of the provider's tables: the existing up migrations in the postgres dialect
+// directory of opts.Dir are applied to the database, the database is diffed against opts.Tables with GenerateDiff,
+// dropping the columns that aren't columns of the tables anymore, and the statements are written to the
+// <timestamp>_<version>.up.sql and .down.sql files, named as expected by migrator.New. No files are written if the database is up to date. Run returns the paths of the written files.
+//
+// Providers generate their migrations from a main package, i.e with go run ./tools/migrations:
+//
+//	func main() {
+//		files, err := migration.Run(context.Background(), migration.RunOptions{
+//			Tables:  provider.Provider().ResourceMap,
+//			Version: os.Args[1],
+//			TSDB:    true,
+//		})
+//		...
+//	}
+func Run(ctx context.Context, opts RunOptions) ([]string, error) {
+	if opts.Version == "" {
+		return nil, fmt.Errorf("migration version is required")
+	}
+	if opts.Dialect == "" {
+		opts.Dialect = schema.Postgres
+	}
+	if opts.Dialect != schema.Postgres {
+		return nil, fmt.Errorf("migrations of the %s dialect can't be generated, only %s migrations are supported", opts.Dialect, schema.Postgres)
+	}
+	if opts.History != nil && !opts.TSDB {
+		return nil, fmt.Errorf("history mode requires the timescale migrations, TSDB must be set")
+	}
+	if opts.Dir == "" {
+		opts.Dir = DefaultMigrationsDir
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	dsn := opts.DSN
+	if dsn == "" {
+		var (
+			stop func()
+			err  error
+		)
+		dsn, stop, err = StartPostgresContainer(ctx, opts.Image)
+		if err != nil {
+			return nil, err
+		}
+		defer stop()
+	}
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	pgDir := filepath.Join(opts.Dir, schema.Postgres.MigrationDirectory())
+	if err := applyMigrations(ctx, conn, pgDir); err != nil {
+		return nil, err
+	}
+
+	dialect := schema.PostgresDialect{}
+	names := make([]string, 0, len(opts.Tables))
+	for name := range opts.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var (
+		up         []string
+		tableDowns [][]string
+	)
+	for _, name := range names {
+		t := opts.Tables[name]
+		// the database only has the provider's migrations, so unknown columns were removed from the provider
+		tableUp, tableDown, err := GenerateDiffWithOptions(ctx, conn, dialect, t, UpgradeOptions{DropUnknownColumns: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate migration of table %s: %w", t.Name, err)
+		}
+		up = append(up, tableUp...)
+		tableDowns = append(tableDowns, tableDown)
+	}
+	if len(up) == 0 {
+		return nil, nil
+	}
+	// revert the tables in the reverse order they were upgraded
+	var down []string
+	for i := len(tableDowns) - 1; i >= 0; i-- {
+		down = append(down, tableDowns[i]...)
+	}
+
+	base := migrationFileName(opts.Now(), opts.Version)
+	files := map[string][]string{base + ".up.sql": up, base + ".down.sql": down}
+	written := make([]string, 0, len(files))
+	for _, name := range []string{base + ".up.sql", base + ".down.sql"} {
+		path := filepath.Join(pgDir, name)
+		data := fmt.Sprintf(runMigrationHeader, opts.Version) + strings.Join(files[name], "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	if opts.TSDB {
+		if err := writeTSDBMigrations(opts.Dir, opts.History); err != nil {
+			return written, fmt.Errorf("failed to write timescale migrations: %w", err)
+		}
+	}
+	return written, nil
+}
+
+// migrationFileName returns the name of a migration of the version created at t, without its up/down suffix
+func migrationFileName(t time.Time, version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return t.UTC().Format("20060102150405") + "_" + version
+}
+
+// applyMigrations executes the up migrations of dir in the order of their sequence numbers, dir may not exist yet
+func applyMigrations(ctx context.Context, conn *pgx.Conn, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".up.sql") {
+			names = append(names, e.Name())
+		}
+	}
+	sortMigrationFiles(names)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(data)) == "" {
+			continue
+		}
+		if _, err := conn.Exec(ctx, string(data)); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// sortMigrationFiles sorts migration file names by their leading sequence numbers
+func sortMigrationFiles(names []string) {
+	seq := func(name string) uint64 {
+		n, _ := strconv.ParseUint(strings.SplitN(name, "_", 2)[0], 10, 64)
+		return n
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return seq(names[i]) < seq(names[j])
+	})
+}
@@ -2,22 +2,25 @@ package migration
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 	"strings"
 
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 )
 
-// CreateTableDefinitions reads schema.Table and builds the CREATE TABLE statement for it, also processing and returning subrelation tables
+// CreateTableDefinitions reads schema.Table and builds the CREATE TABLE statement for it, also processing and returning subrelation tables.
+// Deprecations of the table and its columns, and the classifications of its columns, are noted in comments preceding the
+// statement.
 func CreateTableDefinitions(ctx context.Context, dialect schema.Dialect, t *schema.Table, parent *schema.Table) ([]string, error) {
 	b := &strings.Builder{}
+	writeAnnotations(b, dialect, t)
 
 	// Build a SQL to create a table
-	b.WriteString("CREATE TABLE IF NOT EXISTS " + strconv.Quote(t.Name) + " (\n")
+	b.WriteString("CREATE TABLE IF NOT EXISTS " + dialect.QuoteIdentifier(t.Name) + " (\n")
 
 	for _, c := range dialect.Columns(t) {
 		b.WriteByte('\t')
-		b.WriteString(strconv.Quote(c.Name) + " " + dialect.DBTypeFromType(c.Type))
+		b.WriteString(dialect.QuoteIdentifier(c.Name) + " " + dialect.DBTypeFromType(c.Type))
 		if c.CreationOptions.NotNull {
 			b.WriteString(" NOT NULL")
 		}
@@ -54,3 +57,26 @@ func CreateTableDefinitions(ctx context.Context, dialect schema.Dialect, t *sche
 
 	return up, nil
 }
+
+// writeAnnotations writes a comment line for the table and each of its columns that is deprecated, and for each of its
+// classified columns
+func writeAnnotations(b *strings.Builder, dialect schema.Dialect, t *schema.Table) {
+	if t.Deprecated != "" {
+		b.WriteString(fmt.Sprintf("-- table %s is deprecated: %s\n", dialect.QuoteIdentifier(t.Name), commentText(t.Deprecated)))
+	}
+	for _, c := range t.Columns {
+		if c.Deprecated != "" {
+			b.WriteString(fmt.Sprintf("-- column %s is deprecated: %s\n", dialect.QuoteIdentifier(c.Name), commentText(c.Deprecated)))
+		}
+	}
+	for _, c := range t.Columns {
+		if c.Classification != "" {
+			b.WriteString(fmt.Sprintf("-- column %s is classified as %s\n", dialect.QuoteIdentifier(c.Name), commentText(string(c.Classification))))
+		}
+	}
+}
+
+// commentText returns s on a single line, to be written in a SQL comment
+func commentText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
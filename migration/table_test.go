@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableDefinitions_MySQL(t *testing.T) {
+	up, err := CreateTableDefinitions(context.Background(), schema.MySQLDialect{}, tsdbTestTable, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE TABLE IF NOT EXISTS `test_table` (\n" +
+			"\t`cq_id` char(36) NOT NULL,\n" +
+			"\t`cq_meta` json,\n" +
+			"\t`id` text,\n" +
+			"\t`arn` text,\n" +
+			"\tCONSTRAINT `test_table_pk` PRIMARY KEY(`id`(255)),\n" +
+			"\tUNIQUE(`cq_id`),\n" +
+			"\tUNIQUE(`arn`(255))\n" +
+			");",
+		"CREATE TABLE IF NOT EXISTS `test_table_children` (\n" +
+			"\t`cq_id` char(36) NOT NULL,\n" +
+			"\t`cq_meta` json,\n" +
+			"\t`test_table_cq_id` char(36),\n" +
+			"\t`name` text,\n" +
+			"\tCONSTRAINT `test_table_children_pk` PRIMARY KEY(`cq_id`),\n" +
+			"\tUNIQUE(`cq_id`),\n" +
+			"\tFOREIGN KEY (`test_table_cq_id`) REFERENCES `test_table`(`cq_id`) ON DELETE CASCADE\n" +
+			");",
+	}, up)
+}
+
+func TestCreateTableDefinitions_Annotations(t *testing.T) {
+	table := &schema.Table{
+		Name:       "test_deprecated",
+		Deprecated: "use test_replacement",
+		Columns: []schema.Column{
+			{Name: "id", Type: schema.TypeString},
+			{Name: "old_name", Type: schema.TypeString, Deprecated: "use\nname"},
+			{Name: "email", Type: schema.TypeString, Classification: schema.ClassificationPII},
+		},
+	}
+	up, err := CreateTableDefinitions(context.Background(), schema.PostgresDialect{}, table, nil)
+	require.NoError(t, err)
+	require.Len(t, up, 1)
+	assert.True(t, strings.HasPrefix(up[0], "-- table \"test_deprecated\" is deprecated: use test_replacement\n"+
+		"-- column \"old_name\" is deprecated: use name\n"+
+		"-- column \"email\" is classified as PII\n"+
+		"CREATE TABLE IF NOT EXISTS \"test_deprecated\" (\n"), up[0])
+}
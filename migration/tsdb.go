@@ -0,0 +1,294 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// tsdbFetchDateColumn is the column the timescale dialect adds to every table and primary key
+const tsdbFetchDateColumn = "cq_fetch_date"
+
+const tsdbMigrationHeader = "-- Autogenerated from the %s migration %s by migration.WriteTSDBMigrations, DO NOT EDIT.\n\n"
+
+var (
+	createTableRe = regexp.MustCompile(`(?is)^CREATE\s+TABLE\s+(IF\s+NOT\s+EXISTS\s+)?("[^"]+"|[^\s(]+)\s*\((.*)\)$`)
+	foreignKeyRe  = regexp.MustCompile(`(?is)FOREIGN\s+KEY\s*\(\s*("[^"]+"|[^\s)]+)\s*\)\s*REFERENCES\s+("[^"]+"|[^\s(]+)`)
+	primaryKeyRe  = regexp.MustCompile(`(?is)^((CONSTRAINT\s+("[^"]+"|\S+)\s+)?PRIMARY\s+KEY\s*\()`)
+	uniqueRe      = regexp.MustCompile(`(?is)^(UNIQUE\s*\()`)
+	alterFKRe     = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s.*\sFOREIGN\s+KEY\s`)
+	renameFKRe    = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s.*\sRENAME\s+CONSTRAINT\s+("[^"]+_fkey"|\S+_fkey)\s`)
+	alterKeyRe    = regexp.MustCompile(`(?is)^(ALTER\s+TABLE\s.*\sADD\s+(CONSTRAINT\s+("[^"]+"|\S+)\s+)?(PRIMARY\s+KEY|UNIQUE)\s*\()([^)]*)\)`)
+)
+
+// WriteTSDBMigrations derives the timescale migration files from the postgres migration files under dir, which is
+// laid out as expected by migrator.ReadMigrationFiles, and writes them to the timescale dialect directory, replacing
+// the files derived before. Providers maintaining both dialects only need to edit their postgres migrations.
+func WriteTSDBMigrations(dir string) error {
+	return writeTSDBMigrations(dir, nil)
+}
+
+// writeTSDBMigrations writes the timescale migrations as WriteTSDBMigrations, the tables they create are set up for
+// history mode if history is set
+func writeTSDBMigrations(dir string, history *execution.HistoryConfig) error {
+	pgDir := filepath.Join(dir, schema.Postgres.MigrationDirectory())
+	entries, err := os.ReadDir(pgDir)
+	if err != nil {
+		return err
+	}
+	files := make(map[string][]byte, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(pgDir, e.Name()))
+		if err != nil {
+			return err
+		}
+		files[e.Name()] = data
+	}
+	derived := tsdbMigrations(files, history)
+
+	tsdbDir := filepath.Join(dir, schema.TSDB.MigrationDirectory())
+	if err := os.MkdirAll(tsdbDir, 0755); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(derived))
+	for name := range derived {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(tsdbDir, name), derived[name], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TSDBMigrations derives timescale migration files from the given postgres migration files, keyed by file name
+func TSDBMigrations(files map[string][]byte) map[string][]byte {
+	return tsdbMigrations(files, nil)
+}
+
+func tsdbMigrations(files map[string][]byte, history *execution.HistoryConfig) map[string][]byte {
+	ret := make(map[string][]byte, len(files))
+	for name, data := range files {
+		ret[name] = append([]byte(fmt.Sprintf(tsdbMigrationHeader, schema.Postgres, name)), tsdbMigration(data, history)...)
+	}
+	return ret
+}
+
+// TSDBMigration derives a timescale migration from a postgres migration, in the form CreateTableDefinitions would
+// create the tables with schema.TSDBDialect:
+//
+//   - cq_fetch_date is added to created tables, and prepended to their primary key and unique constraints
+//   - foreign keys are stripped, relations are indexed by cq_fetch_date and their parent id instead
+//   - created tables are set up as hypertables with setup_tsdb_parent and setup_tsdb_child
+//   - cq_fetch_date is prepended to the primary keys and unique constraints added to existing tables
+//
+// Statements that add or rename foreign keys of existing tables are replaced with a comment, other statements are
+// kept as is.
+func TSDBMigration(data []byte) []byte {
+	return tsdbMigration(data, nil)
+}
+
+func tsdbMigration(data []byte, history *execution.HistoryConfig) []byte {
+	derived := tsdbStatements(splitStatements(string(data)), history)
+	if len(derived) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(derived, "\n") + "\n")
+}
+
+func tsdbStatements(stmts []string, history *execution.HistoryConfig) []string {
+	ret := make([]string, 0, len(stmts))
+	for _, s := range stmts {
+		comments, stmt := splitLeadingComments(s)
+		if stmt == "" {
+			ret = append(ret, comments)
+			continue
+		}
+		var derived []string
+		switch {
+		case createTableRe.MatchString(stmt):
+			derived = tsdbCreateTable(stmt, history)
+		case alterFKRe.MatchString(stmt), renameFKRe.MatchString(stmt):
+			derived = []string{"-- foreign keys aren't supported by timescale, omitted: " + strings.Join(strings.Fields(stmt), " ") + ";"}
+		case alterKeyRe.MatchString(stmt):
+			derived = []string{tsdbAlterKey(stmt) + ";"}
+		default:
+			derived = []string{stmt + ";"}
+		}
+		if comments != "" {
+			derived[0] = comments + "\n" + derived[0]
+		}
+		ret = append(ret, derived...)
+	}
+	return ret
+}
+
+// tsdbAlterKey prepends cq_fetch_date to the columns of the primary key or unique constraint added by the ALTER TABLE
+// statement, as they are in created tables
+func tsdbAlterKey(stmt string) string {
+	m := alterKeyRe.FindStringSubmatch(stmt)
+	for _, c := range strings.Split(m[5], ",") {
+		if unquote(strings.TrimSpace(c)) == tsdbFetchDateColumn {
+			return stmt
+		}
+	}
+	return alterKeyRe.ReplaceAllString(stmt, "${1}"+schema.QuoteIdentifier(tsdbFetchDateColumn)+",${5})")
+}
+
+// tsdbCreateTable converts a postgres CREATE TABLE statement, returning it along with the statements setting up the
+// table, and partitioning it for history mode if history is set
+func tsdbCreateTable(stmt string, history *execution.HistoryConfig) []string {
+	m := createTableRe.FindStringSubmatch(stmt)
+	table := m[2]
+	elems := splitTopLevel(m[3])
+
+	var (
+		ret                = make([]string, 0, len(elems)+1)
+		parentCol, parent  string
+		hasFetchDate       bool
+		fetchDateInsertIdx = 0
+	)
+	for _, e := range elems {
+		switch {
+		case foreignKeyRe.MatchString(e):
+			fk := foreignKeyRe.FindStringSubmatch(e)
+			parentCol, parent = fk[1], fk[2]
+			continue
+		case primaryKeyRe.MatchString(e):
+			e = primaryKeyRe.ReplaceAllString(e, "${1}"+schema.QuoteIdentifier(tsdbFetchDateColumn)+",")
+		case uniqueRe.MatchString(e):
+			e = uniqueRe.ReplaceAllString(e, "${1}"+schema.QuoteIdentifier(tsdbFetchDateColumn)+",")
+		default:
+			switch unquote(strings.Fields(e)[0]) {
+			case tsdbFetchDateColumn:
+				hasFetchDate = true
+			case "cq_id", "cq_meta":
+				fetchDateInsertIdx = len(ret) + 1
+			}
+		}
+		ret = append(ret, e)
+	}
+	if !hasFetchDate {
+		col := schema.QuoteIdentifier(tsdbFetchDateColumn) + " timestamp without time zone NOT NULL"
+		ret = append(ret[:fetchDateInsertIdx], append([]string{col}, ret[fetchDateInsertIdx:]...)...)
+	}
+
+	create := "CREATE TABLE " + m[1] + table + " (\n\t" + strings.Join(ret, ",\n\t") + "\n);"
+	var stmts []string
+	if parent == "" {
+		stmts = []string{create, fmt.Sprintf("SELECT setup_tsdb_parent('%s');", unquote(table))}
+	} else {
+		stmts = []string{
+			create,
+			fmt.Sprintf("CREATE INDEX ON %s (%s, %s);", table, schema.QuoteIdentifier(tsdbFetchDateColumn), parentCol),
+			fmt.Sprintf("SELECT setup_tsdb_child('%s', '%s', '%s', 'cq_id');", unquote(table), unquote(parentCol), unquote(parent)),
+		}
+	}
+	if history != nil {
+		stmts = append(stmts, execution.HistoryStatements(unquote(table), *history)...)
+	}
+	return stmts
+}
+
+// splitStatements splits SQL into statements without their terminating semicolons, ignoring semicolons in quotes and comments
+func splitStatements(sql string) []string {
+	var (
+		ret   []string
+		b     strings.Builder
+		quote rune
+	)
+	runes := []rune(sql)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for ; i < len(runes) && runes[i] != '\n'; i++ {
+				b.WriteRune(runes[i])
+			}
+			if i < len(runes) {
+				b.WriteRune('\n')
+			}
+			continue
+		case r == ';':
+			if s := strings.TrimSpace(b.String()); s != "" {
+				ret = append(ret, s)
+			}
+			b.Reset()
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if s := strings.TrimSpace(b.String()); s != "" {
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// splitLeadingComments splits the comment lines preceding a statement from it
+func splitLeadingComments(s string) (comments, stmt string) {
+	lines := strings.Split(s, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		if l := strings.TrimSpace(lines[i]); l != "" && !strings.HasPrefix(l, "--") {
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines[:i], "\n")), strings.TrimSpace(strings.Join(lines[i:], "\n"))
+}
+
+// splitTopLevel splits the column and constraint definitions of a CREATE TABLE statement
+func splitTopLevel(s string) []string {
+	var (
+		ret   []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			if e := strings.TrimSpace(s[start:i]); e != "" {
+				ret = append(ret, e)
+			}
+			start = i + 1
+		}
+	}
+	if e := strings.TrimSpace(s[start:]); e != "" {
+		ret = append(ret, e)
+	}
+	return ret
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package migration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tsdbTestTable = &schema.Table{
+	Name:    "test_table",
+	Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+	Columns: []schema.Column{
+		{Name: "id", Type: schema.TypeString},
+		{Name: "arn", Type: schema.TypeString, CreationOptions: schema.ColumnCreationOptions{Unique: true}},
+	},
+	Relations: []*schema.Table{
+		{
+			Name: "test_table_children",
+			Columns: []schema.Column{
+				{Name: "test_table_cq_id", Type: schema.TypeUUID, Resolver: schema.ParentIdResolver},
+				{Name: "name", Type: schema.TypeString},
+			},
+		},
+	},
+}
+
+func TestTSDBMigration(t *testing.T) {
+	ctx := context.Background()
+	pgUp, err := CreateTableDefinitions(ctx, schema.PostgresDialect{}, tsdbTestTable, nil)
+	require.NoError(t, err)
+	tsdbUp, err := CreateTableDefinitions(ctx, schema.TSDBDialect{}, tsdbTestTable, nil)
+	require.NoError(t, err)
+
+	derived := TSDBMigration([]byte(strings.Join(pgUp, "\n")))
+	assert.Equal(t, strings.Join(tsdbUp, "\n")+"\n", string(derived))
+}
+
+func TestTSDBMigration_History(t *testing.T) {
+	ctx := context.Background()
+	pgUp, err := CreateTableDefinitions(ctx, schema.PostgresDialect{}, tsdbTestTable, nil)
+	require.NoError(t, err)
+	tsdbUp, err := CreateTableDefinitions(ctx, schema.TSDBDialect{}, tsdbTestTable, nil)
+	require.NoError(t, err)
+
+	cfg := execution.HistoryConfig{Retention: 7}
+	var expected []string
+	for _, stmt := range tsdbUp {
+		expected = append(expected, stmt)
+		switch stmt {
+		case "SELECT setup_tsdb_parent('test_table');":
+			expected = append(expected, execution.HistoryStatements("test_table", cfg)...)
+		case "SELECT setup_tsdb_child('test_table_children', 'test_table_cq_id', 'test_table', 'cq_id');":
+			expected = append(expected, execution.HistoryStatements("test_table_children", cfg)...)
+		}
+	}
+	derived := tsdbMigration([]byte(strings.Join(pgUp, "\n")), &cfg)
+	assert.Equal(t, strings.Join(expected, "\n")+"\n", string(derived))
+	assert.Contains(t, string(derived), "SELECT add_retention_policy('test_table_children', INTERVAL '7 days');")
+}
+
+func TestRun_HistoryRequiresTSDB(t *testing.T) {
+	_, err := Run(context.Background(), RunOptions{Version: "v0.0.1", History: &execution.HistoryConfig{}})
+	assert.EqualError(t, err, "history mode requires the timescale migrations, TSDB must be set")
+}
+
+func TestTSDBMigration_Statements(t *testing.T) {
+	derived := TSDBMigration([]byte(`-- add column
+ALTER TABLE "test_table" ADD COLUMN IF NOT EXISTS "name" text;
+ALTER TABLE "test_table_children" ADD CONSTRAINT "fk" FOREIGN KEY ("test_table_cq_id") REFERENCES "test_table"("cq_id");
+UPDATE "test_table" SET "name" = 'a;b';
+ALTER TABLE "test_table_children" RENAME CONSTRAINT "old_children_test_table_cq_id_fkey" TO "test_table_children_test_table_cq_id_fkey";
+ALTER TABLE "test_table" ADD CONSTRAINT "test_table_pk" PRIMARY KEY ("id", "name");
+ALTER TABLE "test_table" ADD UNIQUE("arn");
+ALTER TABLE "test_table" ADD CONSTRAINT "test_table_name_key" UNIQUE ("cq_fetch_date", "name");
+`))
+	assert.Equal(t, `-- add column
+ALTER TABLE "test_table" ADD COLUMN IF NOT EXISTS "name" text;
+-- foreign keys aren't supported by timescale, omitted: ALTER TABLE "test_table_children" ADD CONSTRAINT "fk" FOREIGN KEY ("test_table_cq_id") REFERENCES "test_table"("cq_id");
+UPDATE "test_table" SET "name" = 'a;b';
+-- foreign keys aren't supported by timescale, omitted: ALTER TABLE "test_table_children" RENAME CONSTRAINT "old_children_test_table_cq_id_fkey" TO "test_table_children_test_table_cq_id_fkey";
+ALTER TABLE "test_table" ADD CONSTRAINT "test_table_pk" PRIMARY KEY ("cq_fetch_date","id", "name");
+ALTER TABLE "test_table" ADD UNIQUE("cq_fetch_date","arn");
+ALTER TABLE "test_table" ADD CONSTRAINT "test_table_name_key" UNIQUE ("cq_fetch_date", "name");
+`, string(derived))
+}
+
+func TestWriteTSDBMigrations(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "postgres"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "postgres", "1_v0.0.1.down.sql"), []byte(`DROP TABLE IF EXISTS "test_table";`), 0644))
+
+	require.NoError(t, WriteTSDBMigrations(dir))
+	data, err := os.ReadFile(filepath.Join(dir, "timescale", "1_v0.0.1.down.sql"))
+	require.NoError(t, err)
+	assert.Equal(t, "-- Autogenerated from the postgres migration 1_v0.0.1.down.sql by migration.WriteTSDBMigrations, DO NOT EDIT.\n\nDROP TABLE IF EXISTS \"test_table\";\n", string(data))
+}
@@ -0,0 +1,267 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// GenerateDiff reads the existing columns of schema.Table and its relations from the database, and returns the
+// statements to upgrade them, see UpgradeTable.
+func GenerateDiff(ctx context.Context, q pgxscan.Querier, dialect schema.Dialect, t *schema.Table) (up, down []string, err error) {
+	return GenerateDiffWithOptions(ctx, q, dialect, t, UpgradeOptions{})
+}
+
+// GenerateDiffWithOptions returns the statements to upgrade schema.Table as GenerateDiff, configured by opts
+func GenerateDiffWithOptions(ctx context.Context, q pgxscan.Querier, dialect schema.Dialect, t *schema.Table, opts UpgradeOptions) (up, down []string, err error) {
+	existing, err := ReadTableColumns(ctx, q, upgradeTableNames(t))
+	if err != nil {
+		return nil, nil, err
+	}
+	return UpgradeTableWithOptions(ctx, dialect, t, nil, existing, opts)
+}
+
+// upgradeTableNames returns the names of the table and its relations, along with the Table.RenamedFrom names they may
+// still exist under
+func upgradeTableNames(t *schema.Table) []string {
+	names := []string{t.Name}
+	if t.RenamedFrom != "" {
+		names = append(names, t.RenamedFrom)
+	}
+	for _, r := range t.Relations {
+		names = append(names, upgradeTableNames(r)...)
+	}
+	return names
+}
+
+// UpgradeOptions configure UpgradeTableWithOptions
+type UpgradeOptions struct {
+	// DropUnknownColumns drops existing columns that aren't columns of the table, i.e columns the provider removed,
+	// instead of keeping them. By default they're kept, as they may be columns users added to the provider's tables,
+	// and each kept column is reported in a comment of the up statements.
+	DropUnknownColumns bool
+}
+
+// UpgradeTable compares schema.Table (and its relations) to the existing database columns and builds the statements to
+// upgrade the database to it. Down statements revert the upgrade and are returned in the order they should be executed.
+//
+// Columns declaring Column.RenamedFrom are renamed in place. For other added columns a similarly named column of the
+// same type that isn't a column of the table is suggested as a possible rename, in a comment. Existing columns that
+// aren't columns of the table are kept, see UpgradeOptions.DropUnknownColumns.
+func UpgradeTable(ctx context.Context, dialect schema.Dialect, t *schema.Table, parent *schema.Table, existing TableColumns) (up, down []string, err error) {
+	return UpgradeTableWithOptions(ctx, dialect, t, parent, existing, UpgradeOptions{})
+}
+
+// UpgradeTableWithOptions upgrades schema.Table as UpgradeTable, configured by opts
+func UpgradeTableWithOptions(ctx context.Context, dialect schema.Dialect, t *schema.Table, parent *schema.Table, existing TableColumns, opts UpgradeOptions) (up, down []string, err error) {
+	cols, ok := existing[t.Name]
+	var renameUp, renameDown []string
+	if !ok && t.RenamedFrom != "" {
+		if cols, ok = existing[t.RenamedFrom]; ok {
+			renameUp, renameDown = renameTable(dialect, t, parent)
+		}
+	}
+	if !ok {
+		up, err = CreateTableDefinitions(ctx, dialect, t, parent)
+		if err != nil {
+			return nil, nil, err
+		}
+		names := tableNames(t)
+		for i := len(names) - 1; i >= 0; i-- {
+			down = append(down, "DROP TABLE IF EXISTS "+dialect.QuoteIdentifier(names[i])+";")
+		}
+		return up, down, nil
+	}
+
+	tableName := dialect.QuoteIdentifier(t.Name)
+	up = append(up, renameUp...)
+	wanted := make(map[string]bool)
+	for _, c := range dialect.Columns(t) {
+		wanted[c.Name] = true
+	}
+	// renamed holds existing columns that are renamed, so they aren't dropped
+	renamed := make(map[string]bool)
+	var added []schema.Column
+	for _, c := range dialect.Columns(t) {
+		want := normalizeDBType(dialect.DBTypeFromType(c.Type))
+		if dbType, ok := cols[c.Name]; ok {
+			if dbType != want {
+				up = append(up, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", tableName, dialect.QuoteIdentifier(c.Name), want))
+				down = append(down, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", tableName, dialect.QuoteIdentifier(c.Name), dbType))
+			}
+			continue
+		}
+		if from := renamedFrom(c, cols, wanted, renamed); from != "" {
+			renamed[from] = true
+			up = append(up, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", tableName, dialect.QuoteIdentifier(from), dialect.QuoteIdentifier(c.Name)))
+			down = append(down, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", tableName, dialect.QuoteIdentifier(c.Name), dialect.QuoteIdentifier(from)))
+			if dbType := cols[from]; dbType != want {
+				up = append(up, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", tableName, dialect.QuoteIdentifier(c.Name), want))
+				down = append(down, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", tableName, dialect.QuoteIdentifier(c.Name), dbType))
+			}
+			continue
+		}
+		added = append(added, c)
+	}
+
+	dropped := make(map[string]string)
+	for name, dbType := range cols {
+		if !wanted[name] && !renamed[name] {
+			dropped[name] = dbType
+		}
+	}
+
+	for _, c := range added {
+		want := normalizeDBType(dialect.DBTypeFromType(c.Type))
+		if similar := similarColumn(c.Name, want, dropped); similar != "" {
+			up = append(up, fmt.Sprintf("-- column %s may have been renamed from %s, if so declare it in Column.RenamedFrom to keep its data", dialect.QuoteIdentifier(c.Name), dialect.QuoteIdentifier(similar)))
+		}
+		up = append(up, fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s;", tableName, dialect.QuoteIdentifier(c.Name), want))
+		down = append(down, fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;", tableName, dialect.QuoteIdentifier(c.Name)))
+	}
+
+	for _, name := range sortedKeys(dropped) {
+		if !opts.DropUnknownColumns {
+			up = append(up, fmt.Sprintf("-- column %s isn't a column of the provider, it's kept", dialect.QuoteIdentifier(name)))
+			continue
+		}
+		up = append(up, fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s;", tableName, dialect.QuoteIdentifier(name)))
+		down = append(down, fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s;", tableName, dialect.QuoteIdentifier(name), dropped[name]))
+	}
+
+	// relations are upgraded after their parent, and reverted before it
+	var relationsDown []string
+	for _, r := range t.Relations {
+		rUp, rDown, err := UpgradeTableWithOptions(ctx, dialect, r, t, existing, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, rUp...)
+		relationsDown = append(rDown, relationsDown...)
+	}
+	return up, append(append(relationsDown, reverse(down)...), renameDown...), nil
+}
+
+// renameTable returns the statements to rename table from its RenamedFrom name, along with the constraints created by
+// the dialect, which are named after the table.
+func renameTable(dialect schema.Dialect, t *schema.Table, parent *schema.Table) (up, down []string) {
+	from, to := dialect.QuoteIdentifier(t.RenamedFrom), dialect.QuoteIdentifier(t.Name)
+	up = append(up, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", from, to))
+	down = append(down, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", to, from))
+
+	// primary key and unique constraints are backed by indexes of the same name, renaming the index renames the constraint
+	indexes := [][2]string{{schema.PrimaryKeyConstraintName(t.RenamedFrom), schema.PrimaryKeyConstraintName(t.Name)}}
+	for _, c := range dialect.Columns(t) {
+		if c.CreationOptions.Unique {
+			indexes = append(indexes, [2]string{t.RenamedFrom + "_" + c.Name + "_key", t.Name + "_" + c.Name + "_key"})
+		}
+	}
+	for _, idx := range indexes {
+		up = append(up, fmt.Sprintf("ALTER INDEX IF EXISTS %s RENAME TO %s;", dialect.QuoteIdentifier(idx[0]), dialect.QuoteIdentifier(idx[1])))
+		down = append(down, fmt.Sprintf("ALTER INDEX IF EXISTS %s RENAME TO %s;", dialect.QuoteIdentifier(idx[1]), dialect.QuoteIdentifier(idx[0])))
+	}
+	if parent != nil {
+		if pc := parentIdColumn(t); pc != "" {
+			fromFK, toFK := dialect.QuoteIdentifier(t.RenamedFrom+"_"+pc+"_fkey"), dialect.QuoteIdentifier(t.Name+"_"+pc+"_fkey")
+			up = append(up, fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s TO %s;", to, fromFK, toFK))
+			down = append(down, fmt.Sprintf("ALTER TABLE %s RENAME CONSTRAINT %s TO %s;", to, toFK, fromFK))
+		}
+	}
+	// down statements are executed in reverse order, the table is renamed back last
+	return up, reverse(down)
+}
+
+func parentIdColumn(t *schema.Table) string {
+	for _, c := range t.Columns {
+		if c.Meta().Resolver != nil && c.Meta().Resolver.Name == "schema.ParentIdResolver" {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+// renamedFrom returns the first of the column's previous names that exists in the database and isn't used otherwise
+func renamedFrom(c schema.Column, cols map[string]string, wanted, renamed map[string]bool) string {
+	for _, from := range c.RenamedFrom {
+		if _, ok := cols[from]; ok && !wanted[from] && !renamed[from] {
+			return from
+		}
+	}
+	return ""
+}
+
+// similarColumn returns the column of the given type from candidates whose name is closest to name, if it's similar enough
+func similarColumn(name, dbType string, candidates map[string]string) string {
+	var (
+		best     string
+		bestDist int
+	)
+	for _, c := range sortedKeys(candidates) {
+		if candidates[c] != dbType {
+			continue
+		}
+		d := levenshtein(name, c)
+		maxLen := len(name)
+		if len(c) > maxLen {
+			maxLen = len(c)
+		}
+		// names are similar if one contains the other (i.e region and region_name), or they differ by up to a third
+		if d*3 > maxLen && !strings.Contains(name, c) && !strings.Contains(c, name) {
+			continue
+		}
+		if best == "" || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+	return v
+}
+
+func reverse(s []string) []string {
+	ret := make([]string, len(s))
+	for i, v := range s {
+		ret[len(s)-1-i] = v
+	}
+	return ret
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,233 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// columnsQuerier answers the columns queries of ReadTableColumns with its columns, for the tables queried
+type columnsQuerier struct {
+	columns TableColumns
+	tables  []string
+}
+
+func (q *columnsQuerier) Query(_ context.Context, _ string, args ...interface{}) (pgx.Rows, error) {
+	q.tables = args[0].([]string)
+	rows := &columnRows{i: -1}
+	for _, name := range q.tables {
+		for c, typ := range q.columns[name] {
+			rows.rows = append(rows.rows, [3]string{name, c, typ})
+		}
+	}
+	return rows, nil
+}
+
+// columnRows are the table_name, column_name and data_type rows of columnsQuerier
+type columnRows struct {
+	pgx.Rows
+	rows [][3]string
+	i    int
+}
+
+func (r *columnRows) FieldDescriptions() []pgproto3.FieldDescription {
+	return []pgproto3.FieldDescription{{Name: []byte("table_name")}, {Name: []byte("column_name")}, {Name: []byte("data_type")}}
+}
+
+func (r *columnRows) Next() bool {
+	r.i++
+	return r.i < len(r.rows)
+}
+
+func (r *columnRows) Scan(dest ...interface{}) error {
+	if len(dest) != 3 {
+		return fmt.Errorf("expected 3 destinations, got %d", len(dest))
+	}
+	for i, v := range r.rows[r.i] {
+		*dest[i].(*string) = v
+	}
+	return nil
+}
+
+func (r *columnRows) Err() error { return nil }
+
+func (r *columnRows) Close() {}
+
+func TestUpgradeTable(t *testing.T) {
+	table := &schema.Table{
+		Name: "test_table",
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{Name: "account_id", Type: schema.TypeString, RenamedFrom: []string{"account", "acc"}},
+			{Name: "region_name", Type: schema.TypeString},
+		},
+	}
+	tests := []struct {
+		name     string
+		existing TableColumns
+		opts     UpgradeOptions
+		up       []string
+		down     []string
+	}{
+		{
+			name: "up to date",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "account_id": "text", "region_name": "text"},
+			},
+		},
+		{
+			name: "declared rename",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "acc": "text", "region_name": "text"},
+			},
+			up:   []string{`ALTER TABLE "test_table" RENAME COLUMN "acc" TO "account_id";`},
+			down: []string{`ALTER TABLE "test_table" RENAME COLUMN "account_id" TO "acc";`},
+		},
+		{
+			name: "similar column suggested",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "account_id": "text", "region": "text"},
+			},
+			up: []string{
+				`-- column "region_name" may have been renamed from "region", if so declare it in Column.RenamedFrom to keep its data`,
+				`ALTER TABLE "test_table" ADD COLUMN IF NOT EXISTS "region_name" text;`,
+				`-- column "region" isn't a column of the provider, it's kept`,
+			},
+			down: []string{
+				`ALTER TABLE "test_table" DROP COLUMN IF EXISTS "region_name";`,
+			},
+		},
+		{
+			name: "similar column suggested and dropped",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "account_id": "text", "region": "text"},
+			},
+			opts: UpgradeOptions{DropUnknownColumns: true},
+			up: []string{
+				`-- column "region_name" may have been renamed from "region", if so declare it in Column.RenamedFrom to keep its data`,
+				`ALTER TABLE "test_table" ADD COLUMN IF NOT EXISTS "region_name" text;`,
+				`ALTER TABLE "test_table" DROP COLUMN IF EXISTS "region";`,
+			},
+			down: []string{
+				`ALTER TABLE "test_table" ADD COLUMN IF NOT EXISTS "region" text;`,
+				`ALTER TABLE "test_table" DROP COLUMN IF EXISTS "region_name";`,
+			},
+		},
+		{
+			name: "type change",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "integer", "account_id": "text", "region_name": "text"},
+			},
+			up:   []string{`ALTER TABLE "test_table" ALTER COLUMN "name" TYPE text;`},
+			down: []string{`ALTER TABLE "test_table" ALTER COLUMN "name" TYPE integer;`},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			up, down, err := UpgradeTableWithOptions(context.Background(), schema.PostgresDialect{}, table, nil, tc.existing, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.up, up)
+			assert.Equal(t, tc.down, down)
+		})
+	}
+}
+
+func TestUpgradeTableCreatesMissingTable(t *testing.T) {
+	up, down, err := UpgradeTable(context.Background(), schema.PostgresDialect{}, validateTestTable, nil, TableColumns{})
+	require.NoError(t, err)
+	assert.Len(t, up, 2)
+	assert.Equal(t, []string{`DROP TABLE IF EXISTS "test_table_relation";`, `DROP TABLE IF EXISTS "test_table";`}, down)
+}
+
+func TestUpgradeTableRename(t *testing.T) {
+	table := &schema.Table{
+		Name:        "test_service_items",
+		RenamedFrom: "test_items",
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString, CreationOptions: schema.ColumnCreationOptions{Unique: true}},
+		},
+	}
+	up, down, err := UpgradeTableWithOptions(context.Background(), schema.PostgresDialect{}, table, nil, TableColumns{
+		"test_items": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "old": "text"},
+	}, UpgradeOptions{DropUnknownColumns: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`ALTER TABLE "test_items" RENAME TO "test_service_items";`,
+		`ALTER INDEX IF EXISTS "test_items_pk" RENAME TO "test_service_items_pk";`,
+		`ALTER INDEX IF EXISTS "test_items_cq_id_key" RENAME TO "test_service_items_cq_id_key";`,
+		`ALTER INDEX IF EXISTS "test_items_name_key" RENAME TO "test_service_items_name_key";`,
+		`ALTER TABLE "test_service_items" DROP COLUMN IF EXISTS "old";`,
+	}, up)
+	assert.Equal(t, []string{
+		`ALTER TABLE "test_service_items" ADD COLUMN IF NOT EXISTS "old" text;`,
+		`ALTER INDEX IF EXISTS "test_service_items_name_key" RENAME TO "test_items_name_key";`,
+		`ALTER INDEX IF EXISTS "test_service_items_cq_id_key" RENAME TO "test_items_cq_id_key";`,
+		`ALTER INDEX IF EXISTS "test_service_items_pk" RENAME TO "test_items_pk";`,
+		`ALTER TABLE "test_service_items" RENAME TO "test_items";`,
+	}, down)
+}
+
+func TestUpgradeTableKeepUnknownColumns(t *testing.T) {
+	table := &schema.Table{
+		Name: "test_table",
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+		},
+		Relations: []*schema.Table{
+			{
+				Name: "test_table_relation",
+				Columns: []schema.Column{
+					{Name: "test_table_cq_id", Type: schema.TypeUUID},
+					{Name: "value", Type: schema.TypeInt},
+				},
+			},
+		},
+	}
+	up, down, err := UpgradeTable(context.Background(), schema.PostgresDialect{}, table, nil, TableColumns{
+		"test_table":          {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "owner": "text"},
+		"test_table_relation": {"cq_id": "uuid", "cq_meta": "jsonb", "test_table_cq_id": "uuid", "value": "integer", "note": "text"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`-- column "owner" isn't a column of the provider, it's kept`,
+		`-- column "note" isn't a column of the provider, it's kept`,
+	}, up)
+	assert.Empty(t, down)
+}
+
+func TestGenerateDiffRename(t *testing.T) {
+	table := &schema.Table{
+		Name:        "test_service_items",
+		RenamedFrom: "test_items",
+		Columns:     []schema.Column{{Name: "name", Type: schema.TypeString}},
+		Relations: []*schema.Table{
+			{
+				Name:        "test_service_item_tags",
+				RenamedFrom: "test_item_tags",
+				Columns: []schema.Column{
+					{Name: "test_service_item_cq_id", Type: schema.TypeUUID, RenamedFrom: []string{"test_item_cq_id"}},
+				},
+			},
+		},
+	}
+	q := &columnsQuerier{columns: TableColumns{
+		"test_items":     {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text"},
+		"test_item_tags": {"cq_id": "uuid", "cq_meta": "jsonb", "test_item_cq_id": "uuid"},
+	}}
+	up, down, err := GenerateDiff(context.Background(), q, schema.PostgresDialect{}, table)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test_service_items", "test_items", "test_service_item_tags", "test_item_tags"}, q.tables)
+	assert.Contains(t, up, `ALTER TABLE "test_items" RENAME TO "test_service_items";`)
+	assert.Contains(t, up, `ALTER TABLE "test_item_tags" RENAME TO "test_service_item_tags";`)
+	assert.Contains(t, up, `ALTER TABLE "test_service_item_tags" RENAME COLUMN "test_item_cq_id" TO "test_service_item_cq_id";`)
+	assert.Contains(t, down, `ALTER TABLE "test_service_items" RENAME TO "test_items";`)
+	for _, s := range up {
+		assert.NotContains(t, s, "CREATE TABLE")
+	}
+}
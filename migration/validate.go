@@ -0,0 +1,196 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+const upgradeRemediation = "The database schema is out of date with the provider, run `cloudquery provider upgrade` (or `drop` and re-sync) before fetching"
+
+// listColumnsQuery lists the columns and formatted types of the given tables in the current schema
+const listColumnsQuery = `SELECT c.relname AS table_name, a.attname AS column_name, pg_catalog.format_type(a.atttypid, a.atttypmod) AS data_type
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped AND c.relname = ANY($1)`
+
+// listRequiredColumnsQuery lists the NOT NULL columns without a default value of the given tables in the current schema
+const listRequiredColumnsQuery = `SELECT c.relname AS table_name, a.attname AS column_name
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped AND a.attnotnull AND NOT a.atthasdef AND c.relname = ANY($1)`
+
+// TableColumns maps table name to column name to the database type of the column
+type TableColumns map[string]map[string]string
+
+// dbTypeAliases maps types returned by Dialect.DBTypeFromType to the canonical form the database reports them in
+var dbTypeAliases = map[string]string{
+	"float": "double precision",
+	"mac":   "macaddr",
+	"mac[]": "macaddr[]",
+}
+
+// SupportsValidation returns true if ValidateTables can validate the tables of the dialect, that is the postgres and
+// timescale dialects, as the columns are read from the postgres catalog
+func SupportsValidation(dialect schema.Dialect) bool {
+	if td, ok := dialect.(schema.TenantDialect); ok {
+		dialect = td.Dialect
+	}
+	switch dialect.(type) {
+	case schema.PostgresDialect, schema.TSDBDialect:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateTables introspects the database and validates that every table in resources (and their relations) exists with
+// all the columns the dialect expects, and with matching types. Columns users added to the tables are allowed, unless
+// they are NOT NULL without a default value, as inserts leave them NULL. Each problem found is returned as a USER
+// diagnostic. Dialects that aren't supported, see SupportsValidation, aren't validated and return a warning.
+func ValidateTables(ctx context.Context, q pgxscan.Querier, dialect schema.Dialect, resources map[string]*schema.Table) diag.Diagnostics {
+	if !SupportsValidation(dialect) {
+		return diag.FromError(fmt.Errorf("tables of dialect %T can't be validated, only postgres databases are supported", dialect), diag.DATABASE,
+			diag.WithSeverity(diag.WARNING), diag.WithSummary("database schema wasn't validated"))
+	}
+	var names []string
+	for _, t := range resources {
+		names = append(names, tableNames(t)...)
+	}
+	existing, err := ReadTableColumns(ctx, q, names)
+	if err != nil {
+		return diag.FromError(err, diag.DATABASE, diag.WithSummary("failed to read database schema"))
+	}
+	required, err := readRequiredColumns(ctx, q, names)
+	if err != nil {
+		return diag.FromError(err, diag.DATABASE, diag.WithSummary("failed to read database schema"))
+	}
+
+	resourceNames := make([]string, 0, len(resources))
+	for r := range resources {
+		resourceNames = append(resourceNames, r)
+	}
+	sort.Strings(resourceNames)
+
+	var diags diag.Diagnostics
+	for _, r := range resourceNames {
+		diags = diags.Add(validateTable(r, dialect, resources[r], existing))
+		diags = diags.Add(validateUserColumns(r, dialect, resources[r], required))
+	}
+	return diags
+}
+
+// ReadTableColumns returns the columns of the given tables as they exist in the database. Tables that don't exist are omitted.
+func ReadTableColumns(ctx context.Context, q pgxscan.Querier, tables []string) (TableColumns, error) {
+	var rows []struct {
+		TableName  string `db:"table_name"`
+		ColumnName string `db:"column_name"`
+		DataType   string `db:"data_type"`
+	}
+	if err := pgxscan.Select(ctx, q, &rows, listColumnsQuery, tables); err != nil {
+		return nil, err
+	}
+	ret := make(TableColumns)
+	for _, r := range rows {
+		if ret[r.TableName] == nil {
+			ret[r.TableName] = make(map[string]string)
+		}
+		ret[r.TableName][r.ColumnName] = r.DataType
+	}
+	return ret, nil
+}
+
+// readRequiredColumns returns the NOT NULL columns without a default value of the given tables
+func readRequiredColumns(ctx context.Context, q pgxscan.Querier, tables []string) (map[string][]string, error) {
+	var rows []struct {
+		TableName  string `db:"table_name"`
+		ColumnName string `db:"column_name"`
+	}
+	if err := pgxscan.Select(ctx, q, &rows, listRequiredColumnsQuery, tables); err != nil {
+		return nil, err
+	}
+	ret := make(map[string][]string)
+	for _, r := range rows {
+		ret[r.TableName] = append(ret[r.TableName], r.ColumnName)
+	}
+	return ret, nil
+}
+
+func validateTable(resourceName string, dialect schema.Dialect, t *schema.Table, existing TableColumns) diag.Diagnostics {
+	var diags diag.Diagnostics
+	cols, ok := existing[t.Name]
+	if !ok {
+		return diags.Add(schemaMismatch(resourceName, fmt.Errorf("table %q does not exist", t.Name)))
+	}
+	for _, c := range dialect.Columns(t) {
+		dbType, ok := cols[c.Name]
+		if !ok {
+			diags = diags.Add(schemaMismatch(resourceName, fmt.Errorf("table %q is missing column %q", t.Name, c.Name)))
+			continue
+		}
+		if want := normalizeDBType(dialect.DBTypeFromType(c.Type)); want != dbType {
+			diags = diags.Add(schemaMismatch(resourceName, fmt.Errorf("column %q in table %q has type %q, expected %q", c.Name, t.Name, dbType, want)))
+		}
+	}
+	for _, r := range t.Relations {
+		diags = diags.Add(validateTable(resourceName, dialect, r, existing))
+	}
+	return diags
+}
+
+// validateUserColumns reports the required columns of the table (and its relations) that aren't columns of the dialect,
+// as inserts of the table fail on them
+func validateUserColumns(resourceName string, dialect schema.Dialect, t *schema.Table, required map[string][]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	names := dialect.Columns(t).Names()
+	known := make(map[string]bool, len(names))
+	for _, n := range names {
+		known[n] = true
+	}
+	cols := append([]string(nil), required[t.Name]...)
+	sort.Strings(cols)
+	for _, c := range cols {
+		if known[c] {
+			continue
+		}
+		err := fmt.Errorf("column %q in table %q isn't defined by the provider and doesn't allow NULL values", c, t.Name)
+		diags = diags.Add(diag.NewBaseError(err, diag.USER,
+			diag.WithResourceName(resourceName),
+			diag.WithSummary("%s", err.Error()),
+			diag.WithDetails("columns added to provider tables are left NULL, make the column nullable or give it a default value"),
+		))
+	}
+	for _, r := range t.Relations {
+		diags = diags.Add(validateUserColumns(resourceName, dialect, r, required))
+	}
+	return diags
+}
+
+func schemaMismatch(resourceName string, err error) diag.Diagnostic {
+	return diag.NewBaseError(err, diag.USER,
+		diag.WithResourceName(resourceName),
+		diag.WithSummary("%s", err.Error()),
+		diag.WithDetails(upgradeRemediation),
+	)
+}
+
+func normalizeDBType(t string) string {
+	if alias, ok := dbTypeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+func tableNames(t *schema.Table) []string {
+	names := []string{t.Name}
+	for _, r := range t.Relations {
+		names = append(names, tableNames(r)...)
+	}
+	return names
+}
@@ -0,0 +1,112 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var validateTestTable = &schema.Table{
+	Name: "test_table",
+	Columns: []schema.Column{
+		{Name: "name", Type: schema.TypeString},
+		{Name: "score", Type: schema.TypeFloat},
+	},
+	Relations: []*schema.Table{
+		{
+			Name:    "test_table_relation",
+			Columns: []schema.Column{{Name: "test_table_cq_id", Type: schema.TypeUUID}},
+		},
+	},
+}
+
+func TestValidateTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing TableColumns
+		errors   []string
+	}{
+		{
+			name: "matching schema",
+			existing: TableColumns{
+				"test_table":          {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "score": "double precision"},
+				"test_table_relation": {"cq_id": "uuid", "cq_meta": "jsonb", "test_table_cq_id": "uuid"},
+			},
+		},
+		{
+			name: "extra database columns",
+			existing: TableColumns{
+				"test_table":          {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "score": "double precision", "extra": "text"},
+				"test_table_relation": {"cq_id": "uuid", "cq_meta": "jsonb", "test_table_cq_id": "uuid"},
+			},
+		},
+		{
+			name: "missing relation table",
+			existing: TableColumns{
+				"test_table": {"cq_id": "uuid", "cq_meta": "jsonb", "name": "text", "score": "double precision"},
+			},
+			errors: []string{`table "test_table_relation" does not exist`},
+		},
+		{
+			name: "missing column and type mismatch",
+			existing: TableColumns{
+				"test_table":          {"cq_id": "uuid", "cq_meta": "jsonb", "score": "integer"},
+				"test_table_relation": {"cq_id": "uuid", "cq_meta": "jsonb", "test_table_cq_id": "uuid"},
+			},
+			errors: []string{
+				`table "test_table" is missing column "name"`,
+				`column "score" in table "test_table" has type "integer", expected "double precision"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateTable("test", schema.PostgresDialect{}, validateTestTable, tc.existing)
+			assert.Len(t, diags, len(tc.errors))
+			for i, d := range diags {
+				assert.Equal(t, diag.USER, d.Type())
+				assert.Equal(t, diag.ERROR, d.Severity())
+				assert.Equal(t, "test", d.Description().Resource)
+				assert.Equal(t, tc.errors[i], d.Error())
+				assert.Equal(t, upgradeRemediation, d.Description().Detail)
+			}
+		})
+	}
+}
+
+func TestValidateUserColumns(t *testing.T) {
+	diags := validateUserColumns("test", schema.PostgresDialect{}, validateTestTable, map[string][]string{
+		"test_table":          {"cq_id", "required_extra", "name"},
+		"test_table_relation": {"owner"},
+	})
+	assert.Len(t, diags, 2)
+	for _, d := range diags {
+		assert.Equal(t, diag.USER, d.Type())
+		assert.Equal(t, diag.ERROR, d.Severity())
+		assert.Equal(t, "test", d.Description().Resource)
+	}
+	assert.Equal(t, `column "required_extra" in table "test_table" isn't defined by the provider and doesn't allow NULL values`, diags[0].Error())
+	assert.Equal(t, `column "owner" in table "test_table_relation" isn't defined by the provider and doesn't allow NULL values`, diags[1].Error())
+}
+
+func TestSupportsValidation(t *testing.T) {
+	assert.True(t, SupportsValidation(schema.PostgresDialect{}))
+	assert.True(t, SupportsValidation(schema.TSDBDialect{}))
+	assert.True(t, SupportsValidation(schema.TenantDialect{Dialect: schema.PostgresDialect{}}))
+	assert.False(t, SupportsValidation(schema.MySQLDialect{}))
+	assert.False(t, SupportsValidation(schema.TenantDialect{Dialect: schema.MySQLDialect{}}))
+}
+
+func TestValidateTables_UnsupportedDialect(t *testing.T) {
+	// the database isn't queried, it would fail with a nil querier
+	diags := ValidateTables(context.Background(), nil, schema.MySQLDialect{}, map[string]*schema.Table{"test": validateTestTable})
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.DATABASE, diags[0].Type())
+	assert.Equal(t, diag.WARNING, diags[0].Severity())
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, "tables of dialect schema.MySQLDialect can't be validated, only postgres databases are supported", diags[0].Error())
+}
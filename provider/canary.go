@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// canaryStorage validates the values of stored resources without writing them, so sampled resources aren't left in
+// tables whose resource isn't fully fetched
+type canaryStorage struct {
+	execution.Storage
+}
+
+func (canaryStorage) Insert(_ context.Context, _ *schema.Table, resources schema.Resources, _ bool) error {
+	return validateResourceValues(resources)
+}
+
+func (canaryStorage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	return validateResourceValues(resources)
+}
+
+func (canaryStorage) Delete(context.Context, *schema.Table, []interface{}) error {
+	return nil
+}
+
+func (canaryStorage) RemoveStaleData(context.Context, *schema.Table, execution.StaleFilter, []interface{}) error {
+	return nil
+}
+
+// Close doesn't close the underlying storage, which is used by the full fetch
+func (canaryStorage) Close() {}
+
+func validateResourceValues(resources schema.Resources) error {
+	for _, r := range resources {
+		if _, err := r.Values(); err != nil {
+			return fmt.Errorf("invalid value in table %s: %w", r.TableName(), err)
+		}
+	}
+	return nil
+}
+
+// canaryResources fetches a sample of request.CanaryRows rows per table of each resource. Resources whose sample
+// has errors are reported as failed with the sample's diagnostics, and the resources that can be fetched are returned.
+// Sensitive values of the sample are masked by redactor, if set.
+func (p *Provider) canaryResources(ctx context.Context, conn execution.Storage, state *configuredState, request *cqproto.FetchResourcesRequest,
+	resourceMap map[string]*schema.Table, resources []string, goroutinesSem *semaphore.Weighted, finishedResources map[string]bool, sender cqproto.FetchResourcesSender,
+	redactor *execution.Redactor) ([]string, error) {
+	g, gctx := errgroup.WithContext(ctx)
+	if request.ParallelFetchingLimit > 0 {
+		g.SetLimit(helpers.Uint64ToInt(request.ParallelFetchingLimit))
+	}
+	failed := make([]diag.Diagnostics, len(resources))
+	for i, r := range resources {
+		table, ok := resourceMap[r]
+		if !ok {
+			return nil, fmt.Errorf("plugin %s does not provide resource %s", p.Name, r)
+		}
+		opts := []execution.Option{
+			execution.WithColumnPolicies(state.columnPolicies),
+			execution.WithSampleLimit(request.CanaryRows),
+		}
+		if redactor != nil {
+			opts = append(opts, execution.WithRedactor(redactor))
+		}
+		tableExec := execution.NewTableExecutor(r, canaryStorage{conn}, p.Logger.With("table", table.Name, "canary", true), table, request.Metadata, p.ErrorClassifier, goroutinesSem, request.Timeout, opts...)
+		i := i
+		g.Go(func() error {
+			if _, diags := tableExec.Resolve(gctx, state.meta); diags.HasErrors() {
+				failed[i] = diags
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	passed := make([]string, 0, len(resources))
+	for i, r := range resources {
+		if failed[i] == nil {
+			passed = append(passed, r)
+			continue
+		}
+		p.Logger.Warn("resource canary fetch failed, skipping fetch", "resource", r, "errors", failed[i].Error())
+		finishedResources[r] = true
+		if err := sender.Send(&cqproto.FetchResourcesResponse{
+			ResourceName:      r,
+			FinishedResources: finishedResources,
+			Summary: cqproto.ResourceFetchSummary{
+				Status:      cqproto.ResourceFetchFailed,
+				Diagnostics: failed[i],
+			},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return passed, nil
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedConfigValue replaces the values of sensitive config fields in the effective configuration
+const RedactedConfigValue = "REDACTED"
+
+// GetEffectiveConfig returns the configuration the provider was configured with, after defaults are applied. Values of
+// config fields tagged `cq:"sensitive"` are replaced with RedactedConfigValue:
+//
+//	type Config struct {
+//		Regions []string `yaml:"regions,omitempty"`
+//		APIKey  string   `yaml:"api_key" cq:"sensitive"`
+//	}
+func (p *Provider) GetEffectiveConfig(_ context.Context, _ *cqproto.GetEffectiveConfigRequest) (*cqproto.GetEffectiveConfigResponse, error) {
+	state := p.configuredState()
+	if state == nil {
+		return nil, fmt.Errorf("provider client is not configured (Hint: Try upgrading cloudquery)")
+	}
+	b, err := RedactConfig(state.config)
+	if err != nil {
+		return &cqproto.GetEffectiveConfigResponse{
+			Diagnostics: diag.FromError(err, diag.INTERNAL, diag.WithSummary("failed to encode effective configuration")),
+		}, nil
+	}
+	return &cqproto.GetEffectiveConfigResponse{Config: b}, nil
+}
+
+// RedactConfig encodes the config to YAML, replacing the values of fields tagged `cq:"sensitive"` with RedactedConfigValue
+func RedactConfig(cfg interface{}) ([]byte, error) {
+	var n yaml.Node
+	if err := n.Encode(cfg); err != nil {
+		return nil, err
+	}
+	redactNode(&n, reflect.TypeOf(cfg))
+	return yaml.Marshal(&n)
+}
+
+// redactNode redacts the sensitive fields of the node encoded from a value of type t
+func redactNode(n *yaml.Node, t reflect.Type) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			redactNode(c, t)
+		}
+	case yaml.SequenceNode:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, c := range n.Content {
+			redactNode(c, t.Elem())
+		}
+	case yaml.MappingNode:
+		switch t.Kind() {
+		case reflect.Map:
+			for i := 1; i < len(n.Content); i += 2 {
+				redactNode(n.Content[i], t.Elem())
+			}
+		case reflect.Struct:
+			fields := yamlFields(t)
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				f, ok := fields[n.Content[i].Value]
+				if !ok {
+					continue
+				}
+				if hasCQTag(f, "sensitive") {
+					n.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: RedactedConfigValue}
+					continue
+				}
+				redactNode(n.Content[i+1], f.Type)
+			}
+		}
+	}
+}
+
+// yamlFields returns the exported fields of struct type t by their YAML key, including the fields of inlined structs
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range yamlFields(ft) {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// hclFields returns the exported fields of the struct type by their HCL attribute or block names, as gohcl decodes
+// them. Fields without a hcl tag, block labels and remaining bodies aren't decoded by name, so they're skipped.
+func hclFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("hcl")
+		if f.PkgPath != "" || !ok {
+			continue
+		}
+		name, kind, _ := strings.Cut(tag, ",")
+		if name == "" || kind == "label" || kind == "remain" {
+			continue
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// hasCQTag returns true if the comma separated options of the field's cq tag include opt
+func hasCQTag(f reflect.StructField, opt string) bool {
+	for _, o := range strings.Split(f.Tag.Get("cq"), ",") {
+		if strings.TrimSpace(o) == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// configEnvRef matches the environment variable references of configs, and their escaped "$${" form
+var configEnvRef = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandConfigEnv expands the ${VAR} references of the config's string values to the values of the environment
+// variables, see Provider.ConfigEnvVars. References are expanded once the config is parsed, so the variables' values
+// are never parsed as config. YAML configs are returned with their string values expanded, "$${" being a literal "${".
+// HCL configs are returned as is, with the context their references are evaluated in, as they are template
+// interpolations of HCL, which escapes "$${" itself. Referencing a variable that isn't allowed, or isn't set, is an
+// error.
+func expandConfigEnv(format cqproto.ConfigFormat, config []byte, allowed []string) ([]byte, *hcl.EvalContext, error) {
+	if len(config) == 0 {
+		return config, nil, nil
+	}
+	switch format {
+	case cqproto.ConfigFormatYAML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(config, &doc); err != nil {
+			return nil, nil, err
+		}
+		if doc.Kind == 0 {
+			return config, nil, nil
+		}
+		if err := expandYAMLEnv(&doc, allowed); err != nil {
+			return nil, nil, err
+		}
+		expanded, err := yaml.Marshal(&doc)
+		if err != nil {
+			return nil, nil, err
+		}
+		return expanded, nil, nil
+	case cqproto.ConfigFormatHCL:
+		file, diags := hclsyntax.ParseConfig(config, hclConfigFilename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, nil, diags
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected HCL body %T", file.Body)
+		}
+		variables := make(map[string]cty.Value)
+		if err := hclEnvVariables(body, allowed, variables); err != nil {
+			return nil, nil, err
+		}
+		return config, &hcl.EvalContext{Variables: variables}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported config format %s", format)
+	}
+}
+
+// expandYAMLEnv expands the references of the string values of the node and its children, mapping keys and aliases
+// aren't expanded
+func expandYAMLEnv(n *yaml.Node, allowed []string) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.ShortTag() != "!!str" {
+			return nil
+		}
+		v, err := expandEnvRefs(n.Value, allowed)
+		if err != nil {
+			return err
+		}
+		n.Value, n.Tag = v, "!!str"
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			if err := expandYAMLEnv(n.Content[i], allowed); err != nil {
+				return err
+			}
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := expandYAMLEnv(c, allowed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hclEnvVariables adds the values of the variables referenced by the attributes of the body and its blocks
+func hclEnvVariables(body *hclsyntax.Body, allowed []string, variables map[string]cty.Value) error {
+	for _, attr := range body.Attributes {
+		for _, traversal := range attr.Expr.Variables() {
+			name := traversal.RootName()
+			if _, ok := variables[name]; ok {
+				continue
+			}
+			value, err := configEnvValue(name, allowed)
+			if err != nil {
+				return err
+			}
+			variables[name] = cty.StringVal(value)
+		}
+	}
+	for _, block := range body.Blocks {
+		if err := hclEnvVariables(block.Body, allowed, variables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandEnvRefs replaces the ${VAR} references of s with the values of the environment variables, and "$${" with "${"
+func expandEnvRefs(s string, allowed []string) (string, error) {
+	var err error
+	expanded := configEnvRef.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		if err != nil {
+			return ref
+		}
+		var value string
+		value, err = configEnvValue(ref[2:len(ref)-1], allowed)
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+// configEnvValue returns the value of the environment variable referenced by the config
+func configEnvValue(name string, allowed []string) (string, error) {
+	if len(allowed) == 0 {
+		return "", fmt.Errorf("environment variable %s can't be referenced by the config, the provider doesn't allow referencing environment variables", name)
+	}
+	if !configEnvAllowed(name, allowed) {
+		return "", fmt.Errorf("environment variable %s can't be referenced by the config, allowed variables are %s", name, strings.Join(allowed, ", "))
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s referenced by the config isn't set", name)
+	}
+	return value, nil
+}
+
+// configEnvAllowed returns true if the variable is in the allowlist, entries ending with * allow the variables of their
+// prefix. No variables are allowed by an empty allowlist.
+func configEnvAllowed(name string, allowed []string) bool {
+	for _, a := range allowed {
+		if a == name || (strings.HasSuffix(a, "*") && strings.HasPrefix(name, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
+)
+
+// hclConfigFilename names HCL configs in their diagnostics, its extension selects the HCL native syntax
+const hclConfigFilename = "config.hcl"
+
+// decodeConfig decodes the config of the format into cfg, HCL configs are decoded by the hcl tags of cfg's fields and
+// their expressions evaluated in evalCtx
+func decodeConfig(format cqproto.ConfigFormat, data []byte, evalCtx *hcl.EvalContext, cfg Config) error {
+	switch format {
+	case cqproto.ConfigFormatYAML:
+		return yaml.Unmarshal(data, cfg)
+	case cqproto.ConfigFormatHCL:
+		return hclsimple.Decode(hclConfigFilename, data, evalCtx, cfg)
+	default:
+		return fmt.Errorf("unsupported config format %s", format)
+	}
+}
+
+// configValue decodes the config of the format to its generic value, objects are maps and lists are slices, so it's
+// validated by the schema. Expressions of HCL configs are evaluated in evalCtx.
+func configValue(format cqproto.ConfigFormat, data []byte, evalCtx *hcl.EvalContext, s *jsonSchema) (interface{}, error) {
+	var value interface{}
+	switch format {
+	case cqproto.ConfigFormatYAML:
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+	case cqproto.ConfigFormatHCL:
+		file, diags := hclsyntax.ParseConfig(data, hclConfigFilename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			return nil, fmt.Errorf("unexpected HCL body %T", file.Body)
+		}
+		m, err := hclBodyValue(body, evalCtx, s)
+		if err != nil {
+			return nil, err
+		}
+		value = m
+	default:
+		return nil, fmt.Errorf("unsupported config format %s", format)
+	}
+	if value == nil {
+		value = map[string]interface{}{}
+	}
+	return value, nil
+}
+
+// hclBodyValue converts the attributes and blocks of an HCL body to a map. Blocks whose property in the schema is an
+// array are a list of their bodies, other blocks are the body of their last block. Block labels are ignored.
+func hclBodyValue(body *hclsyntax.Body, evalCtx *hcl.EvalContext, s *jsonSchema) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(body.Attributes)+len(body.Blocks))
+	for name, attr := range body.Attributes {
+		v, diags := attr.Expr.Value(evalCtx)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		b, err := ctyjson.Marshal(v, v.Type())
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if err := json.Unmarshal(b, &value); err != nil {
+			return nil, err
+		}
+		m[name] = value
+	}
+	for _, block := range body.Blocks {
+		var p *jsonSchema
+		if s != nil {
+			if p = s.Properties[block.Type]; p == nil {
+				p = s.AdditionalProperties
+			}
+		}
+		if p != nil && p.Type == "array" {
+			v, err := hclBodyValue(block.Body, evalCtx, p.Items)
+			if err != nil {
+				return nil, err
+			}
+			items, _ := m[block.Type].([]interface{})
+			m[block.Type] = append(items, v)
+			continue
+		}
+		v, err := hclBodyValue(block.Body, evalCtx, p)
+		if err != nil {
+			return nil, err
+		}
+		m[block.Type] = v
+	}
+	return m, nil
+}
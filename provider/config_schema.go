@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/hashicorp/hcl/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var (
+	yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// jsonSchema is the subset of JSON schema generated by GenerateConfigSchema and checked by ValidateProviderConfig
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+}
+
+// GenerateConfigSchema generates the JSON schema of the provider's config struct, keyed by the fields' YAML names. Field
+// tags are reflected in the schema: `default` values are the properties' defaults, fields tagged `cq:"required"` are
+// required and fields tagged `cq:"sensitive"` are write only:
+//
+//	type Config struct {
+//		Regions []string `yaml:"regions,omitempty" default:"[\"us-east-1\"]"`
+//		APIKey  string   `yaml:"api_key" cq:"required,sensitive"`
+//	}
+func GenerateConfigSchema(cfg Config) (string, error) {
+	return generateConfigSchema(cfg, cqproto.ConfigFormatYAML)
+}
+
+// generateConfigSchema generates the JSON schema of the config as GenerateConfigSchema, keyed by the fields' names in
+// the format, so HCL configs are validated by the fields' hcl tags
+func generateConfigSchema(cfg Config, format cqproto.ConfigFormat) (string, error) {
+	fields := yamlFields
+	if format == cqproto.ConfigFormatHCL {
+		fields = hclFields
+	}
+	s := typeSchema(reflect.TypeOf(cfg), fields, make(map[reflect.Type]bool))
+	s.Schema = jsonSchemaDraft
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ValidateProviderConfig validates the config, encoded in the format, against the JSON schema of the provider's config,
+// see GenerateConfigSchema. Each field that is missing or has a value of the wrong type is reported as a USER error. An
+// empty config is validated as an empty object.
+func ValidateProviderConfig(configSchema string, config []byte, format cqproto.ConfigFormat) diag.Diagnostics {
+	return validateProviderConfig(configSchema, config, format, nil)
+}
+
+// validateProviderConfig validates the config as ValidateProviderConfig, expressions of HCL configs are evaluated in
+// evalCtx
+func validateProviderConfig(configSchema string, config []byte, format cqproto.ConfigFormat, evalCtx *hcl.EvalContext) diag.Diagnostics {
+	var s jsonSchema
+	if err := json.Unmarshal([]byte(configSchema), &s); err != nil {
+		return diag.FromError(err, diag.INTERNAL, diag.WithSummary("invalid provider config schema"))
+	}
+	value, err := configValue(format, config, evalCtx, &s)
+	if err != nil {
+		return diag.FromError(err, diag.USER, diag.WithSummary("failed to decode %s provider config", format))
+	}
+	var diags diag.Diagnostics
+	for _, err := range validateSchemaValue(&s, "", value) {
+		diags = diags.Add(diag.NewBaseError(err, diag.USER, diag.WithSeverity(diag.ERROR), diag.WithSummary("invalid provider config")))
+	}
+	return diags
+}
+
+// typeSchema returns the schema of values of type t, struct properties are the fields returned by fields. Types being
+// generated are in visiting so recursive types end.
+func typeSchema(t reflect.Type, fields func(reflect.Type) map[string]reflect.StructField, visiting map[reflect.Type]bool) *jsonSchema {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || visiting[t] {
+		return &jsonSchema{}
+	}
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return &jsonSchema{Type: "string"}
+	}
+	// types decoding themselves accept any value, unless they decode from text, i.e time.Time
+	if implements(t, yamlUnmarshalerType) {
+		return &jsonSchema{}
+	}
+	if implements(t, textUnmarshalerType) {
+		return &jsonSchema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: typeSchema(t.Elem(), fields, visiting)}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: typeSchema(t.Elem(), fields, visiting)}
+	case reflect.Struct:
+		visiting[t] = true
+		defer delete(visiting, t)
+		s := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+		for name, f := range fields(t) {
+			p := typeSchema(f.Type, fields, visiting)
+			d, hasDefault := f.Tag.Lookup("default")
+			if hasDefault {
+				p.Default = defaultValue(d, p.Type)
+			}
+			p.WriteOnly = hasCQTag(f, "sensitive")
+			// fields with a default are set by defaults.Set if they're missing, so they're never missing
+			if hasCQTag(f, "required") && !hasDefault {
+				s.Required = append(s.Required, name)
+			}
+			s.Properties[name] = p
+		}
+		sort.Strings(s.Required)
+		return s
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// implements returns whether values of t, or pointers to them, implement the interface
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// defaultValue parses the default tag of a field by the type of its schema, as it's parsed by defaults.Set. Slices and
+// maps defaults are JSON encoded.
+func defaultValue(d, schemaType string) interface{} {
+	switch schemaType {
+	case "boolean":
+		if v, err := strconv.ParseBool(d); err == nil {
+			return v
+		}
+	case "integer":
+		if v, err := strconv.ParseInt(d, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(d, 64); err == nil {
+			return v
+		}
+	case "array", "object":
+		var v interface{}
+		if err := json.Unmarshal([]byte(d), &v); err == nil {
+			return v
+		}
+	}
+	return d
+}
+
+// validateSchemaValue validates v, the value at path, against the schema. Null values are valid, as are values of
+// schemas without a type.
+func validateSchemaValue(s *jsonSchema, path string, v interface{}) []error {
+	if v == nil || s == nil {
+		return nil
+	}
+	switch s.Type {
+	case "string":
+		switch v.(type) {
+		// unquoted timestamps of YAML configs are decoded as times
+		case string, time.Time:
+		default:
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+	case "integer":
+		switch n := v.(type) {
+		case int, int64, uint64:
+		case float64:
+			// numbers of HCL configs are decoded as floats
+			if n != math.Trunc(n) {
+				return []error{schemaTypeError(path, s.Type, v)}
+			}
+		default:
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+	case "number":
+		switch v.(type) {
+		case int, int64, uint64, float64:
+		default:
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+		var errs []error
+		for i, item := range items {
+			errs = append(errs, validateSchemaValue(s.Items, fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+		return errs
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return []error{schemaTypeError(path, s.Type, v)}
+		}
+		var errs []error
+		for _, name := range s.Required {
+			if m[name] == nil {
+				errs = append(errs, fmt.Errorf("missing required field %s", joinSchemaPath(path, name)))
+			}
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p, ok := s.Properties[k]
+			if !ok {
+				p = s.AdditionalProperties
+			}
+			errs = append(errs, validateSchemaValue(p, joinSchemaPath(path, k), m[k])...)
+		}
+		return errs
+	}
+	return nil
+}
+
+func schemaTypeError(path, schemaType string, v interface{}) error {
+	if path == "" {
+		return fmt.Errorf("config should be of type %s, got %T", schemaType, v)
+	}
+	return fmt.Errorf("field %s should be of type %s, got %T", path, schemaType, v)
+}
+
+func joinSchemaPath(path, name string) string {
+	return strings.TrimPrefix(path+"."+name, ".")
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// deprecationDiagnostics returns a warning for the resource's table, and each of its relations, that is deprecated. They
+// are reported to fetches requesting the resource explicitly, so users can move off it before it's removed.
+func deprecationDiagnostics(resource string, t *schema.Table) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if t.Deprecated != "" {
+		diags = diags.Add(diag.NewBaseError(nil, diag.USER, diag.WithSeverity(diag.WARNING), diag.WithResourceName(resource),
+			diag.WithSummary("table %q is deprecated", t.Name), diag.WithDetails("%s", t.Deprecated)))
+	}
+	for _, r := range t.Relations {
+		diags = diags.Add(deprecationDiagnostics(resource, r))
+	}
+	return diags
+}
@@ -81,6 +81,8 @@ func (d Type) String() string {
 		return "Internal"
 	case TELEMETRY:
 		return "Telemetry"
+	case SCHEMA:
+		return "Schema"
 	case UNKNOWN:
 		fallthrough
 	default:
@@ -117,12 +117,14 @@ func (diags Diagnostics) Squash() Diagnostics {
 		key := fmt.Sprintf("%s_%s_%s_%d_%d", reflect.ValueOf(keygen).Type().String(), keygen.Error(), keygen.Description().Resource, keygen.Severity(), keygen.Type())
 		if sd, ok := dd[key]; ok {
 			sd.count += CountDiag(d)
+			sd.addSamples(d)
 			continue
 		}
 		nsd := &SquashedDiag{
 			Diagnostic: diags[i],
 			count:      CountDiag(d),
 		}
+		nsd.addSamples(d)
 		dd[key] = nsd
 		sdd = append(sdd, nsd)
 	}
@@ -130,6 +132,15 @@ func (diags Diagnostics) Squash() Diagnostics {
 	return sdd
 }
 
+// CountBySummary returns the number of diagnostics by their summary, squashed diags are counted by their occurrences
+func (diags Diagnostics) CountBySummary() map[string]uint64 {
+	counts := make(map[string]uint64)
+	for _, d := range diags {
+		counts[UnsquashDiag(d).Description().Summary] += CountDiag(d)
+	}
+	return counts
+}
+
 func (diags Diagnostics) Warnings() uint64 {
 	return diags.CountBySeverity(WARNING, false)
 }
@@ -283,3 +283,21 @@ func TestDiagnostics_BySeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestDiagnostics_SquashSamples(t *testing.T) {
+	var input Diagnostics
+	for i := 0; i < 10; i++ {
+		input = input.Add(NewBaseError(errors.New("access denied"), ACCESS, WithResourceName("a"), WithSummary("failed to list"), WithResourceId([]string{strconv.Itoa(i % 7)})))
+	}
+	input = input.Add(NewBaseError(errors.New("throttled"), THROTTLE, WithResourceName("a"), WithSummary("failed to describe")))
+
+	out := input.Squash()
+	assert.Len(t, out, 2)
+	assert.Equal(t, uint64(10), CountDiag(out[0]))
+	assert.Equal(t, [][]string{{"0"}, {"1"}, {"2"}, {"3"}, {"4"}}, SampleResourceIDs(out[0]))
+	assert.Nil(t, SampleResourceIDs(out[1]))
+	assert.Equal(t, SampleResourceIDs(out[0]), SampleResourceIDs(out.Squash()[0]))
+
+	assert.Equal(t, map[string]uint64{"failed to list: access denied": 10, "failed to describe: throttled": 1}, out.CountBySummary())
+	assert.Equal(t, out.CountBySummary(), input.CountBySummary())
+}
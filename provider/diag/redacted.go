@@ -23,3 +23,53 @@ func NewRedactedDiagnostic(vanilla, redacted Diagnostic) RedactedDiagnostic {
 func (p RedactedDiagnostic) Redacted() Diagnostic {
 	return p.redacted
 }
+
+// MaskedDiagnostic is a diagnostic whose error and description are masked, i.e to remove sensitive values from them
+// before they leave the provider
+type MaskedDiagnostic struct {
+	Diagnostic
+	mask func(string) string
+}
+
+var (
+	_ Redactable = (*MaskedDiagnostic)(nil)
+)
+
+// NewMaskedDiagnostic masks the error and description of d with mask
+func NewMaskedDiagnostic(d Diagnostic, mask func(string) string) MaskedDiagnostic {
+	return MaskedDiagnostic{
+		Diagnostic: d,
+		mask:       mask,
+	}
+}
+
+func (m MaskedDiagnostic) Error() string {
+	return m.mask(m.Diagnostic.Error())
+}
+
+func (m MaskedDiagnostic) Description() Description {
+	d := m.Diagnostic.Description()
+	d.Summary = m.mask(d.Summary)
+	d.Detail = m.mask(d.Detail)
+	if len(d.ResourceID) > 0 {
+		ids := make([]string, len(d.ResourceID))
+		for i, id := range d.ResourceID {
+			ids[i] = m.mask(id)
+		}
+		d.ResourceID = ids
+	}
+	return d
+}
+
+// Redacted returns the masked redacted version of the diagnostic, if there is any
+func (m MaskedDiagnostic) Redacted() Diagnostic {
+	rd, ok := m.Diagnostic.(Redactable)
+	if !ok {
+		return nil
+	}
+	r := rd.Redacted()
+	if r == nil {
+		return nil
+	}
+	return NewMaskedDiagnostic(r, m.mask)
+}
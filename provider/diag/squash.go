@@ -5,15 +5,34 @@ import (
 	"strings"
 )
 
+// MaxSquashedSamples is the maximum amount of resource ids of the squashed diagnostics kept by a SquashedDiag
+const MaxSquashedSamples = 5
+
 type SquashedDiag struct {
 	Diagnostic
 	count uint64
+	// samples are the distinct resource ids of the first squashed diagnostics, up to MaxSquashedSamples
+	samples [][]string
+}
+
+// NewSquashedDiag creates a squashed diagnostic of count occurrences of d, i.e one received over the plugin protocol.
+// samples are the resource ids of some of the occurrences.
+func NewSquashedDiag(d Diagnostic, count uint64, samples [][]string) Diagnostic {
+	if count == 0 {
+		count = 1
+	}
+	return SquashedDiag{Diagnostic: d, count: count, samples: samples}
 }
 
 type Countable interface {
 	Count() uint64
 }
 
+// Sampled is a diagnostic of several occurrences, keeping the resource ids of some of them
+type Sampled interface {
+	SampleResourceIDs() [][]string
+}
+
 type Unsquashable interface {
 	Unsquash() Diagnostic
 }
@@ -21,6 +40,7 @@ type Unsquashable interface {
 var (
 	_ Countable    = (*SquashedDiag)(nil)
 	_ Unsquashable = (*SquashedDiag)(nil)
+	_ Sampled      = (*SquashedDiag)(nil)
 )
 
 func (s SquashedDiag) Description() Description {
@@ -64,6 +84,24 @@ func (s SquashedDiag) Redacted() Diagnostic {
 	return SquashedDiag{
 		Diagnostic: r,
 		count:      s.count,
+		samples:    s.samples,
+	}
+}
+
+// SampleResourceIDs returns the resource ids of up to MaxSquashedSamples of the squashed diagnostics
+func (s SquashedDiag) SampleResourceIDs() [][]string {
+	return s.samples
+}
+
+// addSamples adds the distinct resource ids of d to the samples, until MaxSquashedSamples are kept
+func (s *SquashedDiag) addSamples(d Diagnostic) {
+	for _, id := range SampleResourceIDs(d) {
+		if len(s.samples) >= MaxSquashedSamples {
+			return
+		}
+		if !containsResourceID(s.samples, id) {
+			s.samples = append(s.samples, id)
+		}
 	}
 }
 
@@ -80,6 +118,26 @@ func CountDiag(d Diagnostic) uint64 {
 	return 1
 }
 
+// SampleResourceIDs returns the sample resource ids of a squashed diagnostic, or the resource id of any other diagnostic
+func SampleResourceIDs(d Diagnostic) [][]string {
+	if s, ok := d.(Sampled); ok {
+		return s.SampleResourceIDs()
+	}
+	if id := d.Description().ResourceID; len(id) > 0 {
+		return [][]string{id}
+	}
+	return nil
+}
+
+func containsResourceID(ids [][]string, id []string) bool {
+	for _, i := range ids {
+		if strings.Join(i, ",") == strings.Join(id, ",") {
+			return true
+		}
+	}
+	return false
+}
+
 func UnsquashDiag(d Diagnostic) Diagnostic {
 	if c, ok := d.(Unsquashable); ok {
 		return c.Unsquash()
@@ -3,12 +3,14 @@ package docs
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -18,23 +20,123 @@ import (
 
 const (
 	tablesDir = "tables"
+	// jsonFile is the name of the file the JSON docs are written to
+	jsonFile = "tables.json"
 )
 
+// Format is the format of the generated documentation
+type Format string
+
+const (
+	// FormatMarkdown writes a Markdown file per table to the tables directory
+	FormatMarkdown Format = "markdown"
+	// FormatJSON writes the documentation of all the tables to a single tables.json file
+	FormatJSON Format = "json"
+)
+
+// TableDoc is the documentation of a table, as written by FormatJSON
+type TableDoc struct {
+	// Resource is the name of the provider's resource of top level tables
+	Resource    string      `json:"resource,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+	PrimaryKeys []string    `json:"primary_keys"`
+	Columns     []ColumnDoc `json:"columns"`
+	// Parent is the name of the table of relations
+	Parent    string     `json:"parent,omitempty"`
+	Relations []TableDoc `json:"relations,omitempty"`
+}
+
+// ColumnDoc is the documentation of a column, as written by FormatJSON
+type ColumnDoc struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Description    string `json:"description,omitempty"`
+	Deprecated     string `json:"deprecated,omitempty"`
+	Classification string `json:"classification,omitempty"`
+}
+
 // GenerateDocs creates table documentation for the provider based on it's ResourceMap
 func GenerateDocs(p *provider.Provider, outputPath string, deleteOld bool) error {
 	if deleteOld {
 		if err := deleteOldFiles(outputPath); err != nil {
-			fmt.Printf("failed to remove old docs: %s", err)
-			return err
+			return fmt.Errorf("failed to remove old docs: %w", err)
 		}
 	}
-	for _, table := range p.ResourceMap {
-		if err := renderAllTables(table, outputPath); err != nil {
-			fmt.Printf("render table %s error: %s", table.Name, err)
+	return GenerateDocsFormat(p, FormatMarkdown, outputPath)
+}
+
+// GenerateDocsFormat creates the documentation of every table of the provider's ResourceMap, and their relations, in
+// the given format: their columns and types, descriptions, primary keys and relation tree. Tables are documented with
+// the columns of the postgres dialect, without the columns internal to the SDK.
+func GenerateDocsFormat(p *provider.Provider, format Format, outputPath string) error {
+	docs := tableDocs(p)
+	switch format {
+	case FormatMarkdown:
+		if err := os.MkdirAll(filepath.Join(outputPath, tablesDir), 0755); err != nil {
+			return err
+		}
+		for _, d := range docs {
+			if err := renderAllTables(d, outputPath); err != nil {
+				return fmt.Errorf("failed to render table %s: %w", d.Name, err)
+			}
+		}
+		return nil
+	case FormatJSON:
+		if err := os.MkdirAll(outputPath, 0755); err != nil {
 			return err
 		}
+		data, err := json.MarshalIndent(docs, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(outputPath, jsonFile), append(data, '\n'), 0644)
+	default:
+		return fmt.Errorf("unknown docs format %q", format)
 	}
-	return nil
+}
+
+// tableDocs returns the documentation of the provider's tables, sorted by resource name
+func tableDocs(p *provider.Provider) []TableDoc {
+	resources := make([]string, 0, len(p.ResourceMap))
+	for r := range p.ResourceMap {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+	docs := make([]TableDoc, len(resources))
+	for i, r := range resources {
+		docs[i] = tableDoc(p.ResourceMap[r], nil)
+		docs[i].Resource = r
+	}
+	return docs
+}
+
+func tableDoc(t *schema.Table, parent *schema.Table) TableDoc {
+	d := schema.PostgresDialect{}
+	doc := TableDoc{
+		Name:        t.Name,
+		Description: t.Description,
+		Deprecated:  t.Deprecated,
+		PrimaryKeys: d.PrimaryKeys(t),
+		Columns:     make([]ColumnDoc, len(t.Columns)),
+	}
+	if parent != nil {
+		doc.Parent = parent.Name
+	}
+	for i, c := range t.Columns {
+		doc.Columns[i] = ColumnDoc{
+			Name:           c.Name,
+			Type:           d.DBTypeFromType(c.Type),
+			Description:    c.Description,
+			Deprecated:     c.Deprecated,
+			Classification: string(c.Classification),
+		}
+	}
+	for _, r := range t.Relations {
+		doc.Relations = append(doc.Relations, tableDoc(r, t))
+	}
+	return doc
 }
 
 // deleteOldFiles removes old files from tables directory, creates tables directory if it does not exist
@@ -44,7 +146,7 @@ func deleteOldFiles(outputPath string) error {
 	if err != nil {
 		// create directory if it does not exist
 		if errors.Is(err, os.ErrNotExist) {
-			if err := os.Mkdir(tablesPath, 0644); err != nil {
+			if err := os.MkdirAll(tablesPath, 0755); err != nil {
 				return err
 			}
 			return nil
@@ -59,7 +161,7 @@ func deleteOldFiles(outputPath string) error {
 	return nil
 }
 
-func renderAllTables(t *schema.Table, outputPath string) error {
+func renderAllTables(t TableDoc, outputPath string) error {
 	if err := renderTable(t, outputPath); err != nil {
 		return err
 	}
@@ -71,12 +173,13 @@ func renderAllTables(t *schema.Table, outputPath string) error {
 	return nil
 }
 
-func renderTable(table *schema.Table, path string) error {
+func renderTable(table TableDoc, path string) error {
 	t := template.New("").Funcs(map[string]interface{}{
-		"pgType": schema.PostgresDialect{}.DBTypeFromType,
 		"removeLineBreaks": func(text string) string {
 			return strings.ReplaceAll(text, "\n", " ")
 		},
+		"join":         strings.Join,
+		"relationTree": relationTree,
 	})
 	t, err := t.New("").Parse(tableTmpl)
 	if err != nil {
@@ -91,13 +194,40 @@ func renderTable(table *schema.Table, path string) error {
 	return ioutil.WriteFile(filepath.Join(path, tablesDir, fmt.Sprintf("%s.md", table.Name)), buf.Bytes(), 0644)
 }
 
+// relationTree renders the relations, and their relations, as a nested Markdown list of links to their docs
+func relationTree(relations []TableDoc) string {
+	var b strings.Builder
+	var write func(relations []TableDoc, depth int)
+	write = func(relations []TableDoc, depth int) {
+		for _, r := range relations {
+			b.WriteString(fmt.Sprintf("%s- [%s](%s.md)\n", strings.Repeat("  ", depth), r.Name, r.Name))
+			write(r.Relations, depth+1)
+		}
+	}
+	write(relations, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 const tableTmpl = `
 # Table: {{.Name}}
-{{ $.Description }}
+{{ if $.Deprecated }}
+**Deprecated:** {{ $.Deprecated }}
+
+{{ end }}{{ $.Description }}
+{{- if $.Parent }}
+
+Relation of [{{ $.Parent }}]({{ $.Parent }}.md)
+{{- end }}
+## Primary Keys
+{{ join $.PrimaryKeys ", " }}
 ## Columns
 | Name        | Type           | Description  |
 | ------------- | ------------- | -----  |
 {{- range $column := $.Columns }}
-|{{$column.Name}}|{{$column.Type|pgType}}|{{$column.Description|removeLineBreaks}}|
+|{{$column.Name}}|{{$column.Type}}|{{if $column.Deprecated}}**Deprecated:** {{$column.Deprecated|removeLineBreaks}} {{end}}{{$column.Description|removeLineBreaks}}|
+{{- end }}
+{{- if $.Relations }}
+## Relations
+{{ relationTree $.Relations }}
 {{- end }}
 `
@@ -0,0 +1,117 @@
+package docs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testProvider = &provider.Provider{
+	Name: "docs",
+	ResourceMap: map[string]*schema.Table{
+		"instances": {
+			Name:        "docs_instances",
+			Description: "Instances of\nthe account",
+			Options:     schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+			Columns: []schema.Column{
+				{Name: "id", Type: schema.TypeString, Description: "The instance's\nid"},
+				{Name: "size", Type: schema.TypeInt, Deprecated: "use type"},
+			},
+			Relations: []*schema.Table{
+				{
+					Name: "docs_instance_volumes",
+					Columns: []schema.Column{
+						{Name: "instance_cq_id", Type: schema.TypeUUID, Resolver: schema.ParentIdResolver},
+						{Name: "volume_id", Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestGenerateDocs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, tablesDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, tablesDir, "removed_table.md"), nil, 0644))
+
+	require.NoError(t, GenerateDocs(testProvider, dir, true))
+	entries, err := os.ReadDir(filepath.Join(dir, tablesDir))
+	require.NoError(t, err)
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name()
+	}
+	assert.Equal(t, []string{"docs_instance_volumes.md", "docs_instances.md"}, names)
+
+	data, err := os.ReadFile(filepath.Join(dir, tablesDir, "docs_instances.md"))
+	require.NoError(t, err)
+	assert.Equal(t, `
+# Table: docs_instances
+Instances of
+the account
+## Primary Keys
+id
+## Columns
+| Name        | Type           | Description  |
+| ------------- | ------------- | -----  |
+|id|text|The instance's id|
+|size|integer|**Deprecated:** use type |
+## Relations
+- [docs_instance_volumes](docs_instance_volumes.md)
+`, string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, tablesDir, "docs_instance_volumes.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Relation of [docs_instances](docs_instances.md)")
+}
+
+func TestGenerateDocs_MissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "docs")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, GenerateDocs(testProvider, dir, true))
+	assert.FileExists(t, filepath.Join(dir, tablesDir, "docs_instances.md"))
+}
+
+func TestGenerateDocs_Errors(t *testing.T) {
+	dir := t.TempDir()
+	// the tables directory can't be created over a file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, tablesDir), nil, 0644))
+	assert.ErrorContains(t, GenerateDocs(testProvider, dir, true), "failed to remove old docs")
+	assert.Error(t, GenerateDocsFormat(testProvider, FormatMarkdown, dir))
+	assert.EqualError(t, GenerateDocsFormat(testProvider, "html", dir), `unknown docs format "html"`)
+}
+
+func TestGenerateDocsFormat_JSON(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, GenerateDocsFormat(testProvider, FormatJSON, dir))
+	data, err := os.ReadFile(filepath.Join(dir, jsonFile))
+	require.NoError(t, err)
+	var docs []TableDoc
+	require.NoError(t, json.Unmarshal(data, &docs))
+	assert.Equal(t, []TableDoc{{
+		Resource:    "instances",
+		Name:        "docs_instances",
+		Description: "Instances of\nthe account",
+		PrimaryKeys: []string{"id"},
+		Columns: []ColumnDoc{
+			{Name: "id", Type: "text", Description: "The instance's\nid"},
+			{Name: "size", Type: "integer", Deprecated: "use type"},
+		},
+		Relations: []TableDoc{{
+			Name:        "docs_instance_volumes",
+			Parent:      "docs_instances",
+			PrimaryKeys: []string{"cq_id"},
+			Columns: []ColumnDoc{
+				{Name: "instance_cq_id", Type: "uuid"},
+				{Name: "volume_id", Type: "text"},
+			},
+		}},
+	}}, docs)
+}
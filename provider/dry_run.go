@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgx/v4"
+)
+
+var errDryRun = errors.New("database isn't available in dry run fetches")
+
+// dryRunStorage is the storage of dry run fetches, it validates and counts the stored resources per table without
+// writing them anywhere
+type dryRunStorage struct {
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func newDryRunStorage() *dryRunStorage {
+	return &dryRunStorage{counts: make(map[string]uint64)}
+}
+
+func (s *dryRunStorage) Insert(_ context.Context, _ *schema.Table, resources schema.Resources, _ bool) error {
+	return s.count(resources)
+}
+
+func (s *dryRunStorage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	return s.count(resources)
+}
+
+func (s *dryRunStorage) count(resources schema.Resources) error {
+	if err := validateResourceValues(resources); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range resources {
+		s.counts[r.TableName()]++
+	}
+	return nil
+}
+
+// tableCounts returns the amount of resources stored in table t and its relations, by table name
+func (s *dryRunStorage) tableCounts(t *schema.Table) map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[string]uint64)
+	for _, name := range t.TableNames() {
+		counts[name] = s.counts[name]
+	}
+	return counts
+}
+
+func (*dryRunStorage) Delete(context.Context, *schema.Table, []interface{}) error {
+	return nil
+}
+
+func (*dryRunStorage) RemoveStaleData(context.Context, *schema.Table, execution.StaleFilter, []interface{}) error {
+	return nil
+}
+
+func (*dryRunStorage) Exec(context.Context, string, ...interface{}) error {
+	return nil
+}
+
+func (*dryRunStorage) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, errDryRun
+}
+
+func (*dryRunStorage) RawCopyTo(context.Context, io.Writer, string) error {
+	return errDryRun
+}
+
+func (*dryRunStorage) RawCopyFrom(context.Context, io.Reader, string) error {
+	return errDryRun
+}
+
+func (*dryRunStorage) Begin(context.Context) (execution.TXQueryExecer, error) {
+	return nil, errDryRun
+}
+
+func (*dryRunStorage) Close() {}
+
+func (*dryRunStorage) Dialect() schema.Dialect {
+	return schema.PostgresDialect{}
+}
@@ -0,0 +1,107 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+)
+
+// auditWriteTimeout bounds each write of the audit log, which is written even if the fetch was canceled
+const auditWriteTimeout = 10 * time.Second
+
+// createAuditLogTable creates the audit log table, which keeps a row per table per client execution of the fetches of
+// all the providers sharing the database
+const createAuditLogTable = `CREATE TABLE IF NOT EXISTS "cq_audit_log" (
+	"id" uuid NOT NULL PRIMARY KEY,
+	"fetch_id" text,
+	"resource_name" text NOT NULL,
+	"table_name" text NOT NULL,
+	"client_id" text,
+	"start" timestamp without time zone NOT NULL,
+	"finish" timestamp without time zone,
+	"resource_count" bigint,
+	"status" text NOT NULL,
+	"error" text
+)`
+
+// Audit log statuses of an execution
+const (
+	AuditStatusRunning  = "running"
+	AuditStatusComplete = "complete"
+	AuditStatusPartial  = "partial"
+	AuditStatusFailed   = "failed"
+	AuditStatusCanceled = "canceled"
+)
+
+// AuditLog writes a row to the cq_audit_log table for each execution of a top level table by a client. The row is
+// inserted once the client starts resolving the table and updated once it finishes, so running fetches can be monitored
+// with SQL. Writes that fail are logged, they don't fail the fetch. The storage must support postgres statements.
+type AuditLog struct {
+	db      Storage
+	fetchID string
+	logger  hclog.Logger
+
+	createOnce sync.Once
+	createErr  error
+}
+
+// NewAuditLog creates an AuditLog writing to db. fetchID identifies the fetch of the rows, it may be empty.
+func NewAuditLog(db Storage, fetchID string, logger hclog.Logger) *AuditLog {
+	return &AuditLog{db: db, fetchID: fetchID, logger: logger}
+}
+
+// start inserts the running execution's row, returning its id or uuid.Nil if it wasn't written
+func (a *AuditLog) start(resourceName, table, client string) uuid.UUID {
+	if a == nil {
+		return uuid.Nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+	a.createOnce.Do(func() {
+		a.createErr = a.db.Exec(ctx, createAuditLogTable)
+	})
+	if a.createErr != nil {
+		a.logger.Debug("audit log isn't written, failed to create its table", "error", a.createErr)
+		return uuid.Nil
+	}
+	id := uuid.New()
+	if err := a.db.Exec(ctx, `INSERT INTO "cq_audit_log" ("id", "fetch_id", "resource_name", "table_name", "client_id", "start", "status") VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		id, a.fetchID, resourceName, table, client, time.Now().UTC(), AuditStatusRunning); err != nil {
+		a.logger.Warn("failed to write audit log", logging.TableKey, table, logging.ClientIDKey, client, "error", err)
+		return uuid.Nil
+	}
+	return id
+}
+
+// finish updates the execution's row with its result
+func (a *AuditLog) finish(id uuid.UUID, canceled bool, count uint64, diags diag.Diagnostics) {
+	if a == nil || id == uuid.Nil {
+		return
+	}
+	status := AuditStatusComplete
+	switch {
+	case canceled:
+		status = AuditStatusCanceled
+	case diags.HasErrors() && count > 0:
+		status = AuditStatusPartial
+	case diags.HasErrors():
+		status = AuditStatusFailed
+	}
+	var errSummary *string
+	if diags.HasErrors() {
+		s := errorSummary(diags)
+		errSummary = &s
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+	if err := a.db.Exec(ctx, `UPDATE "cq_audit_log" SET "finish" = $2, "resource_count" = $3, "status" = $4, "error" = $5 WHERE "id" = $1`,
+		id, time.Now().UTC(), helpers.Uint64ToInt64(count), status, errSummary); err != nil {
+		a.logger.Warn("failed to write audit log", "id", id, "error", err)
+	}
+}
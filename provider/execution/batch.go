@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/cloudquery/cq-provider-sdk/stats"
+)
+
+// callBatchResolve resolves the relation of all parents with a single call of the table's BatchResolver. Each received
+// item is resolved as a child of the parent it's sent with, items of parents that aren't in the batch are reported.
+func (e TableExecutor) callBatchResolve(ctx context.Context, client schema.ClientMeta, parents schema.Resources) diag.Diagnostics {
+	if len(parents) == 0 {
+		return nil
+	}
+	var diags diag.Diagnostics
+
+	inBatch := make(map[*schema.Resource]bool, len(parents))
+	for _, p := range parents {
+		inBatch[p] = true
+	}
+
+	res := make(chan interface{})
+	var resolverErr error
+	ctx = schema.WithClientStats(ctx, e.apiCalls.forClient(e.Table.Name, identifyClient(client)))
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				stack := string(debug.Stack())
+				e.Logger.Error("table batch resolver recovered from panic", "stack", stack)
+				stats.IncrResolverPanics(e.Table.Name)
+				resolverErr = diag.NewBaseError(fmt.Errorf("table batch resolver panic: %s", r), diag.RESOLVING, diag.WithResourceName(e.ResourceName), diag.WithSeverity(diag.PANIC),
+					diag.WithSummary("panic on resource table %q fetch", e.Table.Name), diag.WithDetails("%s", stack))
+			}
+			close(res)
+		}()
+		if err := e.Table.BatchResolver(ctx, client, parents, res); err != nil {
+			if e.IgnoreError(err) {
+				e.Logger.Debug("ignored an error", "err", err)
+				err = diag.NewBaseError(err, diag.RESOLVING, diag.WithSeverity(diag.IGNORE), diag.WithSummary("table %q resolver ignored error", e.Table.Name))
+			}
+			resolverErr = e.handleResolveError(client, nil, err)
+		}
+	}()
+
+	for elem := range res {
+		var (
+			order   []*schema.Resource
+			objects = make(map[*schema.Resource][]interface{})
+		)
+		for _, o := range helpers.InterfaceSlice(elem) {
+			item, ok := o.(schema.ParentItem)
+			if !ok {
+				diags = diags.Add(diag.NewBaseError(fmt.Errorf("received %T, expected schema.ParentItem", o), diag.RESOLVING, diag.WithResourceName(e.ResourceName),
+					diag.WithSeverity(diag.ERROR), diag.WithSummary("table %q batch resolver sent an item without parent", e.Table.Name)))
+				continue
+			}
+			if !inBatch[item.Parent] {
+				diags = diags.Add(diag.NewBaseError(fmt.Errorf("parent of item %T isn't in the batch", item.Item), diag.RESOLVING, diag.WithResourceName(e.ResourceName),
+					diag.WithSeverity(diag.ERROR), diag.WithSummary("table %q batch resolver sent an item of an unknown parent", e.Table.Name)))
+				continue
+			}
+			if _, ok := objects[item.Parent]; !ok {
+				order = append(order, item.Parent)
+			}
+			objects[item.Parent] = append(objects[item.Parent], item.Item)
+		}
+		for _, parent := range order {
+			e.Logger.Debug("received resources from batch resolver", "count", len(objects[parent]))
+			_, dd := e.resolveResources(schema.WithFetchContext(ctx, parent), client, parent, objects[parent])
+			diags = diags.Add(dd)
+		}
+	}
+	if resolverErr != nil {
+		diags = diags.Add(resolverErr)
+	}
+	return diags
+}
@@ -0,0 +1,47 @@
+package execution
+
+// DefaultBatchSize is the maximum amount of resources of a table resolved and inserted together, if not set by
+// WithBatchSize
+const DefaultBatchSize = 500
+
+// resourceBatcher buffers the objects sent by a table resolver, so they are resolved and stored in batches of up to size
+// objects as they arrive. Slices larger than a batch are split, smaller ones are buffered until the batch is full or the
+// resolver has nothing ready to receive. The resolver's channel isn't read while a batch is resolved, so resolvers
+// sending faster than resources are stored are blocked instead of growing the provider's memory.
+type resourceBatcher struct {
+	size    int
+	pending []interface{}
+	resolve func(objects []interface{})
+}
+
+func newResourceBatcher(size uint64, resolve func(objects []interface{})) *resourceBatcher {
+	if size == 0 {
+		size = DefaultBatchSize
+	}
+	return &resourceBatcher{size: int(size), resolve: resolve}
+}
+
+// add buffers the objects, resolving each batch that is full
+func (b *resourceBatcher) add(objects []interface{}) {
+	for len(objects) > 0 {
+		n := b.size - len(b.pending)
+		if n > len(objects) {
+			n = len(objects)
+		}
+		b.pending = append(b.pending, objects[:n]...)
+		objects = objects[n:]
+		if len(b.pending) >= b.size {
+			b.flush()
+		}
+	}
+}
+
+// flush resolves the buffered objects, if any
+func (b *resourceBatcher) flush() {
+	if len(b.pending) == 0 {
+		return
+	}
+	objects := b.pending
+	b.pending = nil
+	b.resolve(objects)
+}
@@ -0,0 +1,133 @@
+package execution
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultFlushTimeout is the time resolved resources may still be stored for once the execution is canceled
+const DefaultFlushTimeout = 30 * time.Second
+
+// ClientState is the completion state of a multiplexed client of an execution
+type ClientState int
+
+const (
+	// ClientPending clients weren't started, the execution was canceled before they were scheduled
+	ClientPending ClientState = iota
+	// ClientComplete clients resolved the table without errors
+	ClientComplete
+	// ClientPartial clients resolved the table, but some resources failed to resolve or store
+	ClientPartial
+	// ClientCanceled clients were canceled while resolving the table, the resources resolved so far are stored
+	ClientCanceled
+)
+
+func (s ClientState) String() string {
+	switch s {
+	case ClientPending:
+		return "pending"
+	case ClientComplete:
+		return "complete"
+	case ClientPartial:
+		return "partial"
+	case ClientCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// ClientStatus is the completion state of a multiplexed client of the execution's table
+type ClientStatus struct {
+	// ClientID identifies the client, prefixed with the table's name
+	ClientID string
+	State    ClientState
+	// ResourceCount is the amount of resources the client resolved, including relations
+	ResourceCount uint64
+}
+
+// clientStatuses records the states of the clients of an execution, in the order of the multiplexed clients
+type clientStatuses struct {
+	mu       sync.Mutex
+	statuses []ClientStatus
+}
+
+func newClientStatuses() *clientStatuses {
+	return &clientStatuses{}
+}
+
+// reset records the clients of the execution as pending
+func (c *clientStatuses) reset(ids []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = make([]ClientStatus, len(ids))
+	for i, id := range ids {
+		c.statuses[i] = ClientStatus{ClientID: id}
+	}
+}
+
+func (c *clientStatuses) finish(i int, state ClientState, count uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses[i].State = state
+	c.statuses[i].ResourceCount = count
+}
+
+// completed returns the amount of clients that finished resolving, with or without errors
+func (c *clientStatuses) completed() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, s := range c.statuses {
+		if s.State == ClientComplete || s.State == ClientPartial {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *clientStatuses) list() []ClientStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.statuses) == 0 {
+		return nil
+	}
+	return append([]ClientStatus(nil), c.statuses...)
+}
+
+// detachedContext has the values of its parent, but isn't canceled with it
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// flushContext returns the context resources are stored with, it has the values of ctx and is canceled timeout after
+// ctx is, so resources resolved before the execution is canceled are still stored. The returned stop function must be
+// called once the resources are stored.
+func flushContext(ctx context.Context, timeout time.Duration) (context.Context, func()) {
+	flushCtx, cancel := context.WithCancel(detachedContext{ctx})
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+			return
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-stop:
+		}
+	}()
+	return flushCtx, func() {
+		close(stop)
+		cancel()
+	}
+}
@@ -0,0 +1,148 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultCheckpointInterval is the minimum time between the checkpoints written for a client of a table, tokens
+// received in between are written once the interval passes or the client finishes
+const DefaultCheckpointInterval = 10 * time.Second
+
+// checkpointWriteTimeout bounds each write of a checkpoint, which is written even if the fetch was canceled
+const checkpointWriteTimeout = 10 * time.Second
+
+// createCheckpointsTable creates the checkpoints table, which keeps the progress of each client of each top level table
+// in a fetch
+const createCheckpointsTable = `CREATE TABLE IF NOT EXISTS "cq_fetch_checkpoints" (
+	"fetch_id" text NOT NULL,
+	"table_name" text NOT NULL,
+	"client_id" text NOT NULL,
+	"token" text,
+	"completed" boolean NOT NULL DEFAULT false,
+	"updated_at" timestamp without time zone NOT NULL,
+	PRIMARY KEY ("fetch_id", "table_name", "client_id")
+)`
+
+const upsertCheckpoint = `INSERT INTO "cq_fetch_checkpoints" ("fetch_id", "table_name", "client_id", "token", "completed", "updated_at") VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT ("fetch_id", "table_name", "client_id") DO UPDATE SET "token" = EXCLUDED."token", "completed" = EXCLUDED."completed", "updated_at" = EXCLUDED."updated_at"`
+
+// checkpoint is the recorded progress of a client of a table
+type checkpoint struct {
+	token     string
+	completed bool
+}
+
+// Checkpoints records the progress of the clients of top level tables in the cq_fetch_checkpoints table, so an
+// interrupted fetch can be resumed: clients that completed are skipped, and the others get the latest schema.ResumeToken
+// their resolver sent. The storage must support postgres statements.
+type Checkpoints struct {
+	db       Storage
+	fetchID  string
+	interval time.Duration
+	logger   hclog.Logger
+	// resumed are the checkpoints of the fetch being resumed, by table and client
+	resumed map[string]checkpoint
+}
+
+// NewCheckpoints creates the checkpoints of the fetch with the given id. If resume is true, the checkpoints already
+// recorded for the fetch are loaded, so the fetch continues where it was interrupted.
+func NewCheckpoints(ctx context.Context, db Storage, fetchID string, resume bool, logger hclog.Logger) (*Checkpoints, error) {
+	if fetchID == "" {
+		return nil, fmt.Errorf("checkpoints require a fetch id")
+	}
+	if err := db.Exec(ctx, createCheckpointsTable); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoints table: %w", err)
+	}
+	c := &Checkpoints{db: db, fetchID: fetchID, interval: DefaultCheckpointInterval, logger: logger, resumed: make(map[string]checkpoint)}
+	if !resume {
+		return c, nil
+	}
+	rows, err := db.Query(ctx, `SELECT "table_name", "client_id", "token", "completed" FROM "cq_fetch_checkpoints" WHERE "fetch_id" = $1`, fetchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints of fetch %s: %w", fetchID, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			table, client string
+			token         *string
+			cp            checkpoint
+		)
+		if err := rows.Scan(&table, &client, &token, &cp.completed); err != nil {
+			return nil, fmt.Errorf("failed to read checkpoints of fetch %s: %w", fetchID, err)
+		}
+		if token != nil {
+			cp.token = *token
+		}
+		c.resumed[checkpointKey(table, client)] = cp
+	}
+	return c, rows.Err()
+}
+
+// forClient returns the checkpoints of a client of a table, and the checkpoint it's resumed from
+func (c *Checkpoints) forClient(table, client string) (*clientCheckpoints, checkpoint) {
+	if c == nil {
+		return nil, checkpoint{}
+	}
+	return &clientCheckpoints{c: c, table: table, client: client}, c.resumed[checkpointKey(table, client)]
+}
+
+func (c *Checkpoints) write(table, client string, token *string, completed bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointWriteTimeout)
+	defer cancel()
+	if err := c.db.Exec(ctx, upsertCheckpoint, c.fetchID, table, client, token, completed, time.Now().UTC()); err != nil {
+		c.logger.Warn("failed to write checkpoint", logging.TableKey, table, logging.ClientIDKey, client, "error", err)
+	}
+}
+
+func checkpointKey(table, client string) string {
+	return table + "\x00" + client
+}
+
+// clientCheckpoints writes the checkpoints of a client of a table, at most once per interval
+type clientCheckpoints struct {
+	c             *Checkpoints
+	table, client string
+
+	mu        sync.Mutex
+	token     *string
+	pending   bool
+	lastWrite time.Time
+}
+
+// record checkpoints the token sent by the resolver, all the items sent before it are stored
+func (cc *clientCheckpoints) record(token string) {
+	if cc == nil {
+		return
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.token = &token
+	cc.pending = true
+	if time.Since(cc.lastWrite) < cc.c.interval {
+		return
+	}
+	cc.c.write(cc.table, cc.client, cc.token, false)
+	cc.pending = false
+	cc.lastWrite = time.Now()
+}
+
+// finish writes the pending token of a client that didn't complete, or marks the client as completed
+func (cc *clientCheckpoints) finish(completed bool) {
+	if cc == nil {
+		return
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if !completed && !cc.pending {
+		return
+	}
+	cc.c.write(cc.table, cc.client, cc.token, completed)
+	cc.pending = false
+}
@@ -0,0 +1,98 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+)
+
+// throttleMessages are lower cased substrings of the errors of throttled API calls, as returned by the common cloud SDKs
+var throttleMessages = []string{"throttl", "rate exceeded", "rate limit", "too many requests", "status code: 429", "requestlimitexceeded"}
+
+// connectionLossMessages are substrings of the errors of pgx connections that were lost
+var connectionLossMessages = []string{"conn closed", "failed to connect to", "failed to receive message", "failed to write startup message"}
+
+// DefaultErrorClassifier classifies the common errors of all providers, see ContextDeadlineClassifier, FDLimitClassifier,
+// ConnectionLossClassifier and ThrottleClassifier. Providers chain it after their own classifier:
+//
+//	ErrorClassifier: execution.ChainClassifiers(client.ErrorClassifier, execution.DefaultErrorClassifier)
+var DefaultErrorClassifier = ChainClassifiers(ContextDeadlineClassifier, FDLimitClassifier, ConnectionLossClassifier, ThrottleClassifier)
+
+// ChainClassifiers returns an ErrorClassifier evaluating classifiers in order, the diagnostics of the first classifier
+// that classifies the error are returned. Nil classifiers are skipped.
+func ChainClassifiers(classifiers ...ErrorClassifier) ErrorClassifier {
+	return func(meta schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+		for _, c := range classifiers {
+			if c == nil {
+				continue
+			}
+			if diags := c(meta, resourceName, err); diags != nil {
+				return diags
+			}
+		}
+		return nil
+	}
+}
+
+// ContextDeadlineClassifier classifies errors of resolvers that exceeded the fetch's timeout
+func ContextDeadlineClassifier(_ schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	return diag.Diagnostics{diag.NewBaseError(err, diag.RESOLVING, diag.WithResourceName(resourceName), diag.WithType(diag.RESOLVING),
+		diag.WithDetails("the resolver exceeded its timeout, consider increasing the timeout of the fetch"))}
+}
+
+// FDLimitClassifier classifies errors of exhausted file descriptors as warnings, as ClassifyError does
+func FDLimitClassifier(_ schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+	if !strings.Contains(err.Error(), "too many open files") {
+		return nil
+	}
+	return diag.Diagnostics{diag.NewBaseError(err, diag.THROTTLE, diag.WithResourceName(resourceName), diag.WithSeverity(diag.WARNING),
+		diag.WithType(diag.THROTTLE), diag.WithSummary(fdLimitMessage))}
+}
+
+// ConnectionLossClassifier classifies errors of lost database connections, so they aren't reported as resolver errors
+func ConnectionLossClassifier(_ schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+	if !isConnectionLoss(err) {
+		return nil
+	}
+	return diag.Diagnostics{diag.NewBaseError(err, diag.DATABASE, diag.WithResourceName(resourceName), diag.WithType(diag.DATABASE),
+		diag.WithSummary("lost connection to the database"), diag.WithDetails("verify the database is reachable and isn't restarting"))}
+}
+
+// ThrottleClassifier classifies errors of throttled API calls, i.e of exceeded rate limits
+func ThrottleClassifier(_ schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+	msg := strings.ToLower(err.Error())
+	for _, m := range throttleMessages {
+		if strings.Contains(msg, m) {
+			return diag.Diagnostics{diag.NewBaseError(err, diag.THROTTLE, diag.WithResourceName(resourceName), diag.WithType(diag.THROTTLE),
+				diag.WithDetails("API calls were throttled, consider lowering the concurrency of the fetch or the table's rate limit"))}
+		}
+	}
+	return nil
+}
+
+// isConnectionLoss returns true for errors of pgx connections that were closed or couldn't be established
+func isConnectionLoss(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.AdminShutdown, pgerrcode.CrashShutdown, pgerrcode.CannotConnectNow:
+			return true
+		}
+		return pgerrcode.IsConnectionException(pgErr.Code)
+	}
+	msg := err.Error()
+	for _, m := range connectionLossMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package execution
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/google/uuid"
+)
+
+// FetchCounters accumulates the rows and diagnostics of a fetch while it runs. It is shared by the fetch's table
+// executors via WithFetchCounters, so the host can query the fetch's progress.
+type FetchCounters struct {
+	rowsResolved   uint64
+	rowsStored     uint64
+	bytesEstimated uint64
+
+	mu    sync.Mutex
+	diags map[diag.Severity]uint64
+}
+
+// FetchCountersSnapshot is the state of FetchCounters at a point in time
+type FetchCountersSnapshot struct {
+	// RowsResolved is the amount of resources resolved, including relations
+	RowsResolved uint64
+	// RowsStored is the amount of resources written to storage
+	RowsStored uint64
+	// BytesEstimated is the estimated size of the stored resources' values
+	BytesEstimated uint64
+	// Diagnostics is the amount of diagnostics added with AddDiagnostics, by severity
+	Diagnostics map[diag.Severity]uint64
+}
+
+// NewFetchCounters creates empty FetchCounters
+func NewFetchCounters() *FetchCounters {
+	return &FetchCounters{diags: make(map[diag.Severity]uint64)}
+}
+
+// WithFetchCounters counts the executor's resolved and stored resources in c
+func WithFetchCounters(c *FetchCounters) Option {
+	return func(e *TableExecutor) {
+		e.counters = c
+	}
+}
+
+// AddDiagnostics counts the given diagnostics by severity, squashed diagnostics are counted by the diagnostics they hold
+func (c *FetchCounters) AddDiagnostics(diags diag.Diagnostics) {
+	if c == nil || len(diags) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, d := range diags {
+		c.diags[d.Severity()] += diag.CountDiag(d)
+	}
+}
+
+// Snapshot returns the counters accumulated so far
+func (c *FetchCounters) Snapshot() FetchCountersSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := FetchCountersSnapshot{
+		RowsResolved:   atomic.LoadUint64(&c.rowsResolved),
+		RowsStored:     atomic.LoadUint64(&c.rowsStored),
+		BytesEstimated: atomic.LoadUint64(&c.bytesEstimated),
+		Diagnostics:    make(map[diag.Severity]uint64, len(c.diags)),
+	}
+	for sev, n := range c.diags {
+		s.Diagnostics[sev] = n
+	}
+	return s
+}
+
+func (c *FetchCounters) resolved() {
+	if c == nil {
+		return
+	}
+	atomic.AddUint64(&c.rowsResolved, 1)
+}
+
+// stored counts the resources written to storage. Their sizes are estimated from the values the storage computed to
+// write them, which resources cache, so the values aren't converted again.
+func (c *FetchCounters) stored(resources schema.Resources) {
+	if c == nil || len(resources) == 0 {
+		return
+	}
+	atomic.AddUint64(&c.rowsStored, uint64(len(resources)))
+	var size uint64
+	for _, r := range resources {
+		size += estimateResourceSize(r)
+	}
+	atomic.AddUint64(&c.bytesEstimated, size)
+}
+
+// estimateResourceSize estimates the size of the resource's values. It is cheaper than encoding the values, and
+// meant to spot runaway fetches rather than to be accurate.
+func estimateResourceSize(r *schema.Resource) uint64 {
+	values, err := r.Values()
+	if err != nil {
+		return 0
+	}
+	var size uint64
+	for _, v := range values {
+		size += estimateValueSize(v)
+	}
+	return size
+}
+
+func estimateValueSize(v interface{}) uint64 {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case bool, int8, uint8:
+		return 1
+	case int16, uint16:
+		return 2
+	case int32, uint32, float32:
+		return 4
+	case int, int64, uint, uint64, float64, time.Time, *time.Time:
+		return 8
+	case uuid.UUID:
+		return 16
+	case string:
+		return uint64(len(val))
+	case *string:
+		if val == nil {
+			return 0
+		}
+		return uint64(len(*val))
+	case []byte:
+		return uint64(len(val))
+	case net.IP:
+		return uint64(len(val))
+	case []string:
+		var size uint64
+		for _, s := range val {
+			size += uint64(len(s))
+		}
+		return size
+	case []interface{}:
+		var size uint64
+		for _, e := range val {
+			size += estimateValueSize(e)
+		}
+		return size
+	default:
+		return uint64(len(fmt.Sprint(val)))
+	}
+}
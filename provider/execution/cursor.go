@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+)
+
+// cursorWriteTimeout bounds each write of a cursor, which is written even if the fetch was canceled
+const cursorWriteTimeout = 10 * time.Second
+
+// createCursorsTable creates the cursors table, which keeps the cursor of each client of each incremental table, per
+// tenant of multi tenant providers
+const createCursorsTable = `CREATE TABLE IF NOT EXISTS "cq_cursors" (
+	"tenant_id" text NOT NULL DEFAULT '',
+	"table_name" text NOT NULL,
+	"client_id" text NOT NULL,
+	"cursor" text NOT NULL,
+	"updated_at" timestamp without time zone NOT NULL,
+	PRIMARY KEY ("tenant_id", "table_name", "client_id")
+)`
+
+const upsertCursor = `INSERT INTO "cq_cursors" ("tenant_id", "table_name", "client_id", "cursor", "updated_at") VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT ("tenant_id", "table_name", "client_id") DO UPDATE SET "cursor" = EXCLUDED."cursor", "updated_at" = EXCLUDED."updated_at"`
+
+// Cursors keeps the cursors of the clients of schema.Table.Incremental tables in the cq_cursors table. Each client's
+// resolver gets the cursor stored by the previous fetch, and once the client is resolved without errors the largest
+// cursor column value it stored is written for the next fetch. The storage must support postgres statements.
+type Cursors struct {
+	db       Storage
+	tenantID string
+	logger   hclog.Logger
+	// stored are the cursors of the previous fetches, by table and client
+	stored map[string]string
+}
+
+// NewCursors creates the cursors of a fetch of the tenant, empty if the provider isn't multi tenant. If fullRefresh is
+// true, the stored cursors are ignored so incremental tables are fully fetched, and replaced once fetched.
+func NewCursors(ctx context.Context, db Storage, tenantID string, fullRefresh bool, logger hclog.Logger) (*Cursors, error) {
+	if err := db.Exec(ctx, createCursorsTable); err != nil {
+		return nil, fmt.Errorf("failed to create cursors table: %w", err)
+	}
+	c := &Cursors{db: db, tenantID: tenantID, logger: logger, stored: make(map[string]string)}
+	if fullRefresh {
+		return c, nil
+	}
+	rows, err := db.Query(ctx, `SELECT "table_name", "client_id", "cursor" FROM "cq_cursors" WHERE "tenant_id" = $1`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cursors: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, client, cursor string
+		if err := rows.Scan(&table, &client, &cursor); err != nil {
+			return nil, fmt.Errorf("failed to read cursors: %w", err)
+		}
+		c.stored[checkpointKey(table, client)] = cursor
+	}
+	return c, rows.Err()
+}
+
+// forClient returns the cursor of a client of an incremental table, and the cursor stored by the previous fetch
+func (c *Cursors) forClient(t *schema.Table, client string) (*clientCursor, string) {
+	if c == nil || t.Incremental == nil {
+		return nil, ""
+	}
+	return &clientCursor{c: c, table: t.Name, client: client, column: t.Incremental.CursorColumn}, c.stored[checkpointKey(t.Name, client)]
+}
+
+// clientCursor tracks the largest cursor column value stored by a client of a table
+type clientCursor struct {
+	c                     *Cursors
+	table, client, column string
+
+	mu  sync.Mutex
+	max interface{}
+}
+
+// observe keeps the largest cursor column value of the stored resources
+func (cc *clientCursor) observe(resources schema.Resources) {
+	if cc == nil || len(resources) == 0 {
+		return
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	for _, r := range resources {
+		v := cursorValue(r.Get(cc.column))
+		if v == nil {
+			continue
+		}
+		if cc.max == nil || cursorLess(cc.max, v) {
+			cc.max = v
+		}
+	}
+}
+
+// finish writes the cursor of a client that completed, clients that failed keep the cursor of the previous fetch so the
+// resources they missed are fetched again
+func (cc *clientCursor) finish(completed bool) {
+	if cc == nil || !completed {
+		return
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.max == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cursorWriteTimeout)
+	defer cancel()
+	if err := cc.c.db.Exec(ctx, upsertCursor, cc.c.tenantID, cc.table, cc.client, formatCursor(cc.max), time.Now().UTC()); err != nil {
+		cc.c.logger.Warn("failed to write cursor", logging.TableKey, cc.table, logging.ClientIDKey, cc.client, "error", err)
+	}
+}
+
+// cursorValue returns the comparable form of a cursor column value: a time.Time, int64, uint64 or string. Nil values
+// return nil.
+func cursorValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return t.UTC()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	default:
+		return fmt.Sprint(rv.Interface())
+	}
+}
+
+// cursorLess returns true if cursor value a is before b, values of different types are compared by their stored form
+func cursorLess(a, b interface{}) bool {
+	switch av := a.(type) {
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Before(bv)
+		}
+	case int64:
+		if bv, ok := b.(int64); ok {
+			return av < bv
+		}
+	case uint64:
+		if bv, ok := b.(uint64); ok {
+			return av < bv
+		}
+	}
+	return formatCursor(a) < formatCursor(b)
+}
+
+// formatCursor returns the stored form of a cursor value, timestamps are formatted as time.RFC3339Nano
+func formatCursor(v interface{}) string {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,90 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// deduplicator drops the resources of tables with schema.Table.Deduplicate that were already resolved, shared by all
+// the executors of a fetch so duplicates are detected across multiplexed clients
+type deduplicator struct {
+	mu      sync.Mutex
+	seen    map[string]map[string]struct{}
+	dropped map[string]int
+}
+
+func newDeduplicator() *deduplicator {
+	return &deduplicator{seen: make(map[string]map[string]struct{}), dropped: make(map[string]int)}
+}
+
+// filter returns the resources of t that weren't seen before, in order
+func (d *deduplicator) filter(t *schema.Table, resources schema.Resources) schema.Resources {
+	if !t.Deduplicate || len(resources) == 0 {
+		return resources
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	seen, ok := d.seen[t.Name]
+	if !ok {
+		seen = make(map[string]struct{})
+		d.seen[t.Name] = seen
+	}
+	unique := make(schema.Resources, 0, len(resources))
+	for _, r := range resources {
+		key := dedupKey(t, r)
+		if _, ok := seen[key]; ok {
+			d.dropped[t.Name]++
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, r)
+	}
+	return unique
+}
+
+// droppedCounts returns the amount of dropped duplicates by table name, sorted by name
+func (d *deduplicator) droppedCounts() ([]string, map[string]int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	names := make([]string, 0, len(d.dropped))
+	counts := make(map[string]int, len(d.dropped))
+	for name, n := range d.dropped {
+		names = append(names, name)
+		counts[name] = n
+	}
+	sort.Strings(names)
+	return names, counts
+}
+
+// dedupKey identifies the resource by the values of the table's DedupKeys, or by its cq_id if the table has primary keys
+// to generate it from. Without either, the cq_id is random, so resources are identified by the values of all the
+// table's columns.
+func dedupKey(t *schema.Table, r *schema.Resource) string {
+	keys := t.DedupKeys
+	if len(keys) == 0 {
+		if len(t.Options.PrimaryKeys) > 0 {
+			return r.Id().String()
+		}
+		keys = t.Columns.Names()
+	}
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = dedupValue(r.Get(k))
+	}
+	return strings.Join(values, "\x00")
+}
+
+// dedupValue formats the value as JSON, so pointers (i.e *string fields of SDK structs) are keyed by the values they
+// point to instead of their addresses
+func dedupValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
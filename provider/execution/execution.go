@@ -3,6 +3,7 @@ package execution
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"runtime/debug"
 	"strconv"
 	"sync"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/logging"
 	"github.com/cloudquery/cq-provider-sdk/provider/diag"
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/cloudquery/cq-provider-sdk/stats"
@@ -17,6 +19,7 @@ import (
 	"github.com/iancoleman/strcase"
 	segmentStats "github.com/segmentio/stats/v4"
 	"github.com/thoas/go-funk"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -44,29 +47,189 @@ type TableExecutor struct {
 	goroutinesSem *semaphore.Weighted
 	// timeout for each parent resource resolve call
 	timeout time.Duration
+	// apiCalls aggregates the API calls recorded by resolvers via schema.ClientStats
+	apiCalls *apiCallCollector
+	// columnPolicies omit or mask restricted columns before they are stored
+	columnPolicies schema.ColumnPolicies
+	// staleJitter is subtracted from executionStart when removing stale data by last update time
+	staleJitter time.Duration
+	// sequences assigns cq_sequence values to resources of tables with the Sequence option
+	sequences *sequenceCounter
+	// semaphoreStats records goroutinesSem acquisitions, if set
+	semaphoreStats *SemaphoreStats
+	// semaphoreWaitThreshold is the time the table may wait for goroutinesSem before a warning is reported
+	semaphoreWaitThreshold time.Duration
+	// counters accumulates the fetch's resolved and stored resources, if set
+	counters *FetchCounters
+	// rateLimiters limit the resolver calls of tables with a schema.RateLimit
+	rateLimiters *rateLimiters
+	// progress reports the execution's progress periodically, if set
+	progress *progressTracker
+	// parallelRelations resolves relations in goroutines of goroutinesSem, see WithParallelRelations
+	parallelRelations bool
+	// auditLog records the executions of the table by each client, if set
+	auditLog *AuditLog
+	// checkpoints record the progress of the table's clients to resume interrupted fetches, if set
+	checkpoints *Checkpoints
+	// checkpoint records the progress of the client resolving the table, set for each client of top level tables
+	checkpoint *clientCheckpoints
+	// resumeToken is the token the client's resolver checkpointed in the fetch being resumed
+	resumeToken string
+	// dedup drops duplicate resources of tables with schema.Table.Deduplicate
+	dedup *deduplicator
+	// sampleLimit if more than 0, is the maximum number of resources resolved per table resolver call
+	sampleLimit uint64
+	// verifier compares the resolved resources to the stored rows instead of writing them, if set
+	verifier *Verifier
+	// lineage records how the columns of resolved resources got their values, if set
+	lineage *Lineage
+	// tracer records the spans of the execution, see WithTracerProvider
+	tracer trace.Tracer
+	// failFast cancels the fetch on the first error of the execution, if set
+	failFast *FailFast
+	// quarantine keeps the items of resources that failed to resolve or insert, if set
+	quarantine *Quarantine
+	// redactor masks the sensitive values of resources in diagnostics, spans and logs, if set
+	redactor *Redactor
+	// tenantScoped is true if the storage's dialect is a schema.TenantDialect, the rows removed are then filtered by the
+	// fetch's tenant
+	tenantScoped bool
+	// cursors keep the cursors of the clients of incremental tables between fetches, if set
+	cursors *Cursors
+	// cursor tracks the cursor of the client resolving the table, set for each client of incremental tables
+	cursor *clientCursor
+	// storedCursor is the cursor of the client stored by the previous fetch, resources unchanged since it aren't fetched
+	storedCursor string
+	// fetchFilters limit the resources fetched of the filtered tables, by table name
+	fetchFilters map[string]*schema.FetchFilter
+	// history keeps the rows of previous fetches, see WithHistory
+	history bool
+	// staleReport counts the stale rows of the table before they are removed, if set
+	staleReport *StaleReport
+	// clients records the completion state of the table's multiplexed clients
+	clients *clientStatuses
+	// flushTimeout is the time resolved resources may still be stored for once the execution is canceled
+	flushTimeout time.Duration
+	// batchSize is the maximum amount of the table's resources resolved and stored together
+	batchSize uint64
 }
 
-// executionJitter adds a -1 minute to execution of fetch, so if a user fetches only 1 resources and it finishes
-// faster than the <1s it won't be deleted by remove stale.
-const executionJitter = -1 * time.Minute
+// Option configures optional behavior of a TableExecutor
+type Option func(*TableExecutor)
+
+// WithBatchSize sets the maximum amount of resources of a table resolved and stored together, DefaultBatchSize by
+// default. Smaller batches bound the memory of tables with large resources, larger batches are stored faster.
+func WithBatchSize(n uint64) Option {
+	return func(e *TableExecutor) {
+		if n > 0 {
+			e.batchSize = n
+		}
+	}
+}
+
+// WithFlushTimeout sets the time resolved resources may still be stored for once the execution is canceled,
+// DefaultFlushTimeout by default
+func WithFlushTimeout(d time.Duration) Option {
+	return func(e *TableExecutor) {
+		e.flushTimeout = d
+	}
+}
+
+// WithColumnPolicies applies the given policies to restricted columns of every resource before it is stored
+func WithColumnPolicies(p schema.ColumnPolicies) Option {
+	return func(e *TableExecutor) {
+		e.columnPolicies = p
+	}
+}
+
+// WithSampleLimit resolves at most n resources per table resolver call, canceling the resolver once they are received.
+// Stale data isn't removed, as the table is only partially resolved. Used to sample tables before a full fetch.
+func WithSampleLimit(n uint64) Option {
+	return func(e *TableExecutor) {
+		e.sampleLimit = n
+	}
+}
+
+// WithParallelRelations resolves the relations of resources in parallel, in goroutines of the executor's goroutinesSem
+// while any are available. When none are, relations are resolved serially by the goroutine of their parents.
+func WithParallelRelations() Option {
+	return func(e *TableExecutor) {
+		e.parallelRelations = true
+	}
+}
+
+// WithAuditLog records the execution of the table by each client in the audit log
+func WithAuditLog(a *AuditLog) Option {
+	return func(e *TableExecutor) {
+		e.auditLog = a
+	}
+}
+
+// WithCheckpoints records the progress of the table's clients, and resumes the clients of the fetch being resumed
+func WithCheckpoints(c *Checkpoints) Option {
+	return func(e *TableExecutor) {
+		e.checkpoints = c
+	}
+}
+
+// WithCursors passes the cursors stored by the previous fetch to the resolvers of incremental tables, and stores the
+// cursors of the clients resolved without errors
+func WithCursors(c *Cursors) Option {
+	return func(e *TableExecutor) {
+		e.cursors = c
+	}
+}
+
+// WithFetchFilters passes the filter of each filtered table, by name, to the table's resolver and stores only the
+// resources matching it. Stale data of filtered top level tables isn't removed.
+func WithFetchFilters(filters map[string]*schema.FetchFilter) Option {
+	return func(e *TableExecutor) {
+		e.fetchFilters = filters
+	}
+}
+
+// WithVerifier compares the resolved resources to the rows stored by previous fetches instead of writing them, the
+// tables that drifted are reported as warnings
+func WithVerifier(v *Verifier) Option {
+	return func(e *TableExecutor) {
+		e.verifier = v
+	}
+}
 
 // NewTableExecutor creates a new TableExecutor for given schema.Table
-func NewTableExecutor(resourceName string, db Storage, logger hclog.Logger, table *schema.Table, metadata map[string]interface{}, classifier ErrorClassifier, goroutinesSem *semaphore.Weighted, timeout time.Duration) TableExecutor {
+func NewTableExecutor(resourceName string, db Storage, logger hclog.Logger, table *schema.Table, metadata map[string]interface{}, classifier ErrorClassifier, goroutinesSem *semaphore.Weighted, timeout time.Duration, opts ...Option) TableExecutor {
 	var c [2]schema.ColumnList
-	c[0], c[1] = db.Dialect().Columns(table).Sift()
+	dialect := db.Dialect()
+	c[0], c[1] = dialect.Columns(table).Sift()
 
-	return TableExecutor{
+	e := TableExecutor{
 		ResourceName:   resourceName,
 		Table:          table,
 		Db:             db,
 		Logger:         logger,
 		metadata:       metadata,
 		classifier:     classifier,
-		executionStart: time.Now().Add(executionJitter),
+		executionStart: time.Now(),
 		columns:        c,
 		goroutinesSem:  goroutinesSem,
 		timeout:        timeout,
+		apiCalls:       newAPICallCollector(),
+		staleJitter:    DefaultStaleJitter,
+		sequences:      newSequenceCounter(),
+		rateLimiters:   newRateLimiters(),
+		dedup:          newDeduplicator(),
+		tracer:         defaultTracer(),
+		tenantScoped:   schema.IsTenantDialect(dialect),
+		clients:        newClientStatuses(),
+		flushTimeout:   DefaultFlushTimeout,
+		batchSize:      DefaultBatchSize,
+
+		semaphoreWaitThreshold: DefaultSemaphoreWaitThreshold,
+	}
+	for _, o := range opts {
+		o(&e)
 	}
+	return e
 }
 
 // Resolve is the root function of table executor which starts an execution of a Table resolving it, and it's relations.
@@ -79,7 +242,36 @@ func (e TableExecutor) Resolve(ctx context.Context, meta schema.ClientMeta) (uin
 		clients = e.Table.Multiplex(meta)
 	}
 
-	return e.doMultiplexResolve(ctx, clients)
+	defer e.progress.start(e.ResourceName, time.Now())()
+	ctx, span := e.startSpan(ctx, "Resolve")
+	count, diags := e.doMultiplexResolve(ctx, clients)
+	names, dropped := e.dedup.droppedCounts()
+	for _, name := range names {
+		e.Logger.Debug("dropped duplicate resources", "table", name, "count", dropped[name])
+		diags = diags.Add(diag.NewBaseError(nil, diag.RESOLVING, diag.WithSeverity(diag.WARNING), diag.WithResourceName(e.ResourceName),
+			diag.WithSummary("table %q dropped %d duplicate resources", name, dropped[name]),
+			diag.WithDetails("resources resolved more than once in the fetch are stored once, i.e when multiplexed clients return the same resource")))
+	}
+	if e.verifier != nil {
+		diags = diags.Add(e.verifier.diagnostics(e.ResourceName, e.Table))
+	}
+	if ctx.Err() != nil {
+		e.Logger.Warn("table fetch canceled", "completed_clients", e.clients.completed(), "clients", len(clients))
+	}
+	diags = e.redactor.Diagnostics(diags)
+	endSpan(span, count, diags)
+	return count, diags
+}
+
+// APICalls returns the API calls recorded by resolvers during the execution, aggregated per table and client
+func (e TableExecutor) APICalls() []APICallStat {
+	return e.apiCalls.stats()
+}
+
+// Clients returns the completion state of the table's multiplexed clients, in the order of the table's Multiplex. Clients
+// not scheduled before the execution was canceled are ClientPending.
+func (e TableExecutor) Clients() []ClientStatus {
+	return e.clients.list()
 }
 
 // withTable allows to create a new TableExecutor for received *schema.Table
@@ -109,6 +301,7 @@ func (e TableExecutor) doMultiplexResolve(ctx context.Context, clients []schema.
 		allDiags        diag.Diagnostics
 		doneClients     = 0
 		numberOfClients = 0
+		maxWait         time.Duration
 	)
 	// initially use client logger here
 	e.Logger.Debug("multiplexing client", "count", len(clients))
@@ -117,31 +310,85 @@ func (e TableExecutor) doMultiplexResolve(ctx context.Context, clients []schema.
 	go func() {
 		defer close(done)
 		for dd := range diagsChan {
+			e.failFast.check(dd)
 			allDiags = allDiags.Add(dd)
 			doneClients++
 		}
 		e.Logger.Debug("multiplexed client finished", "done", doneClients, "total", numberOfClients)
 	}()
 
-	wg := &sync.WaitGroup{}
-	for _, client := range clients {
+	// the table's limit is acquired first, so clients waiting for it don't hold goroutines of other tables
+	var tableSem *semaphore.Weighted
+	if e.Table.MaxConcurrency > 0 {
+		tableSem = semaphore.NewWeighted(int64(e.Table.MaxConcurrency))
+	}
+
+	clientIDs := make([]string, len(clients))
+	for i, client := range clients {
 		clientID := identifyClient(client)
 		if clientID == "" {
-			clientID = strconv.Itoa(numberOfClients + 1)
+			clientID = strconv.Itoa(i + 1)
+		}
+		clientIDs[i] = e.Table.Name + ":" + clientID
+	}
+	e.clients.reset(clientIDs)
+
+	wg := &sync.WaitGroup{}
+	for i, client := range clients {
+		clientID := clientIDs[i]
+		// once canceled no more clients are scheduled, the remaining clients stay pending
+		if ctx.Err() != nil {
+			e.Logger.Debug("execution canceled, skipping remaining clients", "remaining", len(clients)-i)
+			break
 		}
-		clientID = e.Table.Name + ":" + clientID
 
+		// the rate limit is waited for before acquiring goroutines, so throttled clients don't hold them
+		if rateDiags := e.waitRateLimit(ctx); rateDiags.HasDiags() {
+			diagsChan <- rateDiags
+			break
+		}
 		// we can only limit on a granularity of a top table otherwise we can get deadlock
 		e.Logger.Debug("trying acquire for new client", "next_id", clientID)
-		if err := e.goroutinesSem.Acquire(ctx, 1); err != nil {
+		if tableSem != nil {
+			if err := tableSem.Acquire(ctx, 1); err != nil {
+				diagsChan <- ClassifyError(err, diag.WithResourceName(e.ResourceName))
+				break
+			}
+		}
+		clock := stats.NewClockWithObserve("goroutinesSemAcquire", e.clockTags(clientID)...)
+		waitStart := time.Now()
+		err := e.goroutinesSem.Acquire(ctx, 1)
+		clock.Stop()
+		if err != nil {
+			if tableSem != nil {
+				tableSem.Release(1)
+			}
 			diagsChan <- ClassifyError(err, diag.WithResourceName(e.ResourceName))
 			break
 		}
+		// the goroutine may be acquired as the execution is canceled
+		if ctx.Err() != nil {
+			e.goroutinesSem.Release(1)
+			if tableSem != nil {
+				tableSem.Release(1)
+			}
+			break
+		}
+		wait := time.Since(waitStart)
+		e.semaphoreStats.acquired(wait)
+		stats.ObserveSemaphoreWait(e.Table.Name, wait)
+		if wait > maxWait {
+			maxWait = wait
+		}
 		numberOfClients++
-		e.Logger.Debug("creating new multiplex client", "client_id", clientID)
+		e.Logger.Debug("creating new multiplex client", logging.ClientIDKey, clientID)
 		wg.Add(1)
-		go func(c schema.ClientMeta, diags chan<- diag.Diagnostics, id string) {
+		go func(c schema.ClientMeta, diags chan<- diag.Diagnostics, i int, id string) {
+			if tableSem != nil {
+				defer tableSem.Release(1)
+			}
 			defer e.goroutinesSem.Release(1)
+			defer e.semaphoreStats.released()
 			defer wg.Done()
 			tableCtx := ctx
 			if e.timeout > 0 {
@@ -150,17 +397,45 @@ func (e TableExecutor) doMultiplexResolve(ctx context.Context, clients []schema.
 				defer cancel()
 			}
 			defer e.Logger.Debug("releasing multiplex client", "ctx_err", ctx.Err())
+			checkpoint, resumed := e.checkpoints.forClient(e.Table.Name, id)
+			if resumed.completed {
+				e.Logger.Debug("skipping client completed by the resumed fetch", logging.ClientIDKey, id)
+				e.clients.finish(i, ClientComplete, 0)
+				diags <- nil
+				return
+			}
+			auditID := e.auditLog.start(e.ResourceName, e.Table.Name, id)
 			// create client execution add all Client's implied Args to execution logger + add its unique client id, so all its execution can be
 			// identified.
-			count, resolveDiags := e.withLogger(append(c.Logger().ImpliedArgs(), "client_id", id)...).callTableResolve(tableCtx, c, nil)
+			clientExec := e.withLogger(append(c.Logger().ImpliedArgs(), logging.ClientIDKey, id)...)
+			clientExec.checkpoint, clientExec.resumeToken = checkpoint, resumed.token
+			clientExec.cursor, clientExec.storedCursor = e.cursors.forClient(e.Table, id)
+			count, resolveDiags := clientExec.callTableResolve(tableCtx, c, nil)
+			checkpoint.finish(ctx.Err() == nil && !resolveDiags.HasErrors())
+			clientExec.cursor.finish(ctx.Err() == nil && !resolveDiags.HasErrors())
+			e.auditLog.finish(auditID, ctx.Err() != nil, count, resolveDiags)
 			atomic.AddUint64(&totalResources, count)
+			state := ClientComplete
+			if ctx.Err() != nil {
+				state = ClientCanceled
+			} else if resolveDiags.HasErrors() {
+				state = ClientPartial
+			}
+			e.clients.finish(i, state, count)
 			diags <- resolveDiags
-		}(client, diagsChan, clientID)
+		}(client, diagsChan, i, clientID)
 	}
 	wg.Wait()
 	close(diagsChan)
 	<-done
 
+	if e.semaphoreWaitThreshold > 0 && maxWait > e.semaphoreWaitThreshold {
+		e.Logger.Warn("table waited for goroutines to resolve its clients", "max_wait", maxWait)
+		allDiags = allDiags.Add(diag.NewBaseError(nil, diag.INTERNAL, diag.WithSeverity(diag.WARNING), diag.WithResourceName(e.ResourceName),
+			diag.WithSummary("table %q waited %s for goroutines to resolve its clients", e.Table.Name, maxWait.Round(time.Second)),
+			diag.WithDetails("the fetch's max goroutines limit is reached, consider increasing it to fetch faster")))
+	}
+
 	e.Logger.Debug("table multiplex resolve completed")
 	return totalResources, allDiags
 }
@@ -171,34 +446,96 @@ func (e TableExecutor) cleanupStaleData(ctx context.Context, client schema.Clien
 	if parent != nil {
 		return nil
 	}
-	e.Logger.Debug("cleaning table stale data", "last_update", e.executionStart)
+	staleFilter := NewStaleFilter(e.metadata, e.executionStart, e.staleJitter)
+	e.Logger.Debug("cleaning table stale data", "last_update", staleFilter.LastUpdateBefore, "fetch_id", staleFilter.FetchId)
 
+	filters := e.deleteFilters(client, parent)
+	var stale uint64
+	if e.staleReport != nil {
+		var err error
+		if stale, err = e.staleReport.count(ctx, e.Db, e.Table, staleFilter, filters); err != nil {
+			e.Logger.Warn("failed to count table stale data", "last_update", staleFilter.LastUpdateBefore, "fetch_id", staleFilter.FetchId, "err", err)
+		}
+		if e.staleReport.skipCleanup {
+			e.staleReport.add(e.Table, stale)
+			e.Logger.Debug("table stale data cleanup is skipped")
+			return nil
+		}
+	}
+	if err := e.Db.RemoveStaleData(ctx, e.Table, staleFilter, filters); err != nil {
+		e.Logger.Warn("failed to clean table stale data", "last_update", staleFilter.LastUpdateBefore, "fetch_id", staleFilter.FetchId, "err", err)
+		return err
+	}
+	// rows are only reported removed once they are
+	if e.staleReport != nil {
+		e.staleReport.add(e.Table, stale)
+	}
+	e.Logger.Debug("cleaned table stale data successfully", "last_update", staleFilter.LastUpdateBefore, "fetch_id", staleFilter.FetchId)
+	return nil
+}
+
+// deleteFilters returns the key/value filters of the table's DeleteFilter, selecting the client's rows. Tables of a
+// schema.TenantDialect are also filtered by the fetch's tenant, so fetches of a tenant don't remove the rows of others.
+func (e TableExecutor) deleteFilters(client schema.ClientMeta, parent *schema.Resource) []interface{} {
 	var filters []interface{}
 	if e.Table.DeleteFilter != nil {
-		filters = append(filters, e.Table.DeleteFilter(client, parent)...)
+		filters = e.Table.DeleteFilter(client, parent)
 	}
-	if err := e.Db.RemoveStaleData(ctx, e.Table, e.executionStart, filters); err != nil {
-		e.Logger.Warn("failed to clean table stale data", "last_update", e.executionStart, "err", err)
-		return err
+	if e.tenantScoped {
+		// the full slice expression copies the table's filters instead of appending to them
+		filters = append(filters[:len(filters):len(filters)], schema.TenantIdColumnName, e.metadata[schema.TenantIdMetaKey])
+	}
+	return filters
+}
+
+// clockTags returns the tags of the execution's clocks of a client, tagged with the fetch id so the durations of
+// concurrent fetches of the same table aren't mixed
+func (e TableExecutor) clockTags(clientID string) []segmentStats.Tag {
+	tags := []segmentStats.Tag{{Name: "client_id", Value: clientID}, {Name: "table", Value: e.Table.Name}}
+	if id, ok := e.metadata[schema.FetchIdMetaKey].(string); ok && id != "" {
+		tags = append(tags, segmentStats.Tag{Name: "fetch_id", Value: id})
+	}
+	return tags
+}
+
+// waitRateLimit waits for a token of the table's rate limit, if it has one. Callers wait before acquiring goroutines
+// of goroutinesSem for the table's resolve, so throttled resolves don't hold goroutines other tables could use.
+func (e TableExecutor) waitRateLimit(ctx context.Context) diag.Diagnostics {
+	limiter := e.rateLimiters.forTable(e.Table)
+	if limiter == nil {
+		return nil
+	}
+	var diags diag.Diagnostics
+	if err := limiter.Wait(ctx); err != nil {
+		return diags.Add(ClassifyError(err, diag.WithResourceName(e.ResourceName), diag.WithType(diag.THROTTLE), diag.WithSummary("table %q rate limit wait failed", e.Table.Name)))
 	}
-	e.Logger.Debug("cleaned table stale data successfully", "last_update", e.executionStart)
 	return nil
 }
 
 // callTableResolve does the actual resolving of the table calling the root table's resolver and for each returned resource resolves its columns and relations.
-func (e TableExecutor) callTableResolve(ctx context.Context, client schema.ClientMeta, parent *schema.Resource) (uint64, diag.Diagnostics) {
-	clock := stats.NewClockWithObserve("callTableResolve", segmentStats.Tag{Name: "client_id", Value: identifyClient(client)}, segmentStats.Tag{Name: "table", Value: e.Table.Name})
+func (e TableExecutor) callTableResolve(ctx context.Context, client schema.ClientMeta, parent *schema.Resource) (nc uint64, diags diag.Diagnostics) {
+	clock := stats.NewClockWithObserve("callTableResolve", e.clockTags(identifyClient(client))...)
 	defer clock.Stop()
-
-	// set up all diagnostics to collect from resolving table
-	var diags diag.Diagnostics
+	ctx, span := e.startSpan(ctx, "callTableResolve", ClientIDAttribute.String(identifyClient(client)))
+	defer func() {
+		e.failFast.check(diags)
+		endSpan(span, nc, e.redactor.Diagnostics(diags))
+	}()
 
 	if e.Table.Resolver == nil {
 		return 0, diags.Add(diag.NewBaseError(nil, diag.SCHEMA, diag.WithSeverity(diag.ERROR), diag.WithResourceName(e.ResourceName), diag.WithSummary("table %q missing resolver, make sure table implements the resolver", e.Table.Name)))
 	}
 
+	stager := e.stager()
+	if parent == nil && stager != nil {
+		e.discardStaged(ctx, stager, client)
+	}
+
 	res := make(chan interface{})
 	var resolverErr error
+	ctx = schema.WithClientStats(ctx, e.apiCalls.forClient(e.Table.Name, identifyClient(client)))
+	resolverCtx, cancelResolver := context.WithCancel(ctx)
+	defer cancelResolver()
 
 	// we are not using goroutinesSem semaphore here as it's just a +1 goroutine and it might get us deadlocked
 	go func() {
@@ -206,12 +543,31 @@ func (e TableExecutor) callTableResolve(ctx context.Context, client schema.Clien
 			if r := recover(); r != nil {
 				stack := string(debug.Stack())
 				e.Logger.Error("table resolver recovered from panic", "stack", stack)
+				stats.IncrResolverPanics(e.Table.Name)
 				resolverErr = diag.NewBaseError(fmt.Errorf("table resolver panic: %s", r), diag.RESOLVING, diag.WithResourceName(e.ResourceName), diag.WithSeverity(diag.PANIC),
 					diag.WithSummary("panic on resource table %q fetch", e.Table.Name), diag.WithDetails("%s", stack))
 			}
 			close(res)
 		}()
-		if err := e.Table.Resolver(ctx, client, parent, res); err != nil {
+		callCtx := resolverCtx
+		if parent == nil && e.resumeToken != "" {
+			callCtx = schema.WithResumeToken(callCtx, e.resumeToken)
+		}
+		if parent == nil && e.storedCursor != "" {
+			callCtx = schema.WithCursor(callCtx, e.storedCursor)
+		}
+		if f := e.fetchFilters[e.Table.Name]; f != nil {
+			callCtx = schema.WithFetchFilter(callCtx, f)
+		}
+		if parent != nil && e.Table.ParentItemResolver != nil {
+			v, err := e.Table.ParentItemResolver(callCtx, client, parent)
+			if err != nil {
+				resolverErr = e.handleResolveError(client, parent, err, diag.WithSummary("parent item resolver failed for table %q", e.Table.Name))
+				return
+			}
+			callCtx = schema.WithParentItemValue(callCtx, v)
+		}
+		if err := e.callResolver(callCtx, client, parent, res); err != nil {
 			if e.IgnoreError(err) {
 				e.Logger.Debug("ignored an error", "err", err)
 				err = diag.NewBaseError(err, diag.RESOLVING, diag.WithSeverity(diag.IGNORE), diag.WithSummary("table %q resolver ignored error", e.Table.Name))
@@ -220,18 +576,62 @@ func (e TableExecutor) callTableResolve(ctx context.Context, client schema.Clien
 		}
 	}()
 
-	nc := uint64(0)
-	for elem := range res {
-		objects := helpers.InterfaceSlice(elem)
-		if len(objects) == 0 {
-			continue
-		}
-		e.Logger.Debug("received resources from resolver", "count", len(objects))
+	batcher := newResourceBatcher(e.batchSize, func(objects []interface{}) {
 		resolvedCount, dd := e.resolveResources(ctx, client, parent, objects)
 		e.Logger.Debug("resolved resources", "original_count", len(objects), "resolved_count", resolvedCount)
 		// append any diags from resolve resources
 		diags = diags.Add(dd)
+		e.failFast.check(dd)
 		nc += resolvedCount
+	})
+	var (
+		sampled  bool
+		received uint64
+	)
+	for {
+		var (
+			elem interface{}
+			ok   bool
+		)
+		select {
+		case elem, ok = <-res:
+		default:
+			// the resolver has nothing ready, the buffered resources are stored meanwhile
+			batcher.flush()
+			elem, ok = <-res
+		}
+		if !ok {
+			break
+		}
+		// once sampled, drain the resolver until it returns on the canceled context
+		if sampled {
+			continue
+		}
+		if token, ok := elem.(schema.ResumeToken); ok {
+			// the resources received before the token are stored before it's checkpointed
+			batcher.flush()
+			if parent == nil {
+				e.checkpoint.record(string(token))
+			}
+			continue
+		}
+		objects := helpers.InterfaceSlice(elem)
+		if len(objects) == 0 {
+			continue
+		}
+		if e.sampleLimit > 0 && received+uint64(len(objects)) >= e.sampleLimit {
+			objects = objects[:e.sampleLimit-received]
+			sampled = true
+			cancelResolver()
+		}
+		e.Logger.Debug("received resources from resolver", "count", len(objects))
+		received += uint64(len(objects))
+		batcher.add(objects)
+	}
+	batcher.flush()
+	// errors of the canceled resolver are expected once it's sampled
+	if sampled && ctx.Err() == nil {
+		resolverErr = nil
 	}
 	// check if channel iteration stopped because of resolver failure
 	if resolverErr != nil {
@@ -239,6 +639,10 @@ func (e TableExecutor) callTableResolve(ctx context.Context, client schema.Clien
 
 		if diag.FromError(resolverErr, diag.INTERNAL).HasErrors() {
 			e.Logger.Error("received resolve resources error", "error", resolverErr)
+			// the resources resolved before the execution was canceled are stored
+			if ctx.Err() != nil {
+				return nc, diags
+			}
 			return 0, diags
 		}
 	}
@@ -247,6 +651,27 @@ func (e TableExecutor) callTableResolve(ctx context.Context, client schema.Clien
 		e.Logger.Info("fetched successfully", "count", nc)
 	}
 
+	if e.sampleLimit > 0 || e.verifier != nil {
+		return nc, diags
+	}
+	// the table isn't fully fetched once canceled, its previous rows aren't stale
+	if ctx.Err() != nil {
+		return nc, diags
+	}
+	if parent == nil && stager != nil {
+		if err := e.swapStaged(ctx, stager, client); err != nil {
+			return nc, diags.Add(ClassifyError(err, diag.WithType(diag.DATABASE), diag.WithSummary("failed to swap staged table %q", e.Table.Name)))
+		}
+		return nc, diags
+	}
+	// incremental and filtered fetches only get some of the resources, the others aren't stale
+	if parent == nil && (e.storedCursor != "" || e.fetchFilters[e.Table.Name] != nil) {
+		return nc, diags
+	}
+	// the rows of previous fetches are the history of the table
+	if e.history {
+		return nc, diags
+	}
 	if err := e.cleanupStaleData(ctx, client, parent); err != nil {
 		return nc, diags.Add(ClassifyError(err, diag.WithType(diag.DATABASE), diag.WithSummary("failed to cleanup stale data on table %q", e.Table.Name)))
 	}
@@ -254,6 +679,30 @@ func (e TableExecutor) callTableResolve(ctx context.Context, client schema.Clien
 	return nc, diags
 }
 
+// callResolver calls the table's resolver, retrying it on transient errors by the table's schema.RetryPolicy
+func (e TableExecutor) callResolver(ctx context.Context, client schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+	policy := e.Table.RetryPolicy
+	if policy == nil {
+		return e.Table.Resolver(ctx, client, parent, res)
+	}
+	for attempt := 1; ; attempt++ {
+		err := e.Table.Resolver(ctx, client, parent, res)
+		if err == nil || attempt >= policy.Attempts() || ctx.Err() != nil || !policy.Retryable(err) {
+			return err
+		}
+		backoff := policy.Backoff(attempt)
+		e.Logger.Warn("table resolver failed, retrying", "attempt", attempt, "backoff", backoff, "error", err)
+		if helpers.Sleep(ctx, backoff) != nil {
+			return err
+		}
+		if limiter := e.rateLimiters.forTable(e.Table); limiter != nil {
+			if limiter.Wait(ctx) != nil {
+				return err
+			}
+		}
+	}
+}
+
 // resolveResources resolves a list of resource objects inserting them into the database and resolving their relations based on the table.
 func (e TableExecutor) resolveResources(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, objects []interface{}) (uint64, diag.Diagnostics) {
 	var (
@@ -265,76 +714,137 @@ func (e TableExecutor) resolveResources(ctx context.Context, meta schema.ClientM
 		resource := schema.NewResourceData(e.Db.Dialect(), e.Table, parent, objects[i], e.metadata, e.executionStart)
 		// Before inserting resolve all table column resolvers
 		resolveDiags := e.resolveResourceValues(ctx, meta, resource)
+		e.redactor.addResource(resource)
 		diags = diags.Add(resolveDiags)
 		if resolveDiags.HasErrors() {
 			e.Logger.Warn("skipping failed resolved resource", "reason", resolveDiags.Error())
+			e.quarantine.add(e.Table.Name, objects[i], resolveDiags, e.redactor)
+			continue
+		}
+		if f := e.fetchFilters[e.Table.Name]; f != nil && !f.Match(resource) {
+			e.Logger.Trace("skipping resource not matching fetch filter", "filter", f.Expression)
 			continue
 		}
+		if e.Table.Options.Sequence {
+			if err := resource.Set(schema.SequenceColumnName, e.sequences.Next(e.Table.Name)); err != nil {
+				diags = diags.Add(fromError(err, diag.WithResourceName(e.ResourceName), WithResource(resource), diag.WithType(diag.INTERNAL),
+					diag.WithSummary("failed to set sequence of resource in table %q", e.Table.Name)))
+				continue
+			}
+		}
+		e.counters.resolved()
 		resources = append(resources, resource)
 	}
+	e.progress.resolved(identifyClient(meta), len(resources))
+	stats.AddResourcesFetched(e.Table.Name, len(resources))
 
 	// only top level tables should cascade
 	shouldCascade := parent == nil
 	resources, dbDiags := e.saveToStorage(ctx, resources, shouldCascade)
 	e.Logger.Debug("saved resources to storage", "resources", len(resources))
+	e.counters.stored(resources)
+	if parent == nil {
+		e.cursor.observe(resources)
+	}
 	diags = diags.Add(dbDiags)
 	totalCount := uint64(len(resources))
 
 	// Finally, resolve relations of each resource
+	runner := &relationRunner{e: &e}
 	for _, rel := range e.Table.Relations {
 		e.Logger.Debug("resolving table relation", "relation", rel.Name)
+		relExec := e.withTable(rel)
+		if rel.BatchResolver != nil {
+			if rateDiags := relExec.waitRateLimit(ctx); rateDiags.HasDiags() {
+				diags = diags.Add(rateDiags)
+				continue
+			}
+			runner.run(func() diag.Diagnostics {
+				return relExec.callBatchResolve(ctx, meta, resources)
+			})
+			continue
+		}
 		for _, r := range resources {
-			// ignore relation resource count
-			if _, innerDiags := e.withTable(rel).callTableResolve(ctx, meta, r); innerDiags.HasDiags() {
-				diags = diags.Add(innerDiags)
+			r := r
+			if rateDiags := relExec.waitRateLimit(ctx); rateDiags.HasDiags() {
+				diags = diags.Add(rateDiags)
+				continue
 			}
+			runner.run(func() diag.Diagnostics {
+				// ignore relation resource count
+				_, innerDiags := relExec.callTableResolve(schema.WithFetchContext(ctx, r), meta, r)
+				return innerDiags
+			})
 		}
-		e.Logger.Debug("finished resolving table relation", "relation", rel.Name)
+	}
+	diags = diags.Add(runner.wait())
+	if len(e.Table.Relations) > 0 {
+		e.Logger.Debug("finished resolving table relations", "relations", len(e.Table.Relations))
 	}
 	return totalCount, diags
 }
 
 // saveToStorage copies resource data to source, it has ways of inserting, first it tries the most performant CopyFrom if that does work it bulk inserts,
-// finally it inserts each resource separately, appending errors for each failed resource, only successfully inserted resources are returned
-func (e TableExecutor) saveToStorage(ctx context.Context, resources schema.Resources, shouldCascade bool) (schema.Resources, diag.Diagnostics) {
-	var diags diag.Diagnostics
+// finally it inserts each resource separately, appending errors for each failed resource, only successfully inserted resources are returned.
+// Tables with schema.InsertModeUpsert skip CopyFrom, which can't update existing rows. Duplicates of tables with
+// schema.Table.Deduplicate are dropped before they are stored. Resources of executors with a Verifier are verified
+// instead of stored.
+func (e TableExecutor) saveToStorage(ctx context.Context, resources schema.Resources, shouldCascade bool) (saved schema.Resources, diags diag.Diagnostics) {
+	ctx, span := e.startSpan(ctx, "saveToStorage", BatchSizeAttribute.Int(len(resources)))
+	defer func() { endSpan(span, uint64(len(saved)), e.redactor.Diagnostics(diags)) }()
+	// resolved resources are still stored if the execution is canceled meanwhile, until the flush timeout
+	ctx, stopFlush := flushContext(ctx, e.flushTimeout)
+	defer stopFlush()
+	resources = e.dedup.filter(e.Table, resources)
 	if l := len(resources); l > 0 {
-		e.Logger.Debug("storing resources", "count", l)
+		e.Logger.Debug("storing resources", "count", l, "insert_mode", e.Table.InsertMode)
 	}
-	err := e.Db.CopyFrom(ctx, resources, shouldCascade)
-	if err == nil {
+	if e.verifier != nil {
+		if err := e.verifier.verify(ctx, e.Db, e.Table, resources); err != nil {
+			return resources, diags.Add(ClassifyError(err, diag.WithType(diag.DATABASE), diag.WithSummary("failed to verify resources of table %q", e.Table.Name)))
+		}
 		return resources, diags
 	}
-	e.Logger.Warn("failed copy-from to db", "error", err)
-	diags = diags.Add(diag.TelemetryFromError(err, diag.CopyFromFailed))
+	if s := e.stager(); s != nil {
+		return e.stageResources(ctx, s, resources)
+	}
+	var copied schema.Resources
+	remaining := resources
+	if e.Table.InsertMode != schema.InsertModeUpsert {
+		var copyDiags diag.Diagnostics
+		copied, remaining, copyDiags = e.copyToStorage(ctx, resources, shouldCascade)
+		diags = diags.Add(copyDiags)
+		if len(remaining) == 0 {
+			return copied, diags
+		}
+	}
 
 	// fallback insert, copy from sometimes does problems, so we fall back with bulk insert
-	err = e.Db.Insert(ctx, e.Table, resources, shouldCascade)
+	start := time.Now()
+	err := e.Db.Insert(ctx, e.Table, remaining, shouldCascade)
+	stats.ObserveWrite(e.Table.Name, "insert", time.Since(start))
 	if err == nil {
-		return resources, diags
+		return append(copied, remaining...), diags
 	}
 	e.Logger.Error("failed insert to db", "error", err)
 	diags = diags.Add(diag.TelemetryFromError(err, diag.BulkInsertFailed))
 	// Setup diags, adding first diagnostic that bulk insert failed
 	diags = diags.Add(ClassifyError(err, diag.WithType(diag.DATABASE), diag.WithSummary("failed bulk insert on table %q", e.Table.Name)))
-	// Try to insert resource by resource if partial fetch is enabled and an error occurred
-	partialFetchResources := make(schema.Resources, 0)
+	// Insert in smaller sub-batches to isolate the failing resources, if partial fetch is enabled and an error occurred
+	inserted, failures := e.writeSubBatches(remaining, func(batch schema.Resources) error {
+		return e.Db.Insert(ctx, e.Table, batch, shouldCascade)
+	})
 	var failed error
-	failedCount := 0
-	for id := range resources {
-		if err := e.Db.Insert(ctx, e.Table, schema.Resources{resources[id]}, shouldCascade); err != nil {
-			failed = err
-			failedCount++
-			e.Logger.Error("failed to insert resource into db", "error", err, "resource_keys", resources[id].PrimaryKeyValues())
-			diags = diags.Add(ClassifyError(err, diag.WithType(diag.DATABASE)))
-			continue
-		}
-		// If there is no error we add the resource to the final result
-		partialFetchResources = append(partialFetchResources, resources[id])
+	for _, f := range failures {
+		failed = f.err
+		e.Logger.Error("failed to insert resource into db", "error", f.err, "resource_keys", f.resource.PrimaryKeyValues())
+		e.quarantine.add(e.Table.Name, f.resource.Item, f.err, e.redactor)
+		diags = diags.Add(ClassifyError(f.err, diag.WithType(diag.DATABASE), WithResource(f.resource),
+			diag.WithSummary("failed to store resource %v in table %q", f.resource.PrimaryKeyValues(), e.Table.Name)))
 	}
 	if failed != nil {
 		msg := "all resources"
-		if failedCount < len(resources) {
+		if len(failures) < len(resources) {
 			msg = "some resources"
 		}
 		diags = diags.Add(diag.TelemetryFromError(
@@ -343,7 +853,69 @@ func (e TableExecutor) saveToStorage(ctx context.Context, resources schema.Resou
 			diag.WithSummary("%s failed to insert into table %q", msg, e.Table.Name),
 		))
 	}
-	return partialFetchResources, diags
+	return append(copied, inserted...), diags
+}
+
+// copyToStorage copies resources with CopyFrom, returning the copied resources and the resources that failed copy-from
+func (e TableExecutor) copyToStorage(ctx context.Context, resources schema.Resources, shouldCascade bool) (schema.Resources, schema.Resources, diag.Diagnostics) {
+	start := time.Now()
+	err := e.Db.CopyFrom(ctx, resources, shouldCascade)
+	stats.ObserveWrite(e.Table.Name, "copy_from", time.Since(start))
+	if err == nil {
+		return resources, nil, nil
+	}
+	e.Logger.Warn("failed copy-from to db", "error", err)
+	stats.IncrCopyFromFallbacks(e.Table.Name)
+	diags := diag.Diagnostics{}.Add(diag.TelemetryFromError(err, diag.CopyFromFailed))
+
+	// copy in smaller sub-batches to pinpoint the resources failing copy-from, so only they are inserted
+	copied, copyFailures := e.writeSubBatches(resources, func(batch schema.Resources) error {
+		return e.Db.CopyFrom(ctx, batch, shouldCascade)
+	})
+	remaining := make(schema.Resources, len(copyFailures))
+	for i, f := range copyFailures {
+		e.Logger.Warn("failed copy-from of resource to db", "error", f.err, "resource_keys", f.resource.PrimaryKeyValues())
+		remaining[i] = f.resource
+	}
+	return copied, remaining, diags
+}
+
+// failedWrite is a resource that failed to be written on its own
+type failedWrite struct {
+	resource *schema.Resource
+	err      error
+}
+
+// writeSubBatches writes resources, of a batch that failed to be written, in halves. Halves that fail are split again
+// until the failing resources are written on their own, so a few bad resources in a large batch cost a logarithmic
+// number of writes instead of a write per resource. Returns the written resources, in their original order.
+func (e TableExecutor) writeSubBatches(resources schema.Resources, write func(schema.Resources) error) (schema.Resources, []failedWrite) {
+	if len(resources) == 1 {
+		if err := write(resources); err != nil {
+			return nil, []failedWrite{{resources[0], err}}
+		}
+		return resources, nil
+	}
+	var (
+		written  = make(schema.Resources, 0, len(resources))
+		failures []failedWrite
+		mid      = len(resources) / 2
+	)
+	for _, batch := range []schema.Resources{resources[:mid], resources[mid:]} {
+		err := write(batch)
+		switch {
+		case err == nil:
+			written = append(written, batch...)
+		case len(batch) == 1:
+			failures = append(failures, failedWrite{batch[0], err})
+		default:
+			e.Logger.Debug("failed to write sub-batch, splitting", "count", len(batch), "error", err)
+			ok, failed := e.writeSubBatches(batch, write)
+			written = append(written, ok...)
+			failures = append(failures, failed...)
+		}
+	}
+	return written, failures
 }
 
 // resolveResourceValues does the actual resolve of all the columns of table for said resource.
@@ -352,6 +924,7 @@ func (e TableExecutor) resolveResourceValues(ctx context.Context, meta schema.Cl
 		if r := recover(); r != nil {
 			stack := string(debug.Stack())
 			e.Logger.Error("resolve table recovered from panic", "panic_msg", r, "stack", stack)
+			stats.IncrResolverPanics(e.Table.Name)
 			diags = fromError(fmt.Errorf("column resolve panic: %s", r), diag.WithResourceName(e.ResourceName), diag.WithSeverity(diag.PANIC),
 				diag.WithSummary("resolve table %q recovered from panic", e.Table.Name), diag.WithDetails("%s", stack))
 		}
@@ -378,6 +951,7 @@ func (e TableExecutor) resolveResourceValues(ctx context.Context, meta schema.Cl
 			return diags.Add(fromError(err, diag.WithResourceName(e.ResourceName), WithResource(resource), diag.WithType(diag.INTERNAL), diag.WithSummary("default column %q resolver execution", c.Name)))
 		}
 	}
+	e.columnPolicies.Apply(resource)
 	return diags
 }
 
@@ -389,6 +963,7 @@ func (e TableExecutor) resolveColumns(ctx context.Context, meta schema.ClientMet
 		if r := recover(); r != nil {
 			stack := string(debug.Stack())
 			e.Logger.Error("resolve columns recovered from panic", "panic_msg", r, "stack", stack, "column_name", col)
+			stats.IncrResolverPanics(e.Table.Name)
 			diags = fromError(fmt.Errorf("column resolve panic: %s", r), diag.WithResourceName(e.ResourceName), diag.WithSeverity(diag.PANIC),
 				diag.WithSummary("resolve column %q in table %q recovered from panic", col, e.Table.Name), diag.WithDetails("%s", stack))
 		}
@@ -400,27 +975,131 @@ func (e TableExecutor) resolveColumns(ctx context.Context, meta schema.ClientMet
 			e.Logger.Trace("using custom column resolver", "column", c.Name)
 			err := c.Resolver(ctx, meta, resource, c)
 			if err == nil {
+				diags = diags.Add(e.completeColumn(meta, resource, c, SourceResolver, ""))
 				continue
 			}
 			// Not allowed ignoring PK resolver errors
 			if funk.ContainsString(e.Db.Dialect().PrimaryKeys(e.Table), c.Name) {
 				return diags.Add(ClassifyError(err, diag.WithResourceName(e.ResourceName), WithResource(resource), diag.WithSummary("failed to resolve column %s@%s", e.Table.Name, c.Name)))
 			}
-			diags = diags.Add(e.handleResolveError(meta, resource, err, diag.WithSummary("column resolver %q failed for table %q", c.Name, e.Table.Name)))
+			resolveDiags := e.handleResolveError(meta, resource, err, diag.WithSummary("column resolver %q failed for table %q", c.Name, e.Table.Name))
+			if !resolveDiags.HasErrors() {
+				resource.AddFallbackColumn(c.Name)
+				resolveDiags = resolveDiags.Add(e.completeColumn(meta, resource, c, SourceIgnoredError, ""))
+			}
+			diags = diags.Add(resolveDiags)
+			continue
+		}
+		if c.InheritFromParent != "" && resource.Parent != nil {
+			e.Logger.Trace("inheriting column value from parent", "column", c.Name, "parent_column", c.InheritFromParent)
+			if err := resource.Set(c.Name, resource.Parent.Get(c.InheritFromParent)); err != nil {
+				diags = diags.Add(fromError(err, diag.WithResourceName(e.ResourceName), diag.WithType(diag.INTERNAL),
+					diag.WithSummary("failed to set resource value for column %s@%s", e.Table.Name, c.Name)))
+				continue
+			}
+			diags = diags.Add(e.completeColumn(meta, resource, c, SourceParent, c.InheritFromParent))
 			continue
 		}
 		e.Logger.Trace("resolving column value with path", "column", c.Name)
 		// base use case: try to get column with CamelCase name
-		v := funk.Get(resource.Item, strcase.ToCamel(c.Name), funk.WithAllowZero())
-		e.Logger.Trace("setting column value", "column", c.Name, "value", v)
+		path := strcase.ToCamel(c.Name)
+		if len(c.JSONOmitFields) > 0 {
+			if err := schema.JSONResolver(path, c.JSONOmitFields...)(ctx, meta, resource, c); err != nil {
+				diags = diags.Add(fromError(err, diag.WithResourceName(e.ResourceName), diag.WithType(diag.INTERNAL),
+					diag.WithSummary("failed to serialize JSON value for column %s@%s", e.Table.Name, c.Name)))
+				continue
+			}
+			diags = diags.Add(e.completeColumn(meta, resource, c, SourcePath, path))
+			continue
+		}
+		v := funk.Get(resource.Item, path, funk.WithAllowZero())
+		if c.Sensitive {
+			// the value isn't registered with the redactor until the resource is resolved
+			e.Logger.Trace("setting sensitive column value", "column", c.Name)
+		} else {
+			e.Logger.Trace("setting column value", "column", c.Name, "value", v)
+		}
 		if err := resource.Set(c.Name, v); err != nil {
 			diags = diags.Add(fromError(err, diag.WithResourceName(e.ResourceName), diag.WithType(diag.INTERNAL),
 				diag.WithSummary("failed to set resource value for column %s@%s", e.Table.Name, c.Name)))
+			continue
 		}
+		diags = diags.Add(e.completeColumn(meta, resource, c, SourcePath, path))
 	}
 	return diags
 }
 
+// completeColumn resolves the column's fallback paths and transforms its value, once it's resolved from source.
+// The column's lineage is recorded, if enabled.
+func (e TableExecutor) completeColumn(meta schema.ClientMeta, resource *schema.Resource, c schema.Column, source ColumnSource, path string) diag.Diagnostics {
+	if fallback := e.resolveFallbackPaths(resource, c); fallback != "" {
+		source, path = SourceFallbackPath, fallback
+	}
+	e.lineage.record(e.Table, c, source, path, c.Transform != nil && !isNil(resource.Get(c.Name)))
+	return e.transformColumn(meta, resource, c)
+}
+
+// transformColumn applies the column's schema.Column.Transform to its resolved value
+func (e TableExecutor) transformColumn(meta schema.ClientMeta, resource *schema.Resource, c schema.Column) diag.Diagnostics {
+	if c.Transform == nil {
+		return nil
+	}
+	v := resource.Get(c.Name)
+	if isNil(v) {
+		return nil
+	}
+	v, err := c.Transform(v)
+	if err == nil {
+		err = resource.Set(c.Name, v)
+	}
+	if err == nil {
+		return nil
+	}
+	// Not allowed ignoring PK transform errors
+	if funk.ContainsString(e.Db.Dialect().PrimaryKeys(e.Table), c.Name) {
+		return ClassifyError(err, diag.WithResourceName(e.ResourceName), WithResource(resource), diag.WithSummary("failed to transform column %s@%s", e.Table.Name, c.Name))
+	}
+	return e.handleResolveError(meta, resource, err, diag.WithSummary("column transform %q failed for table %q", c.Name, e.Table.Name))
+}
+
+// resolveFallbackPaths sets the column from the first of its schema.Column.FallbackPaths that isn't nil, if the column
+// resolved to nil. Returns the path the column was set from, if any.
+func (e TableExecutor) resolveFallbackPaths(resource *schema.Resource, c schema.Column) string {
+	if len(c.FallbackPaths) == 0 || !isNil(resource.Get(c.Name)) {
+		return ""
+	}
+	for _, path := range c.FallbackPaths {
+		v := funk.Get(resource.Item, path, funk.WithAllowZero())
+		if isNil(v) {
+			continue
+		}
+		if len(c.JSONOmitFields) > 0 {
+			var err error
+			if v, err = schema.JSONValue(v, c.JSONOmitFields...); err != nil {
+				return ""
+			}
+		}
+		e.Logger.Trace("setting column value from fallback path", "column", c.Name, "path", path)
+		if err := resource.Set(c.Name, v); err != nil {
+			return ""
+		}
+		resource.SetValueSource(c.Name, path)
+		return path
+	}
+	return ""
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
 // handleResolveError handles errors returned by user defined functions, using the ErrorClassifiers if defined.
 func (e TableExecutor) handleResolveError(meta schema.ClientMeta, r *schema.Resource, err error, opts ...diag.BaseErrorOption) diag.Diagnostics {
 	errAsDiags := fromError(err, append(opts,
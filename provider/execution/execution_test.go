@@ -1,9 +1,16 @@
 package execution
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,9 +21,12 @@ import (
 	"github.com/cloudquery/cq-provider-sdk/testlog"
 	"github.com/creasty/defaults"
 	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -148,6 +158,12 @@ func (e executionClient) Logger() hclog.Logger {
 	return e.l
 }
 
+// newTestExecutor creates an executor of the table storing to storage, without metadata, classifier or timeout
+func newTestExecutor(t *testing.T, name string, storage Storage, table *schema.Table, opts ...Option) TableExecutor {
+	t.Helper()
+	return NewTableExecutor(name, storage, testlog.New(t), table, nil, nil, semaphore.NewWeighted(int64(limit.GetMaxGoRoutines())), 0, opts...)
+}
+
 func TestTableExecutor_Resolve(t *testing.T) {
 	testCases := []ExecutionTestCase{
 		{
@@ -507,10 +523,10 @@ func TestTableExecutor_Resolve(t *testing.T) {
 			ErrorExpected: true,
 			ExpectedDiags: []diag.FlatDiag{
 				{
-					Err:      `error at github.com/cloudquery/cq-provider-sdk/provider/execution.glob..func4[execution_test.go:74] some error`,
+					Err:      `error at github.com/cloudquery/cq-provider-sdk/provider/execution.init.func4[execution_test.go:84] some error`,
 					Resource: "return_wrap_error",
 					Severity: diag.ERROR,
-					Summary:  `failed to resolve table "simple": error at github.com/cloudquery/cq-provider-sdk/provider/execution.glob..func4[execution_test.go:74] some error`,
+					Summary:  `failed to resolve table "simple": error at github.com/cloudquery/cq-provider-sdk/provider/execution.init.func4[execution_test.go:84] some error`,
 					Type:     diag.RESOLVING,
 				},
 			},
@@ -687,3 +703,1690 @@ func TestTableExecutor_resolveResourceValues(t *testing.T) {
 		})
 	}
 }
+
+func TestTableExecutor_APICalls(t *testing.T) {
+	table := &schema.Table{
+		Name: "api_calls_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			schema.IncAPICall(ctx, "test", "List")
+			schema.IncAPICall(ctx, "test", "List")
+			res <- map[string]string{"name": "test"}
+			return nil
+		},
+		Columns: commonColumns,
+		Relations: []*schema.Table{
+			{
+				Name: "api_calls_table_relation",
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+					schema.IncAPICall(ctx, "test", "Get")
+					return nil
+				},
+				Columns: commonColumns,
+			},
+		},
+		Multiplex: simpleMultiplexer,
+	}
+	exec := newTestExecutor(t, "api_calls", noopStorage{}, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, []APICallStat{
+		{Table: "api_calls_table", Service: "test", Operation: "List", Count: 4},
+		{Table: "api_calls_table_relation", Service: "test", Operation: "Get", Count: 2},
+	}, exec.APICalls())
+}
+
+// capturingStorage records the resources copied to it
+type capturingStorage struct {
+	noopStorage
+	mu        sync.Mutex
+	resources schema.Resources
+}
+
+func newCapturingStorage() *capturingStorage {
+	return &capturingStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}
+}
+
+func (s *capturingStorage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources = append(s.resources, resources...)
+	return nil
+}
+
+func TestTableExecutor_Sequence(t *testing.T) {
+	table := &schema.Table{
+		Name: "sequence_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []map[string]string{{"name": "a"}, {"name": "b"}}
+			return nil
+		},
+		Columns:   commonColumns,
+		Options:   schema.TableCreationOptions{Sequence: true},
+		Multiplex: simpleMultiplexer,
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "sequence", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(4), count)
+
+	sequences := make([]int64, 0, len(storage.resources))
+	for _, r := range storage.resources {
+		sequences = append(sequences, r.Get(schema.SequenceColumnName).(int64))
+	}
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4}, sequences)
+}
+
+func TestTableExecutor_ResolveMeta(t *testing.T) {
+	table := &schema.Table{
+		Name:     "meta_table",
+		Resolver: returnValueResolver,
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{
+				Name: "policy",
+				Type: schema.TypeString,
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, resource *schema.Resource, c schema.Column) error {
+					time.Sleep(10 * time.Millisecond)
+					return diag.NewBaseError(errors.New("access denied"), diag.ACCESS, diag.WithSeverity(diag.IGNORE))
+				},
+			},
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "meta", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.False(t, diags.HasErrors())
+	require.Len(t, storage.resources, 1)
+
+	var meta schema.Meta
+	require.NoError(t, json.Unmarshal(storage.resources[0].Get("cq_meta").([]byte), &meta))
+	assert.Equal(t, []string{"policy"}, meta.FallbackColumns)
+	assert.GreaterOrEqual(t, meta.ResolveDurationMs, int64(10))
+}
+
+func TestTableExecutor_FetchContext(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		values []interface{}
+	)
+	record := func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		v, ok := schema.FetchContextValue(ctx, "policy")
+		require.True(t, ok)
+		mu.Lock()
+		values = append(values, v)
+		mu.Unlock()
+		res <- map[string]string{"name": "child"}
+		return nil
+	}
+	table := &schema.Table{
+		Name:     "fetch_context_table",
+		Resolver: returnValueResolver,
+		Columns:  commonColumns,
+		PostResourceResolver: func(ctx context.Context, meta schema.ClientMeta, resource *schema.Resource) error {
+			resource.SetChildContext("policy", "prefetched")
+			return nil
+		},
+		Relations: []*schema.Table{
+			{
+				Name:      "fetch_context_relation",
+				Resolver:  record,
+				Columns:   commonColumns,
+				Relations: []*schema.Table{{Name: "fetch_context_nested_relation", Resolver: record, Columns: commonColumns}},
+			},
+		},
+	}
+	exec := newTestExecutor(t, "fetch_context", noopStorage{}, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, []interface{}{"prefetched", "prefetched"}, values)
+
+	_, ok := schema.FetchContextValue(context.Background(), "policy")
+	assert.False(t, ok)
+}
+
+func TestTableExecutor_SemaphoreWait(t *testing.T) {
+	table := &schema.Table{
+		Name: "semaphore_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		},
+		Columns:   commonColumns,
+		Multiplex: simpleMultiplexer,
+	}
+	semaphoreStats := NewSemaphoreStats(1)
+	exec := NewTableExecutor("semaphore", noopStorage{}, testlog.New(t), table, nil, nil, semaphore.NewWeighted(1), 0,
+		WithSemaphoreStats(semaphoreStats), WithSemaphoreWaitThreshold(10*time.Millisecond))
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.WARNING, diags[0].Severity())
+	assert.Equal(t, diag.INTERNAL, diags[0].Type())
+
+	report := semaphoreStats.Report()
+	assert.Equal(t, int64(1), report.Capacity)
+	assert.Equal(t, int64(1), report.Peak)
+	assert.Equal(t, uint64(2), report.Acquisitions)
+	assert.GreaterOrEqual(t, report.MaxWait, 40*time.Millisecond)
+	assert.Greater(t, report.Utilization, 0.5)
+	assert.LessOrEqual(t, report.Utilization, 1.0)
+}
+
+// badRowStorage fails CopyFrom, and inserts of batches having a resource named "bad"
+type badRowStorage struct {
+	noopStorage
+	mu      sync.Mutex
+	inserts []int
+}
+
+func (*badRowStorage) CopyFrom(context.Context, schema.Resources, bool) error {
+	return errors.New("copy from failed")
+}
+
+func (s *badRowStorage) Insert(_ context.Context, _ *schema.Table, resources schema.Resources, _ bool) error {
+	s.mu.Lock()
+	s.inserts = append(s.inserts, len(resources))
+	s.mu.Unlock()
+	for _, r := range resources {
+		if r.Get("name") == "bad" {
+			return errors.New("bad row")
+		}
+	}
+	return nil
+}
+
+func TestTableExecutor_saveToStorageSubBatches(t *testing.T) {
+	table := &schema.Table{Name: "sub_batches_table", Columns: commonColumns}
+	storage := &badRowStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}
+	exec := NewTableExecutor("sub_batches", storage, testlog.New(t), table, nil, nil, nil, 0)
+
+	resources := make(schema.Resources, 16)
+	for i := range resources {
+		resources[i] = schema.NewResourceData(storage.Dialect(), table, nil, nil, nil, time.Now())
+		require.NoError(t, resources[i].Set("name", strconv.Itoa(i)))
+	}
+	require.NoError(t, resources[5].Set("name", "bad"))
+
+	saved, diags := exec.saveToStorage(context.Background(), resources, true)
+	assert.Len(t, saved, 15)
+	assert.NotContains(t, saved, resources[5])
+	assert.Equal(t, resources[:5], saved[:5])
+	assert.True(t, diags.HasErrors())
+	// the bulk insert, then halves are split depth first down to the bad resource, instead of 16 inserts of one resource
+	assert.Equal(t, []int{16, 8, 4, 4, 2, 1, 1, 2, 8}, storage.inserts)
+}
+
+// badCopyStorage fails copies of batches having a resource named "bad" or "nocopy"
+type badCopyStorage struct {
+	badRowStorage
+	copies []int
+}
+
+func (s *badCopyStorage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	s.mu.Lock()
+	s.copies = append(s.copies, len(resources))
+	s.mu.Unlock()
+	for _, r := range resources {
+		if name := r.Get("name"); name == "bad" || name == "nocopy" {
+			return errors.New("copy failed")
+		}
+	}
+	return nil
+}
+
+func TestTableExecutor_saveToStorageCopySubBatches(t *testing.T) {
+	table := &schema.Table{Name: "copy_sub_batches_table", Columns: commonColumns, Options: schema.TableCreationOptions{PrimaryKeys: []string{"name"}}}
+	storage := &badCopyStorage{badRowStorage: badRowStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	exec := NewTableExecutor("copy_sub_batches", storage, testlog.New(t), table, nil, nil, nil, 0)
+
+	resources := make(schema.Resources, 8)
+	for i := range resources {
+		resources[i] = schema.NewResourceData(storage.Dialect(), table, nil, nil, nil, time.Now())
+		require.NoError(t, resources[i].Set("name", strconv.Itoa(i)))
+	}
+	require.NoError(t, resources[1].Set("name", "nocopy"))
+	require.NoError(t, resources[6].Set("name", "bad"))
+
+	saved, diags := exec.saveToStorage(context.Background(), resources, true)
+	assert.Len(t, saved, 7)
+	assert.Contains(t, saved, resources[1])
+	assert.NotContains(t, saved, resources[6])
+	// only the resources failing copy-from on their own are inserted
+	assert.Equal(t, []int{8, 4, 2, 1, 1, 2, 4, 2, 2, 1, 1}, storage.copies)
+	assert.Equal(t, []int{2, 1, 1}, storage.inserts)
+
+	var storeErrors []diag.FlatDiag
+	for _, d := range diag.FlattenDiags(diags, false) {
+		if d.Severity == diag.ERROR && len(d.ResourceID) > 0 {
+			storeErrors = append(storeErrors, d)
+		}
+	}
+	require.Len(t, storeErrors, 1)
+	assert.Equal(t, []string{"bad"}, storeErrors[0].ResourceID)
+}
+
+func TestTableExecutor_saveToStorageUpsert(t *testing.T) {
+	table := &schema.Table{Name: "upsert_table", Columns: commonColumns, Options: schema.TableCreationOptions{PrimaryKeys: []string{"name"}}, InsertMode: schema.InsertModeUpsert}
+	storage := &badCopyStorage{badRowStorage: badRowStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	exec := NewTableExecutor("upsert", storage, testlog.New(t), table, nil, nil, nil, 0)
+
+	resources := make(schema.Resources, 4)
+	for i := range resources {
+		resources[i] = schema.NewResourceData(storage.Dialect(), table, nil, nil, nil, time.Now())
+		require.NoError(t, resources[i].Set("name", strconv.Itoa(i)))
+	}
+
+	saved, diags := exec.saveToStorage(context.Background(), resources, true)
+	assert.False(t, diags.HasDiags())
+	assert.Equal(t, resources, saved)
+	// upserts can't be copied, resources are inserted right away
+	assert.Empty(t, storage.copies)
+	assert.Equal(t, []int{4}, storage.inserts)
+}
+
+func TestTableExecutor_Deduplicate(t *testing.T) {
+	table := &schema.Table{
+		Name: "dedup_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "global"}, {Name: "global"}}
+			return nil
+		},
+		Columns:     commonColumns,
+		Options:     schema.TableCreationOptions{PrimaryKeys: []string{"name"}},
+		Multiplex:   simpleMultiplexer,
+		Deduplicate: true,
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "dedup", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.Equal(t, uint64(1), count)
+	assert.Len(t, storage.resources, 1)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.WARNING, diags[0].Severity())
+	assert.Equal(t, `table "dedup_table" dropped 3 duplicate resources`, diags[0].Description().Summary)
+
+	// without cq_id generating primary keys, resources are identified by the dedup keys
+	table.Options.PrimaryKeys = nil
+	table.DedupKeys = []string{"name"}
+	storage = newCapturingStorage()
+	exec = newTestExecutor(t, "dedup", storage, table)
+	_, _ = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.Len(t, storage.resources, 1)
+
+	// without primary keys and dedup keys, the cq_id is random, resources are identified by all their columns
+	table.DedupKeys = nil
+	storage = newCapturingStorage()
+	exec = newTestExecutor(t, "dedup", storage, table)
+	_, _ = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.Len(t, storage.resources, 1)
+}
+
+func TestTableExecutor_DeduplicatePointerFields(t *testing.T) {
+	type pointerItem struct {
+		Name      *string
+		CreatedAt *time.Time
+	}
+	// each client returns the same values in structs of its own, as SDKs do
+	newItem := func() pointerItem {
+		name, created := "global", time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+		return pointerItem{Name: &name, CreatedAt: &created}
+	}
+	table := &schema.Table{
+		Name: "dedup_pointer_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []pointerItem{newItem(), newItem()}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{Name: "created_at", Type: schema.TypeTimestamp},
+		},
+		Multiplex:   simpleMultiplexer,
+		Deduplicate: true,
+		DedupKeys:   []string{"name", "created_at"},
+	}
+	for _, keys := range [][]string{table.DedupKeys, nil} {
+		table.DedupKeys = keys
+		storage := newCapturingStorage()
+		exec := newTestExecutor(t, "dedup", storage, table)
+		_, _ = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+		assert.Len(t, storage.resources, 1)
+	}
+}
+
+func TestTableExecutor_MaxConcurrency(t *testing.T) {
+	var running, maxRunning int32
+	table := &schema.Table{
+		Name: "max_concurrency_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		},
+		Columns: commonColumns,
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta, meta, meta, meta, meta}
+		},
+		MaxConcurrency: 2,
+	}
+	exec := newTestExecutor(t, "max_concurrency", noopStorage{D: schema.PostgresDialect{}}, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, int32(2), maxRunning)
+}
+
+func TestTableExecutor_ParallelRelations(t *testing.T) {
+	var running, maxRunning int32
+	relResolver := func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		res <- batchItem{Name: "child"}
+		return nil
+	}
+	table := &schema.Table{
+		Name: "parallel_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+			return nil
+		},
+		Columns: commonColumns,
+		Relations: []*schema.Table{
+			{Name: "parallel_table_children", Resolver: relResolver, Columns: commonColumns},
+			{Name: "parallel_table_others", Resolver: relResolver, Columns: commonColumns},
+		},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		opts       []Option
+		goroutines int64
+		maxRunning int32
+	}{
+		{name: "serial", goroutines: 10, maxRunning: 1},
+		{name: "parallel", opts: []Option{WithParallelRelations()}, goroutines: 10, maxRunning: 8},
+		// the parent's client holds the only goroutine, relations are resolved serially
+		{name: "parallel_exhausted", opts: []Option{WithParallelRelations()}, goroutines: 1, maxRunning: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&maxRunning, 0)
+			storage := newCapturingStorage()
+			exec := NewTableExecutor("parallel", storage, testlog.New(t), table, nil, nil, semaphore.NewWeighted(tc.goroutines), 0, tc.opts...)
+			count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+			require.Empty(t, diags)
+			assert.Equal(t, uint64(4), count)
+			assert.Len(t, storage.resources, 12)
+			assert.Equal(t, tc.maxRunning, atomic.LoadInt32(&maxRunning))
+		})
+	}
+}
+
+// execStorage records the statements executed by Exec
+type execStorage struct {
+	noopStorage
+	mu    sync.Mutex
+	execs [][]interface{}
+}
+
+func (s *execStorage) Exec(_ context.Context, query string, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execs = append(s.execs, append([]interface{}{query}, args...))
+	return nil
+}
+
+func TestTableExecutor_AuditLog(t *testing.T) {
+	table := &schema.Table{
+		Name: "audit_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			return errors.New("access denied")
+		},
+		Columns:   commonColumns,
+		Multiplex: simpleMultiplexer,
+	}
+	storage := &execStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}
+	exec := newTestExecutor(t, "audit", storage, table, WithAuditLog(NewAuditLog(storage, "fetch-id", testlog.New(t))))
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.True(t, diags.HasErrors())
+
+	require.Len(t, storage.execs, 5)
+	assert.Equal(t, createAuditLogTable, storage.execs[0][0])
+	var inserts, updates int
+	for _, e := range storage.execs[1:] {
+		switch q := e[0].(string); {
+		case strings.HasPrefix(q, "INSERT"):
+			inserts++
+			assert.Equal(t, []interface{}{"fetch-id", "audit", "audit_table"}, e[2:5])
+			assert.Equal(t, AuditStatusRunning, e[7])
+		case strings.HasPrefix(q, "UPDATE"):
+			updates++
+			assert.Equal(t, int64(0), e[3])
+			assert.Equal(t, AuditStatusFailed, e[4])
+			require.NotNil(t, e[5])
+			assert.Equal(t, `failed to resolve table "audit_table": access denied`, *e[5].(*string))
+		}
+	}
+	assert.Equal(t, 2, inserts)
+	assert.Equal(t, 2, updates)
+}
+
+func TestTableExecutor_Checkpoints(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		tokens []string
+	)
+	table := &schema.Table{
+		Name: "checkpoint_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			token, _ := schema.ResumeTokenValue(ctx)
+			mu.Lock()
+			tokens = append(tokens, token)
+			mu.Unlock()
+			res <- batchItem{Name: "a"}
+			res <- schema.ResumeToken("next")
+			return nil
+		},
+		Columns: commonColumns,
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta, meta}
+		},
+	}
+	storage := &execStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}
+	checkpoints := &Checkpoints{db: storage, fetchID: "fetch-id", logger: testlog.New(t), resumed: map[string]checkpoint{
+		checkpointKey("checkpoint_table", "checkpoint_table:1"): {completed: true},
+		checkpointKey("checkpoint_table", "checkpoint_table:2"): {token: "page-2"},
+	}}
+	exec := newTestExecutor(t, "checkpoint", storage, table, WithCheckpoints(checkpoints))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(2), count)
+	// the completed client is skipped, the interrupted client continues from its token
+	assert.ElementsMatch(t, []string{"page-2", ""}, tokens)
+
+	completed := make(map[string]bool)
+	for _, e := range storage.execs {
+		if e[0] != upsertCheckpoint {
+			continue
+		}
+		assert.Equal(t, "fetch-id", e[1])
+		assert.Equal(t, "next", *e[4].(*string))
+		if e[5].(bool) {
+			completed[e[3].(string)] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{"checkpoint_table:2": true, "checkpoint_table:3": true}, completed)
+}
+
+type batchItem struct {
+	Name string
+}
+
+type incrementalItem struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+// incrementalStorage records the statements executed by Exec and the tables whose stale data was removed
+type incrementalStorage struct {
+	execStorage
+	staleRemoved int32
+}
+
+func (s *incrementalStorage) RemoveStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) error {
+	atomic.AddInt32(&s.staleRemoved, 1)
+	return nil
+}
+
+func TestTableExecutor_Incremental(t *testing.T) {
+	since := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	var (
+		mu      sync.Mutex
+		cursors []string
+	)
+	table := &schema.Table{
+		Name: "incremental_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			cursor, ok := schema.CursorTime(ctx)
+			mu.Lock()
+			cursors = append(cursors, cursor.Format(time.RFC3339))
+			mu.Unlock()
+			if ok {
+				res <- incrementalItem{Name: "changed", UpdatedAt: cursor.Add(time.Hour)}
+				return nil
+			}
+			res <- []incrementalItem{{Name: "a", UpdatedAt: since.Add(-time.Hour)}, {Name: "b", UpdatedAt: since.Add(-2 * time.Hour)}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{Name: "updated_at", Type: schema.TypeTimestamp},
+		},
+		Incremental: &schema.Incremental{CursorColumn: "updated_at"},
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta}
+		},
+	}
+	storage := &incrementalStorage{execStorage: execStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	c := &Cursors{db: storage, logger: testlog.New(t), stored: map[string]string{
+		checkpointKey("incremental_table", "incremental_table:2"): since.Format(time.RFC3339Nano),
+	}}
+	exec := newTestExecutor(t, "incremental", storage, table, WithCursors(c))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(3), count)
+	assert.ElementsMatch(t, []string{"0001-01-01T00:00:00Z", "2022-06-01T10:00:00Z"}, cursors)
+	// only the fully fetched client removes its stale data
+	assert.Equal(t, int32(1), atomic.LoadInt32(&storage.staleRemoved))
+
+	written := make(map[string]string)
+	for _, e := range storage.execs {
+		if e[0] != upsertCursor {
+			continue
+		}
+		assert.Equal(t, "incremental_table", e[2])
+		written[e[3].(string)] = e[4].(string)
+	}
+	assert.Equal(t, map[string]string{
+		"incremental_table:1": "2022-06-01T09:00:00Z",
+		"incremental_table:2": "2022-06-01T11:00:00Z",
+	}, written)
+}
+
+func TestTableExecutor_BatchResolver(t *testing.T) {
+	var calls int32
+	table := &schema.Table{
+		Name: "batch_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "a"}, {Name: "b"}}
+			return nil
+		},
+		Columns: commonColumns,
+		Relations: []*schema.Table{{
+			Name: "batch_table_children",
+			BatchResolver: func(ctx context.Context, meta schema.ClientMeta, parents schema.Resources, res chan<- interface{}) error {
+				atomic.AddInt32(&calls, 1)
+				items := make([]schema.ParentItem, 0, len(parents)*2)
+				for _, p := range parents {
+					name := p.Item.(batchItem).Name
+					items = append(items, schema.ParentItem{Parent: p, Item: batchItem{Name: name + "1"}}, schema.ParentItem{Parent: p, Item: batchItem{Name: name + "2"}})
+				}
+				res <- items
+				// items without a parent of the batch aren't resolved
+				res <- schema.ParentItem{Item: batchItem{Name: "orphan"}}
+				return nil
+			},
+			Columns: commonColumns,
+		}},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "batch", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.Equal(t, uint64(2), count)
+	require.Len(t, diags, 1)
+	assert.Equal(t, `table "batch_table_children" batch resolver sent an item of an unknown parent: parent of item execution.batchItem isn't in the batch`, diags[0].Description().Summary)
+	assert.Equal(t, int32(1), calls)
+
+	children := make(map[string]string)
+	for _, r := range storage.resources {
+		if r.TableName() == "batch_table_children" {
+			children[r.Item.(batchItem).Name] = r.Parent.Item.(batchItem).Name
+		}
+	}
+	assert.Equal(t, map[string]string{"a1": "a", "a2": "a", "b1": "b", "b2": "b"}, children)
+}
+
+// stagingStorage records the calls of a StagingStorage, failing the writes that don't go through staging
+type stagingStorage struct {
+	noopStorage
+	mu     sync.Mutex
+	staged map[string]int
+	calls  []string
+}
+
+func (s *stagingStorage) CopyFrom(context.Context, schema.Resources, bool) error {
+	return fmt.Errorf("resources should be staged")
+}
+
+func (s *stagingStorage) RemoveStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) error {
+	return fmt.Errorf("stale data should be removed by the swap")
+}
+
+func (s *stagingStorage) Stage(_ context.Context, t *schema.Table, resources schema.Resources) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staged[t.Name] += len(resources)
+	return nil
+}
+
+func (s *stagingStorage) SwapStaged(_ context.Context, t *schema.Table, _ []interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, "swap "+t.Name)
+	return nil
+}
+
+func (s *stagingStorage) DiscardStaged(_ context.Context, t *schema.Table, _ []interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, "discard "+t.Name)
+	return nil
+}
+
+func TestTableExecutor_StagedInsert(t *testing.T) {
+	resolver := func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		res <- []map[string]string{{"name": "a"}, {"name": "b"}}
+		return nil
+	}
+	table := &schema.Table{
+		Name:         "staged_table",
+		Resolver:     resolver,
+		Columns:      commonColumns,
+		StagedInsert: true,
+		Relations: []*schema.Table{{
+			Name:     "staged_table_children",
+			Resolver: resolver,
+			Columns:  commonColumns,
+		}},
+	}
+	storage := &stagingStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}, staged: make(map[string]int)}
+	exec := newTestExecutor(t, "staged", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, map[string]int{"staged_table": 2, "staged_table_children": 4}, storage.staged)
+	// leftovers of failed fetches are discarded before the client's resources are staged
+	assert.Equal(t, []string{"discard staged_table", "swap staged_table"}, storage.calls)
+
+	// without staging support the table is written directly
+	table.StagedInsert = false
+	exec = newTestExecutor(t, "staged", storage, table)
+	_, diags = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.True(t, diags.HasErrors())
+}
+
+func TestTableExecutor_SampleLimit(t *testing.T) {
+	table := &schema.Table{
+		Name: "sample_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			// an endless resolver, returning only once it's canceled
+			for {
+				select {
+				case res <- []map[string]string{{"name": "a"}, {"name": "b"}}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		},
+		Columns: commonColumns,
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "sample", storage, table, WithSampleLimit(3))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(3), count)
+	assert.Len(t, storage.resources, 3)
+}
+
+func TestTableExecutor_RateLimit(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []time.Time
+	)
+	table := &schema.Table{
+		Name: "rate_limited_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, time.Now())
+			return nil
+		},
+		Columns: commonColumns,
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta, meta, meta}
+		},
+		RateLimit: &schema.RateLimit{RequestsPerSecond: 20, Burst: 1},
+	}
+	exec := newTestExecutor(t, "rate_limit", noopStorage{D: schema.PostgresDialect{}}, table)
+	start := time.Now()
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	require.Len(t, calls, 4)
+	// the bucket is shared by the multiplexed clients, so only the first call is immediate
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exec = newTestExecutor(t, "rate_limit", noopStorage{D: schema.PostgresDialect{}}, table)
+	diags = exec.waitRateLimit(ctx)
+	require.True(t, diags.HasErrors())
+	assert.Equal(t, diag.THROTTLE, diags[0].Type())
+}
+
+func TestTableExecutor_RateLimitWaitsWithoutGoroutines(t *testing.T) {
+	called := make(chan struct{}, 2)
+	table := &schema.Table{
+		Name: "rate_limited_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			called <- struct{}{}
+			return nil
+		},
+		Columns: commonColumns,
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta}
+		},
+		// the second client waits 300ms for its token
+		RateLimit: &schema.RateLimit{RequestsPerSecond: 10.0 / 3, Burst: 1},
+	}
+	limiter := semaphore.NewWeighted(1)
+	exec := NewTableExecutor("rate_limit", noopStorage{D: schema.PostgresDialect{}}, testlog.New(t), table, nil, nil, limiter, 0)
+	done := make(chan diag.Diagnostics)
+	go func() {
+		_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+		done <- diags
+	}()
+	<-called
+	// the first client released its goroutine and the second is throttled without holding it
+	assert.Eventually(t, func() bool {
+		if !limiter.TryAcquire(1) {
+			return false
+		}
+		limiter.Release(1)
+		return true
+	}, 200*time.Millisecond, 5*time.Millisecond)
+	<-called
+	require.Empty(t, <-done)
+}
+
+func TestTableExecutor_FallbackPaths(t *testing.T) {
+	type item struct {
+		Name *string
+		Id   *string
+		Arn  string
+	}
+	name := "named"
+	table := &schema.Table{
+		Name: "fallback_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []item{{Name: &name, Arn: "arn:first"}, {Arn: "arn:second"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString, FallbackPaths: []string{"Id", "Arn"}},
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "fallback", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	require.Len(t, storage.resources, 2)
+
+	var meta schema.Meta
+	assert.Equal(t, &name, storage.resources[0].Get("name"))
+	require.NoError(t, json.Unmarshal(storage.resources[0].Get("cq_meta").([]byte), &meta))
+	assert.Empty(t, meta.ValueSources)
+
+	assert.Equal(t, "arn:second", storage.resources[1].Get("name"))
+	require.NoError(t, json.Unmarshal(storage.resources[1].Get("cq_meta").([]byte), &meta))
+	assert.Equal(t, map[string]string{"name": "Arn"}, meta.ValueSources)
+}
+
+func TestTableExecutor_RetryPolicy(t *testing.T) {
+	transient := errors.New("throttled")
+	var calls int
+	table := &schema.Table{
+		Name: "retry_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			calls++
+			if calls < 3 {
+				return transient
+			}
+			res <- map[string]interface{}{"name": "a"}
+			return nil
+		},
+		Columns: commonColumns,
+		RetryPolicy: &schema.RetryPolicy{
+			MinBackoff:  time.Millisecond,
+			ShouldRetry: func(err error) bool { return errors.Is(err, transient) },
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "retry", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, uint64(1), count)
+
+	// errors the policy doesn't match aren't retried
+	calls = 0
+	transient = errors.New("another error")
+	table.RetryPolicy.ShouldRetry = func(err error) bool { return false }
+	_, diags = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.True(t, diags.HasErrors())
+	assert.Equal(t, 1, calls)
+}
+
+func TestTableExecutor_ParentItemResolver(t *testing.T) {
+	type parentItem struct {
+		Name      string
+		NextToken string
+	}
+	var (
+		mu     sync.Mutex
+		tokens []interface{}
+	)
+	table := &schema.Table{
+		Name: "parent_item_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []parentItem{{Name: "a", NextToken: "token-a"}, {Name: "b", NextToken: "token-b"}}
+			return nil
+		},
+		Columns: commonColumns,
+		Relations: []*schema.Table{
+			{
+				Name: "parent_item_relation",
+				ParentItemResolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource) (interface{}, error) {
+					return parent.Item.(parentItem).NextToken, nil
+				},
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+					v, ok := schema.ParentItemValue(ctx)
+					require.True(t, ok)
+					mu.Lock()
+					tokens = append(tokens, v)
+					mu.Unlock()
+					res <- map[string]string{"name": "child"}
+					return nil
+				},
+				Columns: commonColumns,
+				Relations: []*schema.Table{{
+					Name: "parent_item_nested_relation",
+					Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+						// the value is only passed to the relation's own resolver
+						_, ok := schema.ParentItemValue(ctx)
+						assert.False(t, ok)
+						return nil
+					},
+					Columns: commonColumns,
+				}},
+			},
+			{
+				Name: "parent_item_failing_relation",
+				ParentItemResolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource) (interface{}, error) {
+					return nil, errors.New("no token")
+				},
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+					t.Fatal("resolver called after parent item resolver failed")
+					return nil
+				},
+				Columns: commonColumns,
+			},
+		},
+	}
+	exec := newTestExecutor(t, "parent_item", noopStorage{}, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.ElementsMatch(t, []interface{}{"token-a", "token-b"}, tokens)
+	require.Len(t, diags, 2)
+	assert.Equal(t, `parent item resolver failed for table "parent_item_failing_relation": no token`, diags[0].Description().Summary)
+}
+
+func TestTableExecutor_Progress(t *testing.T) {
+	table := &schema.Table{
+		Name: "progress_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			for i := 0; i < 3; i++ {
+				res <- map[string]interface{}{"name": "a"}
+				time.Sleep(20 * time.Millisecond)
+			}
+			return nil
+		},
+		Columns: commonColumns,
+	}
+	var (
+		mu      sync.Mutex
+		updates []Progress
+	)
+	exec := newTestExecutor(t, "progress", noopStorage{D: schema.PostgresDialect{}}, table,
+		WithProgress(5*time.Millisecond, func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			updates = append(updates, p)
+		}))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(3), count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, updates)
+	last := updates[len(updates)-1]
+	assert.Equal(t, "progress", last.ResourceName)
+	assert.GreaterOrEqual(t, last.ResourceCount, uint64(2))
+	assert.Positive(t, last.Elapsed)
+	for i := 1; i < len(updates); i++ {
+		assert.GreaterOrEqual(t, updates[i].ResourceCount, updates[i-1].ResourceCount)
+	}
+}
+
+func TestTableExecutor_ColumnTransform(t *testing.T) {
+	type item struct {
+		Name   string
+		SizeKb int
+		Region string
+	}
+	table := &schema.Table{
+		Name: "transform_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- item{Name: " Instance ", SizeKb: 2048, Region: "US-EAST-1"}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString, Transform: schema.ChainTransformers(schema.TrimSpaceTransformer, schema.LowerTransformer)},
+			{Name: "size_mb", Type: schema.TypeFloat, Resolver: schema.PathResolver("SizeKb"), Transform: schema.ScaleTransformer(1.0 / 1024)},
+			{Name: "region", Type: schema.TypeString, Transform: func(v interface{}) (interface{}, error) {
+				return nil, errors.New("bad region")
+			}},
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "transform", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Len(t, diags, 1)
+	assert.Equal(t, `column transform "region" failed for table "transform_table": bad region`, diags[0].Description().Summary)
+	assert.Empty(t, storage.resources)
+
+	table.Columns[2].Transform = schema.LowerTransformer
+	exec = newTestExecutor(t, "transform", storage, table)
+	_, diags = exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	require.Len(t, storage.resources, 1)
+	assert.Equal(t, "instance", storage.resources[0].Get("name"))
+	assert.Equal(t, 2.0, storage.resources[0].Get("size_mb"))
+	assert.Equal(t, "us-east-1", storage.resources[0].Get("region"))
+}
+
+// verifyStorage has a stored row for each name, whose value is whether its columns are equal to the verified resource.
+// Verified tables have the name primary key and column.
+type verifyStorage struct {
+	noopStorage
+	rows    map[string]bool
+	writes  int32
+	queries int32
+}
+
+func (s *verifyStorage) Query(_ context.Context, _ string, args ...interface{}) (pgx.Rows, error) {
+	atomic.AddInt32(&s.queries, 1)
+	rows := &verifyRows{}
+	for i := 0; i+2 < len(args); i += 3 {
+		if equal, ok := s.rows[args[i+1].(string)]; ok {
+			rows.values = append(rows.values, []interface{}{args[i].(int32), true, equal})
+		}
+	}
+	return rows, nil
+}
+
+func (s *verifyStorage) CopyFrom(context.Context, schema.Resources, bool) error {
+	atomic.AddInt32(&s.writes, 1)
+	return nil
+}
+
+func (s *verifyStorage) Insert(context.Context, *schema.Table, schema.Resources, bool) error {
+	atomic.AddInt32(&s.writes, 1)
+	return nil
+}
+
+func (s *verifyStorage) RemoveStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) error {
+	atomic.AddInt32(&s.writes, 1)
+	return nil
+}
+
+// verifyRows are the index, found and equal values of the rows of verify queries
+type verifyRows struct {
+	pgx.Rows
+	values [][]interface{}
+	row    []interface{}
+}
+
+func (r *verifyRows) Next() bool {
+	if len(r.values) == 0 {
+		return false
+	}
+	r.row, r.values = r.values[0], r.values[1:]
+	return true
+}
+
+func (r *verifyRows) Scan(dest ...interface{}) error {
+	*dest[0].(*int32) = r.row[0].(int32)
+	*dest[1].(*bool) = r.row[1].(bool)
+	*dest[2].(*bool) = r.row[2].(bool)
+	return nil
+}
+
+func (*verifyRows) Err() error { return nil }
+
+func (*verifyRows) Close() {}
+
+func TestTableExecutor_Verifier(t *testing.T) {
+	table := &schema.Table{
+		Name:    "verify_table",
+		Options: schema.TableCreationOptions{PrimaryKeys: []string{"name"}},
+		Columns: []schema.Column{{Name: "name", Type: schema.TypeString}},
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "same"}, {Name: "changed"}, {Name: "missing"}}
+			return nil
+		},
+		Relations: []*schema.Table{{
+			Name:    "verify_table_children",
+			Columns: []schema.Column{{Name: "name", Type: schema.TypeString}},
+			Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+				res <- batchItem{Name: "child"}
+				return nil
+			},
+		}},
+	}
+	storage := &verifyStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}, rows: map[string]bool{"same": true, "changed": false}}
+	verifier := NewVerifier()
+	exec := newTestExecutor(t, "verify", storage, table, WithVerifier(verifier))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	assert.Equal(t, uint64(3), count)
+	assert.False(t, diags.HasErrors())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&storage.writes))
+
+	assert.Equal(t, map[string]TableDrift{
+		"verify_table":          {Verified: 3, Missing: 1, Changed: 1},
+		"verify_table_children": {Unverified: 3},
+	}, verifier.Drift())
+	require.Len(t, diags, 2)
+	assert.Equal(t, `table "verify_table" drifted from its stored data: 1 of 3 resources are missing, 1 changed`, diags[0].Description().Summary)
+	assert.Equal(t, `table "verify_table_children" can't be verified, it has no primary keys`, diags[1].Description().Summary)
+	assert.Equal(t, diag.WARNING, diags[0].Severity())
+	// the resources of the table are verified by a single query
+	assert.Equal(t, int32(1), atomic.LoadInt32(&storage.queries))
+}
+
+func TestVerifyQuery(t *testing.T) {
+	table := &schema.Table{
+		Name:    "verify_query",
+		Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns: []schema.Column{{Name: "id", Type: schema.TypeString}, {Name: "tags", Type: schema.TypeJSON}},
+	}
+	d := schema.PostgresDialect{}
+	cols := d.Columns(table)
+	pks, compared, err := verifyColumns(table, cols)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT v.i, count(s."id") > 0, coalesce(bool_or(s."id" IS NOT DISTINCT FROM v.c0 AND s."tags" IS NOT DISTINCT FROM v.c1), false) `+
+		`FROM (VALUES ($1::integer, $2::text, $3::text, $4::jsonb), ($5::integer, $6::text, $7::text, $8::jsonb)) AS v(i, p0, c0, c1) `+
+		`LEFT JOIN "verify_query" AS s ON s."id" = v.p0 GROUP BY v.i`, verifyQuery(d, table, cols, pks, compared, 2))
+
+	_, _, err = verifyColumns(&schema.Table{Name: "verify_query", Options: schema.TableCreationOptions{PrimaryKeys: []string{"missing"}}}, cols)
+	assert.Error(t, err)
+}
+
+func TestTableExecutor_Lineage(t *testing.T) {
+	type lineageItem struct {
+		Name string
+		Id   *string
+		Arn  string
+	}
+	table := &schema.Table{
+		Name: "lineage_table",
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString, Transform: func(v interface{}) (interface{}, error) { return strings.ToUpper(v.(string)), nil }},
+			{Name: "id", Type: schema.TypeString, FallbackPaths: []string{"Arn"}},
+			{Name: "region", Type: schema.TypeString, Resolver: schema.PathResolver("Name")},
+		},
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []lineageItem{{Name: "first", Id: ptr.String("id"), Arn: "arn"}, {Name: "second", Arn: "arn"}}
+			return nil
+		},
+	}
+	lineage := NewLineage()
+	exec := newTestExecutor(t, "lineage", noopStorage{D: schema.PostgresDialect{}}, table, WithLineage(lineage))
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.False(t, diags.HasErrors())
+
+	assert.Equal(t, []ColumnLineage{
+		{Table: "lineage_table", Column: "id", Source: SourceFallbackPath, Path: "Arn", Count: 1},
+		{Table: "lineage_table", Column: "id", Source: SourcePath, Path: "Id", Count: 1},
+		{Table: "lineage_table", Column: "name", Source: SourcePath, Path: "Name", Transformed: true, Count: 2},
+		{Table: "lineage_table", Column: "region", Source: SourceResolver, Resolver: "schema.PathResolver", Count: 2},
+	}, lineage.Report())
+}
+
+func TestTableExecutor_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	table := &schema.Table{
+		Name:    "tracing_table",
+		Columns: []schema.Column{{Name: "name", Type: schema.TypeString}},
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "first"}, {Name: "second"}}
+			return nil
+		},
+	}
+	exec := newTestExecutor(t, "tracing", noopStorage{D: schema.PostgresDialect{}}, table, WithTracerProvider(tp))
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.False(t, diags.HasErrors())
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	require.Len(t, spans, 3)
+	resolve, call, save := spans["Resolve"], spans["callTableResolve"], spans["saveToStorage"]
+	require.NotNil(t, resolve)
+	require.NotNil(t, call)
+	require.NotNil(t, save)
+	assert.Equal(t, resolve.SpanContext().SpanID(), call.Parent().SpanID())
+	assert.Equal(t, call.SpanContext().SpanID(), save.Parent().SpanID())
+	assert.Contains(t, resolve.Attributes(), TableAttribute.String("tracing_table"))
+	assert.Contains(t, resolve.Attributes(), ResourceCountAttribute.Int64(2))
+	assert.Contains(t, save.Attributes(), ResourceCountAttribute.Int64(2))
+}
+
+func TestTableExecutor_ChainClassifiers(t *testing.T) {
+	var resolverErr error
+	table := &schema.Table{
+		Name: "classified_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			return resolverErr
+		},
+		Columns: commonColumns,
+	}
+	var providerCalls int
+	providerClassifier := func(meta schema.ClientMeta, resourceName string, err error) diag.Diagnostics {
+		providerCalls++
+		if !strings.Contains(err.Error(), "access denied") {
+			return nil
+		}
+		return diag.Diagnostics{diag.NewBaseError(err, diag.ACCESS, diag.WithType(diag.ACCESS), diag.WithSeverity(diag.WARNING))}
+	}
+	limiter := semaphore.NewWeighted(int64(limit.GetMaxGoRoutines()))
+	exec := NewTableExecutor("classified", noopStorage{D: schema.PostgresDialect{}}, testlog.New(t), table, nil,
+		ChainClassifiers(providerClassifier, nil, DefaultErrorClassifier), limiter, 0)
+
+	for _, tc := range []struct {
+		err      error
+		severity diag.Severity
+		typ      diag.Type
+		summary  string
+	}{
+		{err: errors.New("access denied"), severity: diag.WARNING, typ: diag.ACCESS},
+		{err: fmt.Errorf("operation error: %w", context.DeadlineExceeded), severity: diag.ERROR, typ: diag.RESOLVING},
+		{err: errors.New("dial tcp: socket: too many open files"), severity: diag.WARNING, typ: diag.THROTTLE, summary: fdLimitMessage},
+		{err: errors.New("failed to receive message: unexpected EOF"), severity: diag.ERROR, typ: diag.DATABASE, summary: "lost connection to the database"},
+		{err: errors.New("api error ThrottlingException: Rate exceeded"), severity: diag.ERROR, typ: diag.THROTTLE},
+		{err: errors.New("unknown"), severity: diag.ERROR, typ: diag.RESOLVING},
+	} {
+		resolverErr = tc.err
+		_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+		require.Len(t, diags, 1, tc.err)
+		assert.Equal(t, tc.severity, diags[0].Severity(), tc.err)
+		assert.Equal(t, tc.typ, diags[0].Type(), tc.err)
+		if tc.summary != "" {
+			assert.Contains(t, diags[0].Description().Summary, tc.summary, tc.err)
+		}
+	}
+	assert.Equal(t, 6, providerCalls)
+}
+
+// quarantineStorage fails writes of resources named "bad", recording its execs
+type quarantineStorage struct {
+	badRowStorage
+	execs [][]interface{}
+}
+
+func (s *quarantineStorage) Exec(_ context.Context, query string, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.execs = append(s.execs, append([]interface{}{query}, args...))
+	return nil
+}
+
+func TestTableExecutor_Quarantine(t *testing.T) {
+	type item struct {
+		Name string
+		Size interface{}
+	}
+	table := &schema.Table{
+		Name: "quarantine_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []item{{Name: "good", Size: 1}, {Name: "bad", Size: 2}, {Name: "unmapped", Size: "large"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{
+				Name: "size",
+				Type: schema.TypeInt,
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, resource *schema.Resource, c schema.Column) error {
+					size, ok := resource.Item.(item).Size.(int)
+					if !ok {
+						return fmt.Errorf("unexpected size %v", resource.Item.(item).Size)
+					}
+					return resource.Set(c.Name, size)
+				},
+			},
+		},
+	}
+	storage := &quarantineStorage{badRowStorage: badRowStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	quarantine := NewQuarantine(storage, "fetch-id", testlog.New(t))
+	exec := newTestExecutor(t, "quarantine", storage, table, WithQuarantine(quarantine))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.True(t, diags.HasErrors())
+	assert.Equal(t, uint64(1), count)
+
+	// items are queued until a batch is full or the quarantine is flushed
+	assert.Empty(t, storage.execs)
+	quarantine.Flush()
+	require.Len(t, storage.execs, 2)
+	assert.Equal(t, createQuarantineTable, storage.execs[0][0])
+	insert := storage.execs[1]
+	assert.Equal(t, `INSERT INTO "cq_quarantine" ("id", "fetch_id", "table_name", "error", "payload", "created_at") VALUES ($1, $2, $3, $4, $5, $6), ($7, $8, $9, $10, $11, $12)`, insert[0])
+	require.Len(t, insert, 13)
+	payloads := make(map[string]string)
+	for row := insert[1:]; len(row) > 0; row = row[6:] {
+		assert.Equal(t, []interface{}{"fetch-id", "quarantine_table"}, row[1:3])
+		assert.NotEmpty(t, row[3])
+		payloads[row[4].(string)] = row[3].(string)
+	}
+	assert.Equal(t, "bad row", payloads[`{"Name":"bad","Size":2}`])
+	assert.Contains(t, payloads, `{"Name":"unmapped","Size":"large"}`)
+	assert.Len(t, payloads, 2)
+}
+
+func TestQuarantine_BatchesAndRedacts(t *testing.T) {
+	table := &schema.Table{
+		Name:    "quarantine_secrets",
+		Columns: []schema.Column{{Name: "password", Type: schema.TypeString, Sensitive: true}},
+	}
+	resource := schema.NewResourceData(schema.PostgresDialect{}, table, nil, nil, nil, time.Now())
+	require.NoError(t, resource.Set("password", "hunter22"))
+	redactor := NewRedactor()
+	redactor.addResource(resource)
+
+	storage := &quarantineStorage{badRowStorage: badRowStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	q := NewQuarantine(storage, "fetch-id", testlog.New(t))
+	for i := 0; i < quarantineBatchSize+1; i++ {
+		q.add(table.Name, map[string]string{"password": "hunter22"}, errors.New("invalid password hunter22"), redactor)
+	}
+	q.Flush()
+
+	require.Len(t, storage.execs, 3)
+	assert.Equal(t, createQuarantineTable, storage.execs[0][0])
+	// the full batch is written in the background, so it may be written after the flushed one
+	inserts := storage.execs[1:]
+	sort.Slice(inserts, func(i, j int) bool { return len(inserts[i]) > len(inserts[j]) })
+	rows := inserts[0][1:]
+	assert.Len(t, rows, quarantineBatchSize*6)
+	assert.Len(t, inserts[1][1:], 6)
+	assert.Equal(t, []interface{}{"fetch-id", table.Name, "invalid password ****", `{"password":"****"}`}, rows[1:5])
+}
+
+func TestTableExecutor_Redactor(t *testing.T) {
+	type item struct {
+		Name     string
+		Password string
+	}
+	table := &schema.Table{
+		Name: "redacted_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []item{{Name: "first", Password: "hunter22"}, {Name: "short", Password: "abc"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{Name: "password", Type: schema.TypeString, Sensitive: true},
+			{
+				Name: "owner",
+				Type: schema.TypeString,
+				Resolver: func(ctx context.Context, meta schema.ClientMeta, resource *schema.Resource, c schema.Column) error {
+					return fmt.Errorf("failed to get owner of key %s", resource.Get("password"))
+				},
+			},
+		},
+	}
+	assert.True(t, table.HasSensitiveColumns())
+
+	var logs bytes.Buffer
+	logger := hclog.New(&hclog.LoggerOptions{Output: &logs, Level: hclog.Trace})
+	limiter := semaphore.NewWeighted(int64(limit.GetMaxGoRoutines()))
+	exec := NewTableExecutor("redacted", noopStorage{D: schema.PostgresDialect{}}, logger, table, nil, nil, limiter, 0, WithRedactor(NewRedactor()))
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Len(t, diags, 2)
+	for _, d := range diags {
+		assert.NotContains(t, d.Error(), "hunter22")
+		assert.NotContains(t, d.Description().Summary, "hunter22")
+	}
+	assert.Contains(t, diags.Error(), "failed to get owner of key ****")
+	// values shorter than minSensitiveLength aren't masked
+	assert.Contains(t, diags.Error(), "failed to get owner of key abc")
+	assert.Contains(t, logs.String(), "****")
+	assert.NotContains(t, logs.String(), "hunter22")
+}
+
+func TestTableExecutor_ResolveOne(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	table := &schema.Table{
+		Name:     "get_table",
+		Resolver: returnValueResolver,
+		GetResolver: func(ctx context.Context, meta schema.ClientMeta, primaryKeys map[string]string) (interface{}, error) {
+			switch primaryKeys["name"] {
+			case "found":
+				return item{Name: "found"}, nil
+			case "failed":
+				return nil, errors.New("get failed")
+			case "panic":
+				panic("get resolver panic")
+			}
+			return nil, nil
+		},
+		Columns:   commonColumns,
+		Options:   schema.TableCreationOptions{PrimaryKeys: []string{"name"}},
+		Multiplex: simpleMultiplexer,
+		Relations: []*schema.Table{{Name: "get_table_relation", Resolver: returnValueResolver, Columns: commonColumns}},
+	}
+	client := executionClient{testlog.New(t)}
+
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "get", storage, table)
+	count, diags := exec.ResolveOne(context.Background(), client, map[string]string{"name": "found"})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(2), count)
+	tables := make(map[string]int)
+	for _, r := range storage.resources {
+		tables[r.TableName()]++
+	}
+	assert.Equal(t, map[string]int{"get_table": 2, "get_table_relation": 2}, tables)
+
+	count, diags = exec.ResolveOne(context.Background(), client, map[string]string{"name": "missing"})
+	assert.Empty(t, diags)
+	assert.Equal(t, uint64(0), count)
+
+	_, diags = exec.ResolveOne(context.Background(), client, map[string]string{"name": "failed"})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.RESOLVING, diags[0].Type())
+
+	_, diags = exec.ResolveOne(context.Background(), client, map[string]string{"name": "panic"})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.PANIC, diags[0].Severity())
+
+	_, diags = exec.ResolveOne(context.Background(), client, map[string]string{"id": "found"})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.USER, diags[0].Type())
+
+	exec = newTestExecutor(t, "list", storage, &schema.Table{Name: "list_table", Resolver: returnValueResolver, Columns: commonColumns})
+	_, diags = exec.ResolveOne(context.Background(), client, map[string]string{"name": "found"})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SCHEMA, diags[0].Type())
+}
+
+func TestTableExecutor_History(t *testing.T) {
+	table := &schema.Table{
+		Name: "history_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "a"}, {Name: "b"}}
+			return nil
+		},
+		Columns: commonColumns,
+		Relations: []*schema.Table{{
+			Name: "history_table_children",
+			Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+				res <- batchItem{Name: parent.Item.(batchItem).Name + "1"}
+				return nil
+			},
+			Columns: commonColumns,
+		}},
+	}
+	storage := &incrementalStorage{execStorage: execStorage{noopStorage: noopStorage{D: schema.TSDBDialect{}}}}
+	require.NoError(t, SetupHistory(context.Background(), storage, HistoryConfig{Retention: 30}, table))
+	stmts := make([]string, 0, len(storage.execs))
+	for _, e := range storage.execs {
+		stmts = append(stmts, e[0].(string))
+	}
+	assert.Equal(t, []string{
+		"SELECT create_hypertable('history_table', 'cq_fetch_date', chunk_time_interval => INTERVAL '86400 seconds', if_not_exists => TRUE, migrate_data => TRUE);",
+		"SELECT set_chunk_time_interval('history_table', INTERVAL '86400 seconds');",
+		"SELECT remove_retention_policy('history_table', if_exists => TRUE);",
+		"SELECT add_retention_policy('history_table', INTERVAL '30 days');",
+		"SELECT create_hypertable('history_table_children', 'cq_fetch_date', chunk_time_interval => INTERVAL '86400 seconds', if_not_exists => TRUE, migrate_data => TRUE);",
+		"SELECT set_chunk_time_interval('history_table_children', INTERVAL '86400 seconds');",
+		"SELECT remove_retention_policy('history_table_children', if_exists => TRUE);",
+		"SELECT add_retention_policy('history_table_children', INTERVAL '30 days');",
+	}, stmts)
+	assert.Error(t, SetupHistory(context.Background(), noopStorage{D: schema.PostgresDialect{}}, HistoryConfig{}, table))
+
+	exec := newTestExecutor(t, "history", storage, table, WithHistory())
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(2), count)
+	// the rows of previous fetches are kept
+	assert.Equal(t, int32(0), atomic.LoadInt32(&storage.staleRemoved))
+}
+
+func TestTableExecutor_InheritFromParent(t *testing.T) {
+	type account struct {
+		Name      string
+		AccountId string
+	}
+	table := &schema.Table{
+		Name: "inherit_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []account{{Name: "a", AccountId: "1"}, {Name: "b", AccountId: "2"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "name", Type: schema.TypeString},
+			{Name: "account_id", Type: schema.TypeString},
+		},
+		Relations: []*schema.Table{{
+			Name: "inherit_table_children",
+			Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+				res <- batchItem{Name: parent.Item.(account).Name + "1"}
+				return nil
+			},
+			Columns: []schema.Column{
+				{Name: "name", Type: schema.TypeString},
+				{Name: "account_id", Type: schema.TypeString, InheritFromParent: "account_id"},
+			},
+		}},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "inherit", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+
+	inherited := make(map[string]interface{})
+	for _, r := range storage.resources {
+		if r.TableName() == "inherit_table_children" {
+			inherited[r.Get("name").(string)] = r.Get("account_id")
+		}
+	}
+	assert.Equal(t, map[string]interface{}{"a1": "1", "b1": "2"}, inherited)
+}
+
+func TestTableExecutor_JSONOmitFields(t *testing.T) {
+	type spec struct {
+		Name     string
+		Password string
+	}
+	type item struct {
+		Spec spec
+	}
+	table := &schema.Table{
+		Name: "json_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- item{Spec: spec{Name: "a", Password: "secret"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "spec", Type: schema.TypeJSON, JSONOmitFields: []string{"Password"}},
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "json", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	require.Len(t, storage.resources, 1)
+	assert.Equal(t, map[string]interface{}{"Name": "a"}, storage.resources[0].Get("spec"))
+}
+
+func TestTableExecutor_JSONOmitFieldsFallbackPaths(t *testing.T) {
+	type spec struct {
+		Name     string
+		Password string
+	}
+	type item struct {
+		Spec       *spec
+		LegacySpec spec
+	}
+	table := &schema.Table{
+		Name: "json_fallback_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- item{LegacySpec: spec{Name: "a", Password: "secret"}}
+			return nil
+		},
+		Columns: []schema.Column{
+			{Name: "spec", Type: schema.TypeJSON, FallbackPaths: []string{"LegacySpec"}, JSONOmitFields: []string{"Password"}},
+		},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "json", storage, table)
+	_, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	require.Len(t, storage.resources, 1)
+	assert.Equal(t, map[string]interface{}{"Name": "a"}, storage.resources[0].Get("spec"))
+}
+
+// cancelStorage fails writes of canceled contexts, and counts the removals of stale data
+type cancelStorage struct {
+	capturingStorage
+	staleRemoved int32
+}
+
+func (s *cancelStorage) CopyFrom(ctx context.Context, resources schema.Resources, shouldCascade bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.capturingStorage.CopyFrom(ctx, resources, shouldCascade)
+}
+
+func (s *cancelStorage) RemoveStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) error {
+	atomic.AddInt32(&s.staleRemoved, 1)
+	return nil
+}
+
+func TestTableExecutor_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls int32
+	table := &schema.Table{
+		Name: "cancel_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				res <- batchItem{Name: "a"}
+				return nil
+			}
+			// the resource resolved as the fetch is canceled is still stored
+			cancel()
+			res <- batchItem{Name: "b"}
+			return ctx.Err()
+		},
+		Columns: commonColumns,
+		Multiplex: func(meta schema.ClientMeta) []schema.ClientMeta {
+			return []schema.ClientMeta{meta, meta, meta}
+		},
+	}
+	storage := &cancelStorage{capturingStorage: capturingStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}}
+	exec := NewTableExecutor("cancel", storage, testlog.New(t), table, nil, nil, semaphore.NewWeighted(1), 0)
+	count, diags := exec.Resolve(ctx, executionClient{testlog.New(t)})
+	require.True(t, diags.HasErrors())
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, []ClientStatus{
+		{ClientID: "cancel_table:1", State: ClientComplete, ResourceCount: 1},
+		{ClientID: "cancel_table:2", State: ClientCanceled, ResourceCount: 1},
+		{ClientID: "cancel_table:3", State: ClientPending},
+	}, exec.Clients())
+
+	names := make([]string, len(storage.resources))
+	for i, r := range storage.resources {
+		names[i] = r.Get("name").(string)
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+	// only the completed client's stale data is removed
+	assert.Equal(t, int32(1), atomic.LoadInt32(&storage.staleRemoved))
+}
+
+// batchSizesStorage records the sizes of the batches copied to it
+type batchSizesStorage struct {
+	noopStorage
+	sizes []int
+}
+
+func (s *batchSizesStorage) CopyFrom(_ context.Context, resources schema.Resources, _ bool) error {
+	s.sizes = append(s.sizes, len(resources))
+	return nil
+}
+
+func TestTableExecutor_BatchSize(t *testing.T) {
+	table := &schema.Table{
+		Name: "batch_size_table",
+		Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+			res <- []batchItem{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+			return nil
+		},
+		Columns: commonColumns,
+	}
+	storage := &batchSizesStorage{noopStorage: noopStorage{D: schema.PostgresDialect{}}}
+	exec := newTestExecutor(t, "batch_size", storage, table, WithBatchSize(2))
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(5), count)
+	assert.Equal(t, []int{2, 2, 1}, storage.sizes)
+}
+
+func TestTableExecutor_TypedTableResolver(t *testing.T) {
+	table := &schema.Table{
+		Name: "typed_table",
+		Resolver: TypedTableResolver[batchItem](func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- []batchItem) error {
+			res <- []batchItem{{Name: "a"}, {Name: "b"}}
+			res <- nil
+			res <- []batchItem{{Name: "c"}}
+			return nil
+		}).TableResolver(),
+		Columns: commonColumns,
+		Relations: []*schema.Table{{
+			Name: "typed_table_children",
+			Resolver: func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+				Send(res, batchItem{Name: parent.Item.(batchItem).Name + "1"}, batchItem{Name: parent.Item.(batchItem).Name + "2"})
+				return nil
+			},
+			Columns: commonColumns,
+		}},
+	}
+	storage := newCapturingStorage()
+	exec := newTestExecutor(t, "typed", storage, table)
+	count, diags := exec.Resolve(context.Background(), executionClient{testlog.New(t)})
+	require.Empty(t, diags)
+	assert.Equal(t, uint64(3), count)
+
+	names := make(map[string][]string)
+	for _, r := range storage.resources {
+		names[r.TableName()] = append(names[r.TableName()], r.Get("name").(string))
+	}
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, names["typed_table"])
+	assert.ElementsMatch(t, []string{"a1", "a2", "b1", "b2", "c1", "c2"}, names["typed_table_children"])
+}
@@ -0,0 +1,58 @@
+package execution
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+)
+
+// FailFast cancels a fetch on the first diagnostic of error severity reported by its executors, so fetches that must
+// succeed as a whole, i.e in CI, don't keep fetching once they failed
+type FailFast struct {
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err diag.Diagnostic
+}
+
+// NewFailFast creates a FailFast, shared by the executors of a fetch, calling cancel on the first error
+func NewFailFast(cancel context.CancelFunc) *FailFast {
+	return &FailFast{cancel: cancel}
+}
+
+// WithFailFast cancels the fetch once the execution reports an error
+func WithFailFast(f *FailFast) Option {
+	return func(e *TableExecutor) {
+		e.failFast = f
+	}
+}
+
+// Err returns the error diagnostic that canceled the fetch, nil if it wasn't canceled
+func (f *FailFast) Err() diag.Diagnostic {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// check cancels the fetch if diags have an error, only the first error is kept
+func (f *FailFast) check(diags diag.Diagnostics) {
+	if f == nil {
+		return
+	}
+	for _, d := range diags {
+		if d.Severity() < diag.ERROR {
+			continue
+		}
+		f.mu.Lock()
+		first := f.err == nil
+		if first {
+			f.err = d
+		}
+		f.mu.Unlock()
+		if first {
+			f.cancel()
+		}
+		return
+	}
+}
@@ -0,0 +1,78 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// DefaultHistoryInterval is the time range of the partitions of history tables if HistoryConfig.Interval isn't set
+const DefaultHistoryInterval = 24 * time.Hour
+
+// HistoryConfig configures history mode, where the rows of every fetch are kept, partitioned by their cq_fetch_date,
+// instead of replacing the rows of the previous fetches. History mode requires the timescale dialect, whose tables are
+// keyed by the fetch date.
+type HistoryConfig struct {
+	// Retention is the number of days fetched rows are kept, partitions older than it are dropped. If zero rows are
+	// kept forever.
+	Retention int
+	// Interval is the time range of a partition, DefaultHistoryInterval if not set
+	Interval time.Duration
+}
+
+// WithHistory keeps the rows of previous fetches of the table, for storages set up with SetupHistory. Stale data isn't
+// removed and staged inserts, which replace the table, are disabled.
+func WithHistory() Option {
+	return func(e *TableExecutor) {
+		e.history = true
+	}
+}
+
+// SupportsHistory returns true if the storage supports history mode, that is it has the timescale dialect
+func SupportsHistory(db Storage) bool {
+	d := db.Dialect()
+	if td, ok := d.(schema.TenantDialect); ok {
+		d = td.Dialect
+	}
+	_, ok := d.(schema.TSDBDialect)
+	return ok
+}
+
+// SetupHistory partitions the tables, and their relations, by their fetch date and configures the retention policy of
+// their partitions, as configured by cfg. Setting up tables again updates their partition interval and retention, the
+// partitions created before keep their interval. Only timescale storages are supported, with timescale's hypertables.
+// Tables created by timescale migrations generated with migration.RunOptions.History are set up by the migrations.
+func SetupHistory(ctx context.Context, db Storage, cfg HistoryConfig, tables ...*schema.Table) error {
+	if !SupportsHistory(db) {
+		return fmt.Errorf("history mode isn't supported by the storage, it requires the timescale dialect")
+	}
+	for _, t := range tables {
+		for _, name := range t.TableNames() {
+			for _, stmt := range HistoryStatements(name, cfg) {
+				if err := db.Exec(ctx, stmt); err != nil {
+					return fmt.Errorf("failed to set up history of table %s: %w", t.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// HistoryStatements returns the timescale statements partitioning the table by its fetch date, see SetupHistory
+func HistoryStatements(table string, cfg HistoryConfig) []string {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultHistoryInterval
+	}
+	stmts := []string{
+		fmt.Sprintf("SELECT create_hypertable('%s', 'cq_fetch_date', chunk_time_interval => INTERVAL '%d seconds', if_not_exists => TRUE, migrate_data => TRUE);", table, int64(interval.Seconds())),
+		fmt.Sprintf("SELECT set_chunk_time_interval('%s', INTERVAL '%d seconds');", table, int64(interval.Seconds())),
+		fmt.Sprintf("SELECT remove_retention_policy('%s', if_exists => TRUE);", table),
+	}
+	if cfg.Retention > 0 {
+		stmts = append(stmts, fmt.Sprintf("SELECT add_retention_policy('%s', INTERVAL '%d days');", table, cfg.Retention))
+	}
+	return stmts
+}
@@ -0,0 +1,97 @@
+package execution
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// ColumnSource is how the value of a column was resolved
+type ColumnSource string
+
+const (
+	// SourceResolver the value was set by the column's resolver
+	SourceResolver ColumnSource = "resolver"
+	// SourcePath the value was taken from the column's default path, as it has no resolver
+	SourcePath ColumnSource = "path"
+	// SourceFallbackPath the value was taken from one of the column's schema.Column.FallbackPaths
+	SourceFallbackPath ColumnSource = "fallback_path"
+	// SourceIgnoredError the column's resolver failed with an ignored error, the column was left unset
+	SourceIgnoredError ColumnSource = "ignored_error"
+	// SourceParent the value was copied from the parent resource's column, see schema.Column.InheritFromParent
+	SourceParent ColumnSource = "parent"
+)
+
+// ColumnLineage is the amount of resources whose column was resolved from the same source
+type ColumnLineage struct {
+	Table  string
+	Column string
+	Source ColumnSource
+	// Resolver is the name of the column's resolver, see schema.ResolverMeta
+	Resolver string
+	// Path is the path in the item the value was taken from, set for SourcePath and SourceFallbackPath
+	Path string
+	// Transformed is true if the column's schema.Column.Transform was applied to the value
+	Transformed bool
+	// Count of resources
+	Count uint64
+}
+
+// Lineage records how the columns of resolved resources got their values, so users can understand where a stored
+// value came from. Recording lineage has a cost per resolved column, it's meant for debug fetches.
+type Lineage struct {
+	mu      sync.Mutex
+	columns map[ColumnLineage]uint64
+}
+
+// NewLineage creates a Lineage, shared by the executors of a fetch
+func NewLineage() *Lineage {
+	return &Lineage{columns: make(map[ColumnLineage]uint64)}
+}
+
+// WithLineage records the lineage of the resolved columns
+func WithLineage(l *Lineage) Option {
+	return func(e *TableExecutor) {
+		e.lineage = l
+	}
+}
+
+// Report returns the lineage of the columns resolved so far, sorted by table, column and source
+func (l *Lineage) Report() []ColumnLineage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	report := make([]ColumnLineage, 0, len(l.columns))
+	for cl, count := range l.columns {
+		cl.Count = count
+		report = append(report, cl)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Path < b.Path
+	})
+	return report
+}
+
+// record the source of column c of a resource of table t
+func (l *Lineage) record(t *schema.Table, c schema.Column, source ColumnSource, path string, transformed bool) {
+	if l == nil {
+		return
+	}
+	cl := ColumnLineage{Table: t.Name, Column: c.Name, Source: source, Path: path, Transformed: transformed}
+	if m := c.Meta(); m.Resolver != nil {
+		cl.Resolver = m.Resolver.Name
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.columns[cl]++
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/jackc/pgx/v4"
@@ -106,13 +105,13 @@ func (_m *DatabaseMock) Query(ctx context.Context, query string, args ...interfa
 	return r0, r1
 }
 
-// RemoveStaleData provides a mock function with given fields: ctx, t, executionStart, kvFilters
-func (_m *DatabaseMock) RemoveStaleData(ctx context.Context, t *schema.Table, executionStart time.Time, kvFilters []interface{}) error {
-	ret := _m.Called(ctx, t, executionStart, kvFilters)
+// RemoveStaleData provides a mock function with given fields: ctx, t, filter, kvFilters
+func (_m *DatabaseMock) RemoveStaleData(ctx context.Context, t *schema.Table, filter StaleFilter, kvFilters []interface{}) error {
+	ret := _m.Called(ctx, t, filter, kvFilters)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *schema.Table, time.Time, []interface{}) error); ok {
-		r0 = rf(ctx, t, executionStart, kvFilters)
+	if rf, ok := ret.Get(0).(func(context.Context, *schema.Table, StaleFilter, []interface{}) error); ok {
+		r0 = rf(ctx, t, filter, kvFilters)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,88 @@
+package execution
+
+import (
+	"sync"
+	"time"
+)
+
+// Progress is a periodic update of a running table execution, see WithProgress
+type Progress struct {
+	// ResourceName is the name of the executed resource
+	ResourceName string
+	// ResourceCount is the amount of resources resolved so far, including relations
+	ResourceCount uint64
+	// Client is the id of the multiplexed client that last resolved resources
+	Client string
+	// Elapsed is the time since the execution started
+	Elapsed time.Duration
+}
+
+// WithProgress calls report with the progress of the execution every interval, until Resolve returns
+func WithProgress(interval time.Duration, report func(Progress)) Option {
+	return func(e *TableExecutor) {
+		if interval <= 0 || report == nil {
+			return
+		}
+		e.progress = &progressTracker{interval: interval, report: report}
+	}
+}
+
+// progressTracker accumulates the progress of an execution, shared by the executors of its tables
+type progressTracker struct {
+	interval time.Duration
+	report   func(Progress)
+
+	mu     sync.Mutex
+	count  uint64
+	client string
+}
+
+func (p *progressTracker) resolved(client string, n int) {
+	if p == nil || n == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count += uint64(n)
+	p.client = client
+}
+
+func (p *progressTracker) snapshot(resourceName string, start time.Time) Progress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Progress{
+		ResourceName:  resourceName,
+		ResourceCount: p.count,
+		Client:        p.client,
+		Elapsed:       time.Since(start),
+	}
+}
+
+// start reports the progress every interval until the returned stop function is called
+func (p *progressTracker) start(resourceName string, start time.Time) (stop func()) {
+	if p == nil {
+		return func() {}
+	}
+	var (
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				p.report(p.snapshot(resourceName, start))
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,142 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+)
+
+// createQuarantineTable creates the quarantine table, which keeps the items of the fetches of all the providers sharing
+// the database that couldn't be stored
+const createQuarantineTable = `CREATE TABLE IF NOT EXISTS "cq_quarantine" (
+	"id" uuid NOT NULL PRIMARY KEY,
+	"fetch_id" text,
+	"table_name" text NOT NULL,
+	"error" text NOT NULL,
+	"payload" jsonb,
+	"created_at" timestamp without time zone NOT NULL
+)`
+
+// quarantineBatchSize is the amount of quarantined items inserted together
+const quarantineBatchSize = 100
+
+// Quarantine writes the items of resources that failed to resolve or to insert, i.e with values failing their column's
+// type validation, to the cq_quarantine table with the raw item as JSON and the error. Users can inspect the data the
+// provider couldn't map instead of losing it. Sensitive values are masked by the executor's Redactor, if set. Items are
+// inserted in batches in the background, Flush writes the remaining ones once the fetch is done. Writes that fail are
+// logged, they don't fail the fetch. The storage must support postgres statements.
+type Quarantine struct {
+	db      Storage
+	fetchID string
+	logger  hclog.Logger
+
+	createOnce sync.Once
+	createErr  error
+
+	mu      sync.Mutex
+	pending []quarantineRow
+	// writeMu serializes the writes of batches, writes waits for the batches written in the background
+	writeMu sync.Mutex
+	writes  sync.WaitGroup
+}
+
+// quarantineRow is a row of the cq_quarantine table
+type quarantineRow struct {
+	id        uuid.UUID
+	table     string
+	err       string
+	payload   string
+	createdAt time.Time
+}
+
+// NewQuarantine creates a Quarantine writing to db. fetchID identifies the fetch of the rows, it may be empty.
+func NewQuarantine(db Storage, fetchID string, logger hclog.Logger) *Quarantine {
+	return &Quarantine{db: db, fetchID: fetchID, logger: logger}
+}
+
+// WithQuarantine writes the items of the execution's resources that failed to resolve or insert to the quarantine
+func WithQuarantine(q *Quarantine) Option {
+	return func(e *TableExecutor) {
+		e.quarantine = q
+	}
+}
+
+// add queues the item of a resource of table that failed with err, masking the sensitive values known to r. Once a
+// batch is queued it's written in the background.
+func (q *Quarantine) add(table string, item interface{}, err error, r *Redactor) {
+	if q == nil {
+		return
+	}
+	payload, mErr := json.Marshal(item)
+	if mErr != nil {
+		// items that can't be encoded are kept in their printed form
+		payload, _ = json.Marshal(fmt.Sprintf("%+v", item))
+	}
+	row := quarantineRow{
+		id:        uuid.New(),
+		table:     table,
+		err:       r.String(err.Error()),
+		payload:   r.String(string(payload)),
+		createdAt: time.Now().UTC(),
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, row)
+	if len(q.pending) < quarantineBatchSize {
+		return
+	}
+	batch := q.pending
+	q.pending = nil
+	q.writes.Add(1)
+	go func() {
+		defer q.writes.Done()
+		q.write(batch)
+	}()
+}
+
+// Flush writes the queued items, and waits for the batches being written in the background
+func (q *Quarantine) Flush() {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	batch := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+	if len(batch) > 0 {
+		q.write(batch)
+	}
+	q.writes.Wait()
+}
+
+// write inserts the rows in a single statement, creating the quarantine table on the first write
+func (q *Quarantine) write(rows []quarantineRow) {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), auditWriteTimeout)
+	defer cancel()
+	q.createOnce.Do(func() {
+		q.createErr = q.db.Exec(ctx, createQuarantineTable)
+	})
+	if q.createErr != nil {
+		q.logger.Debug("items aren't quarantined, failed to create the quarantine table", "items", len(rows), "error", q.createErr)
+		return
+	}
+	values := make([]string, len(rows))
+	args := make([]interface{}, 0, len(rows)*6)
+	for i, r := range rows {
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, r.id, q.fetchID, r.table, r.err, r.payload, r.createdAt)
+	}
+	query := `INSERT INTO "cq_quarantine" ("id", "fetch_id", "table_name", "error", "payload", "created_at") VALUES ` + strings.Join(values, ", ")
+	if err := q.db.Exec(ctx, query, args...); err != nil {
+		q.logger.Warn("failed to quarantine items", "items", len(rows), "error", err)
+	}
+}
@@ -0,0 +1,38 @@
+package execution
+
+import (
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiters holds the token bucket of each rate limited table, shared by all the table's multiplexed clients and
+// parent resources in the execution
+type rateLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{limiters: make(map[string]*rate.Limiter)}
+}
+
+// forTable returns the limiter of the table, or nil if the table has no schema.RateLimit
+func (l *rateLimiters) forTable(t *schema.Table) *rate.Limiter {
+	if t.RateLimit == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limiter, ok := l.limiters[t.Name]
+	if !ok {
+		burst := t.RateLimit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(t.RateLimit.RequestsPerSecond), burst)
+		l.limiters[t.Name] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,218 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// redactedMask replaces sensitive values, as values masked by schema.PolicyActionMask
+	redactedMask = "****"
+	// minSensitiveLength is the length of the shortest sensitive value that is masked, shorter values would mask
+	// unrelated text
+	minSensitiveLength = 4
+)
+
+// Redactor masks the values of schema.Column.Sensitive columns in the diagnostics, spans and logs of a fetch. It's shared
+// by the executors of the fetch, and keeps the sensitive values of all the resources resolved so far, so values of a
+// resource are masked in errors reported later, i.e when its batch fails to insert.
+type Redactor struct {
+	mu       sync.RWMutex
+	values   map[string]struct{}
+	replacer *strings.Replacer
+	// stale is true if values were added since replacer was built, it's rebuilt once by the next String call
+	stale bool
+}
+
+// NewRedactor creates a Redactor of a fetch
+func NewRedactor() *Redactor {
+	return &Redactor{values: make(map[string]struct{})}
+}
+
+// WithRedactor masks the sensitive values of the execution's resources in its diagnostics, spans and logs
+func WithRedactor(r *Redactor) Option {
+	return func(e *TableExecutor) {
+		e.redactor = r
+		e.Logger = r.Logger(e.Logger)
+	}
+}
+
+// String masks the sensitive values in s
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	replacer := r.currentReplacer()
+	if replacer == nil {
+		return s
+	}
+	return replacer.Replace(s)
+}
+
+// currentReplacer returns the replacer of the values added so far, rebuilding it if values were added since it was built
+func (r *Redactor) currentReplacer() *strings.Replacer {
+	r.mu.RLock()
+	replacer, stale := r.replacer, r.stale
+	r.mu.RUnlock()
+	if !stale {
+		return replacer
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stale {
+		pairs := make([]string, 0, len(r.values)*2)
+		for v := range r.values {
+			pairs = append(pairs, v, redactedMask)
+		}
+		r.replacer = strings.NewReplacer(pairs...)
+		r.stale = false
+	}
+	return r.replacer
+}
+
+// Diagnostics masks the sensitive values in the errors and descriptions of diags
+func (r *Redactor) Diagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	if r == nil || len(diags) == 0 {
+		return diags
+	}
+	masked := make(diag.Diagnostics, len(diags))
+	for i, d := range diags {
+		if _, ok := d.(diag.MaskedDiagnostic); ok {
+			masked[i] = d
+			continue
+		}
+		masked[i] = diag.NewMaskedDiagnostic(d, r.String)
+	}
+	return masked
+}
+
+// Logger wraps l, masking the sensitive values in the messages and arguments it logs
+func (r *Redactor) Logger(l hclog.Logger) hclog.Logger {
+	if r == nil {
+		return l
+	}
+	if rl, ok := l.(redactingLogger); ok {
+		l = rl.Logger
+	}
+	return redactingLogger{Logger: l, r: r}
+}
+
+// addResource keeps the sensitive values of the resource, the replacer masking them is rebuilt lazily so resolving a
+// batch of resources rebuilds it once
+func (r *Redactor) addResource(resource *schema.Resource) {
+	if r == nil {
+		return
+	}
+	values := resource.SensitiveValues()
+	if len(values) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range values {
+		if len(v) < minSensitiveLength {
+			continue
+		}
+		if _, ok := r.values[v]; !ok {
+			r.values[v] = struct{}{}
+			r.stale = true
+		}
+	}
+}
+
+// args masks the sensitive values of log arguments, values are replaced by their masked printed form if it has any
+func (r *Redactor) args(args []interface{}) []interface{} {
+	masked := make([]interface{}, len(args))
+	for i, a := range args {
+		switch v := a.(type) {
+		case string:
+			masked[i] = r.String(v)
+		case diag.Diagnostics:
+			masked[i] = r.Diagnostics(v)
+		case error:
+			if s := r.String(v.Error()); s != v.Error() {
+				masked[i] = errors.New(s)
+			} else {
+				masked[i] = v
+			}
+		default:
+			if printed := fmt.Sprint(v); r.String(printed) != printed {
+				masked[i] = r.String(printed)
+			} else {
+				masked[i] = v
+			}
+		}
+	}
+	return masked
+}
+
+// redactingLogger masks sensitive values of the messages and arguments of its logs, see Redactor.Logger
+type redactingLogger struct {
+	hclog.Logger
+	r *Redactor
+}
+
+func (l redactingLogger) Log(level hclog.Level, msg string, args ...interface{}) {
+	l.Logger.Log(level, l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) Trace(msg string, args ...interface{}) {
+	l.Logger.Trace(l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) Debug(msg string, args ...interface{}) {
+	l.Logger.Debug(l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) Info(msg string, args ...interface{}) {
+	l.Logger.Info(l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) Warn(msg string, args ...interface{}) {
+	l.Logger.Warn(l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) Error(msg string, args ...interface{}) {
+	l.Logger.Error(l.r.String(msg), l.r.args(args)...)
+}
+
+func (l redactingLogger) With(args ...interface{}) hclog.Logger {
+	return redactingLogger{Logger: l.Logger.With(l.r.args(args)...), r: l.r}
+}
+
+func (l redactingLogger) Named(name string) hclog.Logger {
+	return redactingLogger{Logger: l.Logger.Named(name), r: l.r}
+}
+
+func (l redactingLogger) ResetNamed(name string) hclog.Logger {
+	return redactingLogger{Logger: l.Logger.ResetNamed(name), r: l.r}
+}
+
+func (l redactingLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(l.StandardWriter(opts), "", 0)
+}
+
+func (l redactingLogger) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	return redactingWriter{w: l.Logger.StandardWriter(opts), r: l.r}
+}
+
+// redactingWriter masks sensitive values written to w
+type redactingWriter struct {
+	w io.Writer
+	r *Redactor
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write([]byte(w.r.String(string(p)))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,47 @@
+package execution
+
+import (
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+)
+
+// relationRunner runs the relation resolves of a batch of resources. If the executor resolves relations in parallel,
+// resolves run in goroutines of goroutinesSem while any are available, and in the calling goroutine otherwise, so
+// parents holding goroutines can't deadlock waiting for their relations.
+type relationRunner struct {
+	e     *TableExecutor
+	wg    sync.WaitGroup
+	mu    sync.Mutex
+	diags diag.Diagnostics
+}
+
+func (r *relationRunner) run(resolve func() diag.Diagnostics) {
+	if !r.e.parallelRelations || r.e.goroutinesSem == nil || !r.e.goroutinesSem.TryAcquire(1) {
+		r.add(resolve())
+		return
+	}
+	r.e.semaphoreStats.acquired(0)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer r.e.goroutinesSem.Release(1)
+		defer r.e.semaphoreStats.released()
+		r.add(resolve())
+	}()
+}
+
+func (r *relationRunner) add(diags diag.Diagnostics) {
+	if !diags.HasDiags() {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.diags = r.diags.Add(diags)
+}
+
+// wait waits for the resolves running in goroutines, and returns the diagnostics of all resolves
+func (r *relationRunner) wait() diag.Diagnostics {
+	r.wg.Wait()
+	return r.diags
+}
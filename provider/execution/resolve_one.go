@@ -0,0 +1,102 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/cloudquery/cq-provider-sdk/stats"
+)
+
+// ResolveOne resolves the single resource of the table identified by the values of its primary keys with the table's
+// schema.Table.GetResolver, i.e to refresh one resource without fetching the whole table. The resource's columns and
+// relations are resolved and stored as in Resolve, replacing the stored resource. Multiplexed tables call the
+// GetResolver with each of their clients, the resource is stored for every client that finds it. Stale data isn't
+// removed.
+func (e TableExecutor) ResolveOne(ctx context.Context, meta schema.ClientMeta, primaryKeys map[string]string) (nc uint64, diags diag.Diagnostics) {
+	ctx, span := e.startSpan(ctx, "ResolveOne")
+	defer func() {
+		diags = e.redactor.Diagnostics(diags)
+		endSpan(span, nc, diags)
+	}()
+
+	if e.Table.GetResolver == nil {
+		return 0, diags.Add(diag.NewBaseError(nil, diag.SCHEMA, diag.WithSeverity(diag.ERROR), diag.WithResourceName(e.ResourceName),
+			diag.WithSummary("table %q doesn't support fetching a single resource, it has no get resolver", e.Table.Name)))
+	}
+	if err := validatePrimaryKeys(e.Table, primaryKeys); err != nil {
+		return 0, diags.Add(diag.NewBaseError(err, diag.USER, diag.WithSeverity(diag.ERROR), diag.WithResourceName(e.ResourceName),
+			diag.WithSummary("invalid primary keys of table %q", e.Table.Name)))
+	}
+
+	clients := []schema.ClientMeta{meta}
+	if e.Table.Multiplex != nil {
+		clients = e.Table.Multiplex(meta)
+	}
+	for _, client := range clients {
+		item, err := e.callGetResolver(ctx, client, primaryKeys)
+		if err != nil {
+			diags = diags.Add(err)
+			continue
+		}
+		if item == nil {
+			continue
+		}
+		count, dd := e.resolveResources(ctx, client, nil, []interface{}{item})
+		diags = diags.Add(dd)
+		nc += count
+	}
+	if nc == 0 && !diags.HasErrors() {
+		e.Logger.Debug("resource not found", "primary_keys", primaryKeys)
+	}
+	return nc, diags
+}
+
+// callGetResolver calls the table's GetResolver with the client, recovering its panics
+func (e TableExecutor) callGetResolver(ctx context.Context, client schema.ClientMeta, primaryKeys map[string]string) (item interface{}, diags diag.Diagnostics) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := string(debug.Stack())
+			e.Logger.Error("get resolver recovered from panic", "stack", stack)
+			stats.IncrResolverPanics(e.Table.Name)
+			item, diags = nil, diag.Diagnostics{diag.NewBaseError(fmt.Errorf("get resolver panic: %s", r), diag.RESOLVING, diag.WithResourceName(e.ResourceName),
+				diag.WithSeverity(diag.PANIC), diag.WithSummary("panic on resource table %q get", e.Table.Name), diag.WithDetails("%s", stack))}
+		}
+	}()
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	ctx = schema.WithClientStats(ctx, e.apiCalls.forClient(e.Table.Name, identifyClient(client)))
+	item, err := e.Table.GetResolver(ctx, client, primaryKeys)
+	if err != nil {
+		if e.IgnoreError(err) {
+			e.Logger.Debug("ignored an error", "err", err)
+			err = diag.NewBaseError(err, diag.RESOLVING, diag.WithSeverity(diag.IGNORE), diag.WithSummary("table %q get resolver ignored error", e.Table.Name))
+		}
+		return nil, e.handleResolveError(client, nil, err)
+	}
+	return item, nil
+}
+
+// validatePrimaryKeys verifies the keys are columns of the table, and that all the table's primary keys are set if it
+// has any
+func validatePrimaryKeys(t *schema.Table, primaryKeys map[string]string) error {
+	if len(primaryKeys) == 0 {
+		return fmt.Errorf("no primary keys")
+	}
+	for name := range primaryKeys {
+		if t.Column(name) == nil {
+			return fmt.Errorf("column %q doesn't exist", name)
+		}
+	}
+	for _, pk := range t.Options.PrimaryKeys {
+		if _, ok := primaryKeys[pk]; !ok {
+			return fmt.Errorf("missing value of primary key %q", pk)
+		}
+	}
+	return nil
+}
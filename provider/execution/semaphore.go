@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultSemaphoreWaitThreshold is the time a table may wait for the goroutines semaphore to resolve its clients
+// before a warning is reported
+const DefaultSemaphoreWaitThreshold = time.Minute
+
+// SemaphoreStats records the acquisitions of the goroutines semaphore of a fetch. It is shared by the fetch's table
+// executors via WithSemaphoreStats, and its Report helps tuning the fetch's max goroutines.
+type SemaphoreStats struct {
+	mu           sync.Mutex
+	capacity     int64
+	start        time.Time
+	inUse        int64
+	peak         int64
+	acquisitions uint64
+	totalWait    time.Duration
+	maxWait      time.Duration
+	// busy is the sum of the time each slot of the semaphore was held
+	busy       time.Duration
+	lastChange time.Time
+}
+
+// SemaphoreReport summarizes the goroutines semaphore usage of a fetch
+type SemaphoreReport struct {
+	// Capacity is the semaphore's weight, i.e the fetch's max goroutines
+	Capacity int64
+	// Peak is the highest number of slots held at once
+	Peak int64
+	// Acquisitions is the number of times a slot was acquired
+	Acquisitions uint64
+	// TotalWait is the sum of time spent waiting to acquire slots
+	TotalWait time.Duration
+	// MaxWait is the longest time spent waiting for a single slot
+	MaxWait time.Duration
+	// Utilization is the fraction of the semaphore's capacity held over the fetch, between 0 and 1
+	Utilization float64
+}
+
+// NewSemaphoreStats creates SemaphoreStats for a semaphore with the given capacity
+func NewSemaphoreStats(capacity int64) *SemaphoreStats {
+	now := time.Now()
+	return &SemaphoreStats{capacity: capacity, start: now, lastChange: now}
+}
+
+// WithSemaphoreStats records the executor's goroutines semaphore acquisitions in s
+func WithSemaphoreStats(s *SemaphoreStats) Option {
+	return func(e *TableExecutor) {
+		e.semaphoreStats = s
+	}
+}
+
+// WithSemaphoreWaitThreshold sets the time a table may wait for the goroutines semaphore before a warning is reported,
+// defaults to DefaultSemaphoreWaitThreshold
+func WithSemaphoreWaitThreshold(d time.Duration) Option {
+	return func(e *TableExecutor) {
+		e.semaphoreWaitThreshold = d
+	}
+}
+
+// Report returns the semaphore usage recorded so far
+func (s *SemaphoreStats) Report() SemaphoreReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance(time.Now())
+	r := SemaphoreReport{
+		Capacity:     s.capacity,
+		Peak:         s.peak,
+		Acquisitions: s.acquisitions,
+		TotalWait:    s.totalWait,
+		MaxWait:      s.maxWait,
+	}
+	if elapsed := s.lastChange.Sub(s.start); elapsed > 0 && s.capacity > 0 {
+		r.Utilization = float64(s.busy) / float64(elapsed*time.Duration(s.capacity))
+	}
+	return r
+}
+
+func (s *SemaphoreStats) acquired(wait time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance(time.Now())
+	s.inUse++
+	if s.inUse > s.peak {
+		s.peak = s.inUse
+	}
+	s.acquisitions++
+	s.totalWait += wait
+	if wait > s.maxWait {
+		s.maxWait = wait
+	}
+}
+
+func (s *SemaphoreStats) released() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance(time.Now())
+	s.inUse--
+}
+
+// advance adds the time since the last change, during which inUse slots were held, to busy
+func (s *SemaphoreStats) advance(now time.Time) {
+	s.busy += now.Sub(s.lastChange) * time.Duration(s.inUse)
+	s.lastChange = now
+}
@@ -0,0 +1,22 @@
+package execution
+
+import "sync"
+
+// sequenceCounter numbers the resolved resources of each table, shared by all the executors of a fetch so sequences
+// are unique per table even when multiplexed clients resolve concurrently
+type sequenceCounter struct {
+	mu   sync.Mutex
+	next map[string]int64
+}
+
+func newSequenceCounter() *sequenceCounter {
+	return &sequenceCounter{next: make(map[string]int64)}
+}
+
+// Next returns the next sequence value of the table, starting at 1
+func (s *sequenceCounter) Next(table string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[table]++
+	return s.next[table]
+}
@@ -0,0 +1,70 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// stager returns the storage staging the resources of the execution, or nil if its top level table doesn't have
+// schema.Table.StagedInsert, the storage doesn't support staging or the execution only samples or verifies the table
+func (e TableExecutor) stager() StagingStorage {
+	if e.sampleLimit > 0 || e.verifier != nil {
+		return nil
+	}
+	root := &e
+	for root.ParentExecutor != nil {
+		root = root.ParentExecutor
+	}
+	// the staged rows of incremental and filtered fetches are only some of the resources, they are written in place
+	// instead, as are the rows of history tables, which keep the rows of previous fetches
+	if !root.Table.StagedInsert || root.storedCursor != "" || root.fetchFilters[root.Table.Name] != nil || root.history {
+		return nil
+	}
+	s, ok := e.Db.(StagingStorage)
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+// stageResources writes resources to the staging table, isolating the failing resources in sub-batches. Only
+// successfully staged resources are returned.
+func (e TableExecutor) stageResources(ctx context.Context, s StagingStorage, resources schema.Resources) (schema.Resources, diag.Diagnostics) {
+	err := s.Stage(ctx, e.Table, resources)
+	if err == nil {
+		return resources, nil
+	}
+	e.Logger.Warn("failed to stage resources", "error", err)
+	diags := diag.Diagnostics{}.Add(ClassifyError(err, diag.WithType(diag.DATABASE), diag.WithSummary("failed to stage resources of table %q", e.Table.Name)))
+	staged, failures := e.writeSubBatches(resources, func(batch schema.Resources) error {
+		return s.Stage(ctx, e.Table, batch)
+	})
+	for _, f := range failures {
+		e.Logger.Error("failed to stage resource", "error", f.err, "resource_keys", f.resource.PrimaryKeyValues())
+		diags = diags.Add(ClassifyError(f.err, diag.WithType(diag.DATABASE), WithResource(f.resource),
+			diag.WithSummary("failed to stage resource %v in table %q", f.resource.PrimaryKeyValues(), e.Table.Name)))
+	}
+	return staged, diags
+}
+
+// swapStaged replaces the client's rows of the top level table with the staged rows, once the client is resolved.
+// The swap removes the client's rows that weren't fetched, so stale data isn't removed separately.
+func (e TableExecutor) swapStaged(ctx context.Context, s StagingStorage, client schema.ClientMeta) error {
+	filters := e.deleteFilters(client, nil)
+	e.Logger.Debug("swapping staged table", "filters", filters)
+	if err := s.SwapStaged(ctx, e.Table, filters); err != nil {
+		e.Logger.Warn("failed to swap staged table", "error", err)
+		return err
+	}
+	return nil
+}
+
+// discardStaged removes the client's staged rows of the top level table before it's resolved, which are left over if
+// the client failed to resolve in a previous fetch
+func (e TableExecutor) discardStaged(ctx context.Context, s StagingStorage, client schema.ClientMeta) {
+	if err := s.DiscardStaged(ctx, e.Table, e.deleteFilters(client, nil)); err != nil {
+		e.Logger.Warn("failed to discard staged rows", "error", err)
+	}
+}
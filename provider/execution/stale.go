@@ -0,0 +1,102 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// DefaultStaleJitter is subtracted from the execution start when removing stale data by last update time, so if a user
+// fetches only 1 resource and it finishes faster than the <1s it won't be deleted by remove stale.
+const DefaultStaleJitter = time.Minute
+
+// StaleFilter defines which rows of a table are stale after the table was fetched, and should be removed.
+type StaleFilter struct {
+	// FetchId if set, rows that weren't written by this fetch are stale.
+	FetchId string
+	// LastUpdateBefore rows last updated before this time are stale, used only if FetchId isn't set.
+	LastUpdateBefore time.Time
+}
+
+// WithStaleJitter sets the jitter subtracted from the execution start when removing stale data by last update time.
+// The jitter isn't used if the fetch has an id, see schema.FetchIdMetaKey.
+func WithStaleJitter(jitter time.Duration) Option {
+	return func(e *TableExecutor) {
+		e.staleJitter = jitter
+	}
+}
+
+// NewStaleFilter returns the StaleFilter of a fetch that started at executionStart. If the fetch metadata has a fetch id
+// rows are considered stale by it, otherwise by their last update time, allowing the given jitter.
+func NewStaleFilter(metadata map[string]interface{}, executionStart time.Time, jitter time.Duration) StaleFilter {
+	if id, ok := metadata[schema.FetchIdMetaKey].(string); ok && id != "" {
+		return StaleFilter{FetchId: id}
+	}
+	return StaleFilter{LastUpdateBefore: executionStart.Add(-jitter)}
+}
+
+// StaleCounter is a Storage that can count the stale rows of a table, used by StaleReport to report the rows removed by
+// stale cleanup
+type StaleCounter interface {
+	Storage
+	// CountStaleData counts the rows of the table RemoveStaleData removes, or marks as deleted, with the same filters
+	CountStaleData(ctx context.Context, t *schema.Table, filter StaleFilter, kvFilters []interface{}) (uint64, error)
+}
+
+// StaleReport counts the stale rows of the top level tables of a fetch before they are removed, so they are reported
+// in the fetch's diagnostics once they are. If cleanup is skipped the stale rows are only counted. Rows are counted if
+// the storage is a StaleCounter.
+type StaleReport struct {
+	skipCleanup bool
+	mu          sync.Mutex
+	counts      map[string]uint64
+}
+
+// NewStaleReport creates the StaleReport of a fetch, if skipCleanup is set stale data isn't removed
+func NewStaleReport(skipCleanup bool) *StaleReport {
+	return &StaleReport{skipCleanup: skipCleanup, counts: make(map[string]uint64)}
+}
+
+// WithStaleReport counts the stale rows of the table before they are removed, see StaleReport
+func WithStaleReport(r *StaleReport) Option {
+	return func(e *TableExecutor) {
+		e.staleReport = r
+	}
+}
+
+// Diagnostics returns the diagnostic reporting the stale rows counted of table t, if any were. Stale rows are expected
+// for resources removed from the cloud, so it has IGNORE severity.
+func (r *StaleReport) Diagnostics(resourceName string, t *schema.Table) diag.Diagnostics {
+	r.mu.Lock()
+	count := r.counts[t.Name]
+	r.mu.Unlock()
+	if count == 0 {
+		return nil
+	}
+	summary := fmt.Sprintf("removed %d stale rows of table %q", count, t.Name)
+	if r.skipCleanup {
+		summary = fmt.Sprintf("%d stale rows of table %q weren't removed, stale cleanup is skipped", count, t.Name)
+	}
+	return diag.Diagnostics{diag.NewBaseError(nil, diag.DATABASE, diag.WithSeverity(diag.IGNORE), diag.WithResourceName(resourceName),
+		diag.WithSummary("%s", summary))}
+}
+
+// count returns the stale rows of the table matching the filters, they are reported once added
+func (r *StaleReport) count(ctx context.Context, db Storage, t *schema.Table, filter StaleFilter, kvFilters []interface{}) (uint64, error) {
+	counter, ok := db.(StaleCounter)
+	if !ok {
+		return 0, nil
+	}
+	return counter.CountStaleData(ctx, t, filter, kvFilters)
+}
+
+// add adds n stale rows of the table to the report
+func (r *StaleReport) add(t *schema.Table, n uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[t.Name] += n
+}
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/cloudquery/cq-provider-sdk/testlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStaleFilter(t *testing.T) {
+	start := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		Name     string
+		Metadata map[string]interface{}
+		Jitter   time.Duration
+		Expected StaleFilter
+	}{
+		{
+			Name:     "no metadata",
+			Jitter:   DefaultStaleJitter,
+			Expected: StaleFilter{LastUpdateBefore: start.Add(-time.Minute)},
+		},
+		{
+			Name:     "no jitter",
+			Metadata: map[string]interface{}{"other": "value"},
+			Expected: StaleFilter{LastUpdateBefore: start},
+		},
+		{
+			Name:     "fetch id",
+			Metadata: map[string]interface{}{schema.FetchIdMetaKey: "a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29"},
+			Jitter:   DefaultStaleJitter,
+			Expected: StaleFilter{FetchId: "a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29"},
+		},
+		{
+			Name:     "empty fetch id",
+			Metadata: map[string]interface{}{schema.FetchIdMetaKey: ""},
+			Jitter:   time.Second,
+			Expected: StaleFilter{LastUpdateBefore: start.Add(-time.Second)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, NewStaleFilter(tc.Metadata, start, tc.Jitter))
+		})
+	}
+}
+
+// failingStaleStorage counts stale rows, but fails to remove them
+type failingStaleStorage struct {
+	noopStorage
+}
+
+func (failingStaleStorage) CountStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) (uint64, error) {
+	return 2, nil
+}
+
+func (failingStaleStorage) RemoveStaleData(context.Context, *schema.Table, StaleFilter, []interface{}) error {
+	return errors.New("remove failed")
+}
+
+func TestStaleReport_RemoveFailed(t *testing.T) {
+	table := &schema.Table{Name: "stale_report_table"}
+	report := NewStaleReport(false)
+	exec := newTestExecutor(t, "test", failingStaleStorage{noopStorage{D: schema.PostgresDialect{}}}, table, WithStaleReport(report))
+	assert.Error(t, exec.cleanupStaleData(context.Background(), executionClient{testlog.New(t)}, nil))
+	assert.Empty(t, report.Diagnostics("test", table))
+}
@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// APICallStat is the amount of calls made to a single API operation by a client while resolving a table
+type APICallStat struct {
+	Table     string
+	ClientID  string
+	Service   string
+	Operation string
+	Count     uint64
+}
+
+type apiCallKey struct {
+	table     string
+	clientID  string
+	service   string
+	operation string
+}
+
+// apiCallCollector aggregates API calls recorded by resolvers, per table and client
+type apiCallCollector struct {
+	mu    sync.Mutex
+	calls map[apiCallKey]uint64
+}
+
+// tableClientStats implements schema.ClientStats for a single table and client
+type tableClientStats struct {
+	collector *apiCallCollector
+	table     string
+	clientID  string
+}
+
+func newAPICallCollector() *apiCallCollector {
+	return &apiCallCollector{calls: make(map[apiCallKey]uint64)}
+}
+
+func (c *apiCallCollector) forClient(table, clientID string) schema.ClientStats {
+	return tableClientStats{collector: c, table: table, clientID: clientID}
+}
+
+func (c *apiCallCollector) inc(k apiCallKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[k]++
+}
+
+// stats returns all recorded API call counts, sorted by table, client, service and operation
+func (c *apiCallCollector) stats() []APICallStat {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.calls) == 0 {
+		return nil
+	}
+	ret := make([]APICallStat, 0, len(c.calls))
+	for k, v := range c.calls {
+		ret = append(ret, APICallStat{Table: k.table, ClientID: k.clientID, Service: k.service, Operation: k.operation, Count: v})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.ClientID != b.ClientID {
+			return a.ClientID < b.ClientID
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Operation < b.Operation
+	})
+	return ret
+}
+
+func (s tableClientStats) IncAPICall(service, operation string) {
+	s.collector.inc(apiCallKey{table: s.table, clientID: s.clientID, service: service, operation: operation})
+}
@@ -3,7 +3,6 @@ package execution
 import (
 	"context"
 	"io"
-	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/georgysavva/scany/pgxscan"
@@ -16,7 +15,7 @@ type Storage interface {
 	TXer
 	Insert(ctx context.Context, t *schema.Table, instance schema.Resources, shouldCascade bool) error
 	Delete(ctx context.Context, t *schema.Table, kvFilters []interface{}) error
-	RemoveStaleData(ctx context.Context, t *schema.Table, executionStart time.Time, kvFilters []interface{}) error
+	RemoveStaleData(ctx context.Context, t *schema.Table, filter StaleFilter, kvFilters []interface{}) error
 	CopyFrom(ctx context.Context, resources schema.Resources, shouldCascade bool) error
 	Close()
 	Dialect() schema.Dialect
@@ -42,3 +41,23 @@ type TXQueryExecer interface {
 	Rollback(context.Context) error
 	Commit(context.Context) error
 }
+
+// StagingStorage is a Storage supporting schema.Table.StagedInsert, tables with staged insert are written with it when
+// the storage implements it
+type StagingStorage interface {
+	Storage
+	// Stage writes resources to the staging table of their table, which is created if it doesn't exist
+	Stage(ctx context.Context, t *schema.Table, resources schema.Resources) error
+	// SwapStaged replaces the rows of top level table t matching the key/value filters and their relations with the
+	// staged rows, in a single transaction. The swapped rows are removed from the staging tables.
+	SwapStaged(ctx context.Context, t *schema.Table, kvFilters []interface{}) error
+	// DiscardStaged removes the staged rows of top level table t matching the key/value filters and their relations
+	DiscardStaged(ctx context.Context, t *schema.Table, kvFilters []interface{}) error
+}
+
+// DialectStorage is a Storage whose dialect can be replaced, i.e to scope its tables to tenants with schema.TenantDialect
+type DialectStorage interface {
+	Storage
+	// WithDialect returns the storage writing resources with dialect d, sharing the storage's connection
+	WithDialect(d schema.Dialect) Storage
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/jackc/pgx/v4"
@@ -39,7 +38,7 @@ func (noopStorage) Delete(ctx context.Context, t *schema.Table, kvFilters []inte
 	return nil
 }
 
-func (noopStorage) RemoveStaleData(ctx context.Context, t *schema.Table, executionStart time.Time, kvFilters []interface{}) error {
+func (noopStorage) RemoveStaleData(ctx context.Context, t *schema.Table, filter StaleFilter, kvFilters []interface{}) error {
 	return nil
 }
 
@@ -87,3 +86,7 @@ func (noopDialect) DBTypeFromType(v schema.ValueType) string {
 func (noopDialect) GetResourceValues(r *schema.Resource) ([]interface{}, error) {
 	return r.Values()
 }
+
+func (noopDialect) QuoteIdentifier(name string) string {
+	return schema.QuoteIdentifier(name)
+}
@@ -0,0 +1,66 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the OpenTelemetry tracer of the SDK's spans
+const TracerName = "github.com/cloudquery/cq-provider-sdk"
+
+// Attributes of the SDK's spans
+const (
+	TableAttribute         = attribute.Key("cq.table")
+	ResourceAttribute      = attribute.Key("cq.resource")
+	ClientIDAttribute      = attribute.Key("cq.client_id")
+	ResourceCountAttribute = attribute.Key("cq.resource_count")
+	// BatchSizeAttribute is the amount of resources of a saveToStorage span, ResourceCountAttribute is the amount saved
+	BatchSizeAttribute = attribute.Key("cq.batch_size")
+	// RequestedResourcesAttribute are the resources requested by a FetchResources span
+	RequestedResourcesAttribute = attribute.Key("cq.requested_resources")
+)
+
+// WithTracerProvider records the spans of the execution with the tracer provider, if not set the global tracer provider
+// of otel.GetTracerProvider is used
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(e *TableExecutor) {
+		e.tracer = tp.Tracer(TracerName)
+	}
+}
+
+func defaultTracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// endSpan ends a span of the execution, recording its resource count and whether it had errors
+func endSpan(span trace.Span, count uint64, diags diag.Diagnostics) {
+	span.SetAttributes(ResourceCountAttribute.Int64(helpers.Uint64ToInt64(count)))
+	if diags.HasErrors() {
+		span.SetStatus(codes.Error, errorSummary(diags))
+	}
+	span.End()
+}
+
+// startSpan starts a span of the execution's table
+func (e TableExecutor) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return e.tracer.Start(ctx, name, trace.WithAttributes(append([]attribute.KeyValue{
+		ResourceAttribute.String(e.ResourceName),
+		TableAttribute.String(e.Table.Name),
+	}, attrs...)...))
+}
+
+// errorSummary returns the summary of the first error of diags
+func errorSummary(diags diag.Diagnostics) string {
+	for _, d := range diags {
+		if d.Severity() >= diag.ERROR {
+			return d.Description().Summary
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package execution
+
+import (
+	"context"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// Send sends items on the result channel of a table resolver as a single batch. Items are boxed as they are sent, so
+// the executor doesn't convert the batch with reflection as it does with slices of other types:
+//
+//	func fetchInstances(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+//		instances, err := meta.(*client.Client).Instances(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		execution.Send(res, instances...)
+//		return nil
+//	}
+func Send[T any](res chan<- interface{}, items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	batch := make([]interface{}, len(items))
+	for i := range items {
+		batch[i] = items[i]
+	}
+	res <- batch
+}
+
+// TypedTableResolver is a table resolver whose items are of type T, checked at compile time. Each batch sent on res is
+// a page of the table's resources. Resolvers checkpointing their progress with schema.ResumeToken send it on the
+// untyped channel of a schema.TableResolver instead.
+type TypedTableResolver[T any] func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- []T) error
+
+// TableResolver returns the schema.TableResolver of the table, sending the batches of r with Send:
+//
+//	Resolver: execution.TypedTableResolver[ec2.Instance](fetchInstances).TableResolver(),
+func (r TypedTableResolver[T]) TableResolver() schema.TableResolver {
+	return func(ctx context.Context, meta schema.ClientMeta, parent *schema.Resource, res chan<- interface{}) error {
+		batches := make(chan []T)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for batch := range batches {
+				Send(res, batch...)
+			}
+		}()
+		// the batches are forwarded before returning, also if r panics
+		defer func() {
+			close(batches)
+			<-done
+		}()
+		return r(ctx, meta, parent, batches)
+	}
+}
@@ -0,0 +1,239 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+const (
+	// verifyBatchSize is the maximum amount of resources verified by a query
+	verifyBatchSize = 500
+	// maxQueryArgs is the maximum amount of arguments of a postgres query
+	maxQueryArgs = 65535
+)
+
+// TableDrift is the amount of resolved resources of a table that differ from the rows stored by previous fetches
+type TableDrift struct {
+	// Verified is the amount of resolved resources compared to the stored rows
+	Verified uint64
+	// Missing is the amount of resolved resources without a stored row with the same primary keys
+	Missing uint64
+	// Changed is the amount of resolved resources whose stored row has different column values
+	Changed uint64
+	// Unverified is the amount of resolved resources that couldn't be compared, as their table has no primary keys
+	Unverified uint64
+}
+
+// Drifted returns true if any of the verified resources is missing or changed
+func (d TableDrift) Drifted() bool {
+	return d.Missing > 0 || d.Changed > 0
+}
+
+// Verifier compares the resolved resources to the rows stored by previous fetches, instead of writing them. Resources
+// are matched to rows by the primary keys of their table, so tables without schema.TableCreationOptions.PrimaryKeys
+// can't be verified. Resources are compared by their values as the storage's dialect stores them, in batches. Stale
+// data isn't removed by verified fetches. The storage must support postgres statements.
+type Verifier struct {
+	mu    sync.Mutex
+	drift map[string]*TableDrift
+}
+
+// NewVerifier creates a Verifier, shared by the executors of a fetch
+func NewVerifier() *Verifier {
+	return &Verifier{drift: make(map[string]*TableDrift)}
+}
+
+// Drift returns the drift of each verified table, by table name
+func (v *Verifier) Drift() map[string]TableDrift {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	ret := make(map[string]TableDrift, len(v.drift))
+	for name, d := range v.drift {
+		ret[name] = *d
+	}
+	return ret
+}
+
+// verify compares resources of table t to their stored rows, in batches of verifyBatchSize resources
+func (v *Verifier) verify(ctx context.Context, db Storage, t *schema.Table, resources schema.Resources) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	if len(t.Options.PrimaryKeys) == 0 {
+		v.add(t.Name, TableDrift{Unverified: uint64(len(resources))})
+		return nil
+	}
+	d := db.Dialect()
+	cols := d.Columns(t)
+	pks, compared, err := verifyColumns(t, cols)
+	if err != nil {
+		return err
+	}
+	batchSize := verifyBatchSize
+	if n := maxQueryArgs / (1 + len(pks) + len(compared)); n < batchSize {
+		batchSize = n
+	}
+	var drift TableDrift
+	for start := 0; start < len(resources); start += batchSize {
+		end := start + batchSize
+		if end > len(resources) {
+			end = len(resources)
+		}
+		batch := resources[start:end]
+		args := make([]interface{}, 0, len(batch)*(1+len(pks)+len(compared)))
+		for i, r := range batch {
+			values, err := d.GetResourceValues(r)
+			if err != nil {
+				return fmt.Errorf("failed to get values of resource %v: %w", r.PrimaryKeyValues(), err)
+			}
+			args = append(args, int32(i))
+			for _, j := range pks {
+				args = append(args, values[j])
+			}
+			for _, j := range compared {
+				args = append(args, values[j])
+			}
+		}
+		found, equal, err := queryVerified(ctx, db, verifyQuery(d, t, cols, pks, compared, len(batch)), args, len(batch))
+		if err != nil {
+			return fmt.Errorf("failed to verify resources of table %s: %w", t.Name, err)
+		}
+		for i := range batch {
+			drift.Verified++
+			switch {
+			case !found[i]:
+				drift.Missing++
+			case !equal[i]:
+				drift.Changed++
+			}
+		}
+	}
+	v.add(t.Name, drift)
+	return nil
+}
+
+func (v *Verifier) add(table string, d TableDrift) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	td, ok := v.drift[table]
+	if !ok {
+		td = &TableDrift{}
+		v.drift[table] = td
+	}
+	td.Verified += d.Verified
+	td.Missing += d.Missing
+	td.Changed += d.Changed
+	td.Unverified += d.Unverified
+}
+
+// diagnostics returns a warning for each table of t, or its relations, that drifted or couldn't be verified
+func (v *Verifier) diagnostics(resourceName string, t *schema.Table) diag.Diagnostics {
+	drift := v.Drift()
+	names := t.TableNames()
+	sort.Strings(names)
+	var diags diag.Diagnostics
+	for _, name := range names {
+		d, ok := drift[name]
+		if !ok {
+			continue
+		}
+		if d.Drifted() {
+			diags = diags.Add(diag.NewBaseError(nil, diag.RESOLVING, diag.WithSeverity(diag.WARNING), diag.WithResourceName(resourceName),
+				diag.WithSummary("table %q drifted from its stored data: %d of %d resources are missing, %d changed", name, d.Missing, d.Verified, d.Changed),
+				diag.WithDetails("the resources resolved from the API don't match the rows stored by the previous fetch, fetch the resource to update them")))
+		}
+		if d.Unverified > 0 {
+			diags = diags.Add(diag.NewBaseError(nil, diag.SCHEMA, diag.WithSeverity(diag.WARNING), diag.WithResourceName(resourceName),
+				diag.WithSummary("table %q can't be verified, it has no primary keys", name)))
+		}
+	}
+	return diags
+}
+
+// verifyColumns returns the indexes in cols, the columns of the table's dialect, of the table's primary keys and of its
+// columns, which are compared to the stored rows
+func verifyColumns(t *schema.Table, cols schema.ColumnList) (pks, compared []int, err error) {
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c.Name] = i
+	}
+	for _, pk := range t.Options.PrimaryKeys {
+		i, ok := index[pk]
+		if !ok {
+			return nil, nil, fmt.Errorf("primary key %s isn't a column of table %s", pk, t.Name)
+		}
+		pks = append(pks, i)
+	}
+	for _, c := range t.Columns {
+		compared = append(compared, index[c.Name])
+	}
+	return pks, compared, nil
+}
+
+// verifyQuery selects, for each of n resources, its index, whether a row of t with its primary keys exists, and whether
+// the row has its column values. Arguments are the index, the primary keys and the compared values of each resource,
+// as returned by the dialect's GetResourceValues, and are cast to their columns' types.
+func verifyQuery(d schema.Dialect, t *schema.Table, cols schema.ColumnList, pks, compared []int, n int) string {
+	arity := 1 + len(pks) + len(compared)
+	names := make([]string, 0, arity)
+	names = append(names, "i")
+	join := make([]string, len(pks))
+	for i, j := range pks {
+		names = append(names, fmt.Sprintf("p%d", i))
+		join[i] = fmt.Sprintf("s.%s = v.p%d", d.QuoteIdentifier(cols[j].Name), i)
+	}
+	cmp := make([]string, len(compared))
+	for i, j := range compared {
+		names = append(names, fmt.Sprintf("c%d", i))
+		cmp[i] = fmt.Sprintf("s.%s IS NOT DISTINCT FROM v.c%d", d.QuoteIdentifier(cols[j].Name), i)
+	}
+	if len(cmp) == 0 {
+		cmp = []string{"true"}
+	}
+	types := make([]string, 0, arity)
+	types = append(types, "integer")
+	for _, j := range append(append([]int(nil), pks...), compared...) {
+		types = append(types, d.DBTypeFromType(cols[j].Type))
+	}
+	rows := make([]string, n)
+	for r := range rows {
+		values := make([]string, arity)
+		for i := range values {
+			values[i] = fmt.Sprintf("$%d::%s", r*arity+i+1, types[i])
+		}
+		rows[r] = "(" + strings.Join(values, ", ") + ")"
+	}
+	return fmt.Sprintf("SELECT v.i, count(s.%s) > 0, coalesce(bool_or(%s), false) FROM (VALUES %s) AS v(%s) LEFT JOIN %s AS s ON %s GROUP BY v.i",
+		d.QuoteIdentifier(cols[pks[0]].Name), strings.Join(cmp, " AND "), strings.Join(rows, ", "), strings.Join(names, ", "),
+		d.QuoteIdentifier(t.Name), strings.Join(join, " AND "))
+}
+
+// queryVerified returns, for each of the n resources verified by the query, whether its row was found and is equal
+func queryVerified(ctx context.Context, db Storage, query string, args []interface{}, n int) (found, equal []bool, err error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	found, equal = make([]bool, n), make([]bool, n)
+	for rows.Next() {
+		var (
+			i          int32
+			exists, eq bool
+		)
+		if err := rows.Scan(&i, &exists, &eq); err != nil {
+			return nil, nil, err
+		}
+		if i < 0 || int(i) >= n {
+			return nil, nil, fmt.Errorf("unexpected resource index %d", i)
+		}
+		found[i], equal[i] = exists, eq
+	}
+	return found, equal, rows.Err()
+}
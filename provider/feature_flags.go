@@ -0,0 +1,35 @@
+package provider
+
+// FeatureEnabled returns true if the feature flag is enabled in the latest ConfigureProvider request. Flags enable
+// experimental SDK behaviors per run, and are available from Configure onwards.
+func (p *Provider) FeatureEnabled(name string) bool {
+	p.flagsMu.RLock()
+	defer p.flagsMu.RUnlock()
+	return p.featureFlags[name]
+}
+
+// FeatureFlags returns a copy of the feature flags of the latest ConfigureProvider request, or nil if it had none
+func (p *Provider) FeatureFlags() map[string]bool {
+	p.flagsMu.RLock()
+	defer p.flagsMu.RUnlock()
+	if len(p.featureFlags) == 0 {
+		return nil
+	}
+	flags := make(map[string]bool, len(p.featureFlags))
+	for k, v := range p.featureFlags {
+		flags[k] = v
+	}
+	return flags
+}
+
+func (p *Provider) setFeatureFlags(flags map[string]bool) {
+	p.flagsMu.Lock()
+	defer p.flagsMu.Unlock()
+	p.featureFlags = make(map[string]bool, len(flags))
+	for k, v := range flags {
+		p.featureFlags[k] = v
+	}
+	if len(flags) > 0 {
+		p.Logger.Info("configured feature flags", "flags", flags)
+	}
+}
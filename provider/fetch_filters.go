@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// parseTableFilters parses the filter expressions of a fetch request by table name, validating them against the
+// provider's tables and their relations
+func (p *Provider) parseTableFilters(expressions map[string]string) (map[string]*schema.FetchFilter, error) {
+	if len(expressions) == 0 {
+		return nil, nil
+	}
+	tables := make(map[string]*schema.Table)
+	for _, t := range p.ResourceMap {
+		addTables(tables, t)
+	}
+	names := make([]string, 0, len(expressions))
+	for name := range expressions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	filters := make(map[string]*schema.FetchFilter, len(expressions))
+	for _, name := range names {
+		t, ok := tables[name]
+		if !ok {
+			return nil, fmt.Errorf("plugin %s has no table %s to filter", p.Name, name)
+		}
+		f, err := schema.ParseFetchFilter(expressions[name])
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		if err := f.Validate(t); err != nil {
+			return nil, err
+		}
+		filters[name] = f
+	}
+	return filters, nil
+}
+
+// addTables adds the table and its relations to tables, by name
+func addTables(tables map[string]*schema.Table, t *schema.Table) {
+	tables[t.Name] = t
+	for _, r := range t.Relations {
+		addTables(tables, r)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+)
+
+// persistFetchTimeout bounds writing the fetch history, which is written even if the fetch was canceled
+const persistFetchTimeout = 10 * time.Second
+
+// createFetchHistoryTable creates the fetch history table, which keeps a row per fetch with an id of all the providers
+// sharing the database
+const createFetchHistoryTable = `CREATE TABLE IF NOT EXISTS "cq_fetches" (
+	"fetch_id" text NOT NULL,
+	"provider_name" text NOT NULL,
+	"provider_version" text,
+	"start" timestamp without time zone NOT NULL,
+	"finish" timestamp without time zone NOT NULL,
+	"error" text,
+	"resources" jsonb
+)`
+
+// fetchHistoryResource is a resource's entry of the resources column of the fetch history
+type fetchHistoryResource struct {
+	Resource      string                      `json:"resource"`
+	Status        cqproto.ResourceFetchStatus `json:"status"`
+	ResourceCount uint64                      `json:"resource_count"`
+	ErrorCount    uint64                      `json:"error_count"`
+}
+
+// fetchHistorySender records the summaries of the resources finished by a fetch, so they are persisted once it finishes
+type fetchHistorySender struct {
+	cqproto.FetchResourcesSender
+	mu        sync.Mutex
+	resources map[string]fetchHistoryResource
+}
+
+func newFetchHistorySender(sender cqproto.FetchResourcesSender) *fetchHistorySender {
+	return &fetchHistorySender{FetchResourcesSender: sender, resources: make(map[string]fetchHistoryResource)}
+}
+
+func (s *fetchHistorySender) Send(resp *cqproto.FetchResourcesResponse) error {
+	if resp.Progress == nil && resp.ResourceName != "" {
+		diags := resp.Summary.Diagnostics
+		s.mu.Lock()
+		s.resources[resp.ResourceName] = fetchHistoryResource{
+			Resource:      resp.ResourceName,
+			Status:        resp.Summary.Status,
+			ResourceCount: resp.Summary.ResourceCount,
+			ErrorCount:    diags.CountBySeverity(diag.ERROR, true) + diags.CountBySeverity(diag.PANIC, true),
+		}
+		s.mu.Unlock()
+	}
+	return s.FetchResourcesSender.Send(resp)
+}
+
+// fetchResources returns the recorded resources sorted by name
+func (s *fetchHistorySender) fetchResources() []fetchHistoryResource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]fetchHistoryResource, 0, len(s.resources))
+	for _, r := range s.resources {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Resource < ret[j].Resource })
+	return ret
+}
+
+// supportsFetchHistory returns true if the fetch history can be persisted in the storage, which requires postgres. Dry
+// run fetches have no storage.
+func supportsFetchHistory(conn execution.Storage) bool {
+	if _, ok := conn.(*dryRunStorage); ok {
+		return false
+	}
+	switch conn.Dialect().(type) {
+	case schema.PostgresDialect, schema.TSDBDialect:
+		return true
+	default:
+		return false
+	}
+}
+
+// persistFetch writes a finished fetch to the fetch history. Failures are logged, they don't fail the fetch.
+func (p *Provider) persistFetch(conn execution.Storage, fetchID string, start time.Time, history *fetchHistorySender, fetchErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), persistFetchTimeout)
+	defer cancel()
+
+	resources, err := json.Marshal(history.fetchResources())
+	if err != nil {
+		p.Logger.Warn("failed to encode fetch history", "fetch_id", fetchID, "error", err)
+		return
+	}
+	var errMsg *string
+	if fetchErr != nil {
+		msg := fetchErr.Error()
+		errMsg = &msg
+	}
+	if err := conn.Exec(ctx, createFetchHistoryTable); err != nil {
+		p.Logger.Debug("fetch history isn't persisted, failed to create its table", "fetch_id", fetchID, "error", err)
+		return
+	}
+	if err := conn.Exec(ctx, `INSERT INTO "cq_fetches" ("fetch_id", "provider_name", "provider_version", "start", "finish", "error", "resources") VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		fetchID, p.Name, p.Version, start.UTC(), time.Now().UTC(), errMsg, string(resources)); err != nil {
+		p.Logger.Warn("failed to persist fetch history", "fetch_id", fetchID, "error", err)
+	}
+}
+
+// GetFetches returns the provider's persisted fetches, latest first
+func (p *Provider) GetFetches(ctx context.Context, request *cqproto.GetFetchesRequest) (*cqproto.GetFetchesResponse, error) {
+	state := p.configuredState()
+	if state == nil {
+		return nil, fmt.Errorf("provider client is not configured (Hint: Try upgrading cloudquery)")
+	}
+	conn, err := p.openStorage(ctx, p.Logger, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database. %w", err)
+	}
+	defer conn.Close()
+	if !supportsFetchHistory(conn) {
+		return &cqproto.GetFetchesResponse{
+			Diagnostics: diag.FromError(fmt.Errorf("fetch history isn't supported by the storage"), diag.DATABASE, diag.WithSeverity(diag.WARNING)),
+		}, nil
+	}
+
+	q := `SELECT "fetch_id", "provider_version", "start", "finish", "error", "resources" FROM "cq_fetches" WHERE "provider_name" = $1 ORDER BY "start" DESC`
+	args := []interface{}{p.Name}
+	if request.Limit > 0 {
+		q += " LIMIT $2"
+		args = append(args, request.Limit)
+	}
+	fetches, err := queryFetches(ctx, conn, q, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			// no fetch was persisted yet
+			return &cqproto.GetFetchesResponse{}, nil
+		}
+		return &cqproto.GetFetchesResponse{
+			Diagnostics: diag.FromError(err, diag.DATABASE, diag.WithSummary("failed to query fetch history")),
+		}, nil
+	}
+	return &cqproto.GetFetchesResponse{Fetches: fetches}, nil
+}
+
+func queryFetches(ctx context.Context, conn execution.Storage, query string, args ...interface{}) ([]cqproto.FetchHistory, error) {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fetches []cqproto.FetchHistory
+	for rows.Next() {
+		var (
+			f         cqproto.FetchHistory
+			version   *string
+			errMsg    *string
+			resources []fetchHistoryResource
+		)
+		if err := rows.Scan(&f.FetchID, &version, &f.Start, &f.Finish, &errMsg, &resources); err != nil {
+			return nil, err
+		}
+		if version != nil {
+			f.ProviderVersion = *version
+		}
+		if errMsg != nil {
+			f.Error = *errMsg
+		}
+		f.Resources = make([]cqproto.FetchHistoryResource, len(resources))
+		for i, r := range resources {
+			f.Resources[i] = cqproto.FetchHistoryResource{
+				ResourceName:  r.Resource,
+				Status:        r.Status,
+				ResourceCount: r.ResourceCount,
+				ErrorCount:    r.ErrorCount,
+			}
+		}
+		fetches = append(fetches, f)
+	}
+	return fetches, rows.Err()
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// maxTrackedFetches is the number of fetches whose status is kept for GetFetchStatus, older fetches are forgotten
+const maxTrackedFetches = 32
+
+// fetchStatus is the state of a running or finished fetch, reported by GetFetchStatus. Fetches may run concurrently in
+// the same provider, each with its own status, counters and goroutines semaphore.
+type fetchStatus struct {
+	id       string
+	counters *execution.FetchCounters
+
+	mu   sync.Mutex
+	done bool
+	err  string
+}
+
+func (s *fetchStatus) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	if err != nil {
+		s.err = err.Error()
+	}
+}
+
+func (s *fetchStatus) isDone() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// result returns the error the fetch finished with, nil if it succeeded or didn't finish yet
+func (s *fetchStatus) result() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == "" {
+		return nil
+	}
+	return errors.New(s.err)
+}
+
+// GetFetchStatus returns the counters of the latest fetch with the requested id
+func (p *Provider) GetFetchStatus(_ context.Context, request *cqproto.GetFetchStatusRequest) (*cqproto.GetFetchStatusResponse, error) {
+	s := p.fetchStatus(request.FetchID)
+	if s == nil {
+		return &cqproto.GetFetchStatusResponse{}, nil
+	}
+	snapshot := s.counters.Snapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &cqproto.GetFetchStatusResponse{
+		Found:            true,
+		Done:             s.done,
+		RowsResolved:     snapshot.RowsResolved,
+		RowsStored:       snapshot.RowsStored,
+		BytesEstimated:   snapshot.BytesEstimated,
+		DiagnosticCounts: snapshot.Diagnostics,
+		Error:            s.err,
+	}, nil
+}
+
+// trackFetch starts tracking the status of a fetch, identified by the schema.FetchIdMetaKey of its metadata. Returns the
+// status and the number of running fetches including it, or an error if a fetch with the same id is already running.
+func (p *Provider) trackFetch(metadata map[string]interface{}) (*fetchStatus, int, error) {
+	id, _ := metadata[schema.FetchIdMetaKey].(string)
+	s := &fetchStatus{id: id, counters: execution.NewFetchCounters()}
+
+	p.fetchesMu.Lock()
+	defer p.fetchesMu.Unlock()
+	running := 1
+	for _, f := range p.fetches {
+		if f.isDone() {
+			continue
+		}
+		if id != "" && f.id == id {
+			return nil, 0, fmt.Errorf("fetch %s is already running", id)
+		}
+		running++
+	}
+	if len(p.fetches) >= maxTrackedFetches {
+		p.fetches = p.fetches[len(p.fetches)-maxTrackedFetches+1:]
+	}
+	p.fetches = append(p.fetches, s)
+	return s, running, nil
+}
+
+// fetchStatus returns the latest fetch with the given id, or nil if there is none
+func (p *Provider) fetchStatus(id string) *fetchStatus {
+	p.fetchesMu.Lock()
+	defer p.fetchesMu.Unlock()
+	for i := len(p.fetches) - 1; i >= 0; i-- {
+		if p.fetches[i].id == id {
+			return p.fetches[i]
+		}
+	}
+	return nil
+}
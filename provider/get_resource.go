@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/helpers"
+	"github.com/cloudquery/cq-provider-sdk/helpers/limit"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"golang.org/x/sync/semaphore"
+)
+
+// GetResource fetches the single resource of the requested resource's table with the values of its primary keys and
+// stores it, replacing the stored resource, see execution.TableExecutor.ResolveOne. The table must have a
+// schema.Table.GetResolver.
+func (p *Provider) GetResource(ctx context.Context, request *cqproto.GetResourceRequest) (*cqproto.GetResourceResponse, error) {
+	state := p.configuredState()
+	if state == nil {
+		return nil, fmt.Errorf("provider client is not configured (Hint: Try upgrading cloudquery)")
+	}
+	table, ok := p.ResourceMap[request.Resource]
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not provide resource %s", p.Name, request.Resource)
+	}
+	if table.GetResolver == nil {
+		return nil, fmt.Errorf("resource %s doesn't support fetching a single resource", request.Resource)
+	}
+	if err := p.validateTenant(request.TenantId); err != nil {
+		return nil, err
+	}
+	metadata := request.Metadata
+	if request.TenantId != "" {
+		metadata = tenantMetadata(metadata, request.TenantId)
+	}
+
+	ctx, span := p.Tracer().Start(ctx, "GetResource")
+	defer span.End()
+
+	conn, err := p.openStorage(ctx, p.Logger, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database. %w", err)
+	}
+	if p.MultiTenant {
+		tenantConn, err := tenantStorage(conn)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tenantConn
+	}
+	defer conn.Close()
+
+	maxGoroutines := helpers.Uint64ToInt64(limit.GetMaxGoRoutines())
+	opts := p.executorOptions(state, execution.NewSemaphoreStats(maxGoroutines), execution.NewFetchCounters())
+	if table.HasSensitiveColumns() {
+		opts = append(opts, execution.WithRedactor(execution.NewRedactor()))
+	}
+	tableExec := execution.NewTableExecutor(request.Resource, conn, p.Logger.With("table", table.Name), table, metadata, p.ErrorClassifier,
+		semaphore.NewWeighted(maxGoroutines), request.Timeout, opts...)
+	count, diags := tableExec.ResolveOne(ctx, state.meta, request.PrimaryKeys)
+	p.Logger.Debug("fetched single resource", "table", table.Name, "count", count, "diagnostics", len(diags))
+	return &cqproto.GetResourceResponse{
+		ResourceCount: count,
+		Diagnostics:   diags.Squash(),
+	}, nil
+}
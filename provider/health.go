@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+)
+
+// CheckHealth verifies the configured provider can fetch resources: the database is connected to and the provider's
+// HealthCheck, if set, verifies the credentials and connectivity of its client. Failed checks are reported as
+// diagnostics, so the CLI can report them before starting a fetch.
+func (p *Provider) CheckHealth(ctx context.Context, _ *cqproto.CheckHealthRequest) (*cqproto.CheckHealthResponse, error) {
+	state := p.configuredState()
+	if state == nil {
+		return nil, fmt.Errorf("provider client is not configured (Hint: Try upgrading cloudquery)")
+	}
+
+	ctx, span := p.Tracer().Start(ctx, "CheckHealth")
+	defer span.End()
+
+	var diags diag.Diagnostics
+	conn, err := p.openStorage(ctx, p.Logger, state)
+	if err != nil {
+		diags = diags.Add(diag.FromError(err, diag.DATABASE, diag.WithSummary("failed to connect to database")))
+	} else {
+		conn.Close()
+	}
+	if p.HealthCheck != nil {
+		diags = diags.Add(p.HealthCheck(ctx, state.meta))
+	}
+	p.Logger.Debug("checked provider health", "diagnostics", len(diags))
+	return &cqproto.CheckHealthResponse{Diagnostics: diags}, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// hasIncrementalTables returns true if any of the resources is a schema.Table.Incremental table
+func hasIncrementalTables(resourceMap map[string]*schema.Table, resources []string) bool {
+	for _, r := range resources {
+		if t, ok := resourceMap[r]; ok && t.Incremental != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsCursors returns true if the storage can keep the cursors of incremental tables, as the fetch history, for the
+// tenants of multi tenant providers too
+func supportsCursors(conn execution.Storage) bool {
+	if _, ok := conn.(*dryRunStorage); ok {
+		return false
+	}
+	d := conn.Dialect()
+	if td, ok := d.(schema.TenantDialect); ok {
+		d = td.Dialect
+	}
+	switch d.(type) {
+	case schema.PostgresDialect, schema.TSDBDialect:
+		return true
+	default:
+		return false
+	}
+}
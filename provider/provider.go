@@ -9,11 +9,14 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/cqproto"
 	"github.com/cloudquery/cq-provider-sdk/database"
 	"github.com/cloudquery/cq-provider-sdk/helpers"
 	"github.com/cloudquery/cq-provider-sdk/helpers/limit"
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/cloudquery/cq-provider-sdk/migration"
 	"github.com/cloudquery/cq-provider-sdk/provider/diag"
 	"github.com/cloudquery/cq-provider-sdk/provider/execution"
 	"github.com/cloudquery/cq-provider-sdk/provider/module"
@@ -21,6 +24,9 @@ import (
 	"github.com/creasty/defaults"
 	"github.com/hashicorp/go-hclog"
 	"github.com/thoas/go-funk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 	"gopkg.in/yaml.v3"
@@ -40,24 +46,117 @@ type Provider struct {
 	Version string
 	// Configure the provider and return context
 	Configure func(hclog.Logger, interface{}) (schema.ClientMeta, diag.Diagnostics)
+	// HealthCheck verifies the credentials and connectivity of the client returned by Configure, i.e by calling a cheap
+	// API of the provider. Called by CheckHealth, if not set only the database connectivity is checked.
+	HealthCheck func(context.Context, schema.ClientMeta) diag.Diagnostics
 	// ResourceMap is all resources supported by this plugin
 	ResourceMap map[string]*schema.Table
 	// Configuration decoded from configure request
 	Config func() Config
+	// ConfigSchema is the JSON schema the configuration of configure requests is validated with, see
+	// ValidateProviderConfig. If not set it's generated from Config with GenerateConfigSchema, keyed by the hcl tags of
+	// Config for configurations in the cqproto.ConfigFormatHCL format, which are decoded by them.
+	ConfigSchema string
+	// ConfigEnvVars are the environment variables the string values of configurations may reference as ${VAR}, i.e
+	// secrets like ${GITHUB_TOKEN}, references are expanded before configurations are validated and decoded. Entries
+	// ending with * allow every variable of their prefix, i.e AWS_*, and no variables may be referenced if not set.
+	// Literal "${" is escaped as "$${".
+	ConfigEnvVars []string
 	// Logger to call, this logger is passed to the serve.Serve Client, if not define Serve will create one instead.
 	Logger hclog.Logger
 	// ErrorClassifier allows the provider to classify errors it produces during table execution, and return them as diagnostics to the user.
 	// Classifier function may return empty slice if it cannot meaningfully convert the error into diagnostics. In this case
 	// the error will be converted by the SDK into diagnostic at ERROR level and RESOLVING type.
+	// Several classifiers are evaluated in order with execution.ChainClassifiers, i.e the provider's classifier followed by
+	// the SDK's execution.DefaultErrorClassifier.
 	ErrorClassifier execution.ErrorClassifier
+	// Migrations are the provider's migration files per dialect directory, as read by migrator.ReadMigrationFiles.
+	// Used by SelfTest to verify the migrations reach the latest version and match ResourceMap.
+	Migrations map[string]map[string][]byte
+	// PreviousSchema is the JSON encoded schema.SchemaSnapshot of the provider's previous release, usually embedded from
+	// the output of SchemaSnapshot. If set, GetProviderSchema warns about breaking changes of the schema since.
+	PreviousSchema []byte
 	// ModuleInfoReader is called when the user executes a module, to get provider supported metadata about the given module
 	ModuleInfoReader module.InfoReader
-	// Database connection string
-	dbURL string
+	// Telemetry receives anonymized fetch telemetry, if not set (default) no telemetry is reported
+	Telemetry TelemetryReporter
+	// StaleDataJitter is subtracted from the fetch start when removing stale data by last update time, if nil
+	// execution.DefaultStaleJitter is used. Zero removes every row not updated since the fetch started.
+	StaleDataJitter *time.Duration
+	// SemaphoreWaitThreshold is the time a table may wait for the fetch's max goroutines before a warning diagnostic is
+	// reported, if not set execution.DefaultSemaphoreWaitThreshold is used
+	SemaphoreWaitThreshold time.Duration
+	// AuditLog writes a row per table per client execution of fetches to the cq_audit_log table as they run, see
+	// execution.AuditLog. Only supported by postgres storages.
+	AuditLog bool
+	// Quarantine writes the raw items of resources that failed to resolve or insert, with their error, to the
+	// cq_quarantine table instead of dropping them, see execution.Quarantine. Only supported by postgres storages.
+	Quarantine bool
+	// Checkpoints records the progress of the clients of top level tables in the cq_fetch_checkpoints table during
+	// fetches with an id, so interrupted fetches can be resumed with FetchResourcesRequest.ResumeFetchId. Resolvers
+	// checkpoint their progress by sending a schema.ResumeToken. Only supported by postgres storages.
+	Checkpoints bool
+	// ParallelRelations resolves the relations of fetched resources in parallel, within the fetch's max goroutines, see
+	// execution.WithParallelRelations
+	ParallelRelations bool
+	// TracerProvider records the OpenTelemetry spans of fetches and of the provider's gRPC server, i.e a
+	// sdktrace.TracerProvider with the exporter of the user's tracing backend. If not set the global tracer provider of
+	// otel.GetTracerProvider is used, which doesn't record spans unless it's configured.
+	TracerProvider trace.TracerProvider
+	// Storage is used by fetches instead of opening the connection of the configure request, for providers used as a
+	// library by a host process, i.e with a pool of the host's wrapped by database.NewFromPool. The storage is owned by
+	// the caller and isn't closed when fetches finish.
+	Storage execution.Storage
+	// MultiTenant scopes the rows of every table to the tenant of the fetch, so the resources of many tenants can be
+	// fetched into the same database, see schema.TenantDialect. Fetches must then have a TenantId, and only the stale
+	// data of their tenant is removed. The provider's schema must be created with the tenant dialect, and the storage
+	// must be an execution.DialectStorage. Dry runs aren't scoped.
+	MultiTenant bool
+	// HistoryConfig enables history mode if set: the rows of every fetch are kept, partitioned by their fetch date, and
+	// partitions older than the configured retention are dropped, see execution.HistoryConfig. Tables are partitioned
+	// as they are created by the timescale migrations of migration.RunOptions.History, or by execution.SetupHistory,
+	// not by fetches. Requires the timescale dialect.
+	HistoryConfig *execution.HistoryConfig
+	// ReportStaleData counts the stale rows of the fetched tables before they are removed, and reports the counts in
+	// the diagnostics of the resources' summaries, see execution.StaleReport. Always enabled for fetches skipping stale
+	// cleanup.
+	ReportStaleData bool
+	// stateMu guards state, which may be replaced by ConfigureProvider while fetches are running
+	stateMu sync.RWMutex
+	// state is set when configure is called, it is never mutated only replaced
+	state *configuredState
+	// storageCreator creates a database based on requested engine, if not set the storage registered for the
+	// connection type is opened with database.Open
+	storageCreator func(ctx context.Context, logger hclog.Logger, dbURL string) (execution.Storage, error)
+	// flagsMu guards featureFlags, the feature flags of the latest ConfigureProvider request. They aren't part of state
+	// so they are available to Configure.
+	flagsMu      sync.RWMutex
+	featureFlags map[string]bool
+	// fetchesMu guards fetches, the statuses of the latest fetches reported by GetFetchStatus, oldest first
+	fetchesMu sync.Mutex
+	fetches   []*fetchStatus
+	// lineageMu guards lineage, the column lineage of the latest debug fetch
+	lineageMu sync.Mutex
+	lineage   []execution.ColumnLineage
+	// goroutinesOnce creates goroutinesSem, limiting the goroutines of the fetches that don't set MaxGoroutines. It's
+	// shared by the running fetches, so together they don't exceed the process' limits.
+	goroutinesOnce sync.Once
+	goroutinesSem  *semaphore.Weighted
+	maxGoroutines  int64
+}
+
+// configuredState is the provider's state created by ConfigureProvider
+type configuredState struct {
 	// meta is the provider's client created when configure is called
 	meta schema.ClientMeta
-	// storageCreator creates a database based on requested engine
-	storageCreator func(ctx context.Context, logger hclog.Logger, dbURL string) (execution.Storage, error)
+	// config is the provider's configuration decoded from the configure request, after defaults are applied
+	config Config
+	// Database connection string
+	dbURL string
+	// storageType is the registered storage type of the connection, see database.RegisterStorage
+	storageType string
+	// columnPolicies applied to restricted columns written to the database
+	columnPolicies schema.ColumnPolicies
 }
 
 var _ cqproto.CQProviderServer = (*Provider)(nil)
@@ -67,6 +166,7 @@ func (p *Provider) GetProviderSchema(_ context.Context, _ *cqproto.GetProviderSc
 		Name:           p.Name,
 		Version:        p.Version,
 		ResourceTables: p.ResourceMap,
+		Diagnostics:    p.schemaChangeDiagnostics(),
 	}, nil
 }
 
@@ -127,7 +227,11 @@ func (p *Provider) ConfigureProvider(_ context.Context, request *cqproto.Configu
 		}, nil
 	}
 
-	if p.meta != nil {
+	// configuration is serialized, fetches only take the read lock to get the current state
+	p.stateMu.Lock()
+	defer p.stateMu.Unlock()
+
+	if p.state != nil {
 		if !IsDebug() {
 			return &cqproto.ConfigureProviderResponse{
 				Diagnostics: diag.FromError(fmt.Errorf("provider %s was already configured", p.Name), diag.INTERNAL),
@@ -135,17 +239,22 @@ func (p *Provider) ConfigureProvider(_ context.Context, request *cqproto.Configu
 		}
 
 		p.Logger.Info("Reconfiguring provider: Previous configuration has been reset.")
-		p.storageCreator = nil
 	}
 
-	// set database creator
-	if p.storageCreator == nil {
-		p.storageCreator = func(ctx context.Context, logger hclog.Logger, dbURL string) (execution.Storage, error) {
-			return database.New(ctx, logger, dbURL)
-		}
+	columnPolicies, err := schema.ParseColumnPolicies(request.Connection.ColumnPolicies)
+	if err != nil {
+		return &cqproto.ConfigureProviderResponse{
+			Diagnostics: diag.FromError(err, diag.USER),
+		}, nil
 	}
 
-	p.dbURL = request.Connection.DSN
+	if p.storageCreator == nil && p.Storage == nil && !database.IsRegisteredStorage(request.Connection.Type) {
+		return &cqproto.ConfigureProviderResponse{
+			Diagnostics: diag.FromError(fmt.Errorf("unknown storage type %q, registered types are %s", request.Connection.Type, strings.Join(database.Storages(), ", ")), diag.USER),
+		}, nil
+	}
+
+	p.setFeatureFlags(request.FeatureFlags)
 
 	providerConfig := p.Config()
 	if err := defaults.Set(providerConfig); err != nil {
@@ -154,11 +263,32 @@ func (p *Provider) ConfigureProvider(_ context.Context, request *cqproto.Configu
 		}, nil
 	}
 
+	config, evalCtx, err := expandConfigEnv(request.Format, request.Config, p.ConfigEnvVars)
+	if err != nil {
+		return &cqproto.ConfigureProviderResponse{
+			Diagnostics: diag.FromError(err, diag.USER, diag.WithSummary("failed to expand provider config")),
+		}, nil
+	}
+
+	configSchema := p.ConfigSchema
+	if configSchema == "" {
+		if configSchema, err = generateConfigSchema(providerConfig, request.Format); err != nil {
+			return &cqproto.ConfigureProviderResponse{
+				Diagnostics: diag.FromError(err, diag.INTERNAL, diag.WithSummary("failed to generate config schema")),
+			}, nil
+		}
+	}
+	if diags := validateProviderConfig(configSchema, config, request.Format, evalCtx); diags.HasErrors() {
+		return &cqproto.ConfigureProviderResponse{
+			Diagnostics: diags,
+		}, nil
+	}
+
 	// if we received an empty config we notify in log and only use defaults.
-	if len(request.Config) == 0 {
+	if len(config) == 0 {
 		p.Logger.Info("Received empty configuration, using only defaults")
 	} else {
-		if err := yaml.Unmarshal(request.Config, providerConfig); err != nil {
+		if err := decodeConfig(request.Format, config, evalCtx, providerConfig); err != nil {
 			p.Logger.Error("Failed to load configuration.", "error", err)
 			return &cqproto.ConfigureProviderResponse{
 				Diagnostics: diag.FromError(err, diag.USER),
@@ -173,23 +303,27 @@ func (p *Provider) ConfigureProvider(_ context.Context, request *cqproto.Configu
 		}, nil
 	}
 
-	tables := make(map[string]string)
-	for r, t := range p.ResourceMap {
-		if err := getTableDuplicates(r, t, tables); err != nil {
-			return &cqproto.ConfigureProviderResponse{
-				Diagnostics: diags.Add(diag.FromError(err, diag.INTERNAL)),
-			}, nil
-		}
+	if vd := ValidateResourceMap(p.ResourceMap); vd.HasErrors() {
+		return &cqproto.ConfigureProviderResponse{
+			Diagnostics: diags.Add(vd),
+		}, nil
 	}
 
-	p.meta = client
+	p.state = &configuredState{
+		meta:           client,
+		config:         providerConfig,
+		dbURL:          request.Connection.DSN,
+		storageType:    request.Connection.Type,
+		columnPolicies: columnPolicies,
+	}
 	return &cqproto.ConfigureProviderResponse{
 		Diagnostics: diags,
 	}, nil
 }
 
 func (p *Provider) FetchResources(ctx context.Context, request *cqproto.FetchResourcesRequest, sender cqproto.FetchResourcesSender) error {
-	if p.meta == nil {
+	state := p.configuredState()
+	if state == nil {
 		return fmt.Errorf("provider client is not configured (Hint: Try upgrading cloudquery)")
 	}
 
@@ -197,59 +331,311 @@ func (p *Provider) FetchResources(ctx context.Context, request *cqproto.FetchRes
 		return fmt.Errorf("provider has duplicate resources requested")
 	}
 
+	ctx, span := p.Tracer().Start(ctx, "FetchResources", trace.WithAttributes(execution.RequestedResourcesAttribute.StringSlice(request.Resources)))
+	defer span.End()
+	resourceMap := p.resourceMap(ctx)
+
+	if request.DryRun && (request.ValidateSchema || request.ResumeFetchId != "" || request.Verify) {
+		return fmt.Errorf("dry run fetches can't validate the schema, resume or verify fetches, they don't connect to the database")
+	}
+	if request.Verify && request.ResumeFetchId != "" {
+		return fmt.Errorf("verified fetches can't be resumed")
+	}
+	if err := p.validateTenant(request.TenantId); err != nil {
+		return err
+	}
+	tableFilters, err := p.parseTableFilters(request.TableFilters)
+	if err != nil {
+		return err
+	}
+	if request.TenantId != "" {
+		tenant := *request
+		tenant.Metadata = tenantMetadata(request.Metadata, request.TenantId)
+		request = &tenant
+	}
+
+	if request.ResumeFetchId != "" {
+		if !p.Checkpoints {
+			return fmt.Errorf("provider %s can't resume fetch %s, checkpoints aren't enabled", p.Name, request.ResumeFetchId)
+		}
+		// the resumed fetch keeps its id, so the rows it already stored aren't removed as stale
+		resumed := *request
+		resumed.Metadata = make(map[string]interface{}, len(request.Metadata)+1)
+		for k, v := range request.Metadata {
+			resumed.Metadata[k] = v
+		}
+		resumed.Metadata[schema.FetchIdMetaKey] = request.ResumeFetchId
+		request = &resumed
+	}
+
 	// if resources ["*"] is requested we will fetch all resources
-	resources, err := p.interpolateAllResources(request.Resources)
+	resources, err := interpolateAllResources(resourceMap, request.Resources)
 	if err != nil {
 		return err
 	}
+	resources = filterResourcesByLabels(resourceMap, resources, request.Labels)
 
-	conn, err := p.storageCreator(ctx, p.Logger, p.dbURL)
+	// fetches may run concurrently, i.e a targeted fetch while a full fetch is in progress. Each has its own status,
+	// counters and logger, and they share the goroutines limit, only fetches with the same id can't run at once.
+	fetch, runningFetches, err := p.trackFetch(request.Metadata)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database. %w", err)
+		return err
+	}
+	logger := p.Logger
+	if fetch.id != "" {
+		logger = logger.With("fetch_id", fetch.id)
+	}
+
+	var (
+		conn   execution.Storage
+		dryRun *dryRunStorage
+	)
+	if request.DryRun {
+		logger.Info("dry run fetch, resources aren't written to the database")
+		dryRun = newDryRunStorage()
+		conn = dryRun
+	} else {
+		conn, err = p.openStorage(ctx, logger, state)
+		if err != nil {
+			err = fmt.Errorf("failed to connect to database. %w", err)
+			fetch.finish(err)
+			return err
+		}
+		if p.MultiTenant {
+			tenantConn, err := tenantStorage(conn)
+			if err != nil {
+				conn.Close()
+				fetch.finish(err)
+				return err
+			}
+			conn = tenantConn
+		}
 	}
 
 	defer conn.Close()
 
+	// verified fetches are read only, they aren't recorded in the fetch history, audit log, quarantine or checkpoints
+	readOnly := request.Verify
+	if fetch.id != "" && !readOnly && supportsFetchHistory(conn) {
+		history := newFetchHistorySender(sender)
+		sender = history
+		fetchStart := time.Now()
+		defer func() { p.persistFetch(conn, fetch.id, fetchStart, history, fetch.result()) }()
+	}
+
+	finishedResources := make(map[string]bool, len(resources))
+	if request.ValidateSchema {
+		resources, err = p.validateResourcesSchema(ctx, conn, resourceMap, resources, finishedResources, sender)
+		if err != nil {
+			fetch.finish(err)
+			return err
+		}
+	}
+
 	// limiter used to limit the amount of resources fetched concurrently
-	var goroutinesSem *semaphore.Weighted
-	maxGoroutines := request.MaxGoroutines
-	if maxGoroutines == 0 {
-		maxGoroutines = limit.GetMaxGoRoutines()
+	goroutinesSem, maxGoroutines := p.fetchSemaphore(request.MaxGoroutines)
+	logger.Info("calculated max goroutines for fetch execution", "max_goroutines", maxGoroutines, "running_fetches", runningFetches)
+	semaphoreStats := execution.NewSemaphoreStats(maxGoroutines)
+	var checkpoints *execution.Checkpoints
+	if p.Checkpoints && fetch.id != "" && !readOnly {
+		if supportsFetchHistory(conn) {
+			checkpoints, err = execution.NewCheckpoints(ctx, conn, fetch.id, request.ResumeFetchId != "", logger)
+		} else {
+			err = fmt.Errorf("checkpoints aren't supported by the storage")
+		}
+		if err != nil && request.ResumeFetchId != "" {
+			fetch.finish(err)
+			return err
+		}
+		if err != nil {
+			logger.Warn("fetch isn't checkpointed", "error", err)
+		}
+	}
+	var cursors *execution.Cursors
+	if !readOnly && hasIncrementalTables(resourceMap, resources) {
+		if supportsCursors(conn) {
+			cursors, err = execution.NewCursors(ctx, conn, request.TenantId, request.FullRefresh, logger)
+		} else {
+			err = fmt.Errorf("cursors aren't supported by the storage")
+		}
+		if err != nil {
+			logger.Warn("incremental tables are fully fetched", "error", err)
+		}
+	}
+	var auditLog *execution.AuditLog
+	if p.AuditLog && !readOnly {
+		if supportsFetchHistory(conn) {
+			auditLog = execution.NewAuditLog(conn, fetch.id, logger)
+		} else {
+			logger.Warn("audit log isn't supported by the storage")
+		}
+	}
+	var quarantine *execution.Quarantine
+	if p.Quarantine && !readOnly {
+		if supportsFetchHistory(conn) {
+			quarantine = execution.NewQuarantine(conn, fetch.id, logger)
+		} else {
+			logger.Warn("quarantine isn't supported by the storage")
+		}
+	}
+	// history tables keep the rows of every fetch, they are partitioned by the provider's migrations
+	history := p.HistoryConfig != nil && !readOnly && dryRun == nil
+	if history && !execution.SupportsHistory(conn) {
+		err := fmt.Errorf("history mode isn't supported by the storage, it requires the timescale dialect")
+		fetch.finish(err)
+		return err
+	}
+	var staleReport *execution.StaleReport
+	if (p.ReportStaleData || request.SkipStaleCleanup) && !readOnly {
+		staleReport = execution.NewStaleReport(request.SkipStaleCleanup)
+	}
+	// values of sensitive columns are masked in the diagnostics and logs of the fetch
+	var redactor *execution.Redactor
+	for _, resource := range resources {
+		if table, ok := resourceMap[resource]; ok && table.HasSensitiveColumns() {
+			redactor = execution.NewRedactor()
+			logger = redactor.Logger(logger)
+			break
+		}
+	}
+
+	if request.CanaryRows > 0 {
+		logger.Info("fetching resources canary", "rows", request.CanaryRows)
+		resources, err = p.canaryResources(ctx, conn, state, request, resourceMap, resources, goroutinesSem, finishedResources, sender, redactor)
+		if err != nil {
+			fetch.finish(err)
+			return err
+		}
+	}
+
+	// fail fast fetches are canceled on the first error of a resource, the remaining resources are reported as canceled
+	var failFast *execution.FailFast
+	if request.ErrorPolicy == cqproto.ErrorPolicyFailFast {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		failFast = execution.NewFailFast(cancel)
 	}
-	p.Logger.Info("calculated max goroutines for fetch execution", "max_goroutines", maxGoroutines)
-	goroutinesSem = semaphore.NewWeighted(helpers.Uint64ToInt64(maxGoroutines))
 
 	g, gctx := errgroup.WithContext(ctx)
 	if request.ParallelFetchingLimit > 0 {
 		g.SetLimit(helpers.Uint64ToInt(request.ParallelFetchingLimit))
 	}
-	finishedResources := make(map[string]bool, len(resources))
 	l := &sync.Mutex{}
 	var totalResourceCount uint64
+	fetchStart := time.Now()
+	var verifier *execution.Verifier
+	if request.Verify {
+		verifier = execution.NewVerifier()
+	}
+	var lineage *execution.Lineage
+	if IsDebug() {
+		lineage = execution.NewLineage()
+		defer p.reportLineage(lineage)
+	}
+	// deprecated resources are only reported to fetches requesting them by name
+	explicitResources := !funk.ContainsString(request.Resources, "*")
 	for _, resource := range resources {
-		table, ok := p.ResourceMap[resource]
+		table, ok := resourceMap[resource]
 		if !ok {
-			return fmt.Errorf("plugin %s does not provide resource %s", p.Name, resource)
+			err := fmt.Errorf("plugin %s does not provide resource %s", p.Name, resource)
+			fetch.finish(err)
+			return err
 		}
-		tableExec := execution.NewTableExecutor(resource, conn, p.Logger.With("table", table.Name), table, request.Metadata, p.ErrorClassifier, goroutinesSem, request.Timeout)
-		p.Logger.Debug("fetching table...", "provider", p.Name, "table", table.Name)
 		// Save resource aside
 		r := resource
+		opts := p.executorOptions(state, semaphoreStats, fetch.counters)
+		if auditLog != nil {
+			opts = append(opts, execution.WithAuditLog(auditLog))
+		}
+		if quarantine != nil {
+			opts = append(opts, execution.WithQuarantine(quarantine))
+		}
+		if checkpoints != nil {
+			opts = append(opts, execution.WithCheckpoints(checkpoints))
+		}
+		if cursors != nil {
+			opts = append(opts, execution.WithCursors(cursors))
+		}
+		if tableFilters != nil {
+			opts = append(opts, execution.WithFetchFilters(tableFilters))
+		}
+		if history {
+			opts = append(opts, execution.WithHistory())
+		}
+		if staleReport != nil {
+			opts = append(opts, execution.WithStaleReport(staleReport))
+		}
+		if verifier != nil {
+			opts = append(opts, execution.WithVerifier(verifier))
+		}
+		if lineage != nil {
+			opts = append(opts, execution.WithLineage(lineage))
+		}
+		if failFast != nil {
+			opts = append(opts, execution.WithFailFast(failFast))
+		}
+		if redactor != nil {
+			opts = append(opts, execution.WithRedactor(redactor))
+		}
+		if request.BatchSize > 0 {
+			opts = append(opts, execution.WithBatchSize(request.BatchSize))
+		}
+		if request.ProgressInterval > 0 {
+			opts = append(opts, execution.WithProgress(request.ProgressInterval, func(progress execution.Progress) {
+				l.Lock()
+				defer l.Unlock()
+				if err := sender.Send(&cqproto.FetchResourcesResponse{
+					ResourceName: r,
+					Progress: &cqproto.ResourceFetchProgress{
+						ResourceCount: progress.ResourceCount,
+						Client:        progress.Client,
+						Elapsed:       progress.Elapsed,
+					},
+				}); err != nil {
+					logger.Warn("failed to send resource progress", "resource", r, "error", err)
+				}
+			}))
+		}
+		tableExec := execution.NewTableExecutor(resource, conn, logger.With("table", table.Name), table, request.Metadata, p.ErrorClassifier, goroutinesSem, request.Timeout, opts...)
+		logger.Debug("fetching table...", "provider", p.Name, "table", table.Name)
 		l.Lock()
 		finishedResources[r] = false
 		l.Unlock()
 		g.Go(func() error {
-			resourceCount, diags := tableExec.Resolve(gctx, p.meta)
-			l.Lock()
-			defer l.Unlock()
-			finishedResources[r] = true
-			atomic.AddUint64(&totalResourceCount, resourceCount)
+			resourceStart := time.Now()
+			resourceCount, diags := tableExec.Resolve(gctx, state.meta)
+			if explicitResources {
+				diags = diags.Add(deprecationDiagnostics(r, table))
+			}
+			if staleReport != nil {
+				diags = diags.Add(staleReport.Diagnostics(r, table))
+			}
 			status := cqproto.ResourceFetchComplete
 			if isCancelled(ctx) {
 				status = cqproto.ResourceFetchCanceled
 			} else if diags.HasErrors() {
 				status = cqproto.ResourceFetchPartial
 			}
+			// reported before taking the lock, so a slow telemetry sink doesn't block the other resources' responses
+			p.reportResourceTelemetry(ctx, r, status, time.Since(resourceStart), resourceCount, diags)
+			l.Lock()
+			defer l.Unlock()
+			finishedResources[r] = true
+			atomic.AddUint64(&totalResourceCount, resourceCount)
+			fetch.counters.AddDiagnostics(diags)
+			var tableCounts map[string]uint64
+			if dryRun != nil {
+				tableCounts = dryRun.tableCounts(table)
+			}
+			var failFastError string
+			if failFast != nil && failFast.Err() != nil {
+				failFastError = redactor.String(failFast.Err().Description().Summary)
+			}
+			var clients []cqproto.ClientFetchSummary
+			if status == cqproto.ResourceFetchCanceled {
+				clients = clientsToSummary(tableExec.Clients())
+			}
 			if err := sender.Send(&cqproto.FetchResourcesResponse{
 				ResourceName:      r,
 				FinishedResources: finishedResources,
@@ -257,16 +643,214 @@ func (p *Provider) FetchResources(ctx context.Context, request *cqproto.FetchRes
 				Summary: cqproto.ResourceFetchSummary{
 					Status:        status,
 					ResourceCount: resourceCount,
-					Diagnostics:   diags,
+					Diagnostics:   diags.Squash(),
+					APICalls:      apiCallsToSummary(tableExec.APICalls()),
+					FeatureFlags:  p.FeatureFlags(),
+					TableCounts:   tableCounts,
+					ErrorPolicy:   request.ErrorPolicy,
+					FailFastError: failFastError,
+					Clients:       clients,
 				},
 			}); err != nil {
 				return err
 			}
-			p.Logger.Debug("finished fetching table...", "provider", p.Name, "table", table.Name)
+			logger.Debug("finished fetching table...", "provider", p.Name, logging.TableKey, table.Name, logging.ResourceCountKey, resourceCount,
+				logging.DiagnosticsKey, logging.DiagnosticSummaries(diags))
 			return nil
 		})
 	}
-	return g.Wait()
+	err = g.Wait()
+	quarantine.Flush()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if failFast != nil && failFast.Err() != nil {
+		logger.Warn("fail fast fetch canceled on error", "error", failFast.Err().Description().Summary)
+	}
+	span.SetAttributes(execution.ResourceCountAttribute.Int64(helpers.Uint64ToInt64(atomic.LoadUint64(&totalResourceCount))))
+	fetch.finish(err)
+	report := semaphoreStats.Report()
+	logger.Info("goroutines utilization", "max_goroutines", report.Capacity, "peak", report.Peak, "acquisitions", report.Acquisitions,
+		"total_wait", report.TotalWait, "max_wait", report.MaxWait, "utilization", fmt.Sprintf("%.2f", report.Utilization))
+	p.reportFetchTelemetry(ctx, len(resources), time.Since(fetchStart), atomic.LoadUint64(&totalResourceCount), err)
+	return err
+}
+
+// ColumnLineage returns how the columns of the resources of the latest fetch in debug mode got their values, i.e by
+// their resolver or default path, see execution.Lineage. Lineage isn't recorded unless CQ_PROVIDER_DEBUG is set.
+func (p *Provider) ColumnLineage() []execution.ColumnLineage {
+	p.lineageMu.Lock()
+	defer p.lineageMu.Unlock()
+	return p.lineage
+}
+
+func (p *Provider) reportLineage(l *execution.Lineage) {
+	report := l.Report()
+	for _, cl := range report {
+		p.Logger.Debug("column lineage", logging.TableKey, cl.Table, "column", cl.Column, "source", cl.Source, "resolver", cl.Resolver,
+			"path", cl.Path, "transformed", cl.Transformed, logging.ResourceCountKey, cl.Count)
+	}
+	p.lineageMu.Lock()
+	defer p.lineageMu.Unlock()
+	p.lineage = report
+}
+
+// Tracer returns the tracer of the provider's spans, from Provider.TracerProvider or the global tracer provider
+func (p *Provider) Tracer() trace.Tracer {
+	return p.tracerProvider().Tracer(execution.TracerName)
+}
+
+func (p *Provider) tracerProvider() trace.TracerProvider {
+	if p.TracerProvider != nil {
+		return p.TracerProvider
+	}
+	return otel.GetTracerProvider()
+}
+
+// executorOptions returns the options of the table executors of a fetch
+func (p *Provider) executorOptions(state *configuredState, semaphoreStats *execution.SemaphoreStats, counters *execution.FetchCounters) []execution.Option {
+	opts := []execution.Option{
+		execution.WithColumnPolicies(state.columnPolicies),
+		execution.WithSemaphoreStats(semaphoreStats),
+		execution.WithFetchCounters(counters),
+		execution.WithTracerProvider(p.tracerProvider()),
+	}
+	if p.StaleDataJitter != nil {
+		opts = append(opts, execution.WithStaleJitter(*p.StaleDataJitter))
+	}
+	if p.SemaphoreWaitThreshold > 0 {
+		opts = append(opts, execution.WithSemaphoreWaitThreshold(p.SemaphoreWaitThreshold))
+	}
+	if p.ParallelRelations {
+		opts = append(opts, execution.WithParallelRelations())
+	}
+	return opts
+}
+
+func (p *Provider) reportResourceTelemetry(ctx context.Context, resource string, status cqproto.ResourceFetchStatus, d time.Duration, resourceCount uint64, diags diag.Diagnostics) {
+	if p.Telemetry == nil {
+		return
+	}
+	p.Telemetry.ReportResource(ctx, ResourceTelemetry{
+		ProviderName:    p.Name,
+		ProviderVersion: p.Version,
+		Resource:        resource,
+		Status:          status,
+		Duration:        d,
+		ResourceCount:   resourceCount,
+		Diagnostics:     countDiagnostics(diags),
+	})
+}
+
+func (p *Provider) reportFetchTelemetry(ctx context.Context, resources int, d time.Duration, resourceCount uint64, err error) {
+	if p.Telemetry == nil {
+		return
+	}
+	p.Telemetry.ReportFetch(ctx, FetchTelemetry{
+		ProviderName:    p.Name,
+		ProviderVersion: p.Version,
+		ResourcesCount:  resources,
+		Duration:        d,
+		ResourceCount:   resourceCount,
+		Error:           err != nil,
+	})
+}
+
+// configuredState returns the state set by the last ConfigureProvider call, or nil if the provider wasn't configured.
+// The returned state is never mutated, so it's safe to use it for the duration of a fetch.
+func (p *Provider) configuredState() *configuredState {
+	p.stateMu.RLock()
+	defer p.stateMu.RUnlock()
+	return p.state
+}
+
+// openStorage opens the database of the configured state: with storageCreator if set, which may be overridden after
+// configuration (i.e in tests), with Provider.Storage, or with the storage registered for the connection type
+func (p *Provider) openStorage(ctx context.Context, logger hclog.Logger, state *configuredState) (execution.Storage, error) {
+	if p.storageCreator != nil {
+		return p.storageCreator(ctx, logger, state.dbURL)
+	}
+	if p.Storage != nil {
+		if s, ok := p.Storage.(execution.StagingStorage); ok {
+			return borrowedStagingStorage{s}, nil
+		}
+		return borrowedStorage{p.Storage}, nil
+	}
+	return database.Open(ctx, logger, state.storageType, state.dbURL)
+}
+
+// borrowedStorage is a Provider.Storage used by a fetch, which is kept open once the fetch finishes
+type borrowedStorage struct {
+	execution.Storage
+}
+
+func (borrowedStorage) Close() {}
+
+// borrowedStagingStorage is a borrowedStorage keeping the staging support of the Provider.Storage
+type borrowedStagingStorage struct {
+	execution.StagingStorage
+}
+
+func (borrowedStagingStorage) Close() {}
+
+// fetchSemaphore returns the semaphore limiting the goroutines of a fetch, and its capacity. Fetches requesting a
+// maximum have a semaphore of their own, the others share the semaphore of the process' limits.
+func (p *Provider) fetchSemaphore(requested uint64) (*semaphore.Weighted, int64) {
+	if requested > 0 {
+		return semaphore.NewWeighted(helpers.Uint64ToInt64(requested)), helpers.Uint64ToInt64(requested)
+	}
+	p.goroutinesOnce.Do(func() {
+		p.maxGoroutines = helpers.Uint64ToInt64(limit.GetMaxGoRoutines())
+		p.goroutinesSem = semaphore.NewWeighted(p.maxGoroutines)
+	})
+	return p.goroutinesSem, p.maxGoroutines
+}
+
+// validateResourcesSchema validates the requested resources against the database schema. Resources that don't match
+// are reported as failed with the validation diagnostics, and the resources that can be fetched are returned.
+func (p *Provider) validateResourcesSchema(ctx context.Context, conn execution.Storage, resourceMap map[string]*schema.Table, resources []string, finishedResources map[string]bool, sender cqproto.FetchResourcesSender) ([]string, error) {
+	tables := make(map[string]*schema.Table, len(resources))
+	for _, r := range resources {
+		table, ok := resourceMap[r]
+		if !ok {
+			return nil, fmt.Errorf("plugin %s does not provide resource %s", p.Name, r)
+		}
+		tables[r] = table
+	}
+	if !migration.SupportsValidation(conn.Dialect()) {
+		p.Logger.Warn("database schema validation isn't supported by the storage's dialect, skipping it")
+		return resources, nil
+	}
+	diags := migration.ValidateTables(ctx, conn, conn.Dialect(), tables)
+	if !diags.HasDiags() {
+		return resources, nil
+	}
+	valid := make([]string, 0, len(resources))
+	for _, r := range resources {
+		var resourceDiags diag.Diagnostics
+		for _, d := range diags {
+			if d.Description().Resource == r || d.Type() == diag.DATABASE {
+				resourceDiags = append(resourceDiags, d)
+			}
+		}
+		if !resourceDiags.HasErrors() {
+			valid = append(valid, r)
+			continue
+		}
+		p.Logger.Warn("resource doesn't match database schema, skipping fetch", "resource", r, "errors", resourceDiags.Error())
+		finishedResources[r] = true
+		if err := sender.Send(&cqproto.FetchResourcesResponse{
+			ResourceName:      r,
+			FinishedResources: finishedResources,
+			Summary: cqproto.ResourceFetchSummary{
+				Status:      cqproto.ResourceFetchFailed,
+				Diagnostics: resourceDiags,
+			},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return valid, nil
 }
 
 func (p *Provider) GetModuleInfo(_ context.Context, request *cqproto.GetModuleRequest) (*cqproto.GetModuleResponse, error) {
@@ -286,7 +870,7 @@ func (p *Provider) GetModuleInfo(_ context.Context, request *cqproto.GetModuleRe
 	}, nil
 }
 
-func (p *Provider) interpolateAllResources(requestedResources []string) ([]string, error) {
+func interpolateAllResources(resourceMap map[string]*schema.Table, requestedResources []string) ([]string, error) {
 	if len(requestedResources) != 1 {
 		if funk.ContainsString(requestedResources, "*") {
 			return nil, fmt.Errorf("invalid \"*\" resource, with explicit resources")
@@ -296,19 +880,91 @@ func (p *Provider) interpolateAllResources(requestedResources []string) ([]strin
 	if requestedResources[0] != "*" {
 		return requestedResources, nil
 	}
-	allResources := make([]string, 0, len(p.ResourceMap))
-	for k := range p.ResourceMap {
+	allResources := make([]string, 0, len(resourceMap))
+	for k := range resourceMap {
 		allResources = append(allResources, k)
 	}
 	return allResources, nil
 }
 
+// filterResourcesByLabels returns the resources whose table has all the labels. Unknown resources are kept, so they are
+// reported by the fetch.
+func filterResourcesByLabels(resourceMap map[string]*schema.Table, resources []string, labels map[string]string) []string {
+	if len(labels) == 0 {
+		return resources
+	}
+	filtered := make([]string, 0, len(resources))
+	for _, r := range resources {
+		if t, ok := resourceMap[r]; ok && !t.HasLabels(labels) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+type resourceMapKey struct{}
+
+// WithResourceMap returns a context whose fetches resolve the tables of resourceMap instead of the provider's
+// ResourceMap, i.e copies of its tables multiplexed with mock clients by tests
+func WithResourceMap(ctx context.Context, resourceMap map[string]*schema.Table) context.Context {
+	return context.WithValue(ctx, resourceMapKey{}, resourceMap)
+}
+
+// resourceMap returns the tables fetched with the context, see WithResourceMap
+func (p *Provider) resourceMap(ctx context.Context) map[string]*schema.Table {
+	if m, ok := ctx.Value(resourceMapKey{}).(map[string]*schema.Table); ok {
+		return m
+	}
+	return p.ResourceMap
+}
+
 // IsDebug checks if CQ_PROVIDER_DEBUG is turned on. In case it's true the plugin is executed in debug mode.
 func IsDebug() bool {
 	b, _ := strconv.ParseBool(os.Getenv("CQ_PROVIDER_DEBUG"))
 	return b
 }
 
+func clientsToSummary(in []execution.ClientStatus) []cqproto.ClientFetchSummary {
+	if len(in) == 0 {
+		return nil
+	}
+	clients := make([]cqproto.ClientFetchSummary, len(in))
+	for i, c := range in {
+		status := cqproto.ResourceFetchCanceled
+		switch c.State {
+		case execution.ClientComplete:
+			status = cqproto.ResourceFetchComplete
+		case execution.ClientPartial:
+			status = cqproto.ResourceFetchPartial
+		}
+		clients[i] = cqproto.ClientFetchSummary{
+			ClientID:      c.ClientID,
+			Status:        status,
+			Started:       c.State != execution.ClientPending,
+			ResourceCount: c.ResourceCount,
+		}
+	}
+	return clients
+}
+
+func apiCallsToSummary(in []execution.APICallStat) []cqproto.APICallCount {
+	if len(in) == 0 {
+		return nil
+	}
+	calls := make([]cqproto.APICallCount, len(in))
+	for i, c := range in {
+		calls[i] = cqproto.APICallCount{
+			Table:     c.Table,
+			ClientID:  c.ClientID,
+			Service:   c.Service,
+			Operation: c.Operation,
+			Count:     c.Count,
+		}
+	}
+	return calls
+}
+
 func isCancelled(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
@@ -318,6 +974,34 @@ func isCancelled(ctx context.Context) bool {
 	}
 }
 
+// ValidateResourceMap validates the tables of a provider's resource map, returning a SCHEMA diagnostic for each problem
+// found, see schema.ValidateTableStructure. The resource map is validated on ConfigureProvider, so invalid tables are
+// reported before they fail a fetch.
+func ValidateResourceMap(resourceMap map[string]*schema.Table) diag.Diagnostics {
+	names := make([]string, 0, len(resourceMap))
+	for r := range resourceMap {
+		names = append(names, r)
+	}
+	sort.Strings(names)
+
+	var diags diag.Diagnostics
+	tables := make(map[string]string)
+	for _, r := range names {
+		t := resourceMap[r]
+		if t == nil {
+			diags = diags.Add(diag.FromError(fmt.Errorf("resource %s has no table", r), diag.SCHEMA, diag.WithResourceName(r)))
+			continue
+		}
+		for _, err := range schema.ValidateTableStructure(t) {
+			diags = diags.Add(diag.FromError(err, diag.SCHEMA, diag.WithResourceName(r), diag.WithSummary("table %q is invalid", t.Name)))
+		}
+		if err := getTableDuplicates(r, t, tables); err != nil {
+			diags = diags.Add(diag.FromError(err, diag.SCHEMA, diag.WithResourceName(r)))
+		}
+	}
+	return diags
+}
+
 func getTableDuplicates(resource string, table *schema.Table, tableNames map[string]string) error {
 	for _, r := range table.Relations {
 		if err := getTableDuplicates(resource, r, tableNames); err != nil {
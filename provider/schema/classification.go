@@ -0,0 +1,29 @@
+package schema
+
+// Classification is the kind of data a column holds, see Column.Classification. Providers may use their own
+// classifications, the common ones are defined below.
+type Classification string
+
+const (
+	// ClassificationPublic columns hold data that is public, i.e public IPs or DNS names
+	ClassificationPublic Classification = "PUBLIC"
+	// ClassificationInternal columns hold data internal to the organization, i.e resource names and tags
+	ClassificationInternal Classification = "INTERNAL"
+	// ClassificationPII columns hold personally identifiable information, i.e email addresses or user names
+	ClassificationPII Classification = "PII"
+	// ClassificationSecret columns hold secrets, i.e passwords or keys, they are usually Column.Sensitive too
+	ClassificationSecret Classification = "SECRET"
+)
+
+// ClassifiedColumns returns the columns of the table with the given classification, or all classified columns if
+// classification is empty
+func (t Table) ClassifiedColumns(classification Classification) ColumnList {
+	var ret ColumnList
+	for _, c := range t.Columns {
+		if c.Classification == "" || classification != "" && c.Classification != classification {
+			continue
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
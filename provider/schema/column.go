@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"runtime"
 	"sort"
 	"strings"
@@ -12,7 +13,10 @@ import (
 
 	gofrs "github.com/gofrs/uuid"
 	"github.com/google/uuid"
+	"github.com/iancoleman/strcase"
+	"github.com/jackc/pgtype"
 	"github.com/modern-go/reflect2"
+	"github.com/shopspring/decimal"
 	"github.com/thoas/go-funk"
 )
 
@@ -26,6 +30,10 @@ type ResolverMeta struct {
 type ColumnMeta struct {
 	Resolver     *ResolverMeta
 	IgnoreExists bool
+	// DefaultPath is the path in the resource's item the column is resolved from, if it has no resolver
+	DefaultPath string
+	// FallbackPaths are the column's Column.FallbackPaths
+	FallbackPaths []string
 }
 
 type ColumnList []Column
@@ -35,10 +43,17 @@ type ColumnList []Column
 // resource holds the current row we are resolving the column for.
 type ColumnResolver func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error
 
+// BackfillFunc returns the value of a column for a row stored before the column was added, from the row's values by
+// column name. Returning nil leaves the column NULL.
+type BackfillFunc func(ctx context.Context, row map[string]interface{}) (interface{}, error)
+
 // ColumnCreationOptions allow modification of how column is defined when table is created
 type ColumnCreationOptions struct {
 	Unique  bool
 	NotNull bool
+	// IncludeInCQID adds the column's value to the cq_id hash of tables with primary keys, without making it a primary key.
+	// The value must be stable between fetches of the same resource, otherwise the resource gets a new cq_id every fetch.
+	IncludeInCQID bool
 }
 
 // Column definition for Table
@@ -59,6 +74,41 @@ type Column struct {
 	// If IgnoreInTests is true, verification is skipped for this column.
 	// Used when it is hard to create a reproducible environment with this column being non-nil (e.g. various error columns).
 	IgnoreInTests bool
+	// Restricted marks the column as restricted under the named policy tag. Destinations configured with a policy for the
+	// tag omit or mask the column's values, see ColumnPolicies.
+	Restricted string
+	// RenamedFrom lists previous names of the column, newest first. When upgrading an existing table the first previous
+	// name found in the database is renamed to Name, keeping the column's data.
+	RenamedFrom []string
+	// Backfill is an SQL expression used to set the column's value in rows stored before the column was added, evaluated
+	// per row, i.e `split_part(arn, ':', 5)` for an account id column derived from the arn column. See migrator.Backfill.
+	Backfill string
+	// BackfillFunc sets the column's value in rows stored before the column was added, for values that can't be derived
+	// in SQL. It's called with each of the rows where the column is NULL, and only used if Backfill isn't set.
+	BackfillFunc BackfillFunc
+	// FallbackPaths are paths in the resource's item tried in order when the column resolves to nil, i.e
+	// []string{"Id", "Arn"} for a name column. The path the value was taken from is reported in cq_meta.
+	FallbackPaths []string
+	// Transform is applied to the column's value once resolved, by its Resolver, default path or FallbackPaths, and
+	// before the resource is stored. It isn't called for nil values.
+	Transform ColumnTransformer
+	// Sensitive marks the column's values as secrets, i.e passwords or keys. They are stored as is, but masked by the
+	// SDK in the diagnostics, errors, spans and logs of the fetch before they leave the provider.
+	Sensitive bool
+	// Deprecated if set, marks the column as deprecated with the reason, i.e the column replacing it. Deprecated columns
+	// are still fetched, they are annotated in the provider's schema, docs and migrations until they are removed.
+	Deprecated string
+	// Classification classifies the kind of data the column holds, i.e ClassificationPII, so policy tooling can discover
+	// the columns holding sensitive data. It isn't enforced by the SDK, see Sensitive to mask values.
+	Classification Classification
+	// InheritFromParent if set, the value of the column is copied from the named column of the parent resource, i.e
+	// "account_id" for the relations of a table resolving it, instead of using a ParentResourceFieldResolver. Used if
+	// the column has no Resolver.
+	InheritFromParent string
+	// JSONOmitFields if set, the column's value resolved by its default path, or by its FallbackPaths, is serialized to
+	// JSON without the listed fields, as with JSONResolver, i.e []string{"Credentials.Password"} for a TypeJSON column of
+	// a nested struct. Used if the column has no Resolver, and only valid for TypeJSON columns.
+	JSONOmitFields []string
 	// internal is true if this column is managed by the SDK
 	internal bool
 	// meta holds serializable information about the column's resolvers and functions
@@ -86,6 +136,13 @@ const (
 	TypeCIDRArray
 	TypeMacAddr
 	TypeMacAddrArray
+	// TypeTimestampTZ is a timestamp stored with its time zone, values are time.Time
+	TypeTimestampTZ
+	// TypeDecimal is a fixed precision number, i.e of monetary values, values are decimal.Decimal
+	TypeDecimal
+	// TypeHStore is a map of string keys to string values, stored with the hstore extension. Values are
+	// map[string]string, map[string]*string for null values, or pgtype.Hstore
+	TypeHStore
 )
 
 func (v ValueType) String() string {
@@ -124,6 +181,12 @@ func (v ValueType) String() string {
 		return "TypeCIDRArray"
 	case TypeCIDR:
 		return "TypeCIDR"
+	case TypeTimestampTZ:
+		return "TypeTimestampTZ"
+	case TypeDecimal:
+		return "TypeDecimal"
+	case TypeHStore:
+		return "TypeHStore"
 	case TypeInvalid:
 		fallthrough
 	default:
@@ -168,6 +231,12 @@ func ValueTypeFromString(s string) ValueType {
 		return TypeCIDR
 	case "cidrarray":
 		return TypeCIDRArray
+	case "timestamptz":
+		return TypeTimestampTZ
+	case "decimal":
+		return TypeDecimal
+	case "hstore":
+		return TypeHStore
 	case "invalid":
 		return TypeInvalid
 	default:
@@ -197,6 +266,13 @@ func (c Column) checkType(v interface{}) bool {
 
 	// Maps or slices are jsons
 	if reflect2.TypeOf(v).Kind() == reflect.Map {
+		if c.Type == TypeHStore {
+			switch v.(type) {
+			case map[string]string, map[string]*string:
+				return true
+			}
+			return false
+		}
 		return c.Type == TypeJSON
 	}
 
@@ -237,7 +313,11 @@ func (c Column) checkType(v interface{}) bool {
 	case []interface{}:
 		return c.Type == TypeJSON
 	case time.Time, *time.Time:
-		return c.Type == TypeTimestamp
+		return c.Type == TypeTimestamp || c.Type == TypeTimestampTZ
+	case decimal.Decimal, *decimal.Decimal:
+		return c.Type == TypeDecimal
+	case pgtype.Hstore, *pgtype.Hstore:
+		return c.Type == TypeHStore
 	case uuid.UUID, *uuid.UUID:
 		return c.Type == TypeUUID
 	case gofrs.UUID, *gofrs.UUID:
@@ -300,20 +380,28 @@ func (c Column) Meta() *ColumnMeta {
 	}
 	if c.Resolver == nil {
 		return &ColumnMeta{
-			Resolver:     nil,
-			IgnoreExists: false,
+			Resolver:      nil,
+			IgnoreExists:  false,
+			DefaultPath:   strcase.ToCamel(c.Name),
+			FallbackPaths: c.FallbackPaths,
 		}
 	}
-	fnName := runtime.FuncForPC(reflect.ValueOf(c.Resolver).Pointer()).Name()
+	// resolvers returned by functions such as PathResolver are closures, named by the function returning them
+	fnName := closureSuffix.ReplaceAllString(runtime.FuncForPC(reflect.ValueOf(c.Resolver).Pointer()).Name(), "$1")
 	return &ColumnMeta{
 		Resolver: &ResolverMeta{
 			Name:    strings.TrimPrefix(fnName, "github.com/cloudquery/cq-provider-sdk/provider/"),
 			Builtin: strings.HasPrefix(fnName, "github.com/cloudquery/cq-provider-sdk/"),
 		},
-		IgnoreExists: false,
+		IgnoreExists:  false,
+		FallbackPaths: c.FallbackPaths,
 	}
 }
 
+// closureSuffix matches the suffix the runtime adds to the names of closures, i.e .func1 or .func1.2, unless the closure
+// is a package level variable
+var closureSuffix = regexp.MustCompile(`([^.])\.func\d+(\.\d+)*$`)
+
 func (c Column) signature() string {
 	return strings.Join([]string{
 		"c",
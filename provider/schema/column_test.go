@@ -9,6 +9,8 @@ import (
 
 	"github.com/cloudquery/faker/v3"
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/thoas/go-funk"
 )
@@ -99,6 +101,21 @@ var validateFixtures = []validateFixture{
 		TestValues: []interface{}{[]*net.IPNet{GenerateCIDR(), GenerateCIDR()}, []*net.IPNet{}, []net.IPNet{}},
 		BadValues:  []interface{}{"asdasdsadads", 555, "127.0.0.1/24", net.IPNet{}, net.IP{}},
 	},
+	{
+		Column:     Column{Type: TypeTimestampTZ},
+		TestValues: []interface{}{time.Now(), funk.PtrOf(time.Now())},
+		BadValues:  []interface{}{"2011-10-05T14:48:00.000Z", 555},
+	},
+	{
+		Column:     Column{Type: TypeDecimal},
+		TestValues: []interface{}{decimal.RequireFromString("10.25"), funk.PtrOf(decimal.NewFromInt(1))},
+		BadValues:  []interface{}{"10.25", 10.25, 10},
+	},
+	{
+		Column:     Column{Type: TypeHStore},
+		TestValues: []interface{}{map[string]string{"a": "b"}, map[string]*string{"a": nil}, pgtype.Hstore{Status: pgtype.Present}},
+		BadValues:  []interface{}{map[string]interface{}{"a": 1}, "a=>b", []string{"a"}},
+	},
 }
 
 func GenerateMac() net.HardwareAddr {
@@ -146,6 +163,9 @@ func TestValueTypeFromString(t *testing.T) {
 
 	assert.Equal(t, ValueTypeFromString("TypeBigInt"), TypeBigInt)
 	assert.Equal(t, ValueTypeFromString("TypeString"), TypeString)
+	assert.Equal(t, ValueTypeFromString("TypeTimestampTZ"), TypeTimestampTZ)
+	assert.Equal(t, ValueTypeFromString("decimal"), TypeDecimal)
+	assert.Equal(t, ValueTypeFromString("hstore"), TypeHStore)
 }
 
 func BenchmarkColumn_ValidateTypeInt(b *testing.B) {
@@ -176,3 +196,10 @@ func BenchmarkColumn_ValidateTypeMap(b *testing.B) {
 		_ = col.ValidateType(m)
 	}
 }
+
+func TestColumn_Meta(t *testing.T) {
+	assert.Equal(t, &ColumnMeta{DefaultPath: "InstanceId", FallbackPaths: []string{"Id"}}, Column{Name: "instance_id", FallbackPaths: []string{"Id"}}.Meta())
+	assert.Equal(t, &ResolverMeta{Name: "schema.ParentIdResolver", Builtin: true}, Column{Name: "parent_id", Resolver: ParentIdResolver}.Meta().Resolver)
+	// closures are named by the function returning them
+	assert.Equal(t, &ResolverMeta{Name: "schema.PathResolver", Builtin: true}, Column{Name: "id", Resolver: PathResolver("Id")}.Meta().Resolver)
+}
@@ -6,7 +6,9 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/jackc/pgtype"
 	"github.com/modern-go/reflect2"
+	"github.com/thoas/go-funk"
 )
 
 type DialectType string
@@ -29,6 +31,9 @@ type Dialect interface {
 
 	// GetResourceValues will return column values from the resource, ready to go in pgx.CopyFromSlice
 	GetResourceValues(r *Resource) ([]interface{}, error)
+
+	// QuoteIdentifier quotes a table or column name to be used in SQL statements, according to dialect
+	QuoteIdentifier(name string) string
 }
 
 type PostgresDialect struct{}
@@ -40,6 +45,7 @@ type TSDBDialect struct {
 const (
 	Postgres = DialectType("postgres")
 	TSDB     = DialectType("timescale")
+	MySQL    = DialectType("mysql")
 )
 
 var (
@@ -58,6 +64,8 @@ func GetDialect(t DialectType) (Dialect, error) {
 		return PostgresDialect{}, nil
 	case TSDB:
 		return TSDBDialect{}, nil
+	case MySQL:
+		return MySQLDialect{}, nil
 	default:
 		return nil, fmt.Errorf("unknown dialect %q", t)
 	}
@@ -71,26 +79,34 @@ func (PostgresDialect) PrimaryKeys(t *Table) []string {
 }
 
 func (PostgresDialect) Columns(t *Table) ColumnList {
-	return append([]Column{cqIdColumn, cqMeta}, t.Columns...)
+	return append(optionalColumns(t, cqIdColumn, cqMeta), t.Columns...)
 }
 
 func (d PostgresDialect) Constraints(t, parent *Table) []string {
+	return d.scopedConstraints(t, parent, nil)
+}
+
+func (d PostgresDialect) scopedConstraints(t, parent *Table, scope ColumnList) []string {
 	ret := make([]string, 0, len(t.Columns))
 
-	ret = append(ret, fmt.Sprintf("CONSTRAINT %s_pk PRIMARY KEY(%s)", truncatePKConstraint(t.Name), strings.Join(d.PrimaryKeys(t), ",")))
+	ret = append(ret, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY(%s)", QuoteIdentifier(PrimaryKeyConstraintName(t.Name)), strings.Join(QuoteIdentifiers(d, append(scope.Names(), d.PrimaryKeys(t)...)), ",")))
 
 	for _, c := range d.Columns(t) {
 		if !c.CreationOptions.Unique {
 			continue
 		}
-
-		ret = append(ret, fmt.Sprintf("UNIQUE(%s)", c.Name))
+		// the cq_id stays unique by itself, as relations reference it
+		if c.Name == cqIdColumn.Name {
+			ret = append(ret, fmt.Sprintf("UNIQUE(%s)", QuoteIdentifier(c.Name)))
+			continue
+		}
+		ret = append(ret, fmt.Sprintf("UNIQUE(%s)", strings.Join(QuoteIdentifiers(d, append(scope.Names(), c.Name)), ",")))
 	}
 
 	if parent != nil {
 		pc := findParentIdColumn(t)
 		if pc != nil {
-			ret = append(ret, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s) ON DELETE CASCADE", pc.Name, parent.Name, cqIdColumn.Name))
+			ret = append(ret, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s) ON DELETE CASCADE", QuoteIdentifier(pc.Name), QuoteIdentifier(parent.Name), QuoteIdentifier(cqIdColumn.Name)))
 		}
 	}
 
@@ -141,6 +157,12 @@ func (PostgresDialect) DBTypeFromType(v ValueType) string {
 		return "cidr"
 	case TypeCIDRArray:
 		return "cidr[]"
+	case TypeTimestampTZ:
+		return "timestamp with time zone"
+	case TypeDecimal:
+		return "numeric"
+	case TypeHStore:
+		return "hstore"
 	default:
 		panic("invalid type")
 	}
@@ -150,25 +172,44 @@ func (d PostgresDialect) GetResourceValues(r *Resource) ([]interface{}, error) {
 	return doResourceValues(d, r)
 }
 
+func (PostgresDialect) QuoteIdentifier(name string) string {
+	return QuoteIdentifier(name)
+}
+
 func (d TSDBDialect) PrimaryKeys(t *Table) []string {
 	return append([]string{cqFetchDateColumn.Name}, d.pg.PrimaryKeys(t)...)
 }
 
 func (TSDBDialect) Columns(t *Table) ColumnList {
-	return append([]Column{cqIdColumn, cqMeta, cqFetchDateColumn}, t.Columns...)
+	return append(optionalColumns(t, cqIdColumn, cqMeta, cqFetchDateColumn), t.Columns...)
+}
+
+// optionalColumns appends the internal columns enabled by the table's options to the given internal columns
+func optionalColumns(t *Table, cols ...Column) []Column {
+	if t.Options.Sequence {
+		cols = append(cols, cqSequenceColumn)
+	}
+	if t.Options.SoftDelete {
+		cols = append(cols, cqDeletedAtColumn)
+	}
+	return cols
 }
 
-func (d TSDBDialect) Constraints(t, _ *Table) []string {
+func (d TSDBDialect) Constraints(t, parent *Table) []string {
+	return d.scopedConstraints(t, parent, nil)
+}
+
+func (d TSDBDialect) scopedConstraints(t, _ *Table, scope ColumnList) []string {
 	ret := make([]string, 0, len(t.Columns))
 
-	ret = append(ret, fmt.Sprintf("CONSTRAINT %s_pk PRIMARY KEY(%s)", truncatePKConstraint(t.Name), strings.Join(d.PrimaryKeys(t), ",")))
+	ret = append(ret, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY(%s)", QuoteIdentifier(PrimaryKeyConstraintName(t.Name)), strings.Join(QuoteIdentifiers(d, append(scope.Names(), d.PrimaryKeys(t)...)), ",")))
 
 	for _, c := range d.Columns(t) {
 		if !c.CreationOptions.Unique {
 			continue
 		}
 
-		ret = append(ret, fmt.Sprintf("UNIQUE(%s,%s)", cqFetchDateColumn.Name, c.Name))
+		ret = append(ret, fmt.Sprintf("UNIQUE(%s)", strings.Join(QuoteIdentifiers(d, append(scope.Names(), cqFetchDateColumn.Name, c.Name)), ",")))
 	}
 
 	return ret
@@ -184,7 +225,7 @@ func (TSDBDialect) Extra(t, parent *Table) []string {
 	}
 
 	return []string{
-		fmt.Sprintf("CREATE INDEX ON %s (%s, %s);", t.Name, cqFetchDateColumn.Name, pc.Name),
+		fmt.Sprintf("CREATE INDEX ON %s (%s, %s);", QuoteIdentifier(t.Name), QuoteIdentifier(cqFetchDateColumn.Name), QuoteIdentifier(pc.Name)),
 		fmt.Sprintf("SELECT setup_tsdb_child('%s', '%s', '%s', '%s');", t.Name, pc.Name, parent.Name, cqIdColumn.Name),
 	}
 }
@@ -197,6 +238,10 @@ func (d TSDBDialect) GetResourceValues(r *Resource) ([]interface{}, error) {
 	return doResourceValues(d, r)
 }
 
+func (d TSDBDialect) QuoteIdentifier(name string) string {
+	return d.pg.QuoteIdentifier(name)
+}
+
 func doResourceValues(dialect Dialect, r *Resource) ([]interface{}, error) {
 	values := make([]interface{}, 0)
 	for _, c := range dialect.Columns(r.table) {
@@ -257,6 +302,13 @@ func doResourceValues(dialect Dialect, r *Resource) ([]interface{}, error) {
 			default:
 				values = append(values, data)
 			}
+		case TypeHStore:
+			// hstore is an extension type unknown to pgx, so values are encoded as pgtype.Hstore
+			hstore, err := hstoreValue(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, hstore)
 		default:
 			values = append(values, v)
 		}
@@ -274,6 +326,27 @@ func findParentIdColumn(t *Table) (ret *Column) {
 	return nil
 }
 
+func hstoreValue(v interface{}) (interface{}, error) {
+	switch data := v.(type) {
+	case nil:
+		return nil, nil
+	case pgtype.Hstore:
+		return &data, nil
+	case *pgtype.Hstore:
+		return data, nil
+	}
+	var hstore pgtype.Hstore
+	if err := hstore.Set(funk.GetOrElse(v, nil)); err != nil {
+		return nil, err
+	}
+	return &hstore, nil
+}
+
+// PrimaryKeyConstraintName returns the name of the primary key constraint the dialects create for the given table
+func PrimaryKeyConstraintName(tableName string) string {
+	return truncatePKConstraint(tableName) + "_pk"
+}
+
 func truncatePKConstraint(name string) string {
 	const (
 		// MaxTableLength in postgres is 63 when building _fk or _pk we want to truncate the name to 60 chars max
@@ -0,0 +1,210 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/modern-go/reflect2"
+)
+
+// MySQLDialect creates tables and encodes values for MySQL and MariaDB. MySQL has no array, network or hstore types,
+// these are stored as JSON or text.
+type MySQLDialect struct {
+	pg PostgresDialect
+}
+
+// mysqlIndexPrefixLength is the prefix length of text columns in primary keys and unique constraints, since MySQL can
+// only index a prefix of text columns
+const mysqlIndexPrefixLength = 255
+
+var _ Dialect = (*MySQLDialect)(nil)
+
+func (d MySQLDialect) PrimaryKeys(t *Table) []string {
+	return d.pg.PrimaryKeys(t)
+}
+
+func (d MySQLDialect) Columns(t *Table) ColumnList {
+	return d.pg.Columns(t)
+}
+
+func (d MySQLDialect) Constraints(t, parent *Table) []string {
+	return d.scopedConstraints(t, parent, nil)
+}
+
+func (d MySQLDialect) scopedConstraints(t, parent *Table, scope ColumnList) []string {
+	ret := make([]string, 0, len(t.Columns))
+	cols := append(append(ColumnList{}, scope...), d.Columns(t)...)
+	indexColumns := func(names []string) string {
+		quoted := make([]string, len(names))
+		for i, name := range names {
+			quoted[i] = d.indexColumn(cols, name)
+		}
+		return strings.Join(quoted, ",")
+	}
+
+	ret = append(ret, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY(%s)", d.QuoteIdentifier(PrimaryKeyConstraintName(t.Name)), indexColumns(append(scope.Names(), d.PrimaryKeys(t)...))))
+
+	for _, c := range cols {
+		if !c.CreationOptions.Unique {
+			continue
+		}
+		// the cq_id stays unique by itself, as relations reference it
+		if c.Name == cqIdColumn.Name {
+			ret = append(ret, fmt.Sprintf("UNIQUE(%s)", d.indexColumn(cols, c.Name)))
+			continue
+		}
+		ret = append(ret, fmt.Sprintf("UNIQUE(%s)", indexColumns(append(scope.Names(), c.Name))))
+	}
+
+	if parent != nil {
+		pc := findParentIdColumn(t)
+		if pc != nil {
+			ret = append(ret, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(%s) ON DELETE CASCADE", d.QuoteIdentifier(pc.Name), d.QuoteIdentifier(parent.Name), d.QuoteIdentifier(cqIdColumn.Name)))
+		}
+	}
+
+	return ret
+}
+
+// indexColumn returns the quoted column to use in an index, with a prefix length if the column is stored as text
+func (d MySQLDialect) indexColumn(cols ColumnList, name string) string {
+	if c := cols.Get(name); c != nil {
+		switch d.DBTypeFromType(c.Type) {
+		case "text", "longtext", "json", "longblob":
+			return fmt.Sprintf("%s(%d)", d.QuoteIdentifier(name), mysqlIndexPrefixLength)
+		}
+	}
+	return d.QuoteIdentifier(name)
+}
+
+func (MySQLDialect) Extra(_, _ *Table) []string {
+	return nil
+}
+
+func (MySQLDialect) DBTypeFromType(v ValueType) string {
+	switch v {
+	case TypeBool:
+		return "boolean"
+	case TypeInt:
+		return "int"
+	case TypeBigInt:
+		return "bigint"
+	case TypeSmallInt:
+		return "smallint"
+	case TypeFloat:
+		return "double"
+	case TypeUUID:
+		return "char(36)"
+	case TypeString:
+		return "text"
+	case TypeJSON, TypeIntArray, TypeStringArray, TypeUUIDArray, TypeInetArray, TypeMacAddrArray, TypeCIDRArray, TypeHStore:
+		return "json"
+	case TypeTimestamp, TypeTimestampTZ:
+		return "datetime(6)"
+	case TypeByteArray:
+		return "longblob"
+	case TypeInvalid:
+		fallthrough
+	case TypeInet, TypeCIDR:
+		return "varchar(43)"
+	case TypeMacAddr:
+		return "varchar(17)"
+	case TypeDecimal:
+		return "decimal(65,30)"
+	default:
+		panic("invalid type")
+	}
+}
+
+// GetResourceValues returns the resource's values encoded for MySQL drivers: JSON stored columns are marshalled,
+// network addresses are formatted as text, and timestamps are converted to UTC.
+func (d MySQLDialect) GetResourceValues(r *Resource) ([]interface{}, error) {
+	values, err := doResourceValues(d, r)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range d.Columns(r.table) {
+		if values[i], err = mysqlValue(d.DBTypeFromType(c.Type), values[i]); err != nil {
+			return nil, fmt.Errorf("column %s: %w", c.Name, err)
+		}
+	}
+	return values, nil
+}
+
+func mysqlValue(dbType string, v interface{}) (interface{}, error) {
+	if reflect2.IsNil(v) {
+		return nil, nil
+	}
+	switch dbType {
+	case "json":
+		if h, ok := v.(*pgtype.Hstore); ok {
+			var m map[string]*string
+			if err := h.AssignTo(&m); err != nil {
+				return nil, err
+			}
+			v = m
+		}
+		if strs, ok := stringerSlice(v); ok {
+			v = strs
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case "datetime(6)":
+		switch t := v.(type) {
+		case time.Time:
+			return t.UTC(), nil
+		case *time.Time:
+			return t.UTC(), nil
+		}
+	case "varchar(43)", "varchar(17)":
+		switch a := v.(type) {
+		case net.IPNet:
+			return a.String(), nil
+		case fmt.Stringer:
+			return a.String(), nil
+		}
+	case "char(36)":
+		if s, ok := v.(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+	}
+	return v, nil
+}
+
+// stringerSlice formats slices of fmt.Stringer elements (i.e net.HardwareAddr or net.IPNet) as slices of strings, since
+// they aren't all marshalled to JSON as text
+func stringerSlice(v interface{}) ([]string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	stringer := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	elem := rv.Type().Elem()
+	if !elem.Implements(stringer) && !reflect.PtrTo(elem).Implements(stringer) {
+		return nil, false
+	}
+	ret := make([]string, rv.Len())
+	for i := range ret {
+		e := rv.Index(i)
+		if !elem.Implements(stringer) {
+			e = e.Addr()
+		} else if e.Kind() == reflect.Ptr && e.IsNil() {
+			continue
+		}
+		ret[i] = e.Interface().(fmt.Stringer).String()
+	}
+	return ret, true
+}
+
+// QuoteIdentifier quotes name with backticks, as MySQL doesn't quote identifiers with double quotes by default
+func (MySQLDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
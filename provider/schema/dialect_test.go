@@ -1,8 +1,11 @@
 package schema
 
 import (
+	"net"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgtype"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -187,3 +190,66 @@ func TestIntColumn(t *testing.T) {
 		assert.Nil(t, err)
 	}
 }
+
+func TestSequenceColumn(t *testing.T) {
+	table := &Table{Name: "sequence_table", Columns: []Column{{Name: "name", Type: TypeString}}}
+	assert.NotContains(t, PostgresDialect{}.Columns(table).Names(), SequenceColumnName)
+
+	table.Options.Sequence = true
+	assert.Equal(t, []string{"cq_id", "cq_meta", SequenceColumnName, "name"}, PostgresDialect{}.Columns(table).Names())
+	assert.Equal(t, []string{"cq_id", "cq_meta", "cq_fetch_date", SequenceColumnName, "name"}, TSDBDialect{}.Columns(table).Names())
+}
+
+func TestDeletedAtColumn(t *testing.T) {
+	table := &Table{Name: "soft_delete_table", Columns: []Column{{Name: "name", Type: TypeString}}}
+	assert.NotContains(t, PostgresDialect{}.Columns(table).Names(), DeletedAtColumnName)
+
+	table.Options.SoftDelete = true
+	assert.Equal(t, []string{"cq_id", "cq_meta", DeletedAtColumnName, "name"}, PostgresDialect{}.Columns(table).Names())
+	assert.Equal(t, []string{"cq_id", "cq_meta", "cq_fetch_date", DeletedAtColumnName, "name"}, TSDBDialect{}.Columns(table).Names())
+}
+
+func TestHStoreColumn(t *testing.T) {
+	table := &Table{Name: "hstore_table", Columns: []Column{{Name: "tags", Type: TypeHStore}}}
+	r := NewResourceData(PostgresDialect{}, table, nil, nil, nil, time.Now())
+	assert.NoError(t, r.Set("tags", map[string]string{"env": "prod"}))
+	values, err := PostgresDialect{}.GetResourceValues(r)
+	assert.NoError(t, err)
+	assert.Equal(t, &pgtype.Hstore{Map: map[string]pgtype.Text{"env": {String: "prod", Status: pgtype.Present}}, Status: pgtype.Present}, values[len(values)-1])
+	assert.Equal(t, "hstore", PostgresDialect{}.DBTypeFromType(TypeHStore))
+}
+
+func TestMySQLDialect(t *testing.T) {
+	parent := &Table{Name: "parent", Columns: []Column{{Name: "name", Type: TypeString}}, Options: TableCreationOptions{PrimaryKeys: []string{"name"}}}
+	child := &Table{Name: "child", Columns: []Column{
+		{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver},
+		{Name: "arn", Type: TypeString, CreationOptions: ColumnCreationOptions{Unique: true}},
+	}}
+	assert.Equal(t, []string{
+		"CONSTRAINT `child_pk` PRIMARY KEY(`cq_id`)",
+		"UNIQUE(`cq_id`)",
+		"UNIQUE(`arn`(255))",
+		"FOREIGN KEY (`parent_cq_id`) REFERENCES `parent`(`cq_id`) ON DELETE CASCADE",
+	}, MySQLDialect{}.Constraints(child, parent))
+	assert.Equal(t, []string{"CONSTRAINT `parent_pk` PRIMARY KEY(`name`(255))", "UNIQUE(`cq_id`)"}, MySQLDialect{}.Constraints(parent, nil))
+	assert.Equal(t, "`a``b`", MySQLDialect{}.QuoteIdentifier("a`b"))
+}
+
+func TestMySQLResourceValues(t *testing.T) {
+	table := &Table{Name: "mysql_table", Columns: []Column{
+		{Name: "tags", Type: TypeHStore},
+		{Name: "names", Type: TypeStringArray},
+		{Name: "ip", Type: TypeInet},
+		{Name: "created", Type: TypeTimestamp},
+		{Name: "empty", Type: TypeJSON},
+	}}
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.FixedZone("test", 3600))
+	r := NewResourceData(MySQLDialect{}, table, nil, nil, nil, time.Now())
+	assert.NoError(t, r.Set("tags", map[string]string{"env": "prod"}))
+	assert.NoError(t, r.Set("names", []string{"a", "b"}))
+	assert.NoError(t, r.Set("ip", net.ParseIP("10.0.0.1")))
+	assert.NoError(t, r.Set("created", created))
+	values, err := MySQLDialect{}.GetResourceValues(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{`{"env":"prod"}`, `["a","b"]`, "10.0.0.1", created.UTC(), nil}, values[2:])
+}
@@ -0,0 +1,77 @@
+package schema
+
+import "context"
+
+type fetchContextKey struct{}
+
+type parentItemKey struct{}
+
+type resumeTokenKey struct{}
+
+// ResumeToken is sent by a top level table's TableResolver on its result channel to checkpoint its progress, once all
+// the items it covers were sent. If the fetch is interrupted and resumed, the latest token is passed to the resolver
+// of the same client, see ResumeTokenValue, so it can continue from there, i.e from a page token.
+type ResumeToken string
+
+// SetChildContext sets a value made available to the resolvers of the resource's relations, and their relations, via
+// FetchContextValue. Use it to pass data computed while resolving the parent, such as a pre-fetched sub-object, without
+// storing it in a column or calling the API again in the relation.
+func (r *Resource) SetChildContext(key string, value interface{}) {
+	if r.childContext == nil {
+		r.childContext = make(map[string]interface{})
+	}
+	r.childContext[key] = value
+}
+
+// WithFetchContext returns a copy of ctx carrying the child context values set on parent, on top of the values already
+// in ctx. The executor calls it before resolving the relations of parent.
+func WithFetchContext(ctx context.Context, parent *Resource) context.Context {
+	if parent == nil || len(parent.childContext) == 0 {
+		return ctx
+	}
+	inherited, _ := ctx.Value(fetchContextKey{}).(map[string]interface{})
+	values := make(map[string]interface{}, len(inherited)+len(parent.childContext))
+	for k, v := range inherited {
+		values[k] = v
+	}
+	for k, v := range parent.childContext {
+		values[k] = v
+	}
+	return context.WithValue(ctx, fetchContextKey{}, values)
+}
+
+// FetchContextValue returns the value set by SetChildContext on the closest ancestor resource of the table being resolved
+func FetchContextValue(ctx context.Context, key string) (interface{}, bool) {
+	values, _ := ctx.Value(fetchContextKey{}).(map[string]interface{})
+	v, ok := values[key]
+	return v, ok
+}
+
+// WithParentItemValue returns a copy of ctx carrying the value returned by the table's ParentItemResolver. The executor
+// calls it before calling the resolver of a relation.
+func WithParentItemValue(ctx context.Context, value interface{}) context.Context {
+	return context.WithValue(ctx, parentItemKey{}, value)
+}
+
+// ParentItemValue returns the value the table's ParentItemResolver returned for the parent resource being resolved.
+// Unlike FetchContextValue, the value is only available to the table's own resolver.
+func ParentItemValue(ctx context.Context) (interface{}, bool) {
+	v := ctx.Value(parentItemKey{})
+	if v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// WithResumeToken returns a copy of ctx carrying the resume token checkpointed by the table's resolver in the fetch
+// being resumed. The executor calls it before calling the resolver of a top level table.
+func WithResumeToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, resumeTokenKey{}, token)
+}
+
+// ResumeTokenValue returns the latest ResumeToken the table's resolver sent for the client in the fetch being resumed,
+// false if the fetch isn't resumed or the resolver didn't send one
+func ResumeTokenValue(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(resumeTokenKey{}).(string)
+	return v, ok && v != ""
+}
@@ -0,0 +1,291 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type fetchFilterKey struct{}
+
+// FilterOperator compares a column to the values of a FilterCondition
+type FilterOperator string
+
+const (
+	FilterEqual    FilterOperator = "="
+	FilterNotEqual FilterOperator = "!="
+	FilterIn       FilterOperator = "in"
+	FilterNotIn    FilterOperator = "not in"
+)
+
+// FilterCondition compares a column of the table to one or more values
+type FilterCondition struct {
+	Column   string
+	Operator FilterOperator
+	Values   []string
+}
+
+// FetchFilter limits the resources fetched of a table, parsed from the filter expression of the fetch request, see
+// ParseFetchFilter. Resolvers get the filter of their table with FetchFilterValue, so they can push it down to the API,
+// i.e only list the instances of the filtered regions. The SDK only stores the resources matching the filter, and stale
+// data of filtered tables isn't removed.
+type FetchFilter struct {
+	// Expression is the filter expression the filter was parsed from
+	Expression string
+	// Conditions that must all match
+	Conditions []FilterCondition
+}
+
+// ParseFetchFilter parses a filter expression of conditions joined by AND. Conditions compare a column to quoted or bare
+// values with =, !=, IN or NOT IN, i.e `region in ('us-east-1', 'us-west-2') and state != 'terminated'`.
+func ParseFetchFilter(expr string) (*FetchFilter, error) {
+	tokens, err := filterTokens(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+	}
+	p := &filterParser{tokens: tokens}
+	f := &FetchFilter{Expression: expr}
+	for {
+		c, err := p.condition()
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+		}
+		f.Conditions = append(f.Conditions, c)
+		if p.done() {
+			return f, nil
+		}
+		if !p.keyword("and") {
+			return nil, fmt.Errorf("invalid filter %q: expected AND, got %q", expr, p.peek().text)
+		}
+	}
+}
+
+// Validate checks the filter's columns are columns of the table
+func (f *FetchFilter) Validate(t *Table) error {
+	for _, c := range f.Conditions {
+		if t.Column(c.Column) == nil {
+			return fmt.Errorf("filter column %s isn't a column of table %s", c.Column, t.Name)
+		}
+	}
+	return nil
+}
+
+// Values returns the values a column is filtered by with = or IN, i.e the regions to list. Returns false if the filter
+// doesn't limit the column's values.
+func (f *FetchFilter) Values(column string) ([]string, bool) {
+	if f == nil {
+		return nil, false
+	}
+	for _, c := range f.Conditions {
+		if c.Column == column && (c.Operator == FilterEqual || c.Operator == FilterIn) {
+			return c.Values, true
+		}
+	}
+	return nil, false
+}
+
+// Match returns true if the resource's values match all the conditions of the filter. Values are compared in their
+// printed form, nil values only match != and NOT IN conditions.
+func (f *FetchFilter) Match(r *Resource) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range f.Conditions {
+		v, ok := filterValue(r.Get(c.Column))
+		found := false
+		for _, want := range c.Values {
+			if ok && v == want {
+				found = true
+				break
+			}
+		}
+		if found != (c.Operator == FilterEqual || c.Operator == FilterIn) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithFetchFilter returns a copy of ctx carrying the fetch filter of the table. The executor calls it before calling
+// the resolver of a filtered table.
+func WithFetchFilter(ctx context.Context, f *FetchFilter) context.Context {
+	return context.WithValue(ctx, fetchFilterKey{}, f)
+}
+
+// FetchFilterValue returns the filter of the fetch request for the table being resolved, false if the table isn't
+// filtered
+func FetchFilterValue(ctx context.Context) (*FetchFilter, bool) {
+	f, ok := ctx.Value(fetchFilterKey{}).(*FetchFilter)
+	return f, ok && f != nil
+}
+
+// filterValue returns the printed form of a resource value, false if it's nil
+func filterValue(v interface{}) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "", false
+		}
+		rv = rv.Elem()
+	}
+	return fmt.Sprint(rv.Interface()), true
+}
+
+type filterTokenKind int
+
+const (
+	filterWord filterTokenKind = iota
+	filterString
+	filterSymbol
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+// filterTokens splits a filter expression into words, single quoted strings and the symbols ( ) , = != <>
+func filterTokens(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("unterminated string")
+				}
+				if runes[i] == '\'' {
+					// quotes are escaped by doubling them
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						b.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterString, text: b.String()})
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, filterToken{kind: filterSymbol, text: string(r)})
+			i++
+		case strings.HasPrefix(string(runes[i:]), "!=") || strings.HasPrefix(string(runes[i:]), "<>"):
+			tokens = append(tokens, filterToken{kind: filterSymbol, text: "!="})
+			i += 2
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()',=!<", runes[i]) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected %q", string(r))
+			}
+			tokens = append(tokens, filterToken{kind: filterWord, text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.done() {
+		return filterToken{kind: filterSymbol, text: "end of filter"}
+	}
+	return p.tokens[p.pos]
+}
+
+// keyword consumes the next token if it's the case insensitive keyword
+func (p *filterParser) keyword(k string) bool {
+	if t := p.peek(); !p.done() && t.kind == filterWord && strings.EqualFold(t.text, k) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// symbol consumes the next token if it's the symbol
+func (p *filterParser) symbol(s string) bool {
+	if t := p.peek(); !p.done() && t.kind == filterSymbol && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) condition() (FilterCondition, error) {
+	t := p.peek()
+	if p.done() || t.kind != filterWord {
+		return FilterCondition{}, fmt.Errorf("expected column, got %q", t.text)
+	}
+	p.pos++
+	c := FilterCondition{Column: t.text}
+	switch {
+	case p.symbol("="):
+		c.Operator = FilterEqual
+	case p.symbol("!="):
+		c.Operator = FilterNotEqual
+	case p.keyword("in"):
+		c.Operator = FilterIn
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return FilterCondition{}, fmt.Errorf("expected IN after NOT, got %q", p.peek().text)
+		}
+		c.Operator = FilterNotIn
+	default:
+		return FilterCondition{}, fmt.Errorf("expected operator after %s, got %q", c.Column, p.peek().text)
+	}
+	if c.Operator == FilterEqual || c.Operator == FilterNotEqual {
+		v, err := p.value()
+		if err != nil {
+			return FilterCondition{}, err
+		}
+		c.Values = []string{v}
+		return c, nil
+	}
+	if !p.symbol("(") {
+		return FilterCondition{}, fmt.Errorf("expected ( after %s, got %q", c.Operator, p.peek().text)
+	}
+	for {
+		v, err := p.value()
+		if err != nil {
+			return FilterCondition{}, err
+		}
+		c.Values = append(c.Values, v)
+		if p.symbol(")") {
+			return c, nil
+		}
+		if !p.symbol(",") {
+			return FilterCondition{}, fmt.Errorf("expected , or ), got %q", p.peek().text)
+		}
+	}
+}
+
+func (p *filterParser) value() (string, error) {
+	t := p.peek()
+	if p.done() || t.kind == filterSymbol {
+		return "", fmt.Errorf("expected value, got %q", t.text)
+	}
+	p.pos++
+	return t.text, nil
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFetchFilter(t *testing.T) {
+	tests := []struct {
+		expr       string
+		conditions []FilterCondition
+		err        string
+	}{
+		{
+			expr:       "region in ('us-east-1')",
+			conditions: []FilterCondition{{Column: "region", Operator: FilterIn, Values: []string{"us-east-1"}}},
+		},
+		{
+			expr: "region IN ('us-east-1', us-west-2) AND state != 'it''s terminated' and id <> 3 and kind NOT IN (a) and name = x",
+			conditions: []FilterCondition{
+				{Column: "region", Operator: FilterIn, Values: []string{"us-east-1", "us-west-2"}},
+				{Column: "state", Operator: FilterNotEqual, Values: []string{"it's terminated"}},
+				{Column: "id", Operator: FilterNotEqual, Values: []string{"3"}},
+				{Column: "kind", Operator: FilterNotIn, Values: []string{"a"}},
+				{Column: "name", Operator: FilterEqual, Values: []string{"x"}},
+			},
+		},
+		{expr: "", err: `invalid filter "": expected column, got "end of filter"`},
+		{expr: "region in ('us-east-1'", err: `invalid filter "region in ('us-east-1'": expected , or ), got "end of filter"`},
+		{expr: "region = 'a' or region = 'b'", err: `invalid filter "region = 'a' or region = 'b'": expected AND, got "or"`},
+		{expr: "region not ('a')", err: `invalid filter "region not ('a')": expected IN after NOT, got "("`},
+		{expr: "region <= 'a'", err: `invalid filter "region <= 'a'": unexpected "<"`},
+		{expr: "region = 'a", err: `invalid filter "region = 'a": unterminated string`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			f, err := ParseFetchFilter(tc.expr)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expr, f.Expression)
+			assert.Equal(t, tc.conditions, f.Conditions)
+		})
+	}
+}
+
+func TestFetchFilter_Match(t *testing.T) {
+	table := &Table{Name: "test_filter", Columns: []Column{{Name: "region", Type: TypeString}, {Name: "size", Type: TypeInt}}}
+	f, err := ParseFetchFilter("region in ('us-east-1', 'us-west-2') and size != 0")
+	require.NoError(t, err)
+	require.NoError(t, f.Validate(table))
+	regions, ok := f.Values("region")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"us-east-1", "us-west-2"}, regions)
+	_, ok = f.Values("size")
+	assert.False(t, ok)
+
+	resource := func(region interface{}, size int) *Resource {
+		r := NewResourceData(PostgresDialect{}, table, nil, nil, nil, time.Now())
+		require.NoError(t, r.Set("region", region))
+		require.NoError(t, r.Set("size", size))
+		return r
+	}
+	west := "us-west-2"
+	assert.True(t, f.Match(resource("us-east-1", 1)))
+	assert.True(t, f.Match(resource(&west, 2)))
+	assert.False(t, f.Match(resource("eu-west-1", 1)))
+	assert.False(t, f.Match(resource("us-east-1", 0)))
+	assert.False(t, f.Match(resource(nil, 1)))
+
+	unknown, err := ParseFetchFilter("zone = a")
+	require.NoError(t, err)
+	assert.EqualError(t, unknown.Validate(table), "filter column zone isn't a column of table test_filter")
+
+	ctx := WithFetchFilter(context.Background(), f)
+	got, ok := FetchFilterValue(ctx)
+	assert.True(t, ok)
+	assert.Same(t, f, got)
+	_, ok = FetchFilterValue(context.Background())
+	assert.False(t, ok)
+}
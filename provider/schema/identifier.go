@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var validIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateIdentifier returns an error if name isn't a valid table or column name, that is letters, digits and
+// underscores, not starting with a digit
+func ValidateIdentifier(name string) error {
+	if !validIdentifier.MatchString(name) {
+		return fmt.Errorf("invalid identifier %q, must contain only letters, digits and underscores and not start with a digit", name)
+	}
+	return nil
+}
+
+// QuoteIdentifier quotes name as an SQL identifier, escaping any double quotes in it. Used by the postgres dialects and
+// by SQL built outside a dialect, dialects with other quoting rules implement Dialect.QuoteIdentifier.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteIdentifiers quotes each of names with the dialect's QuoteIdentifier
+func QuoteIdentifiers(d Dialect, names []string) []string {
+	ret := make([]string, len(names))
+	for i, n := range names {
+		ret[i] = d.QuoteIdentifier(n)
+	}
+	return ret
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, `"aws_ec2_instances"`, QuoteIdentifier("aws_ec2_instances"))
+	assert.Equal(t, `"odd""name"`, QuoteIdentifier(`odd"name`))
+	assert.Equal(t, []string{`"cq_id"`, `"name"`}, QuoteIdentifiers(PostgresDialect{}, []string{"cq_id", "name"}))
+	assert.Equal(t, `"name"`, TSDBDialect{}.QuoteIdentifier("name"))
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	for _, name := range []string{"aws_ec2_instances", "_private", "column1", "camelCase"} {
+		assert.NoError(t, ValidateIdentifier(name), name)
+	}
+	for _, name := range []string{"", "1column", "with space", "with-dash", `odd"name`, "drop;table", "schema.table"} {
+		assert.Error(t, ValidateIdentifier(name), name)
+	}
+
+	assert.Error(t, ValidateTable(&Table{Name: "bad-table"}))
+	assert.Error(t, ValidateTable(&Table{Name: "parent", Relations: []*Table{{Name: "child", Columns: []Column{{Name: "bad column", Type: TypeString}}}}}))
+}
+
+func TestConstraintsQuoted(t *testing.T) {
+	parent := &Table{Name: "parent_table"}
+	child := &Table{
+		Name:    "child_table",
+		Options: TableCreationOptions{PrimaryKeys: []string{"parent_cq_id", "name"}},
+		Columns: []Column{{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver}, {Name: "name", Type: TypeString}},
+	}
+	assert.Equal(t, []string{
+		`CONSTRAINT "child_table_pk" PRIMARY KEY("parent_cq_id","name")`,
+		`UNIQUE("cq_id")`,
+		`FOREIGN KEY ("parent_cq_id") REFERENCES "parent_table"("cq_id") ON DELETE CASCADE`,
+	}, PostgresDialect{}.Constraints(child, parent))
+}
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"context"
+	"time"
+)
+
+type cursorKey struct{}
+
+// Incremental fetches only the resources of a top level table that changed since the previous fetch. The SDK keeps the
+// largest value of the CursorColumn stored by each client, i.e the resources' update time, and passes it to the
+// table's Resolver in the next fetch, see CursorValue. Resolvers with a cursor should request only the resources
+// changed since it. Stale data of clients fetched with a cursor isn't removed, as unchanged resources aren't fetched
+// again.
+type Incremental struct {
+	// CursorColumn is the column compared between fetches, of type TypeTimestamp, TypeTimestampTZ, TypeSmallInt,
+	// TypeInt, TypeBigInt or TypeString. Its values must grow as resources change.
+	CursorColumn string
+}
+
+// CursorTypes are the types of columns that can be a cursor of Incremental tables
+var CursorTypes = []ValueType{TypeTimestamp, TypeTimestampTZ, TypeSmallInt, TypeInt, TypeBigInt, TypeString}
+
+// WithCursor returns a copy of ctx carrying the cursor of the table stored by the previous fetch. The executor calls it
+// before calling the resolver of an Incremental table.
+func WithCursor(ctx context.Context, cursor string) context.Context {
+	return context.WithValue(ctx, cursorKey{}, cursor)
+}
+
+// CursorValue returns the cursor stored for the client by the previous fetch of an Incremental table, the largest value
+// of its cursor column. Timestamps are formatted as time.RFC3339Nano in UTC, see CursorTime. Returns false on the first
+// fetch of the client, or if the fetch is a full refresh, in which case the resolver should fetch all the resources.
+func CursorValue(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(cursorKey{}).(string)
+	return v, ok && v != ""
+}
+
+// CursorTime returns the cursor of a timestamp cursor column, see CursorValue
+func CursorTime(ctx context.Context) (time.Time, bool) {
+	v, ok := CursorValue(ctx)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"reflect"
+
+	"github.com/iancoleman/strcase"
+)
+
+// UnmappedColumns returns the names of the columns of t that have no Resolver, and no field in item reachable by the
+// default resolving path, i.e the camel cased column name. Columns inheriting from the parent, or having
+// Column.FallbackPaths, are mapped by other paths and aren't returned. item is an example of the objects the table's resolver
+// sends, such as ec2.Instance{}. Unmapped columns are always null, usually because of a renamed or acronym field, i.e
+// the column instance_id of a struct with an InstanceID field.
+//
+// Map and interface values can't be checked statically and are considered mapped.
+func UnmappedColumns(t *Table, item interface{}) []string {
+	typ := reflect.TypeOf(item)
+	var unmapped []string
+	for _, c := range t.Columns {
+		if c.Resolver != nil || c.internal || c.InheritFromParent != "" || len(c.FallbackPaths) > 0 {
+			continue
+		}
+		if !hasDefaultPath(typ, strcase.ToCamel(c.Name)) {
+			unmapped = append(unmapped, c.Name)
+		}
+	}
+	return unmapped
+}
+
+// hasDefaultPath reports whether a value of typ has the field name, as resolved by the default column resolver
+func hasDefaultPath(typ reflect.Type, name string) bool {
+	if typ == nil {
+		return false
+	}
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		_, ok := typ.FieldByName(name)
+		return ok
+	case reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mappingTestEmbedded struct {
+	Region string
+}
+
+type mappingTestItem struct {
+	mappingTestEmbedded
+	Name       string
+	InstanceID string
+	Tags       map[string]string
+}
+
+func TestUnmappedColumns(t *testing.T) {
+	table := &Table{
+		Name: "mapping_table",
+		Columns: []Column{
+			{Name: "name", Type: TypeString},
+			{Name: "region", Type: TypeString},
+			{Name: "instance_id", Type: TypeString},
+			{Name: "tags", Type: TypeJSON},
+			{Name: "arn", Type: TypeString, Resolver: func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error {
+				return nil
+			}},
+			{Name: "missing", Type: TypeString},
+			{Name: "account_id", Type: TypeString, InheritFromParent: "account_id"},
+			{Name: "zone", Type: TypeString, FallbackPaths: []string{"Placement.Zone"}},
+		},
+	}
+	assert.Equal(t, []string{"instance_id", "missing"}, UnmappedColumns(table, mappingTestItem{}))
+	assert.Equal(t, []string{"instance_id", "missing"}, UnmappedColumns(table, &mappingTestItem{}))
+	assert.Equal(t, []string{"instance_id", "missing"}, UnmappedColumns(table, []*mappingTestItem{}))
+	assert.Empty(t, UnmappedColumns(table, map[string]interface{}{}))
+}
@@ -20,10 +20,22 @@ type ClientIdentifier interface {
 type Meta struct {
 	LastUpdate time.Time `json:"last_updated"`
 	FetchId    string    `json:"fetch_id,omitempty"`
+	// ResolveDurationMs is the time spent resolving the resource's columns and post resource resolver, in milliseconds
+	ResolveDurationMs int64 `json:"resolve_duration_ms"`
+	// FallbackColumns are the columns left unset because their resolver returned an ignored error
+	FallbackColumns []string `json:"fallback_columns,omitempty"`
+	// ValueSources maps columns to the fallback path their value was taken from, see Column.FallbackPaths
+	ValueSources map[string]string `json:"value_sources,omitempty"`
 }
 
 const FetchIdMetaKey = "cq_fetch_id"
 
+// SequenceColumnName is the name of the internal column added to tables with TableCreationOptions.Sequence
+const SequenceColumnName = "cq_sequence"
+
+// DeletedAtColumnName is the name of the internal column added to tables with TableCreationOptions.SoftDelete
+const DeletedAtColumnName = "cq_deleted_at"
+
 var (
 	cqMeta = Column{
 		Name:        "cq_meta",
@@ -31,7 +43,10 @@ var (
 		Description: "Meta column holds fetch information",
 		Resolver: func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error {
 			mi := Meta{
-				LastUpdate: time.Now().UTC(),
+				LastUpdate:        time.Now().UTC(),
+				ResolveDurationMs: time.Since(resource.resolveStart).Milliseconds(),
+				FallbackColumns:   resource.fallbackColumns,
+				ValueSources:      resource.valueSources,
 			}
 			if val, ok := resource.GetMeta(FetchIdMetaKey); ok {
 				if s, ok := val.(string); ok {
@@ -63,6 +78,26 @@ var (
 		},
 		internal: true,
 	}
+	cqSequenceColumn = Column{
+		Name:        SequenceColumnName,
+		Type:        TypeBigInt,
+		Description: "Order in which the resource was resolved in the fetch, unique per table and fetch",
+		// the value is assigned by the executor once the resource is resolved
+		Resolver: func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error {
+			return nil
+		},
+		internal: true,
+	}
+	cqDeletedAtColumn = Column{
+		Name:        DeletedAtColumnName,
+		Type:        TypeTimestamp,
+		Description: "Time the resource was found deleted, null while it exists",
+		// resources are written as existing, stale resources are marked by the storage
+		Resolver: func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error {
+			return nil
+		},
+		internal: true,
+	}
 	cqFetchDateColumn = Column{
 		Name:        "cq_fetch_date",
 		Type:        TypeTimestamp,
@@ -8,7 +8,6 @@ import (
 	context "context"
 	io "io"
 	reflect "reflect"
-	time "time"
 
 	execution "github.com/cloudquery/cq-provider-sdk/provider/execution"
 	schema "github.com/cloudquery/cq-provider-sdk/provider/schema"
@@ -190,7 +189,7 @@ func (mr *MockStorageMockRecorder) RawCopyTo(arg0, arg1, arg2 interface{}) *gomo
 }
 
 // RemoveStaleData mocks base method.
-func (m *MockStorage) RemoveStaleData(arg0 context.Context, arg1 *schema.Table, arg2 time.Time, arg3 []interface{}) error {
+func (m *MockStorage) RemoveStaleData(arg0 context.Context, arg1 *schema.Table, arg2 execution.StaleFilter, arg3 []interface{}) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RemoveStaleData", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,244 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thoas/go-funk"
+)
+
+type pathSegmentKind int
+
+const (
+	// segmentField is a struct field, or a map key, selected with a dot
+	segmentField pathSegmentKind = iota
+	// segmentIndex is an element of a slice, selected with [n]
+	segmentIndex
+	// segmentKey is a value of a map, selected with [key] or ["key"]
+	segmentKey
+	// segmentWildcard is every element of a slice or value of a map, selected with [*]
+	segmentWildcard
+)
+
+type pathSegment struct {
+	kind  pathSegmentKind
+	name  string
+	index int
+}
+
+// valuePath is a parsed path of a value in a resource's item, see PathResolver for its syntax
+type valuePath struct {
+	path     string
+	segments []pathSegment
+}
+
+// parseValuePath parses the path, paths without brackets are resolved with funk.Get as before
+func parseValuePath(path string) (*valuePath, error) {
+	p := &valuePath{path: path}
+	if !strings.Contains(path, "[") {
+		return p, nil
+	}
+	rest := path
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, fmt.Errorf("invalid path %q: empty field name", path)
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if strings.HasPrefix(rest, `["`) {
+				if q := strings.Index(rest[2:], `"]`); q >= 0 {
+					end = q + 3
+				} else {
+					end = -1
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket", path)
+			}
+			s, err := parseBracketSegment(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", path, err)
+			}
+			p.segments = append(p.segments, s)
+			rest = rest[end+1:]
+			continue
+		}
+		n := strings.IndexAny(rest, ".[")
+		if n < 0 {
+			n = len(rest)
+		}
+		if n == 0 {
+			continue
+		}
+		p.segments = append(p.segments, pathSegment{kind: segmentField, name: rest[:n]})
+		rest = rest[n:]
+	}
+	return p, nil
+}
+
+func parseBracketSegment(s string) (pathSegment, error) {
+	switch {
+	case s == "*":
+		return pathSegment{kind: segmentWildcard}, nil
+	case s == "":
+		return pathSegment{}, fmt.Errorf("empty brackets")
+	case strings.HasPrefix(s, `"`):
+		key, err := strconv.Unquote(s)
+		if err != nil {
+			return pathSegment{}, fmt.Errorf("invalid key %s", s)
+		}
+		return pathSegment{kind: segmentKey, name: key}, nil
+	}
+	if i, err := strconv.Atoi(s); err == nil {
+		if i < 0 {
+			return pathSegment{}, fmt.Errorf("negative index %d", i)
+		}
+		return pathSegment{kind: segmentIndex, index: i}, nil
+	}
+	return pathSegment{kind: segmentKey, name: s}, nil
+}
+
+// pathGetter returns the value of a path in v, see PathResolver
+type pathGetter func(v interface{}) (interface{}, error)
+
+// newPathGetter parses the path once, for resolvers getting it from the item of each resource. Resolver constructors
+// can't return errors, so an invalid path fails each time the resolver is called.
+func newPathGetter(path string) pathGetter {
+	p, err := parseValuePath(path)
+	if err != nil {
+		return func(interface{}) (interface{}, error) {
+			return nil, err
+		}
+	}
+	return func(v interface{}) (interface{}, error) {
+		return p.get(v), nil
+	}
+}
+
+// get returns the value of the path in v, nil if any of its segments doesn't exist
+func (p *valuePath) get(v interface{}) interface{} {
+	if p.segments == nil {
+		return funk.Get(v, p.path, funk.WithAllowZero())
+	}
+	value, _ := getSegments(reflect.ValueOf(v), p.segments)
+	return value
+}
+
+// getSegments returns the value of the segments in v, collected is true if it's a slice of values collected by a
+// wildcard, or a field of a slice's elements
+func getSegments(v reflect.Value, segments []pathSegment) (value interface{}, collected bool) {
+	for i, s := range segments {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return nil, false
+		}
+		switch s.kind {
+		case segmentField:
+			switch v.Kind() {
+			case reflect.Struct:
+				v = v.FieldByName(s.name)
+			case reflect.Map:
+				v = mapValue(v, s.name)
+			case reflect.Slice, reflect.Array:
+				// as with funk.Get, the field is collected from each element
+				return collectSegments(v, segments[i:]), true
+			default:
+				return nil, false
+			}
+		case segmentIndex:
+			if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || s.index >= v.Len() {
+				return nil, false
+			}
+			v = v.Index(s.index)
+		case segmentKey:
+			if v.Kind() != reflect.Map {
+				return nil, false
+			}
+			v = mapValue(v, s.name)
+		case segmentWildcard:
+			switch v.Kind() {
+			case reflect.Slice, reflect.Array:
+				return collectSegments(v, segments[i+1:]), true
+			case reflect.Map:
+				return collectSegments(mapValues(v), segments[i+1:]), true
+			default:
+				return nil, false
+			}
+		}
+		if !v.IsValid() {
+			return nil, false
+		}
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	return v.Interface(), false
+}
+
+// collectSegments returns the values of the segments in each element of v, values collected of nested slices are
+// flattened and nil values are skipped. The values are returned as a slice of their type, i.e []*string, if they are of
+// the same type.
+func collectSegments(v reflect.Value, segments []pathSegment) interface{} {
+	var values []interface{}
+	for i := 0; i < v.Len(); i++ {
+		value, collected := getSegments(v.Index(i), segments)
+		if collected {
+			if value != nil {
+				items := reflect.ValueOf(value)
+				for j := 0; j < items.Len(); j++ {
+					values = append(values, items.Index(j).Interface())
+				}
+			}
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			continue
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	t := reflect.TypeOf(values[0])
+	for _, value := range values[1:] {
+		if reflect.TypeOf(value) != t {
+			return values
+		}
+	}
+	typed := reflect.MakeSlice(reflect.SliceOf(t), len(values), len(values))
+	for i, value := range values {
+		typed.Index(i).Set(reflect.ValueOf(value))
+	}
+	return typed.Interface()
+}
+
+func mapValue(m reflect.Value, key string) reflect.Value {
+	if m.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}
+	}
+	return m.MapIndex(reflect.ValueOf(key).Convert(m.Type().Key()))
+}
+
+// mapValues returns the values of m as a slice, in the order of their keys
+func mapValues(m reflect.Value) reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	values := reflect.MakeSlice(reflect.SliceOf(m.Type().Elem()), len(keys), len(keys))
+	for i, k := range keys {
+		values.Index(i).Set(m.MapIndex(k))
+	}
+	return values
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PolicyAction defines what happens to the values of restricted columns when writing them to a destination
+type PolicyAction string
+
+const (
+	// PolicyActionOmit writes restricted columns as NULL
+	PolicyActionOmit PolicyAction = "omit"
+	// PolicyActionMask replaces string values of restricted columns with a mask, other types are written as NULL
+	PolicyActionMask PolicyAction = "mask"
+)
+
+const policyMask = "****"
+
+// ColumnPolicies maps a policy tag (see Column.Restricted) to the action applied to the columns restricted under it.
+// Columns restricted under a tag that is not in the map are written as is.
+type ColumnPolicies map[string]PolicyAction
+
+// ParseColumnPolicies builds ColumnPolicies from policy tag to action name pairs, i.e {"pii": "mask"}
+func ParseColumnPolicies(in map[string]string) (ColumnPolicies, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	p := make(ColumnPolicies, len(in))
+	for tag, action := range in {
+		switch a := PolicyAction(strings.ToLower(action)); a {
+		case PolicyActionOmit, PolicyActionMask:
+			p[tag] = a
+		default:
+			return nil, fmt.Errorf("unknown column policy action %q for policy %q", action, tag)
+		}
+	}
+	return p, nil
+}
+
+// Apply omits or masks the values of restricted columns of the resource, according to the policies.
+// Apply should be called after all columns were resolved as restricted values take part in cq_id generation.
+func (p ColumnPolicies) Apply(r *Resource) {
+	if len(p) == 0 {
+		return
+	}
+	for _, c := range r.table.Columns {
+		if c.Restricted == "" {
+			continue
+		}
+		action, ok := p[c.Restricted]
+		if !ok {
+			continue
+		}
+		if action == PolicyActionMask {
+			switch v := r.data[c.Name].(type) {
+			case string:
+				r.data[c.Name] = policyMask
+				continue
+			case *string:
+				if v != nil {
+					r.data[c.Name] = policyMask
+				}
+				continue
+			case []string:
+				masked := make([]string, len(v))
+				for i := range masked {
+					masked[i] = policyMask
+				}
+				r.data[c.Name] = masked
+				continue
+			}
+		}
+		r.data[c.Name] = nil
+	}
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRestrictedTable = &Table{
+	Name: "test_restricted_table",
+	Columns: []Column{
+		{Name: "name", Type: TypeString},
+		{Name: "email", Type: TypeString, Restricted: "pii"},
+		{Name: "phones", Type: TypeStringArray, Restricted: "pii"},
+		{Name: "age", Type: TypeInt, Restricted: "pii"},
+		{Name: "salary", Type: TypeInt, Restricted: "finance"},
+	},
+}
+
+func TestParseColumnPolicies(t *testing.T) {
+	p, err := ParseColumnPolicies(map[string]string{"pii": "MASK", "finance": "omit"})
+	require.NoError(t, err)
+	assert.Equal(t, ColumnPolicies{"pii": PolicyActionMask, "finance": PolicyActionOmit}, p)
+
+	_, err = ParseColumnPolicies(map[string]string{"pii": "drop"})
+	assert.Error(t, err)
+
+	p, err = ParseColumnPolicies(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestColumnPolicies_Apply(t *testing.T) {
+	newResource := func() *Resource {
+		r := NewResourceData(PostgresDialect{}, testRestrictedTable, nil, nil, nil, time.Now())
+		_ = r.Set("name", "test")
+		_ = r.Set("email", "test@example.com")
+		_ = r.Set("phones", []string{"1", "2"})
+		_ = r.Set("age", 30)
+		_ = r.Set("salary", 100)
+		return r
+	}
+
+	r := newResource()
+	ColumnPolicies(nil).Apply(r)
+	assert.Equal(t, "test@example.com", r.Get("email"))
+
+	r = newResource()
+	ColumnPolicies{"pii": PolicyActionMask}.Apply(r)
+	assert.Equal(t, "test", r.Get("name"))
+	assert.Equal(t, policyMask, r.Get("email"))
+	assert.Equal(t, []string{policyMask, policyMask}, r.Get("phones"))
+	assert.Nil(t, r.Get("age"))
+	assert.Equal(t, 100, r.Get("salary"))
+
+	r = newResource()
+	ColumnPolicies{"pii": PolicyActionOmit, "finance": PolicyActionOmit}.Apply(r)
+	assert.Equal(t, "test", r.Get("name"))
+	assert.Nil(t, r.Get("email"))
+	assert.Nil(t, r.Get("phones"))
+	assert.Nil(t, r.Get("salary"))
+}
@@ -2,14 +2,16 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/helpers"
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
-	"github.com/thoas/go-funk"
 )
 
 // PathTableResolver resolves a table in the parent.Item
@@ -19,21 +21,36 @@ import (
 // PathTableResolver("InnerStruct.Field")
 // PathTableResolver("InnerStruct.InnerInnerStruct.Field")
 func PathTableResolver(path string) TableResolver {
+	get := newPathGetter(path)
 	return func(ctx context.Context, meta ClientMeta, parent *Resource, res chan<- interface{}) error {
-		res <- funk.Get(parent.Item, path, funk.WithAllowZero())
+		value, err := get(parent.Item)
+		if err != nil {
+			return err
+		}
+		res <- value
 		return nil
 	}
 }
 
-// PathResolver resolves a field in the Resource.Item
+// PathResolver resolves a field in the Resource.Item. Elements of slices are selected by their index, values of maps by
+// their key, and [*] collects the values of the path in every element of a slice, or value of a map, into a slice of
+// their type. Paths without brackets are resolved as before with funk.Get, collecting fields of slices' elements.
 //
 // Examples:
 // PathResolver("Field")
 // PathResolver("InnerStruct.Field")
 // PathResolver("InnerStruct.InnerInnerStruct.Field")
+// PathResolver("Spec.Containers[*].Image")
+// PathResolver("Tags[0].Value")
+// PathResolver(`Labels["app.kubernetes.io/name"]`)
 func PathResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		return r.Set(c.Name, funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		return r.Set(c.Name, value)
 	}
 }
 
@@ -53,8 +70,13 @@ func ParentResourceFieldResolver(name string) ColumnResolver {
 
 // ParentPathResolver resolves a field from the parent
 func ParentPathResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, _ ClientMeta, r *Resource, c Column) error {
-		return r.Set(c.Name, funk.Get(r.Parent.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Parent.Item)
+		if err != nil {
+			return err
+		}
+		return r.Set(c.Name, value)
 	}
 }
 
@@ -65,8 +87,13 @@ func ParentPathResolver(path string) ColumnResolver {
 // DateUTCResolver("InnerStruct.Field", time.RFC822)  - resolves using time.RFC822
 // DateUTCResolver("InnerStruct.Field", time.RFC822, "2011-10-05")  - resolves using a few resolvers one by one
 func DateUTCResolver(path string, rfcs ...string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		data, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		data, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -85,8 +112,13 @@ func DateUTCResolver(path string, rfcs ...string) ColumnResolver {
 // DateResolver("InnerStruct.Field", time.RFC822)  - resolves using time.RFC822
 // DateResolver("InnerStruct.Field", time.RFC822, "2011-10-05")  - resolves using a few resolvers one by one
 func DateResolver(path string, rfcs ...string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		data, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		data, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -98,6 +130,40 @@ func DateResolver(path string, rfcs ...string) ColumnResolver {
 	}
 }
 
+// TimestampTZResolver resolves time.Time values, or the different date formats (ISODate - 2011-10-05T14:48:00.000Z is
+// default) into *time.Time keeping their time zone, for TypeTimestampTZ columns
+//
+// Examples:
+// TimestampTZResolver("CreatedAt") - resolves using RFC.RFC3339 as default
+// TimestampTZResolver("InnerStruct.Field", time.RFC1123Z)  - resolves using time.RFC1123Z
+func TimestampTZResolver(path string, rfcs ...string) ColumnResolver {
+	get := newPathGetter(path)
+	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case time.Time:
+			return r.Set(c.Name, v)
+		case *time.Time:
+			return r.Set(c.Name, v)
+		case nil:
+			return r.Set(c.Name, nil)
+		default:
+			data, err := cast.ToStringE(v)
+			if err != nil {
+				return err
+			}
+			date, err := parseDate(data, rfcs...)
+			if err != nil {
+				return err
+			}
+			return r.Set(c.Name, date)
+		}
+	}
+}
+
 func parseDate(dateStr string, rfcs ...string) (date *time.Time, err error) {
 	if dateStr == "" {
 		return nil, nil
@@ -124,8 +190,13 @@ func parseDate(dateStr string, rfcs ...string) (date *time.Time, err error) {
 // Examples:
 // IPAddressResolver("IP")
 func IPAddressResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		ipStr, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		ipStr, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -145,8 +216,13 @@ func IPAddressResolver(path string) ColumnResolver {
 // Examples:
 // IPAddressesResolver("IP")
 func IPAddressesResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		ipStrs, err := helpers.ToStringSliceE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		ipStrs, err := helpers.ToStringSliceE(value)
 		if err != nil {
 			return err
 		}
@@ -170,8 +246,13 @@ func IPAddressesResolver(path string) ColumnResolver {
 // Examples:
 // MACAddressResolver("MAC")
 func MACAddressResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		macStr, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		macStr, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -188,8 +269,13 @@ func MACAddressResolver(path string) ColumnResolver {
 // Examples:
 // IPNetResolver("Network")
 func IPNetResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		ipStr, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		ipStr, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -206,8 +292,13 @@ func IPNetResolver(path string) ColumnResolver {
 // Examples:
 // UUIDResolver("Resource.UUID")
 func UUIDResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		uuidString, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		uuidString, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -220,13 +311,54 @@ func UUIDResolver(path string) ColumnResolver {
 	}
 }
 
+// DecimalResolver resolves numbers or numeric strings into decimal.Decimal, for TypeDecimal columns. Prefer passing
+// strings, floats lose precision before they are resolved.
+//
+// Examples:
+// DecimalResolver("Price")
+func DecimalResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
+	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case decimal.Decimal:
+			return r.Set(c.Name, v)
+		case *decimal.Decimal:
+			return r.Set(c.Name, v)
+		case nil:
+			return r.Set(c.Name, nil)
+		default:
+			str, err := cast.ToStringE(v)
+			if err != nil {
+				return err
+			}
+			if str == "" {
+				return r.Set(c.Name, nil)
+			}
+			d, err := decimal.NewFromString(str)
+			if err != nil {
+				return err
+			}
+			return r.Set(c.Name, d)
+		}
+	}
+}
+
 // StringResolver tries to cast value into string
 //
 // Examples:
 // StringResolver("Id")
 func StringResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		str, err := cast.ToStringE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		str, err := cast.ToStringE(value)
 		if err != nil {
 			return err
 		}
@@ -240,8 +372,13 @@ func StringResolver(path string) ColumnResolver {
 // Examples:
 // IntResolver("Id")
 func IntResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
 	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
-		i, err := cast.ToIntE(funk.Get(r.Item, path, funk.WithAllowZero()))
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		i, err := cast.ToIntE(value)
 		if err != nil {
 			return err
 		}
@@ -249,3 +386,57 @@ func IntResolver(path string) ColumnResolver {
 		return r.Set(c.Name, i)
 	}
 }
+
+// JSONResolver resolves a nested struct, or any value, into its JSON value for TypeJSON columns, stripping the
+// omitted fields, i.e secrets or huge blobs. Omitted fields are dotted paths of the fields' JSON names, fields of
+// slices' elements are stripped from each element.
+//
+// Examples:
+// JSONResolver("Spec")
+// JSONResolver("Spec", "Credentials.Password", "Containers.Env")
+func JSONResolver(path string, omitFields ...string) ColumnResolver {
+	get := newPathGetter(path)
+	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		v, err := JSONValue(value, omitFields...)
+		if err != nil {
+			return err
+		}
+		return r.Set(c.Name, v)
+	}
+}
+
+// JSONValue returns the JSON value of v, objects are maps and arrays are slices, without the omitted fields as with
+// JSONResolver
+func JSONValue(v interface{}, omitFields ...string) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	for _, f := range omitFields {
+		omitJSONField(value, strings.Split(f, "."))
+	}
+	return value, nil
+}
+
+func omitJSONField(v interface{}, path []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		omitJSONField(v[path[0]], path[1:])
+	case []interface{}:
+		for _, item := range v {
+			omitJSONField(item, path)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cloudquery/cq-provider-sdk/helpers"
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -205,6 +206,79 @@ func TestPathResolver(t *testing.T) {
 	}
 }
 
+func TestPathResolverExpressions(t *testing.T) {
+	type container struct {
+		Image *string
+		Ports []int
+	}
+	type tag struct {
+		Key   string
+		Value string
+	}
+	type item struct {
+		Spec struct {
+			Containers []*container
+		}
+		Tags   []tag
+		Labels map[string]string
+		Groups map[string][]tag
+	}
+	image := func(s string) *string { return &s }
+	var i item
+	i.Spec.Containers = []*container{{Image: image("nginx"), Ports: []int{80, 443}}, {Ports: []int{8080}}, {Image: image("redis")}}
+	i.Tags = []tag{{Key: "env", Value: "prod"}, {Key: "team", Value: "core"}}
+	i.Labels = map[string]string{"app.kubernetes.io/name": "web", "tier": "frontend"}
+	i.Groups = map[string][]tag{"b": {{Key: "x"}}, "a": {{Key: "y"}, {Key: "z"}}}
+
+	table := &Table{Columns: []Column{{Name: "value", Type: TypeJSON}}}
+	tests := []struct {
+		path  string
+		value interface{}
+		err   bool
+	}{
+		{path: "Spec.Containers[*].Image", value: []*string{image("nginx"), image("redis")}},
+		{path: "Spec.Containers[*].Ports[*]", value: []int{80, 443, 8080}},
+		{path: "Spec.Containers[1].Ports[0]", value: 8080},
+		{path: "Spec.Containers[5].Image", value: nil},
+		{path: "Tags[0].Value", value: "prod"},
+		{path: "Tags[*].Key", value: []string{"env", "team"}},
+		{path: `Labels["app.kubernetes.io/name"]`, value: "web"},
+		{path: "Labels[tier]", value: "frontend"},
+		{path: "Labels[missing]", value: nil},
+		{path: "Groups[*][*].Key", value: []string{"y", "z", "x"}},
+		{path: "Groups[a][1].Key", value: "z"},
+		{path: "Tags[0", err: true},
+		{path: "Tags[-1]", err: true},
+		{path: "Tags[0]..Value", err: true},
+	}
+	for _, test := range tests {
+		resource := NewResourceData(PostgresDialect{}, table, nil, i, nil, time.Now())
+		err := PathResolver(test.path)(context.TODO(), nil, resource, Column{Name: "value"})
+		if test.err {
+			assert.Error(t, err, "path: %s", test.path)
+			continue
+		}
+		assert.NoError(t, err, "path: %s", test.path)
+		assert.Equal(t, test.value, resource.Get("value"), "path: %s", test.path)
+	}
+}
+
+func TestPathResolverReused(t *testing.T) {
+	type item struct {
+		Tags []string
+	}
+	table := &Table{Columns: []Column{{Name: "value", Type: TypeJSON}}}
+	// the path is parsed once, when the resolver is created
+	resolver := PathResolver("Tags[1]")
+	invalid := PathResolver("Tags[1")
+	for _, tags := range [][]string{{"a", "b"}, {"c", "d"}} {
+		resource := NewResourceData(PostgresDialect{}, table, nil, item{Tags: tags}, nil, time.Now())
+		assert.NoError(t, resolver(context.TODO(), nil, resource, Column{Name: "value"}))
+		assert.Equal(t, tags[1], resource.Get("value"))
+		assert.EqualError(t, invalid(context.TODO(), nil, resource, Column{Name: "value"}), `invalid path "Tags[1": unclosed bracket`)
+	}
+}
+
 func TestInterfaceSlice(t *testing.T) {
 	var sType []interface{}
 	var names []string
@@ -320,3 +394,73 @@ func TestUUIDResolver(t *testing.T) {
 	err = r2(context.TODO(), nil, resource, Column{Name: "uuid"})
 	assert.Error(t, err)
 }
+
+func TestDecimalResolver(t *testing.T) {
+	table := &Table{Columns: []Column{{Name: "price", Type: TypeDecimal}}}
+	for _, v := range []interface{}{"10.25", 10.25, decimal.RequireFromString("10.25")} {
+		resource := NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"Price": v}, nil, time.Now())
+		assert.NoError(t, DecimalResolver("Price")(context.TODO(), nil, resource, table.Columns[0]))
+		assert.True(t, decimal.RequireFromString("10.25").Equal(resource.Get("price").(decimal.Decimal)))
+	}
+
+	resource := NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"Price": ""}, nil, time.Now())
+	assert.NoError(t, DecimalResolver("Price")(context.TODO(), nil, resource, table.Columns[0]))
+	assert.Nil(t, resource.Get("price"))
+
+	resource = NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"Price": "10,25"}, nil, time.Now())
+	assert.Error(t, DecimalResolver("Price")(context.TODO(), nil, resource, table.Columns[0]))
+}
+
+func TestTimestampTZResolver(t *testing.T) {
+	table := &Table{Columns: []Column{{Name: "created_at", Type: TypeTimestampTZ}}}
+	resource := NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"CreatedAt": "2011-10-05T14:48:00+02:00"}, nil, time.Now())
+	assert.NoError(t, TimestampTZResolver("CreatedAt")(context.TODO(), nil, resource, table.Columns[0]))
+	date := resource.Get("created_at").(*time.Time)
+	_, offset := date.Zone()
+	assert.Equal(t, 2*60*60, offset)
+	assert.True(t, time.Date(2011, 10, 5, 12, 48, 0, 0, time.UTC).Equal(*date))
+
+	now := time.Now()
+	resource = NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"CreatedAt": now}, nil, time.Now())
+	assert.NoError(t, TimestampTZResolver("CreatedAt")(context.TODO(), nil, resource, table.Columns[0]))
+	assert.Equal(t, now, resource.Get("created_at"))
+
+	resource = NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"CreatedAt": "05 Oct 11"}, nil, time.Now())
+	assert.Error(t, TimestampTZResolver("CreatedAt")(context.TODO(), nil, resource, table.Columns[0]))
+}
+
+func TestJSONResolver(t *testing.T) {
+	type container struct {
+		Image string
+		Env   map[string]string `json:"env"`
+	}
+	type spec struct {
+		Name        string
+		Credentials struct {
+			User     string
+			Password string
+		}
+		Containers []container
+	}
+	item := map[string]interface{}{"Spec": spec{
+		Name: "test",
+		Credentials: struct {
+			User     string
+			Password string
+		}{User: "admin", Password: "secret"},
+		Containers: []container{{Image: "a", Env: map[string]string{"KEY": "v"}}, {Image: "b"}},
+	}}
+	table := &Table{Columns: []Column{{Name: "spec", Type: TypeJSON}}}
+
+	resource := NewResourceData(PostgresDialect{}, table, nil, item, nil, time.Now())
+	assert.NoError(t, JSONResolver("Spec", "Credentials.Password", "Containers.env", "Missing.Field")(context.TODO(), nil, resource, table.Columns[0]))
+	assert.Equal(t, map[string]interface{}{
+		"Name":        "test",
+		"Credentials": map[string]interface{}{"User": "admin"},
+		"Containers":  []interface{}{map[string]interface{}{"Image": "a"}, map[string]interface{}{"Image": "b"}},
+	}, resource.Get("spec"))
+
+	resource = NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{}, nil, time.Now())
+	assert.NoError(t, JSONResolver("Spec", "Credentials")(context.TODO(), nil, resource, table.Columns[0]))
+	assert.Nil(t, resource.Get("spec"))
+}
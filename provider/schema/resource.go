@@ -28,6 +28,15 @@ type Resource struct {
 	columns        []string
 	dialect        Dialect
 	executionStart time.Time
+	// resolveStart is when the resource was created for resolving, used to report its resolve duration in cq_meta
+	resolveStart    time.Time
+	fallbackColumns []string
+	// valueSources maps columns to the fallback path their value was taken from
+	valueSources map[string]string
+	// childContext holds the values set by SetChildContext, passed to the resolvers of relations
+	childContext map[string]interface{}
+	// values caches the result of Values until the resource is Set
+	values []interface{}
 }
 
 func NewResourceData(dialect Dialect, t *Table, parent *Resource, item interface{}, metadata map[string]interface{}, startTime time.Time) *Resource {
@@ -41,6 +50,7 @@ func NewResourceData(dialect Dialect, t *Table, parent *Resource, item interface
 		metadata:       metadata,
 		dialect:        dialect,
 		executionStart: startTime,
+		resolveStart:   time.Now(),
 	}
 }
 func (r *Resource) PrimaryKeyValues() []string {
@@ -70,6 +80,21 @@ func (r *Resource) PrimaryKeyValues() []string {
 	return results
 }
 
+// AddFallbackColumn records that the column's resolver failed with an ignored error and the column was left unset.
+// Fallback columns are reported in cq_meta.
+func (r *Resource) AddFallbackColumn(name string) {
+	r.fallbackColumns = append(r.fallbackColumns, name)
+}
+
+// SetValueSource records that the column's value was taken from the given fallback path, see Column.FallbackPaths.
+// Value sources are reported in cq_meta.
+func (r *Resource) SetValueSource(column, path string) {
+	if r.valueSources == nil {
+		r.valueSources = make(map[string]string)
+	}
+	r.valueSources[column] = path
+}
+
 func (r *Resource) Get(key string) interface{} {
 	return r.data[key]
 }
@@ -80,14 +105,44 @@ func (r *Resource) Set(key string, value interface{}) error {
 		return fmt.Errorf("column %s does not exist", key)
 	}
 	r.data[key] = value
+	r.values = nil
 	return nil
 }
 
+// SensitiveValues returns the values of the resource's Sensitive columns that are set, as strings
+func (r *Resource) SensitiveValues() []string {
+	var values []string
+	for _, c := range r.table.Columns {
+		if !c.Sensitive {
+			continue
+		}
+		switch v := r.data[c.Name].(type) {
+		case nil:
+		case string:
+			values = append(values, v)
+		case *string:
+			if v != nil {
+				values = append(values, *v)
+			}
+		case []string:
+			values = append(values, v...)
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return values
+}
+
 func (r *Resource) Id() uuid.UUID {
 	return r.cqId
 }
 
+// Values returns the validated values of the resource's columns in the dialect, in the order of its columns. They are
+// computed once until the resource is Set again, so the returned slice must not be modified.
 func (r *Resource) Values() ([]interface{}, error) {
+	if r.values != nil {
+		return r.values, nil
+	}
 	values := make([]interface{}, 0)
 	for _, c := range r.dialect.Columns(r.table) {
 		v := r.Get(c.Name)
@@ -96,6 +151,7 @@ func (r *Resource) Values() ([]interface{}, error) {
 		}
 		values = append(values, v)
 	}
+	r.values = values
 	return values, nil
 }
 
@@ -108,7 +164,8 @@ func (r *Resource) GenerateCQId() error {
 	for _, pk := range pks {
 		if col := r.getColumnByName(pk); col == nil {
 			return fmt.Errorf("failed to generate cq_id for %s, pk column missing %s", r.table.Name, pk)
-		} else if col.internal {
+		} else if col.internal && pk != TenantIdColumnName {
+			// the tenant id is part of the cq_id, so tenants' resources don't collide
 			continue
 		}
 
@@ -118,6 +175,11 @@ func (r *Resource) GenerateCQId() error {
 		}
 		objs = append(objs, value)
 	}
+	for _, c := range r.table.Columns {
+		if c.CreationOptions.IncludeInCQID && !funk.ContainsString(pks, c.Name) {
+			objs = append(objs, r.Get(c.Name))
+		}
+	}
 	id, err := hashUUID(objs)
 	if err != nil {
 		return err
@@ -171,6 +233,14 @@ func (rr Resources) ColumnNames() []string {
 	return rr[0].columns
 }
 
+// Columns returns the columns of the resources' table in the dialect, in the order of ColumnNames
+func (rr Resources) Columns() ColumnList {
+	if len(rr) == 0 {
+		return ColumnList{}
+	}
+	return rr[0].dialect.Columns(rr[0].table)
+}
+
 func hashUUID(objs interface{}) (uuid.UUID, error) {
 	// Use SHA1 because it's fast and is reasonably enough protected against accidental collisions.
 	// There is no scenario here where intentional created collisions could do harm.
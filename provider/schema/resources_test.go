@@ -59,6 +59,36 @@ func TestResourcePrimaryKey(t *testing.T) {
 	assert.Equal(t, r.PrimaryKeyValues(), []string{uuidPK.String()})
 }
 
+// TestResourceIncludeInCQID checks that columns included in cq_id change the id without being primary keys
+func TestResourceIncludeInCQID(t *testing.T) {
+	table := &Table{
+		Name:    "test_cq_id_table",
+		Options: TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns: []Column{
+			{Name: "id", Type: TypeString},
+			{Name: "region", Type: TypeString, CreationOptions: ColumnCreationOptions{IncludeInCQID: true}},
+		},
+	}
+	assert.NoError(t, ValidateTable(table))
+	ids := make(map[string]uuid.UUID)
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		r := NewResourceData(PostgresDialect{}, table, nil, nil, nil, time.Now())
+		assert.NoError(t, r.Set("id", "test"))
+		assert.NoError(t, r.Set("region", region))
+		assert.NoError(t, r.GenerateCQId())
+		ids[region] = r.Id()
+	}
+	assert.NotEqual(t, ids["us-east-1"], ids["eu-west-1"])
+
+	// tables without included columns keep their ids
+	r := NewResourceData(PostgresDialect{}, testPrimaryKeyTable, nil, nil, nil, time.Now())
+	assert.NoError(t, r.Set("primary_key_str", "test"))
+	assert.NoError(t, r.GenerateCQId())
+	expected, err := hashUUID([]interface{}{"test"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, r.Id())
+}
+
 // TestResourcePrimaryKey checks resource id generation when primary key is set on table
 func TestResourceAddColumns(t *testing.T) {
 	r := NewResourceData(PostgresDialect{}, testPrimaryKeyTable, nil, nil, nil, time.Now())
@@ -73,6 +103,9 @@ func TestResourceColumns(t *testing.T) {
 	v, err := r.Values()
 	assert.Nil(t, err)
 	assert.Equal(t, []interface{}{nil, nil, "test", nil, nil}, v)
+	cached, err := r.Values()
+	assert.Nil(t, err)
+	assert.Same(t, &v[0], &cached[0])
 	// Set invalid type to resource
 	errf = r.Set("name", 5)
 	assert.Nil(t, errf)
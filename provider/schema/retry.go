@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = time.Second
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy configures the retries of a table resolver. A retried resolver is called again from the start, so
+// resources sent by the failed attempt are sent again; they replace the previous ones by cq_id in tables with primary
+// keys.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum amount of calls of the resolver, including the first one. Defaults to 3.
+	MaxAttempts int
+	// MinBackoff is the backoff before the first retry, doubled on every retry. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff between retries. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+	// ShouldRetry decides if the error of the last attempt is transient and should be retried. Defaults to
+	// DefaultShouldRetry.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultShouldRetry retries all errors except context cancellation and deadlines
+func DefaultShouldRetry(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// Attempts returns the maximum amount of attempts, applying the default
+func (p RetryPolicy) Attempts() int {
+	if p.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// Backoff returns the backoff before the given retry, starting at 1, applying the defaults
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultRetryMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	backoff := minBackoff
+	for i := 1; i < retry && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// Retryable returns true if err should be retried, applying the default
+func (p RetryPolicy) Retryable(err error) bool {
+	if p.ShouldRetry == nil {
+		return DefaultShouldRetry(err)
+	}
+	return p.ShouldRetry(err)
+}
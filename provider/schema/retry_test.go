@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var p RetryPolicy
+	assert.Equal(t, 3, p.Attempts())
+	assert.Equal(t, time.Second, p.Backoff(1))
+	assert.Equal(t, 4*time.Second, p.Backoff(3))
+	assert.Equal(t, 30*time.Second, p.Backoff(10))
+	assert.True(t, p.Retryable(errors.New("throttled")))
+	assert.False(t, p.Retryable(fmt.Errorf("call failed: %w", context.Canceled)))
+
+	p = RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	assert.Equal(t, 5, p.Attempts())
+	assert.Equal(t, 200*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, p.Backoff(3))
+}
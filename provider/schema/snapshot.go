@@ -0,0 +1,173 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SchemaSnapshot is a JSON serializable snapshot of a provider's tables. Providers embed the snapshot of their previous
+// release, so breaking changes of their schema are reported before the user upgrades, see BreakingChanges.
+type SchemaSnapshot struct {
+	// Version of the provider the snapshot was taken of
+	Version string `json:"version"`
+	// Tables by resource name
+	Tables map[string]TableSnapshot `json:"tables"`
+}
+
+// TableSnapshot is the snapshot of a table and its relations
+type TableSnapshot struct {
+	Name      string           `json:"name"`
+	Columns   []ColumnSnapshot `json:"columns"`
+	Relations []TableSnapshot  `json:"relations,omitempty"`
+}
+
+// ColumnSnapshot is the snapshot of a table's column
+type ColumnSnapshot struct {
+	Name string `json:"name"`
+	// Type is the postgres type of the column, i.e bigint, so changes between types of the same ValueType name, such as
+	// integer widths, are reported
+	Type string `json:"type"`
+}
+
+// SchemaChangeKind is the kind of a schema change
+type SchemaChangeKind string
+
+const (
+	// TableDropped the table was removed from the provider, it's no longer fetched
+	TableDropped SchemaChangeKind = "table_dropped"
+	// ColumnDropped the column was removed from its table
+	ColumnDropped SchemaChangeKind = "column_dropped"
+	// ColumnTypeChanged the column has a different type
+	ColumnTypeChanged SchemaChangeKind = "column_type_changed"
+	// TableAdded the table was added to the provider
+	TableAdded SchemaChangeKind = "table_added"
+	// TableRenamed the table was renamed from its Table.RenamedFrom name
+	TableRenamed SchemaChangeKind = "table_renamed"
+	// ColumnAdded the column was added to its table
+	ColumnAdded SchemaChangeKind = "column_added"
+	// ColumnRenamed the column was renamed from one of its Column.RenamedFrom names
+	ColumnRenamed SchemaChangeKind = "column_renamed"
+	// PrimaryKeysChanged the table has different primary keys
+	PrimaryKeysChanged SchemaChangeKind = "primary_keys_changed"
+)
+
+// SchemaChange is a change of the schema since a snapshot or a previous version
+type SchemaChange struct {
+	Kind SchemaChangeKind
+	// Table is the name of the changed table
+	Table string
+	// Column is the name of the changed column, empty if the change is of the table
+	Column string
+	// Previous and Current are the types of a column with ColumnTypeChanged, the names of a renamed table or column,
+	// or the primary keys of a table with PrimaryKeysChanged. Current is the type of an added column.
+	Previous string
+	Current  string
+}
+
+// Breaking returns true if the change may break existing queries of the table
+func (c SchemaChange) Breaking() bool {
+	switch c.Kind {
+	case TableAdded, ColumnAdded:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c SchemaChange) String() string {
+	switch c.Kind {
+	case TableDropped:
+		return fmt.Sprintf("table %s was dropped", c.Table)
+	case ColumnDropped:
+		return fmt.Sprintf("column %s of table %s was dropped", c.Column, c.Table)
+	case ColumnTypeChanged:
+		return fmt.Sprintf("type of column %s of table %s changed from %s to %s", c.Column, c.Table, c.Previous, c.Current)
+	case TableAdded:
+		return fmt.Sprintf("table %s was added", c.Table)
+	case TableRenamed:
+		return fmt.Sprintf("table %s was renamed from %s", c.Table, c.Previous)
+	case ColumnAdded:
+		return fmt.Sprintf("column %s of type %s was added to table %s", c.Column, c.Current, c.Table)
+	case ColumnRenamed:
+		return fmt.Sprintf("column %s of table %s was renamed from %s", c.Column, c.Table, c.Previous)
+	case PrimaryKeysChanged:
+		return fmt.Sprintf("primary keys of table %s changed from (%s) to (%s)", c.Table, c.Previous, c.Current)
+	default:
+		return fmt.Sprintf("%s of table %s", c.Kind, c.Table)
+	}
+}
+
+// NewSchemaSnapshot takes a snapshot of the provider's tables by resource name
+func NewSchemaSnapshot(version string, tables map[string]*Table) SchemaSnapshot {
+	s := SchemaSnapshot{Version: version, Tables: make(map[string]TableSnapshot, len(tables))}
+	for name, t := range tables {
+		s.Tables[name] = newTableSnapshot(t)
+	}
+	return s
+}
+
+func newTableSnapshot(t *Table) TableSnapshot {
+	ts := TableSnapshot{Name: t.Name, Columns: make([]ColumnSnapshot, len(t.Columns))}
+	for i, c := range t.Columns {
+		ts.Columns[i] = ColumnSnapshot{Name: c.Name, Type: snapshotType(c.Type)}
+	}
+	for _, rel := range t.Relations {
+		ts.Relations = append(ts.Relations, newTableSnapshot(rel))
+	}
+	return ts
+}
+
+// BreakingChanges returns the changes of tables since the snapshot that break existing queries: dropped tables and
+// columns, and columns whose type changed. Added tables and columns aren't reported. Changes are sorted by table name.
+func (s SchemaSnapshot) BreakingChanges(tables map[string]*Table) []SchemaChange {
+	current := make(map[string]*Table)
+	for _, t := range tables {
+		flattenTables(t, current)
+	}
+	var changes []SchemaChange
+	for _, ts := range s.Tables {
+		changes = append(changes, ts.breakingChanges(current)...)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Table != changes[j].Table {
+			return changes[i].Table < changes[j].Table
+		}
+		return changes[i].Column < changes[j].Column
+	})
+	return changes
+}
+
+func (ts TableSnapshot) breakingChanges(current map[string]*Table) []SchemaChange {
+	t, ok := current[ts.Name]
+	if !ok {
+		// relations of a dropped table are dropped with it
+		return []SchemaChange{{Kind: TableDropped, Table: ts.Name}}
+	}
+	var changes []SchemaChange
+	for _, cs := range ts.Columns {
+		c := t.Column(cs.Name)
+		if c == nil {
+			changes = append(changes, SchemaChange{Kind: ColumnDropped, Table: ts.Name, Column: cs.Name})
+			continue
+		}
+		if typ := snapshotType(c.Type); typ != cs.Type {
+			changes = append(changes, SchemaChange{Kind: ColumnTypeChanged, Table: ts.Name, Column: cs.Name, Previous: cs.Type, Current: typ})
+		}
+	}
+	for _, rel := range ts.Relations {
+		changes = append(changes, rel.breakingChanges(current)...)
+	}
+	return changes
+}
+
+// snapshotType returns the type of a column's snapshot
+func snapshotType(v ValueType) string {
+	return PostgresDialect{}.DBTypeFromType(v)
+}
+
+func flattenTables(t *Table, tables map[string]*Table) {
+	tables[t.Name] = t
+	for _, rel := range t.Relations {
+		flattenTables(rel, tables)
+	}
+}
@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaSnapshot_BreakingChanges(t *testing.T) {
+	previous := map[string]*Table{
+		"instances": {
+			Name:    "test_instances",
+			Columns: []Column{{Name: "id", Type: TypeString}, {Name: "size", Type: TypeInt}, {Name: "zone", Type: TypeString}, {Name: "count", Type: TypeBigInt}},
+			Relations: []*Table{
+				{Name: "test_instance_disks", Columns: []Column{{Name: "parent_cq_id", Type: TypeUUID}, {Name: "name", Type: TypeString}}},
+				{Name: "test_instance_tags", Columns: []Column{{Name: "parent_cq_id", Type: TypeUUID}}},
+			},
+		},
+		"buckets": {Name: "test_buckets", Columns: []Column{{Name: "name", Type: TypeString}}},
+	}
+	b, err := json.Marshal(NewSchemaSnapshot("v0.1.0", previous))
+	require.NoError(t, err)
+	var snapshot SchemaSnapshot
+	require.NoError(t, json.Unmarshal(b, &snapshot))
+	assert.Empty(t, snapshot.BreakingChanges(previous))
+
+	current := map[string]*Table{
+		"instances": {
+			Name:    "test_instances",
+			Columns: []Column{{Name: "id", Type: TypeString}, {Name: "size", Type: TypeString}, {Name: "region", Type: TypeString}, {Name: "count", Type: TypeSmallInt}},
+			Relations: []*Table{
+				{Name: "test_instance_disks", Columns: []Column{{Name: "parent_cq_id", Type: TypeUUID}, {Name: "name", Type: TypeString}, {Name: "added", Type: TypeBool}}},
+			},
+		},
+	}
+	assert.Equal(t, []SchemaChange{
+		{Kind: TableDropped, Table: "test_buckets"},
+		{Kind: TableDropped, Table: "test_instance_tags"},
+		{Kind: ColumnTypeChanged, Table: "test_instances", Column: "count", Previous: "bigint", Current: "smallint"},
+		{Kind: ColumnTypeChanged, Table: "test_instances", Column: "size", Previous: "integer", Current: "text"},
+		{Kind: ColumnDropped, Table: "test_instances", Column: "zone"},
+	}, snapshot.BreakingChanges(current))
+}
@@ -0,0 +1,35 @@
+package schema
+
+import "context"
+
+// ClientStats is an optional instrumentation hook used to record the API calls a client makes while resolving a table.
+// The executor attaches a ClientStats to the context passed to resolvers, aggregating the calls per table and client,
+// and the aggregated counts are reported in the fetch summary.
+type ClientStats interface {
+	// IncAPICall records a single call to the given service operation, i.e IncAPICall("ec2", "DescribeInstances")
+	IncAPICall(service, operation string)
+}
+
+type clientStatsKey struct{}
+
+type noopClientStats struct{}
+
+func (noopClientStats) IncAPICall(_, _ string) {}
+
+// WithClientStats returns a copy of ctx that carries the given ClientStats
+func WithClientStats(ctx context.Context, s ClientStats) context.Context {
+	return context.WithValue(ctx, clientStatsKey{}, s)
+}
+
+// ClientStatsFromContext returns the ClientStats attached to ctx, if no ClientStats is attached a no-op implementation is returned.
+func ClientStatsFromContext(ctx context.Context) ClientStats {
+	if s, ok := ctx.Value(clientStatsKey{}).(ClientStats); ok && s != nil {
+		return s
+	}
+	return noopClientStats{}
+}
+
+// IncAPICall records a single API call on the ClientStats attached to ctx
+func IncAPICall(ctx context.Context, service, operation string) {
+	ClientStatsFromContext(ctx).IncAPICall(service, operation)
+}
@@ -17,6 +17,28 @@ import (
 //
 type TableResolver func(ctx context.Context, meta ClientMeta, parent *Resource, res chan<- interface{}) error
 
+// ParentItemResolver extracts the values a relation's TableResolver needs from the parent resource's Item, such as a
+// token or id of the parent's API response that isn't stored in a column. The returned value is passed to the
+// relation's resolver, see ParentItemValue.
+type ParentItemResolver func(ctx context.Context, meta ClientMeta, parent *Resource) (interface{}, error)
+
+// BatchTableResolver resolves a relation for a batch of parent resources with a single call, i.e of an API describing
+// the children of many parents at once. Items must be sent as ParentItem or []ParentItem, so each item is associated
+// with the parent resource it belongs to.
+type BatchTableResolver func(ctx context.Context, meta ClientMeta, parents Resources, res chan<- interface{}) error
+
+// GetTableResolver returns the single item of a top level table identified by the values of its primary keys, by
+// column name, i.e from an API describing one resource. It returns a nil item if the resource doesn't exist.
+type GetTableResolver func(ctx context.Context, meta ClientMeta, primaryKeys map[string]string) (interface{}, error)
+
+// ParentItem is an item sent by a BatchTableResolver, with the parent resource it belongs to
+type ParentItem struct {
+	// Parent is one of the parents the BatchTableResolver was called with
+	Parent *Resource
+	// Item is the relation's item, as sent by a TableResolver
+	Item interface{}
+}
+
 // IgnoreErrorFunc checks if returned error from table resolver should be ignored.
 type IgnoreErrorFunc func(err error) bool
 
@@ -27,6 +49,9 @@ type Table struct {
 	Name string
 	// table description
 	Description string
+	// Deprecated if set, marks the table as deprecated with the reason, i.e the table replacing it. Deprecated tables
+	// are still fetched, fetches that request them explicitly get a warning.
+	Deprecated string
 	// Columns are the set of fields that are part of this table
 	Columns ColumnList
 	// Relations are a set of related tables defines
@@ -35,6 +60,15 @@ type Table struct {
 	Resolver TableResolver
 	// Ignore errors checks if returned error from table resolver should be ignored.
 	IgnoreError IgnoreErrorFunc
+	// ParentItemResolver is called for each parent resource of a relation before its Resolver, and the value it returns
+	// is available to the Resolver via ParentItemValue. Ignored in top level tables.
+	ParentItemResolver ParentItemResolver
+	// BatchResolver is called once with all the parent resources of a batch stored together, instead of calling Resolver
+	// for each of them, see BatchTableResolver. Only used in relations, which then don't need a Resolver.
+	BatchResolver BatchTableResolver
+	// GetResolver is called to fetch a single resource of the table instead of all of them, see GetTableResolver. The
+	// resource's columns and relations are resolved as the items of Resolver. Ignored in relations.
+	GetResolver GetTableResolver
 	// Multiplex returns re-purposed meta clients. The sdk will execute the table with each of them
 	Multiplex func(meta ClientMeta) []ClientMeta
 	// DeleteFilter returns a list of key/value pairs to add when truncating this table's data from the database.
@@ -43,6 +77,9 @@ type Table struct {
 	PostResourceResolver RowResolver
 	// Options allow modification of how the table is defined when created
 	Options TableCreationOptions
+	// Labels classify the table with a vendor-neutral taxonomy, so equivalent resources of different providers can be
+	// grouped, i.e kind=compute or criticality=high. Fetches can be limited to tables with given labels.
+	Labels map[string]string
 
 	// IgnoreInTests is used to exclude a table from integration tests.
 	// By default, integration tests fetch all resources from cloudquery's test account, and verify all tables
@@ -53,12 +90,96 @@ type Table struct {
 
 	// Serial is used to force a signature change, which forces new table creation and cascading removal of old table and relations
 	Serial string
+
+	// RenamedFrom is the previous name of the table. When upgrading, an existing table with this name is renamed to Name
+	// along with its constraints, keeping its data.
+	RenamedFrom string
+
+	// RateLimit limits the calls to the table's Resolver during a fetch, shared by all of the table's multiplexed
+	// clients and parent resources. If not set, resolver calls aren't limited.
+	RateLimit *RateLimit
+
+	// MaxConcurrency limits the amount of the table's multiplexed clients resolved at a time, within the fetch's max
+	// goroutines limit. If not set, only the fetch's limit applies. Ignored in relations.
+	MaxConcurrency int
+
+	// RetryPolicy retries calls to the table's Resolver that fail with a transient error, before the error is reported.
+	// If not set, resolver errors aren't retried.
+	RetryPolicy *RetryPolicy
+
+	// InsertMode is how the table's resources are written to the database, if not set InsertModeDefault is used
+	InsertMode InsertMode
+
+	// StagedInsert writes the resources of each client to the staging table of the table and its relations, see
+	// StagingTableName. Once the client is resolved its rows, as selected by DeleteFilter, are replaced by the staged
+	// rows in a single transaction, so readers never see a partially fetched table. Ignored in relations, and by
+	// storages that don't support staging. Multiplexed tables must have a DeleteFilter to tell their clients apart.
+	StagedInsert bool
+
+	// Deduplicate drops resources already resolved in the fetch, i.e when multiplexed clients return the same global
+	// resource, instead of failing to store them on their primary keys. Resources are identified by the values of
+	// DedupKeys if set, by their cq_id if the table has primary keys, or else by the values of all the table's columns,
+	// as the cq_id of tables without primary keys is random.
+	Deduplicate bool
+	// DedupKeys are the columns identifying the resources of a table with Deduplicate
+	DedupKeys []string
+
+	// Incremental fetches only the resources changed since the previous fetch, by the cursor passed to the Resolver. See
+	// Incremental. Ignored in relations.
+	Incremental *Incremental
+}
+
+// StagingTableSuffix is appended to table names to get the name of their staging table
+const StagingTableSuffix = "_tmp"
+
+// StagingTableName returns the name of the staging table of the given table, used by Table.StagedInsert
+func StagingTableName(name string) string {
+	return name + StagingTableSuffix
+}
+
+// InsertMode is how resources of a table are written to the database
+type InsertMode int
+
+const (
+	// InsertModeDefault copies resources with CopyFrom, falling back to bulk inserts. Existing top level resources are
+	// deleted along with their relations before they are written again.
+	InsertModeDefault InsertMode = iota
+	// InsertModeUpsert inserts resources with INSERT ... ON CONFLICT (primary keys) DO UPDATE, updating existing rows in
+	// place instead of deleting them. Relations of updated resources aren't deleted, relations that weren't fetched
+	// again are removed with the stale data.
+	InsertModeUpsert
+)
+
+func (m InsertMode) String() string {
+	switch m {
+	case InsertModeDefault:
+		return "default"
+	case InsertModeUpsert:
+		return "upsert"
+	default:
+		return fmt.Sprintf("InsertMode(%d)", int(m))
+	}
+}
+
+// RateLimit is a token bucket limit of table resolver calls
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which resolver calls are allowed
+	RequestsPerSecond float64
+	// Burst is the number of resolver calls allowed at once, if less than 1 a single call is allowed
+	Burst int
 }
 
 // TableCreationOptions allow modifying how table is created such as defining primary keys, indices, foreign keys and constraints.
 type TableCreationOptions struct {
 	// List of columns to set as primary keys. If this is empty, a random unique ID is generated.
 	PrimaryKeys []string
+	// Sequence adds the internal cq_sequence column, numbering the table's resources in the order they are resolved
+	// within a fetch. Allows consumers to order rows deterministically without relying on insertion order.
+	Sequence bool
+	// SoftDelete adds the internal cq_deleted_at column, and stale resources are marked with the time they were found
+	// stale instead of being removed, so it can be queried when a resource disappeared. Resources fetched again are
+	// unmarked. Relations of marked resources are kept as they are.
+	SoftDelete bool
 }
 
 func (t Table) Column(name string) *Column {
@@ -70,6 +191,22 @@ func (t Table) Column(name string) *Column {
 	return nil
 }
 
+// HasLabels returns true if the table has all the given labels with the same values
+func (t Table) HasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if lv, ok := t.Labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ParentIdColumn returns the column of a relation resolved by ParentIdResolver, which references its parent's cq_id, or
+// nil if the table has no such column
+func (t Table) ParentIdColumn() *Column {
+	return findParentIdColumn(&t)
+}
+
 func (tco TableCreationOptions) signature() string {
 	return strings.Join(tco.PrimaryKeys, ";")
 }
@@ -98,6 +235,21 @@ func (t Table) TableNames() []string {
 	return ret
 }
 
+// HasSensitiveColumns returns true if the table or any of its relations has a Sensitive column
+func (t Table) HasSensitiveColumns() bool {
+	for _, c := range t.Columns {
+		if c.Sensitive {
+			return true
+		}
+	}
+	for _, rel := range t.Relations {
+		if rel.HasSensitiveColumns() {
+			return true
+		}
+	}
+	return false
+}
+
 func (t Table) signature(d Dialect, parent *Table) []string {
 	sigs := make([]string, 0, len(t.Relations)+1)
 	sigs = append(sigs, strings.Join([]string{
@@ -153,3 +153,14 @@ func TestTableSignatures(t *testing.T) {
 		assert.NotEqual(t, newSig, newSig4)
 	}
 }
+
+func TestTableClassifiedColumns(t *testing.T) {
+	table := Table{Name: "test_classified", Columns: []Column{
+		{Name: "id", Type: TypeString},
+		{Name: "email", Type: TypeString, Classification: ClassificationPII},
+		{Name: "password", Type: TypeString, Classification: ClassificationSecret, Sensitive: true},
+	}}
+	assert.Equal(t, []string{"email", "password"}, table.ClassifiedColumns("").Names())
+	assert.Equal(t, []string{"password"}, table.ClassifiedColumns(ClassificationSecret).Names())
+	assert.Empty(t, table.ClassifiedColumns(ClassificationPublic))
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/cast"
+)
+
+// Tag is a key and value of a resource's tags, as sent by the resolver of a TagsTable
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// TagsResolver resolves the tags of a resource into a canonical map[string]string for TypeJSON columns. Tags may be
+// a slice of structs, or maps, with Key and Value fields, i.e []ec2.Tag, or a map of strings or string pointers. Nil
+// values are resolved as empty strings, and tags without a key are skipped.
+//
+// Examples:
+// TagsResolver("Tags")
+// TagsResolver("Metadata.Labels")
+func TagsResolver(path string) ColumnResolver {
+	get := newPathGetter(path)
+	return func(_ context.Context, meta ClientMeta, r *Resource, c Column) error {
+		value, err := get(r.Item)
+		if err != nil {
+			return err
+		}
+		tags, err := normalizeTags(value)
+		if err != nil {
+			return err
+		}
+		if tags == nil {
+			return r.Set(c.Name, nil)
+		}
+		return r.Set(c.Name, tags)
+	}
+}
+
+// TagsTable returns a relation of the table, named <table>_tags, with a row of key and value for each of the tags
+// at the path of the parent's item, normalized as with TagsResolver. Add it to the table's Relations to query tags
+// without JSON operators.
+//
+// Examples:
+// TagsTable("aws_ec2_instances", "Tags")
+func TagsTable(table, path string) *Table {
+	get := newPathGetter(path)
+	return &Table{
+		Name:        table + "_tags",
+		Description: fmt.Sprintf("Tags of %s", table),
+		Resolver: func(_ context.Context, _ ClientMeta, parent *Resource, res chan<- interface{}) error {
+			value, err := get(parent.Item)
+			if err != nil {
+				return err
+			}
+			tags, err := normalizeTags(value)
+			if err != nil {
+				return err
+			}
+			keys := make([]string, 0, len(tags))
+			for k := range tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			items := make([]Tag, len(keys))
+			for i, k := range keys {
+				items[i] = Tag{Key: k, Value: tags[k]}
+			}
+			res <- items
+			return nil
+		},
+		Options: TableCreationOptions{PrimaryKeys: []string{"parent_cq_id", "key"}},
+		Columns: []Column{
+			{
+				Name:        "parent_cq_id",
+				Description: fmt.Sprintf("Unique CloudQuery ID of %s table (FK)", table),
+				Type:        TypeUUID,
+				Resolver:    ParentIdResolver,
+			},
+			{
+				Name:        "key",
+				Description: "Key of the tag",
+				Type:        TypeString,
+			},
+			{
+				Name:        "value",
+				Description: "Value of the tag",
+				Type:        TypeString,
+			},
+		},
+	}
+}
+
+// normalizeTags converts tags to a map of their keys and values, nil tags are returned as a nil map
+func normalizeTags(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported tags type %T", v)
+		}
+		tags := make(map[string]string, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			value, err := tagString(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			if k := iter.Key().String(); k != "" {
+				tags[k] = value
+			}
+		}
+		return tags, nil
+	case reflect.Slice, reflect.Array:
+		tags := make(map[string]string, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			key, value, err := tagKeyValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if key != "" {
+				tags[key] = value
+			}
+		}
+		return tags, nil
+	default:
+		return nil, fmt.Errorf("unsupported tags type %T", v)
+	}
+}
+
+// tagKeyValue returns the Key and Value fields of a tag, a struct or a map
+func tagKeyValue(v reflect.Value) (string, string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	var key, value reflect.Value
+	switch v.Kind() {
+	case reflect.Invalid:
+		return "", "", nil
+	case reflect.Struct:
+		key, value = v.FieldByName("Key"), v.FieldByName("Value")
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return "", "", fmt.Errorf("unsupported tag type %s", v.Type())
+		}
+		key, value = mapValue(v, "Key"), mapValue(v, "Value")
+	default:
+		return "", "", fmt.Errorf("unsupported tag type %s", v.Type())
+	}
+	k, err := tagString(key)
+	if err != nil {
+		return "", "", err
+	}
+	val, err := tagString(value)
+	if err != nil {
+		return "", "", err
+	}
+	return k, val, nil
+}
+
+// tagString returns the string of a tag's key or value, invalid or nil values are empty strings
+func tagString(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return "", nil
+	}
+	return cast.ToStringE(v.Interface())
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTag struct {
+	Key   *string
+	Value *string
+}
+
+func TestTagsResolver(t *testing.T) {
+	key, value := "env", "prod"
+	table := &Table{Columns: []Column{{Name: "tags", Type: TypeJSON}}}
+	for name, tags := range map[string]interface{}{
+		"struct slice":       []testTag{{Key: &key, Value: &value}, {Key: &value}, {Value: &key}},
+		"struct ptr slice":   []*testTag{{Key: &key, Value: &value}, {Key: &value}, nil},
+		"map slice":          []map[string]interface{}{{"Key": "env", "Value": "prod"}, {"Key": "prod"}},
+		"string map":         map[string]string{"env": "prod", "prod": ""},
+		"string pointer map": map[string]*string{"env": &value, "prod": nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource := NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"Tags": tags}, nil, time.Now())
+			require.NoError(t, TagsResolver("Tags")(context.TODO(), nil, resource, table.Columns[0]))
+			assert.Equal(t, map[string]string{"env": "prod", "prod": ""}, resource.Get("tags"))
+		})
+	}
+
+	resource := NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{}, nil, time.Now())
+	require.NoError(t, TagsResolver("Tags")(context.TODO(), nil, resource, table.Columns[0]))
+	assert.Nil(t, resource.Get("tags"))
+
+	resource = NewResourceData(PostgresDialect{}, table, nil, map[string]interface{}{"Tags": 1}, nil, time.Now())
+	assert.Error(t, TagsResolver("Tags")(context.TODO(), nil, resource, table.Columns[0]))
+}
+
+func TestTagsTable(t *testing.T) {
+	parent := &Table{
+		Name:      "test_resources",
+		Columns:   []Column{{Name: "name", Type: TypeString}},
+		Relations: []*Table{TagsTable("test_resources", "Tags")},
+	}
+	require.NoError(t, ValidateTable(parent))
+	tags := parent.Relations[0]
+	assert.Equal(t, "test_resources_tags", tags.Name)
+
+	res := make(chan interface{}, 1)
+	item := map[string]interface{}{"Tags": map[string]string{"b": "2", "a": "1"}}
+	require.NoError(t, tags.Resolver(context.TODO(), nil, NewResourceData(PostgresDialect{}, parent, nil, item, nil, time.Now()), res))
+	assert.Equal(t, []Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, <-res)
+}
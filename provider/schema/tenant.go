@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// TenantIdMetaKey is the fetch metadata key of the id of the tenant owning the fetched resources
+	TenantIdMetaKey = "cq_tenant_id"
+	// TenantIdColumnName is the name of the internal column added to every table by TenantDialect
+	TenantIdColumnName = "cq_tenant_id"
+)
+
+var cqTenantIdColumn = Column{
+	Name:        TenantIdColumnName,
+	Type:        TypeString,
+	Description: "Id of the tenant owning the resource",
+	Resolver: func(ctx context.Context, meta ClientMeta, resource *Resource, c Column) error {
+		val, _ := resource.GetMeta(TenantIdMetaKey)
+		id, ok := val.(string)
+		if !ok || id == "" {
+			return fmt.Errorf("fetch has no tenant id")
+		}
+		return resource.Set(c.Name, id)
+	},
+	CreationOptions: ColumnCreationOptions{
+		NotNull: true,
+	},
+	internal: true,
+}
+
+// TenantDialect scopes the rows of every table of a postgres based dialect to a tenant, so many tenants, i.e the
+// customers of a SaaS, can be fetched into the same database. Tables get the cq_tenant_id column, set from the fetch's
+// TenantIdMetaKey metadata, which is the first of their primary keys and part of their unique constraints. The tenant
+// id is part of the cq_id, so the same resource of two tenants has a different cq_id.
+type TenantDialect struct {
+	Dialect
+}
+
+var _ Dialect = (*TenantDialect)(nil)
+
+// NewTenantDialect scopes the tables of d to tenants, see TenantDialect
+func NewTenantDialect(d Dialect) TenantDialect {
+	return TenantDialect{Dialect: d}
+}
+
+// IsTenantDialect returns true if d scopes tables to tenants
+func IsTenantDialect(d Dialect) bool {
+	switch d.(type) {
+	case TenantDialect, *TenantDialect:
+		return true
+	}
+	return false
+}
+
+func (d TenantDialect) PrimaryKeys(t *Table) []string {
+	return append([]string{TenantIdColumnName}, d.Dialect.PrimaryKeys(t)...)
+}
+
+func (d TenantDialect) Columns(t *Table) ColumnList {
+	return append(ColumnList{cqTenantIdColumn}, d.Dialect.Columns(t)...)
+}
+
+// scopedDialect is implemented by the dialects of the package, returning their constraints with the scope's columns
+// prepended to the primary key and unique constraints
+type scopedDialect interface {
+	scopedConstraints(t, parent *Table, scope ColumnList) []string
+}
+
+// Constraints adds the tenant id to the primary key and unique constraints of the wrapped dialect. The cq_id stays unique
+// by itself, as relations reference it. Dialects of other packages get the constraints of PostgresDialect.
+func (d TenantDialect) Constraints(t, parent *Table) []string {
+	scope := ColumnList{cqTenantIdColumn}
+	if sd, ok := d.Dialect.(scopedDialect); ok {
+		return sd.scopedConstraints(t, parent, scope)
+	}
+	return PostgresDialect{}.scopedConstraints(t, parent, scope)
+}
+
+func (d TenantDialect) GetResourceValues(r *Resource) ([]interface{}, error) {
+	return doResourceValues(d, r)
+}
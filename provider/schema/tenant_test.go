@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tenantTestTable = &Table{
+	Name: "tenant_table",
+	Columns: []Column{
+		{Name: "id", Type: TypeString},
+		{Name: "arn", Type: TypeString, CreationOptions: ColumnCreationOptions{Unique: true}},
+	},
+	Options: TableCreationOptions{PrimaryKeys: []string{"id"}},
+}
+
+func TestTenantDialect(t *testing.T) {
+	d := NewTenantDialect(PostgresDialect{})
+	assert.True(t, IsTenantDialect(d))
+	assert.False(t, IsTenantDialect(PostgresDialect{}))
+	assert.Equal(t, []string{"cq_tenant_id", "id"}, d.PrimaryKeys(tenantTestTable))
+	assert.Equal(t, []string{"cq_tenant_id", "cq_id", "cq_meta", "id", "arn"}, d.Columns(tenantTestTable).Names())
+	assert.Equal(t, []string{
+		`CONSTRAINT "tenant_table_pk" PRIMARY KEY("cq_tenant_id","id")`,
+		`UNIQUE("cq_id")`,
+		`UNIQUE("cq_tenant_id","arn")`,
+	}, d.Constraints(tenantTestTable, nil))
+
+	tsdb := NewTenantDialect(TSDBDialect{})
+	assert.Equal(t, []string{"cq_tenant_id", "cq_fetch_date", "id"}, tsdb.PrimaryKeys(tenantTestTable))
+	assert.Equal(t, []string{
+		`CONSTRAINT "tenant_table_pk" PRIMARY KEY("cq_tenant_id","cq_fetch_date","id")`,
+		`UNIQUE("cq_tenant_id","cq_fetch_date","cq_id")`,
+		`UNIQUE("cq_tenant_id","cq_fetch_date","arn")`,
+	}, tsdb.Constraints(tenantTestTable, nil))
+
+	// text columns of MySQL keys, including the tenant id, are indexed by a prefix
+	mysql := NewTenantDialect(MySQLDialect{})
+	assert.Equal(t, []string{
+		"CONSTRAINT `tenant_table_pk` PRIMARY KEY(`cq_tenant_id`(255),`id`(255))",
+		"UNIQUE(`cq_id`)",
+		"UNIQUE(`cq_tenant_id`(255),`arn`(255))",
+	}, mysql.Constraints(tenantTestTable, nil))
+}
+
+func TestTenantDialect_CQId(t *testing.T) {
+	d := NewTenantDialect(PostgresDialect{})
+	resolve := func(tenant string) *Resource {
+		r := NewResourceData(d, tenantTestTable, nil, nil, map[string]interface{}{TenantIdMetaKey: tenant}, time.Now())
+		require.NoError(t, r.Set("id", "shared"))
+		require.NoError(t, cqTenantIdColumn.Resolver(context.Background(), nil, r, cqTenantIdColumn))
+		require.NoError(t, r.GenerateCQId())
+		return r
+	}
+	first, second := resolve("first"), resolve("second")
+	assert.Equal(t, "first", first.Get(TenantIdColumnName))
+	assert.NotEqual(t, first.Id(), second.Id())
+	assert.Equal(t, first.Id(), resolve("first").Id())
+
+	r := NewResourceData(d, tenantTestTable, nil, nil, nil, time.Now())
+	assert.Error(t, cqTenantIdColumn.Resolver(context.Background(), nil, r, cqTenantIdColumn))
+}
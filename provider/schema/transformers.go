@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// ColumnTransformer transforms a resolved column value, see Column.Transform
+type ColumnTransformer func(v interface{}) (interface{}, error)
+
+// ChainTransformers applies the transformers in order, stopping at the first error or nil value
+//
+// Examples:
+// ChainTransformers(TrimSpaceTransformer, LowerTransformer)
+func ChainTransformers(transformers ...ColumnTransformer) ColumnTransformer {
+	return func(v interface{}) (interface{}, error) {
+		var err error
+		for _, t := range transformers {
+			if v == nil {
+				return nil, nil
+			}
+			if v, err = t(v); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	}
+}
+
+// LowerTransformer lower cases string values
+func LowerTransformer(v interface{}) (interface{}, error) {
+	return transformString(v, strings.ToLower)
+}
+
+// UpperTransformer upper cases string values
+func UpperTransformer(v interface{}) (interface{}, error) {
+	return transformString(v, strings.ToUpper)
+}
+
+// TrimSpaceTransformer removes leading and trailing white space of string values
+func TrimSpaceTransformer(v interface{}) (interface{}, error) {
+	return transformString(v, strings.TrimSpace)
+}
+
+// ScaleTransformer multiplies numeric values by factor, i.e for unit conversion. The result is a float64.
+//
+// Examples:
+// ScaleTransformer(1.0 / 1024) - KiB to MiB
+func ScaleTransformer(factor float64) ColumnTransformer {
+	return func(v interface{}) (interface{}, error) {
+		f, err := cast.ToFloat64E(v)
+		if err != nil {
+			return nil, fmt.Errorf("scale transformer: %w", err)
+		}
+		return f * factor, nil
+	}
+}
+
+func transformString(v interface{}, f func(string) string) (interface{}, error) {
+	switch s := v.(type) {
+	case string:
+		return f(s), nil
+	case *string:
+		if s == nil {
+			return nil, nil
+		}
+		return f(*s), nil
+	case []string:
+		out := make([]string, len(s))
+		for i := range s {
+			out[i] = f(s[i])
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("string transformer: unexpected type %T", v)
+	}
+}
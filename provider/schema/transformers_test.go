@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformers(t *testing.T) {
+	s := "  MixedCase "
+	cases := []struct {
+		Name        string
+		Transformer ColumnTransformer
+		Value       interface{}
+		Expected    interface{}
+		Error       bool
+	}{
+		{Name: "lower", Transformer: LowerTransformer, Value: "MixedCase", Expected: "mixedcase"},
+		{Name: "lower pointer", Transformer: LowerTransformer, Value: &s, Expected: "  mixedcase "},
+		{Name: "upper list", Transformer: UpperTransformer, Value: []string{"a", "b"}, Expected: []string{"A", "B"}},
+		{Name: "trim", Transformer: TrimSpaceTransformer, Value: s, Expected: "MixedCase"},
+		{Name: "string unexpected type", Transformer: LowerTransformer, Value: 1, Error: true},
+		{Name: "scale", Transformer: ScaleTransformer(1.0 / 1024), Value: 2048, Expected: 2.0},
+		{Name: "scale string", Transformer: ScaleTransformer(2), Value: "1.5", Expected: 3.0},
+		{Name: "scale invalid", Transformer: ScaleTransformer(2), Value: "many", Error: true},
+		{Name: "chain", Transformer: ChainTransformers(TrimSpaceTransformer, LowerTransformer), Value: &s, Expected: "mixedcase"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			v, err := tc.Transformer(tc.Value)
+			if tc.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, v)
+		})
+	}
+}
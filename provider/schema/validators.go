@@ -11,6 +11,46 @@ type TableValidator interface {
 
 type LengthTableValidator struct{}
 
+// IdentifierTableValidator validates that table and column names are valid identifiers, see ValidateIdentifier
+type IdentifierTableValidator struct{}
+
+// CQIDColumnsValidator validates that columns included in cq_id are on tables with primary keys, and aren't JSON, which
+// usually holds values that change between fetches
+type CQIDColumnsValidator struct{}
+
+// RestrictedColumnsValidator validates that restricted columns can be omitted, i.e they aren't primary keys or NOT NULL
+type RestrictedColumnsValidator struct{}
+
+// ResolverTableValidator validates that the table and its relations have a table resolver, relations may have a batch
+// resolver instead
+type ResolverTableValidator struct{}
+
+// ColumnsTableValidator validates that column names are unique, including the internal columns added by the dialects,
+// and that columns have a valid type
+type ColumnsTableValidator struct{}
+
+// PrimaryKeysTableValidator validates that primary keys are columns of the table, and aren't listed more than once
+type PrimaryKeysTableValidator struct{}
+
+// RelationsTableValidator validates that relations have a column resolved by ParentIdResolver, which links their rows
+// to the parent's
+type RelationsTableValidator struct{}
+
+// StagedInsertTableValidator validates that the staging table names of tables with StagedInsert are valid, and that
+// multiplexed tables with StagedInsert have a DeleteFilter
+type StagedInsertTableValidator struct{}
+
+// InheritedColumnsValidator validates that columns with Column.InheritFromParent are columns of relations, inheriting
+// a column of their parent of the same type
+type InheritedColumnsValidator struct{}
+
+// JSONOmitFieldsValidator validates that only TypeJSON columns have Column.JSONOmitFields
+type JSONOmitFieldsValidator struct{}
+
+// IncrementalTableValidator validates that the cursor column of Incremental tables is a column of the table, of one of
+// the CursorTypes
+type IncrementalTableValidator struct{}
+
 const (
 	maxTableName  = 63 // maximum allowed identifier length is 63 bytes https://www.postgresql.org/docs/13/limits.html
 	maxColumnName = 63
@@ -18,15 +58,46 @@ const (
 
 var defaultValidators = []TableValidator{
 	LengthTableValidator{},
+	IdentifierTableValidator{},
+	RestrictedColumnsValidator{},
+	CQIDColumnsValidator{},
+}
+
+// structureValidators detect table definitions that fail at fetch time, they are run by ValidateTableStructure
+var structureValidators = []TableValidator{
+	ResolverTableValidator{},
+	ColumnsTableValidator{},
+	PrimaryKeysTableValidator{},
+	RelationsTableValidator{},
+	StagedInsertTableValidator{},
+	IncrementalTableValidator{},
+	InheritedColumnsValidator{},
+	JSONOmitFieldsValidator{},
 }
 
 func ValidateTable(t *Table) error {
 	for _, validator := range defaultValidators {
-		return validator.Validate(t)
+		if err := validator.Validate(t); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// ValidateTableStructure runs the validators of ValidateTable, along with validators of the table's structure, i.e
+// resolvers and primary keys. The first error of each validator is returned, so all kinds of problems are reported at once.
+func ValidateTableStructure(t *Table) []error {
+	var errs []error
+	for _, validators := range [][]TableValidator{defaultValidators, structureValidators} {
+		for _, validator := range validators {
+			if err := validator.Validate(t); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 func validateTableAttributesNameLength(t *Table) error {
 	// validate table name
 	if len(t.Name) > maxTableName {
@@ -53,3 +124,216 @@ func validateTableAttributesNameLength(t *Table) error {
 func (LengthTableValidator) Validate(t *Table) error {
 	return validateTableAttributesNameLength(t)
 }
+
+func (RestrictedColumnsValidator) Validate(t *Table) error {
+	for _, c := range t.Columns {
+		if c.Restricted == "" {
+			continue
+		}
+		if c.CreationOptions.NotNull {
+			return fmt.Errorf("restricted column %s in table %s can't be NOT NULL", c.Name, t.Name)
+		}
+		for _, pk := range t.Options.PrimaryKeys {
+			if pk == c.Name {
+				return fmt.Errorf("restricted column %s in table %s can't be a primary key", c.Name, t.Name)
+			}
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := (RestrictedColumnsValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (CQIDColumnsValidator) Validate(t *Table) error {
+	for _, c := range t.Columns {
+		if !c.CreationOptions.IncludeInCQID {
+			continue
+		}
+		if len(t.Options.PrimaryKeys) == 0 {
+			return fmt.Errorf("column %s in table %s is included in cq_id, but the table has no primary keys", c.Name, t.Name)
+		}
+		if c.Type == TypeJSON {
+			return fmt.Errorf("column %s in table %s is included in cq_id, but JSON values aren't a stable identity", c.Name, t.Name)
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := (CQIDColumnsValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (IdentifierTableValidator) Validate(t *Table) error {
+	if err := ValidateIdentifier(t.Name); err != nil {
+		return fmt.Errorf("table %s: %w", t.Name, err)
+	}
+	for _, c := range t.Columns {
+		if err := ValidateIdentifier(c.Name); err != nil {
+			return fmt.Errorf("column %s in table %s: %w", c.Name, t.Name, err)
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := (IdentifierTableValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ResolverTableValidator) Validate(t *Table) error {
+	if t.Resolver == nil {
+		return fmt.Errorf("table %s has no resolver", t.Name)
+	}
+	return validateRelationResolvers(t)
+}
+
+func validateRelationResolvers(t *Table) error {
+	for _, rel := range t.Relations {
+		if rel.Resolver == nil && rel.BatchResolver == nil {
+			return fmt.Errorf("table %s has no resolver", rel.Name)
+		}
+		if err := validateRelationResolvers(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ColumnsTableValidator) Validate(t *Table) error {
+	// the timescale dialect's columns are a superset of postgres', the tenant dialect adds the tenant id column
+	names := make(map[string]bool)
+	for _, c := range NewTenantDialect(TSDBDialect{}).Columns(t) {
+		if names[c.Name] {
+			return fmt.Errorf("column %s in table %s is defined more than once, or is reserved for internal use", c.Name, t.Name)
+		}
+		names[c.Name] = true
+		if c.Type == TypeInvalid {
+			return fmt.Errorf("column %s in table %s has no type", c.Name, t.Name)
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := (ColumnsTableValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (PrimaryKeysTableValidator) Validate(t *Table) error {
+	cols := make(map[string]bool)
+	for _, c := range (PostgresDialect{}).Columns(t) {
+		cols[c.Name] = true
+	}
+	pks := make(map[string]bool, len(t.Options.PrimaryKeys))
+	for _, pk := range t.Options.PrimaryKeys {
+		if !cols[pk] {
+			return fmt.Errorf("primary key %s isn't a column of table %s", pk, t.Name)
+		}
+		if pks[pk] {
+			return fmt.Errorf("primary key %s of table %s is listed more than once", pk, t.Name)
+		}
+		pks[pk] = true
+	}
+	for _, rel := range t.Relations {
+		if err := (PrimaryKeysTableValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (RelationsTableValidator) Validate(t *Table) error {
+	for _, rel := range t.Relations {
+		if findParentIdColumn(rel) == nil {
+			return fmt.Errorf("relation %s of table %s has no column resolved by schema.ParentIdResolver", rel.Name, t.Name)
+		}
+		if err := (RelationsTableValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (StagedInsertTableValidator) Validate(t *Table) error {
+	if !t.StagedInsert {
+		return nil
+	}
+	if t.Multiplex != nil && t.DeleteFilter == nil {
+		return fmt.Errorf("table %s with staged insert is multiplexed and has no delete filter, clients would replace each other's rows", t.Name)
+	}
+	return validateStagingTableNames(t)
+}
+
+func validateStagingTableNames(t *Table) error {
+	if name := StagingTableName(t.Name); len(name) > maxTableName {
+		return fmt.Errorf("staging table name %s of table %s is too long, table names with staged insert must be at most %d characters", name, t.Name, maxTableName-len(StagingTableSuffix))
+	}
+	for _, rel := range t.Relations {
+		if err := validateStagingTableNames(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (IncrementalTableValidator) Validate(t *Table) error {
+	if t.Incremental == nil {
+		return nil
+	}
+	c := t.Column(t.Incremental.CursorColumn)
+	if c == nil {
+		return fmt.Errorf("cursor column %s isn't a column of incremental table %s", t.Incremental.CursorColumn, t.Name)
+	}
+	for _, vt := range CursorTypes {
+		if c.Type == vt {
+			return nil
+		}
+	}
+	return fmt.Errorf("cursor column %s of incremental table %s has type %s, which can't be a cursor", c.Name, t.Name, c.Type)
+}
+
+func (InheritedColumnsValidator) Validate(t *Table) error {
+	return validateInheritedColumns(t, nil)
+}
+
+func validateInheritedColumns(t, parent *Table) error {
+	for _, c := range t.Columns {
+		if c.InheritFromParent == "" || c.Resolver != nil {
+			continue
+		}
+		if parent == nil {
+			return fmt.Errorf("column %s of table %s inherits from its parent, but the table has no parent", c.Name, t.Name)
+		}
+		pc := parent.Column(c.InheritFromParent)
+		if pc == nil {
+			return fmt.Errorf("column %s of table %s inherits from %s, which isn't a column of parent table %s", c.Name, t.Name, c.InheritFromParent, parent.Name)
+		}
+		if pc.Type != c.Type {
+			return fmt.Errorf("column %s of table %s has type %s, but it inherits from column %s of parent table %s of type %s", c.Name, t.Name, c.Type, pc.Name, parent.Name, pc.Type)
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := validateInheritedColumns(rel, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (JSONOmitFieldsValidator) Validate(t *Table) error {
+	for _, c := range t.Columns {
+		if len(c.JSONOmitFields) > 0 && c.Type != TypeJSON {
+			return fmt.Errorf("column %s of table %s has type %s, but only TypeJSON columns can have JSONOmitFields", c.Name, t.Name, c.Type)
+		}
+	}
+	for _, rel := range t.Relations {
+		if err := (JSONOmitFieldsValidator{}).Validate(rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
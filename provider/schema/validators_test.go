@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,150 @@ func TestTableValidators(t *testing.T) {
 	err = ValidateTable(&tableWithLongColumnName)
 	assert.Error(t, err)
 }
+
+func TestRestrictedColumnsValidator(t *testing.T) {
+	table := Table{
+		Name:    "test_restricted_validator",
+		Columns: []Column{{Name: "id", Type: TypeString}, {Name: "email", Type: TypeString, Restricted: "pii"}},
+	}
+	assert.NoError(t, ValidateTable(&table))
+
+	pkTable := table
+	pkTable.Options.PrimaryKeys = []string{"email"}
+	assert.Error(t, ValidateTable(&pkTable))
+
+	notNullTable := Table{
+		Name:    "test_restricted_validator",
+		Columns: []Column{{Name: "email", Type: TypeString, Restricted: "pii", CreationOptions: ColumnCreationOptions{NotNull: true}}},
+	}
+	assert.Error(t, ValidateTable(&Table{Name: "parent", Relations: []*Table{&notNullTable}}))
+}
+
+func TestCQIDColumnsValidator(t *testing.T) {
+	table := Table{
+		Name:    "test_cq_id_validator",
+		Options: TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns: []Column{{Name: "id", Type: TypeString}, {Name: "region", Type: TypeString, CreationOptions: ColumnCreationOptions{IncludeInCQID: true}}},
+	}
+	assert.NoError(t, ValidateTable(&table))
+
+	noPkTable := table
+	noPkTable.Options.PrimaryKeys = nil
+	assert.Error(t, ValidateTable(&Table{Name: "parent", Relations: []*Table{&noPkTable}}))
+
+	jsonTable := Table{
+		Name:    "test_cq_id_validator",
+		Options: TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns: []Column{{Name: "id", Type: TypeString}, {Name: "tags", Type: TypeJSON, CreationOptions: ColumnCreationOptions{IncludeInCQID: true}}},
+	}
+	assert.Error(t, ValidateTable(&jsonTable))
+}
+
+func TestValidateTableStructure(t *testing.T) {
+	resolver := func(context.Context, ClientMeta, *Resource, chan<- interface{}) error { return nil }
+	table := Table{
+		Name:     "test_structure_validator",
+		Resolver: resolver,
+		Options:  TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns:  []Column{{Name: "id", Type: TypeString}},
+		Relations: []*Table{{
+			Name:     "test_structure_validator_children",
+			Resolver: resolver,
+			Columns:  []Column{{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver}},
+		}},
+	}
+	assert.Empty(t, ValidateTableStructure(&table))
+
+	invalid := Table{
+		Name:    "test_structure_validator",
+		Options: TableCreationOptions{PrimaryKeys: []string{"id", "missing"}},
+		Columns: []Column{{Name: "id", Type: TypeString}, {Name: "id", Type: TypeString}},
+		Relations: []*Table{{
+			Name:     "test_structure_validator_children",
+			Resolver: resolver,
+			Columns:  []Column{{Name: "cq_fetch_date", Type: TypeTimestamp}},
+		}},
+	}
+	assert.Equal(t, []string{
+		"table test_structure_validator has no resolver",
+		"column id in table test_structure_validator is defined more than once, or is reserved for internal use",
+		"primary key missing isn't a column of table test_structure_validator",
+		"relation test_structure_validator_children of table test_structure_validator has no column resolved by schema.ParentIdResolver",
+	}, errorStrings(ValidateTableStructure(&invalid)))
+
+	noType := Table{Name: "test_structure_validator", Resolver: resolver, Columns: []Column{{Name: "id"}}}
+	assert.Equal(t, []string{"column id in table test_structure_validator has no type"}, errorStrings(ValidateTableStructure(&noType)))
+}
+
+func TestStagedInsertTableValidator(t *testing.T) {
+	resolver := func(context.Context, ClientMeta, *Resource, chan<- interface{}) error { return nil }
+	table := Table{Name: "test_staged_validator", Resolver: resolver, StagedInsert: true, Columns: []Column{{Name: "id", Type: TypeString}}}
+	assert.Empty(t, ValidateTableStructure(&table))
+
+	multiplexed := table
+	multiplexed.Multiplex = func(meta ClientMeta) []ClientMeta { return nil }
+	assert.Equal(t, []string{"table test_staged_validator with staged insert is multiplexed and has no delete filter, clients would replace each other's rows"},
+		errorStrings(ValidateTableStructure(&multiplexed)))
+	multiplexed.DeleteFilter = func(meta ClientMeta, parent *Resource) []interface{} { return nil }
+	assert.Empty(t, ValidateTableStructure(&multiplexed))
+
+	longRelation := table
+	longRelation.Relations = []*Table{{
+		Name:     "test_staged_validator_children_with_a_name_too_long_for_staging",
+		Resolver: resolver,
+		Columns:  []Column{{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver}},
+	}}
+	assert.Equal(t, []string{"staging table name test_staged_validator_children_with_a_name_too_long_for_staging_tmp of table test_staged_validator_children_with_a_name_too_long_for_staging is too long, table names with staged insert must be at most 59 characters"},
+		errorStrings(ValidateTableStructure(&longRelation)))
+}
+
+func TestIncrementalTableValidator(t *testing.T) {
+	resolver := func(context.Context, ClientMeta, *Resource, chan<- interface{}) error { return nil }
+	table := Table{Name: "test_incremental_validator", Resolver: resolver, Incremental: &Incremental{CursorColumn: "updated_at"},
+		Columns: []Column{{Name: "id", Type: TypeString}, {Name: "updated_at", Type: TypeTimestamp}, {Name: "tags", Type: TypeJSON}}}
+	assert.Empty(t, ValidateTableStructure(&table))
+
+	table.Incremental = &Incremental{CursorColumn: "missing"}
+	assert.Equal(t, []string{"cursor column missing isn't a column of incremental table test_incremental_validator"}, errorStrings(ValidateTableStructure(&table)))
+	table.Incremental = &Incremental{CursorColumn: "tags"}
+	assert.Equal(t, []string{"cursor column tags of incremental table test_incremental_validator has type TypeJSON, which can't be a cursor"}, errorStrings(ValidateTableStructure(&table)))
+}
+
+func TestInheritedColumnsValidator(t *testing.T) {
+	resolver := func(context.Context, ClientMeta, *Resource, chan<- interface{}) error { return nil }
+	child := &Table{Name: "test_inherit_validator_children", Resolver: resolver, Columns: []Column{
+		{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver},
+		{Name: "account_id", Type: TypeString, InheritFromParent: "account_id"},
+	}}
+	table := Table{Name: "test_inherit_validator", Resolver: resolver, Columns: []Column{{Name: "account_id", Type: TypeString}}, Relations: []*Table{child}}
+	assert.Empty(t, ValidateTableStructure(&table))
+
+	child.Columns[1].InheritFromParent = "region"
+	assert.Equal(t, []string{"column account_id of table test_inherit_validator_children inherits from region, which isn't a column of parent table test_inherit_validator"}, errorStrings(ValidateTableStructure(&table)))
+	child.Columns[1] = Column{Name: "account_id", Type: TypeJSON, InheritFromParent: "account_id"}
+	assert.Equal(t, []string{"column account_id of table test_inherit_validator_children has type TypeJSON, but it inherits from column account_id of parent table test_inherit_validator of type TypeString"}, errorStrings(ValidateTableStructure(&table)))
+
+	top := Table{Name: "test_inherit_validator_top", Resolver: resolver, Columns: []Column{{Name: "account_id", Type: TypeString, InheritFromParent: "account_id"}}}
+	assert.Equal(t, []string{"column account_id of table test_inherit_validator_top inherits from its parent, but the table has no parent"}, errorStrings(ValidateTableStructure(&top)))
+}
+
+func TestJSONOmitFieldsValidator(t *testing.T) {
+	resolver := func(context.Context, ClientMeta, *Resource, chan<- interface{}) error { return nil }
+	child := &Table{Name: "test_json_omit_validator_children", Resolver: resolver, Columns: []Column{
+		{Name: "parent_cq_id", Type: TypeUUID, Resolver: ParentIdResolver},
+		{Name: "spec", Type: TypeJSON, JSONOmitFields: []string{"Password"}},
+	}}
+	table := Table{Name: "test_json_omit_validator", Resolver: resolver, Columns: []Column{{Name: "spec", Type: TypeJSON, JSONOmitFields: []string{"Password"}}}, Relations: []*Table{child}}
+	assert.Empty(t, ValidateTableStructure(&table))
+
+	child.Columns[1].Type = TypeString
+	assert.Equal(t, []string{"column spec of table test_json_omit_validator_children has type TypeString, but only TypeJSON columns can have JSONOmitFields"}, errorStrings(ValidateTableStructure(&table)))
+}
+
+func errorStrings(errs []error) []string {
+	ret := make([]string, len(errs))
+	for i, err := range errs {
+		ret[i] = err.Error()
+	}
+	return ret
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// SchemaSnapshot returns the JSON encoded snapshot of the provider's schema, to be embedded as the PreviousSchema of
+// the provider's next release
+func (p *Provider) SchemaSnapshot() ([]byte, error) {
+	return json.MarshalIndent(schema.NewSchemaSnapshot(p.Version, p.ResourceMap), "", "  ")
+}
+
+// schemaChangeDiagnostics returns a warning for each breaking change of the schema since the PreviousSchema
+func (p *Provider) schemaChangeDiagnostics() diag.Diagnostics {
+	if len(p.PreviousSchema) == 0 {
+		return nil
+	}
+	var previous schema.SchemaSnapshot
+	if err := json.Unmarshal(p.PreviousSchema, &previous); err != nil {
+		return diag.FromError(err, diag.SCHEMA, diag.WithSeverity(diag.WARNING), diag.WithSummary("failed to decode previous schema of provider %s", p.Name))
+	}
+	var diags diag.Diagnostics
+	for _, c := range previous.BreakingChanges(p.ResourceMap) {
+		diags = diags.Add(diag.NewBaseError(nil, diag.SCHEMA, diag.WithSeverity(diag.WARNING), diag.WithResourceName(c.Table),
+			diag.WithSummary("breaking schema change since version %s: %s", previous.Version, c),
+			diag.WithDetails("queries using the %s may break once the provider is upgraded and its migrations are run", changedObject(c))))
+	}
+	return diags
+}
+
+func changedObject(c schema.SchemaChange) string {
+	if c.Kind == schema.TableDropped {
+		return "table"
+	}
+	return "column"
+}
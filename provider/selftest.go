@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/cloudquery/cq-provider-sdk/migration"
+	"github.com/cloudquery/cq-provider-sdk/migration/migrator"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/creasty/defaults"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/hashicorp/go-hclog"
+	"github.com/jackc/pgx/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// SelfTestOptions configure which checks SelfTest runs
+type SelfTestOptions struct {
+	// DSN of an empty database to run the provider's migrations against
+	DSN string
+	// Docker starts an ephemeral postgres docker container to run the migrations against, if DSN isn't set
+	Docker bool
+	// Image of the postgres container started if Docker is set, defaults to migrator.DefaultPostgresImage
+	Image string
+}
+
+// SelfTest validates the provider end to end, so it can be run in CI: all tables are validated, the example
+// configuration is decoded into the provider's config, and if a database is given the migrations are run to the
+// latest version, and the resulting database schema is validated against the tables.
+func (p *Provider) SelfTest(ctx context.Context, opts SelfTestOptions) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags = diags.Add(ValidateResourceMap(p.ResourceMap))
+	diags = diags.Add(p.selfTestConfig())
+	return diags.Add(p.selfTestMigrations(ctx, opts))
+}
+
+func (p *Provider) selfTestConfig() diag.Diagnostics {
+	if p.Config == nil {
+		return diag.FromError(errors.New("provider has no config"), diag.INTERNAL)
+	}
+	cfg := p.Config()
+	if err := defaults.Set(cfg); err != nil {
+		return diag.FromError(err, diag.INTERNAL, diag.WithSummary("failed to set config defaults"))
+	}
+	dec := yaml.NewDecoder(bytes.NewBufferString(cfg.Example()))
+	dec.KnownFields(true)
+	// an example with only comments is decoded as an empty document
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return diag.FromError(err, diag.INTERNAL, diag.WithSummary("example configuration doesn't match the provider's config"))
+	}
+	return nil
+}
+
+func (p *Provider) selfTestMigrations(ctx context.Context, opts SelfTestOptions) diag.Diagnostics {
+	if len(p.Migrations) == 0 {
+		return diag.FromError(errors.New("provider has no migrations"), diag.SCHEMA, diag.WithSeverity(diag.WARNING))
+	}
+	dsn := opts.DSN
+	if dsn == "" {
+		if !opts.Docker {
+			return diag.FromError(errors.New("no database given, skipping migrations check"), diag.DATABASE, diag.WithSeverity(diag.WARNING))
+		}
+		var (
+			stop func()
+			err  error
+		)
+		dsn, stop, err = migrator.StartPostgresContainer(ctx, opts.Image)
+		if err != nil {
+			return diag.FromError(err, diag.DATABASE)
+		}
+		defer stop()
+	}
+
+	logger := p.Logger
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	m, err := migrator.New(logger, schema.Postgres, p.Migrations, dsn, p.Name)
+	if err != nil {
+		return diag.FromError(err, diag.SCHEMA, diag.WithSummary("failed to read migrations"))
+	}
+	defer m.Close()
+	if err := m.UpgradeProvider(migrator.Latest); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return diag.FromError(err, diag.SCHEMA, diag.WithSummary("failed to upgrade to latest migration"))
+	}
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return diag.FromError(err, diag.DATABASE)
+	}
+	defer conn.Close(ctx)
+	return migration.ValidateTables(ctx, conn, schema.PostgresDialect{}, p.ResourceMap)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+)
+
+// TelemetryReporter receives anonymized usage telemetry of the SDK. Telemetry is opt-in: it is only reported if a
+// reporter is set on the Provider (or passed via serve.Options). Events never carry user data, only resource names,
+// counts, durations and diagnostic types. Implementations must be safe for concurrent use.
+type TelemetryReporter interface {
+	// ReportResource is called when a resource finished fetching
+	ReportResource(ctx context.Context, e ResourceTelemetry)
+	// ReportFetch is called when a FetchResources call finished
+	ReportFetch(ctx context.Context, e FetchTelemetry)
+}
+
+// ResourceTelemetry is an anonymized summary of a single resource fetch
+type ResourceTelemetry struct {
+	ProviderName    string
+	ProviderVersion string
+	Resource        string
+	Status          cqproto.ResourceFetchStatus
+	Duration        time.Duration
+	ResourceCount   uint64
+	// Diagnostics counts the diagnostics returned for the resource by type and severity
+	Diagnostics map[diag.Type]map[diag.Severity]uint64
+}
+
+// FetchTelemetry is an anonymized summary of a FetchResources call
+type FetchTelemetry struct {
+	ProviderName    string
+	ProviderVersion string
+	ResourcesCount  int
+	Duration        time.Duration
+	ResourceCount   uint64
+	Error           bool
+}
+
+func countDiagnostics(diags diag.Diagnostics) map[diag.Type]map[diag.Severity]uint64 {
+	if len(diags) == 0 {
+		return nil
+	}
+	ret := make(map[diag.Type]map[diag.Severity]uint64)
+	for _, d := range diags {
+		if ret[d.Type()] == nil {
+			ret[d.Type()] = make(map[diag.Severity]uint64)
+		}
+		c := uint64(1)
+		if cd, ok := d.(diag.Countable); ok {
+			c = cd.Count()
+		}
+		ret[d.Type()][d.Severity()] += c
+	}
+	return ret
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// validateTenant verifies fetches of multi tenant providers have a tenant, and that other providers' don't
+func (p *Provider) validateTenant(tenantID string) error {
+	if p.MultiTenant && tenantID == "" {
+		return fmt.Errorf("provider %s is multi tenant, requests must have a tenant id", p.Name)
+	}
+	if !p.MultiTenant && tenantID != "" {
+		return fmt.Errorf("provider %s isn't multi tenant, requests can't have a tenant id", p.Name)
+	}
+	return nil
+}
+
+// tenantMetadata returns a copy of metadata with the tenant id, which sets the cq_tenant_id column of the resources
+func tenantMetadata(metadata map[string]interface{}, tenantID string) map[string]interface{} {
+	md := make(map[string]interface{}, len(metadata)+1)
+	for k, v := range metadata {
+		md[k] = v
+	}
+	md[schema.TenantIdMetaKey] = tenantID
+	return md
+}
+
+// tenantStorage returns conn writing with its dialect wrapped by schema.TenantDialect, conn must be an
+// execution.DialectStorage or a borrowed one
+func tenantStorage(conn execution.Storage) (execution.Storage, error) {
+	switch s := conn.(type) {
+	case borrowedStorage:
+		inner, err := tenantStorage(s.Storage)
+		if err != nil {
+			return nil, err
+		}
+		return borrowedStorage{inner}, nil
+	case borrowedStagingStorage:
+		inner, err := tenantStorage(s.StagingStorage)
+		if err != nil {
+			return nil, err
+		}
+		if staging, ok := inner.(execution.StagingStorage); ok {
+			return borrowedStagingStorage{staging}, nil
+		}
+		return borrowedStorage{inner}, nil
+	case execution.DialectStorage:
+		return s.WithDialect(schema.NewTenantDialect(s.Dialect())), nil
+	}
+	return nil, fmt.Errorf("storage doesn't support tenants")
+}
@@ -0,0 +1,64 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// InsertModes are the insert modes benchmarked by BenchmarkInsertModes
+var InsertModes = []schema.InsertMode{schema.InsertModeDefault, schema.InsertModeUpsert}
+
+// BenchmarkInsertModes benchmarks writing resources of table with each of InsertModes, against the database of
+// DATABASE_URL, so providers can measure which mode is faster for their data shape. resources is called for every
+// iteration and should return resources of table, the first batch is written before the timer starts so the
+// benchmarked writes replace existing rows, as a refetch does.
+//
+//	func BenchmarkInstances(b *testing.B) {
+//		providertest.BenchmarkInsertModes(b, resources.Instances(), func() schema.Resources { return fakeInstances(1000) })
+//	}
+func BenchmarkInsertModes(b *testing.B, table *schema.Table, resources func() schema.Resources) {
+	conn, err := setupDatabase()
+	if err != nil {
+		b.Fatal(err)
+	}
+	storage, ok := conn.(execution.Storage)
+	if !ok {
+		b.Fatalf("database connection %T isn't a storage", conn)
+	}
+	ctx := context.Background()
+	for _, mode := range InsertModes {
+		t := *table
+		t.InsertMode = mode
+		b.Run(mode.String(), func(b *testing.B) {
+			if err := dropAndCreateTable(ctx, conn, &t); err != nil {
+				b.Fatal(err)
+			}
+			if err := writeResources(ctx, storage, &t, resources()); err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				batch := resources()
+				b.StartTimer()
+				if err := writeResources(ctx, storage, &t, batch); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	if err := dropTables(ctx, conn, table); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// writeResources writes top level resources the way the table executor does, without the fallbacks of failed writes
+func writeResources(ctx context.Context, storage execution.Storage, t *schema.Table, resources schema.Resources) error {
+	if t.InsertMode == schema.InsertModeUpsert {
+		return storage.Insert(ctx, t, resources, true)
+	}
+	return storage.CopyFrom(ctx, resources, true)
+}
@@ -0,0 +1,346 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudquery/cq-provider-sdk/helpers/httpclient"
+)
+
+// CassetteModeEnv selects the mode of recorders created with CassetteModeFromEnv, one of record, replay or passthrough
+const CassetteModeEnv = "CQ_CASSETTE_MODE"
+
+// CassetteMode is how a Recorder handles requests
+type CassetteMode int
+
+const (
+	// ModeReplay responds to requests with the responses recorded in the cassette, without calling the API. Requests
+	// without a recorded response fail.
+	ModeReplay CassetteMode = iota
+	// ModeRecord calls the API and records the requests and responses, the cassette is written as each of them is
+	// recorded, so it survives tests that panic
+	ModeRecord
+	// ModePassthrough calls the API without recording or replaying
+	ModePassthrough
+)
+
+func (m CassetteMode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModePassthrough:
+		return "passthrough"
+	default:
+		return "unknown"
+	}
+}
+
+// CassetteModeFromEnv returns the mode set by CassetteModeEnv, ModeReplay if it isn't set, so CI runs replay the
+// recorded cassettes and live runs record them with CQ_CASSETTE_MODE=record.
+func CassetteModeFromEnv() (CassetteMode, error) {
+	switch v := os.Getenv(CassetteModeEnv); v {
+	case "", "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "passthrough":
+		return ModePassthrough, nil
+	default:
+		return ModeReplay, fmt.Errorf("invalid %s %q, expected record, replay or passthrough", CassetteModeEnv, v)
+	}
+}
+
+// DefaultScrubHeaders are the headers removed from recorded requests and responses if RecorderOptions.ScrubHeaders
+// isn't set
+var DefaultScrubHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Amz-Security-Token",
+	"X-Goog-Api-Key",
+}
+
+// RecordedRequest is a request of an Interaction
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response of an Interaction
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a request to the API and its response, as recorded in a cassette
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// cassette is the file the interactions of a Recorder are saved to
+type cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// RecorderOptions configure a Recorder
+type RecorderOptions struct {
+	Mode CassetteMode
+	// ScrubHeaders are removed from the recorded requests and responses, DefaultScrubHeaders if nil
+	ScrubHeaders []string
+	// Redact replaces each key with its value in the recorded URLs, headers and bodies, i.e account identifiers with
+	// placeholders: map[string]string{"123456789012": "000000000000"}. Replayed requests are redacted before they are
+	// matched, so they match whether they are made with the real or the placeholder values.
+	Redact map[string]string
+	// Scrub is called with each interaction before it's recorded, to remove other secrets
+	Scrub func(*Interaction)
+	// Transport is the transport of the API calls in ModeRecord and ModePassthrough, http.DefaultTransport if nil
+	Transport http.RoundTripper
+}
+
+// Recorder is a http.RoundTripper recording the API responses of a live integration run to a cassette file, and
+// replaying them so the test can run in CI without credentials, see ResourceTestCase.Recorder. Providers use it as the
+// transport of their API clients in tests:
+//
+//	rec, err := providertest.NewRecorder("testdata/instances.json", providertest.RecorderOptions{Mode: mode})
+//	client := &http.Client{Transport: rec}
+type Recorder struct {
+	path       string
+	opts       RecorderOptions
+	mu         sync.Mutex
+	tape       cassette
+	used       []bool
+	redactKeys []string
+}
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// NewRecorder creates a Recorder of the cassette at path. In ModeReplay the cassette is read, and must exist.
+func NewRecorder(path string, opts RecorderOptions) (*Recorder, error) {
+	if opts.ScrubHeaders == nil {
+		opts.ScrubHeaders = DefaultScrubHeaders
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, opts: opts}
+	for k := range opts.Redact {
+		r.redactKeys = append(r.redactKeys, k)
+	}
+	// longer keys are replaced first, so keys containing others are redacted whole
+	sort.Slice(r.redactKeys, func(i, j int) bool {
+		if len(r.redactKeys[i]) != len(r.redactKeys[j]) {
+			return len(r.redactKeys[i]) > len(r.redactKeys[j])
+		}
+		return r.redactKeys[i] < r.redactKeys[j]
+	})
+	if opts.Mode != ModeReplay {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.tape); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.tape.Interactions))
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() CassetteMode {
+	return r.opts.Mode
+}
+
+// Middleware returns a httpclient.Middleware using the recorder, the wrapped transport is called in ModeRecord and
+// ModePassthrough instead of RecorderOptions.Transport
+func (r *Recorder) Middleware() httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return httpclient.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return r.roundTrip(req, next)
+		})
+	}
+}
+
+// RoundTrip records, replays or passes through the request, by the recorder's mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, r.opts.Transport)
+}
+
+func (r *Recorder) roundTrip(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	switch r.opts.Mode {
+	case ModePassthrough:
+		return next.RoundTrip(req)
+	case ModeRecord:
+		return r.record(req, next)
+	default:
+		return r.replay(req)
+	}
+}
+
+func (r *Recorder) record(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	i := &Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     r.redact(req.URL.String()),
+			Headers: r.scrubHeaders(req.Header),
+			Body:    r.redact(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeaders(resp.Header),
+			Body:       r.redact(respBody),
+		},
+	}
+	if r.opts.Scrub != nil {
+		r.opts.Scrub(i)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tape.Interactions = append(r.tape.Interactions, i)
+	if err := r.save(); err != nil {
+		return nil, fmt.Errorf("failed to save cassette: %w", err)
+	}
+	return resp, nil
+}
+
+// replay responds with the first unused interaction matching the request by method, URL and body. Once all of the
+// matching interactions are used, the last of them is replayed again, i.e for polled requests.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	method, url, body := req.Method, r.redact(req.URL.String()), r.redact(body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, in := range r.tape.Interactions {
+		if in.Request.Method != method || in.Request.URL != url || in.Request.Body != body {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s in cassette %s", method, url, r.path)
+	}
+	r.used[match] = true
+	recorded := r.tape.Interactions[match].Response
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	// redacted bodies may differ in length from the recorded response
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette, it's a no-op in modes other than ModeRecord. Interactions are
+// saved as they are recorded, so it's only needed to write the cassette of a recording without interactions.
+func (r *Recorder) Save() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.save()
+}
+
+// save writes the cassette to a temporary file that replaces it, so it isn't left partially written. r.mu must be held.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.tape, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+func (r *Recorder) redact(s string) string {
+	for _, k := range r.redactKeys {
+		s = strings.ReplaceAll(s, k, r.opts.Redact[k])
+	}
+	return s
+}
+
+// scrubHeaders returns a copy of h without the scrubbed headers, and with the other values redacted
+func (r *Recorder) scrubHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	scrubbed := make(http.Header, len(h))
+	for k, values := range h {
+		redacted := make([]string, len(values))
+		for i, v := range values {
+			redacted[i] = r.redact(v)
+		}
+		scrubbed[k] = redacted
+	}
+	for _, k := range r.opts.ScrubHeaders {
+		scrubbed.Del(k)
+	}
+	return scrubbed
+}
+
+// readBody reads the body and replaces it with a reader of its content, so it can be read again
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return "", err
+	}
+	if err := (*body).Close(); err != nil {
+		return "", err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
@@ -0,0 +1,122 @@
+package testing
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	cassetteAccount = "123456789012"
+	cassetteToken   = "secret-token"
+)
+
+// newCassetteServer returns a server responding with the account of the request's path, and the number of requests it
+// received
+func newCassetteServer(t *testing.T) (*httptest.Server, *int) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		body, _ := io.ReadAll(req.Body)
+		w.Header().Set("Set-Cookie", "session="+cassetteToken)
+		w.Header().Set("X-Account", cassetteAccount)
+		_, _ = io.WriteString(w, `{"account":"`+cassetteAccount+`","path":"`+req.URL.Path+`","body":"`+string(body)+`"}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func cassetteRequest(t *testing.T, client *http.Client, url, body string) string {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+cassetteToken)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRecorder_RecordReplay(t *testing.T) {
+	srv, calls := newCassetteServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "accounts.json")
+	opts := RecorderOptions{
+		Redact: map[string]string{cassetteAccount: "000000000000"},
+		Scrub: func(i *Interaction) {
+			i.Response.Headers.Del("X-Account")
+		},
+	}
+	url := srv.URL + "/accounts/" + cassetteAccount
+
+	opts.Mode = ModeRecord
+	rec, err := NewRecorder(path, opts)
+	require.NoError(t, err)
+	recorded := cassetteRequest(t, &http.Client{Transport: rec}, url, "first")
+	// responses are recorded redacted, but returned as the API responded
+	assert.Contains(t, recorded, cassetteAccount)
+	assert.Equal(t, 1, *calls)
+
+	// the cassette is saved as the interaction is recorded, without Save, and has no secrets
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), cassetteAccount)
+	assert.NotContains(t, string(data), cassetteToken)
+	assert.NotContains(t, string(data), "X-Account")
+	assert.Contains(t, string(data), "000000000000")
+	require.NoError(t, rec.Save())
+
+	opts.Mode = ModeReplay
+	rec, err = NewRecorder(path, opts)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rec}
+	// requests match the cassette with the real or the redacted values
+	assert.Equal(t, strings.ReplaceAll(recorded, cassetteAccount, "000000000000"), cassetteRequest(t, client, url, "first"))
+	assert.Equal(t, strings.ReplaceAll(recorded, cassetteAccount, "000000000000"), cassetteRequest(t, client, srv.URL+"/accounts/000000000000", "first"))
+	assert.Equal(t, 1, *calls)
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader("other"))
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorContains(t, err, "no recorded response for POST")
+}
+
+func TestRecorder_Passthrough(t *testing.T) {
+	srv, calls := newCassetteServer(t)
+	path := filepath.Join(t.TempDir(), "passthrough.json")
+	rec, err := NewRecorder(path, RecorderOptions{Mode: ModePassthrough})
+	require.NoError(t, err)
+	client := &http.Client{Transport: rec}
+	assert.Contains(t, cassetteRequest(t, client, srv.URL+"/a", "body"), `"path":"/a"`)
+	assert.Contains(t, cassetteRequest(t, client, srv.URL+"/a", "body"), `"path":"/a"`)
+	assert.Equal(t, 2, *calls)
+
+	require.NoError(t, rec.Save())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderOptions{Mode: ModeReplay})
+	assert.Error(t, err)
+}
+
+func TestCassetteModeFromEnv(t *testing.T) {
+	for v, expected := range map[string]CassetteMode{"": ModeReplay, "replay": ModeReplay, "record": ModeRecord, "passthrough": ModePassthrough} {
+		t.Setenv(CassetteModeEnv, v)
+		mode, err := CassetteModeFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+	t.Setenv(CassetteModeEnv, "live")
+	_, err := CassetteModeFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/database/memory"
+	"github.com/cloudquery/cq-provider-sdk/helpers/limit"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/cloudquery/cq-provider-sdk/testlog"
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/sync/semaphore"
+)
+
+// FixtureTestCase resolves a table against recorded fixtures instead of a cloud account, and stores its resources in
+// memory instead of a database, see TestFixture.
+type FixtureTestCase struct {
+	Table *schema.Table
+	// Fixture is the path of a JSON file with an array of the items the table's resolver sends, i.e recorded API
+	// responses. The table's Resolver isn't called, its columns and relations are resolved from the fixture's items.
+	Fixture string
+	// Item is an example of the items the table's resolver sends, the fixture's objects are decoded into values of its
+	// type. If not set, they are decoded into maps.
+	Item interface{}
+	// Meta is the client the table is resolved with, for relations and column resolvers calling APIs. If not set a
+	// client logging to the test is used.
+	Meta schema.ClientMeta
+	// Expected are the rows expected in each table, by name. Each expected row must match a stored row of the table, in
+	// any order, and the table must have as many rows. Values can be a Matcher, other values must be equal to the
+	// column's value in its JSON form. Only the listed columns are asserted.
+	Expected map[string][]Row
+	// ExpectedDiagnostics are the summaries of the diagnostics the fetch is expected to return, in any order. If not
+	// set, any diagnostic other than ignored ones fails the test.
+	ExpectedDiagnostics []string
+	// Relations are the fixtures of the relations calling APIs, by table name. The resolver of each of these relations
+	// isn't called, the items of its fixture are sent for each of its parent resources. Other relations are resolved by
+	// their resolvers, i.e from the parent's item.
+	Relations map[string]RelationFixture
+}
+
+// RelationFixture is the fixture a relation is resolved with, see FixtureTestCase.Relations
+type RelationFixture struct {
+	// Fixture is the path of a JSON file with an array of the items the relation's resolver sends
+	Fixture string
+	// Item is an example of the items the relation's resolver sends, see FixtureTestCase.Item
+	Item interface{}
+}
+
+// fixtureClient is the client of fixture tests without a Meta
+type fixtureClient struct {
+	logger hclog.Logger
+}
+
+func (c fixtureClient) Logger() hclog.Logger {
+	return c.logger
+}
+
+// TestFixture resolves the table of the test case with the items of its fixture, and asserts the rows stored and the
+// diagnostics returned. It's deterministic and doesn't need faker or a database, so it can run in provider unit tests:
+//
+//	func TestInstances(t *testing.T) {
+//		providertest.TestFixture(t, providertest.FixtureTestCase{
+//			Table:   Instances(),
+//			Fixture: "testdata/instances.json",
+//			Item:    ec2.Instance{},
+//			Expected: map[string][]providertest.Row{
+//				"aws_ec2_instances": {{"id": "i-1", "state": "running"}},
+//			},
+//		})
+//	}
+func TestFixture(t *testing.T, tc FixtureTestCase) {
+	t.Helper()
+	fixtures := map[string]RelationFixture{tc.Table.Name: {Fixture: tc.Fixture, Item: tc.Item}}
+	for name, f := range tc.Relations {
+		fixtures[name] = f
+	}
+	table, err := fixtureTable(tc.Table, fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Multiplex = nil
+	meta := tc.Meta
+	if meta == nil {
+		meta = fixtureClient{testlog.New(t)}
+	}
+
+	storage := memory.New()
+	exec := execution.NewTableExecutor(table.Name, storage, meta.Logger(), table, nil, nil,
+		semaphore.NewWeighted(int64(limit.GetMaxGoRoutines())), 0)
+	_, diags := exec.Resolve(context.Background(), meta)
+	assertFixtureDiagnostics(t, diags, tc.ExpectedDiagnostics)
+
+	names := make([]string, 0, len(tc.Expected))
+	for name := range tc.Expected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rows, err := fixtureRows(storage, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertFixtureRows(t, name, rows, tc.Expected[name])
+	}
+}
+
+// fixtureTable returns a copy of the table and its relations, with the tables that have a fixture resolved from its
+// items instead of their resolvers. The table isn't modified.
+func fixtureTable(t *schema.Table, fixtures map[string]RelationFixture) (*schema.Table, error) {
+	table := *t
+	if f, ok := fixtures[t.Name]; ok {
+		items, err := LoadFixture(f.Fixture, f.Item)
+		if err != nil {
+			return nil, err
+		}
+		table.Resolver = func(_ context.Context, _ schema.ClientMeta, _ *schema.Resource, res chan<- interface{}) error {
+			res <- items
+			return nil
+		}
+		table.BatchResolver, table.GetResolver = nil, nil
+	}
+	if len(t.Relations) == 0 {
+		return &table, nil
+	}
+	table.Relations = make([]*schema.Table, len(t.Relations))
+	for i, r := range t.Relations {
+		rel, err := fixtureTable(r, fixtures)
+		if err != nil {
+			return nil, err
+		}
+		table.Relations[i] = rel
+	}
+	return &table, nil
+}
+
+// LoadFixture decodes the JSON array of the fixture file into items of the type of item, or maps if item is nil.
+// Items are returned as pointers to values of the type, as they are usually sent by resolvers.
+func LoadFixture(path string, item interface{}) ([]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("fixture %s isn't a JSON array: %w", path, err)
+	}
+	items := make([]interface{}, len(raw))
+	for i, r := range raw {
+		if item == nil {
+			var m map[string]interface{}
+			if err := json.Unmarshal(r, &m); err != nil {
+				return nil, fmt.Errorf("failed to decode item %d of fixture %s: %w", i, path, err)
+			}
+			items[i] = m
+			continue
+		}
+		t := reflect.TypeOf(item)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		v := reflect.New(t)
+		if err := json.Unmarshal(r, v.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to decode item %d of fixture %s into %s: %w", i, path, t, err)
+		}
+		items[i] = v.Interface()
+	}
+	return items, nil
+}
+
+// fixtureRows returns the rows stored in the table, with their values in JSON form as the matchers expect them
+func fixtureRows(storage *memory.Storage, table string) ([]Row, error) {
+	stored := storage.Table(table).Rows()
+	rows := make([]Row, len(stored))
+	for i, r := range stored {
+		row := make(Row, len(r))
+		for k, v := range r {
+			nv, err := normalizeValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to normalize column %s of table %s: %w", k, table, err)
+			}
+			row[k] = nv
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// assertFixtureRows asserts each of the expected rows matches a different row of rows, and there are no other rows
+func assertFixtureRows(t *testing.T, table string, rows []Row, expected []Row) {
+	t.Helper()
+	if len(rows) != len(expected) {
+		t.Errorf("expected %d rows in table %s, got %d", len(expected), table, len(rows))
+	}
+	for _, i := range unmatchedFixtureRows(rows, expected) {
+		t.Errorf("expected row %d of table %s doesn't match a row%s", i, table, closestRows(rows, toMatchers(expected[i])))
+	}
+}
+
+// unmatchedFixtureRows returns the indexes of the expected rows that don't match a row, once each row is matched with
+// at most one expected row. Rows are matched by maximum bipartite matching, so the result doesn't depend on the order
+// of the rows, even if an expected row matches several of them.
+func unmatchedFixtureRows(rows []Row, expected []Row) []int {
+	candidates := make([][]int, len(expected))
+	for i, e := range expected {
+		matchers := toMatchers(e)
+		for j, r := range rows {
+			if len(rowDiff(r, matchers)) == 0 {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+	}
+	// matchedBy is the expected row each row is matched with, -1 if it isn't
+	matchedBy := make([]int, len(rows))
+	for j := range matchedBy {
+		matchedBy[j] = -1
+	}
+	// augment looks for a row of expected row i, taking it from the expected row it's matched with if that one can be
+	// matched with another row
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for _, j := range candidates[i] {
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if matchedBy[j] < 0 || augment(matchedBy[j], visited) {
+				matchedBy[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	var unmatched []int
+	for i := range expected {
+		if !augment(i, make([]bool, len(rows))) {
+			unmatched = append(unmatched, i)
+		}
+	}
+	return unmatched
+}
+
+func assertFixtureDiagnostics(t *testing.T, diags diag.Diagnostics, expected []string) {
+	t.Helper()
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity() == diag.IGNORE {
+			continue
+		}
+		summaries = append(summaries, d.Description().Summary)
+	}
+	sort.Strings(summaries)
+	want := append([]string(nil), expected...)
+	sort.Strings(want)
+	if strings.Join(summaries, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected diagnostics %q, got %q", want, summaries)
+	}
+}
@@ -0,0 +1,115 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixtureInstance struct {
+	Id    string
+	State string
+	Tags  map[string]string
+}
+
+type fixtureVolume struct {
+	VolumeId string
+	Size     int
+}
+
+func fixtureInstancesTable() *schema.Table {
+	apiResolver := func(context.Context, schema.ClientMeta, *schema.Resource, chan<- interface{}) error {
+		return errors.New("the API isn't called by fixture tests")
+	}
+	return &schema.Table{
+		Name:     "fixture_instances",
+		Resolver: apiResolver,
+		Columns: []schema.Column{
+			{Name: "id", Type: schema.TypeString},
+			{Name: "state", Type: schema.TypeString},
+			{Name: "tags", Type: schema.TypeJSON},
+		},
+		Relations: []*schema.Table{
+			{
+				Name:     "fixture_instance_volumes",
+				Resolver: apiResolver,
+				Columns: []schema.Column{
+					{Name: "instance_id", Type: schema.TypeString, Resolver: schema.ParentResourceFieldResolver("id")},
+					{Name: "volume_id", Type: schema.TypeString},
+					{Name: "size", Type: schema.TypeInt},
+				},
+			},
+		},
+	}
+}
+
+func TestFixture_Relations(t *testing.T) {
+	table := fixtureInstancesTable()
+	TestFixture(t, FixtureTestCase{
+		Table:   table,
+		Fixture: "testdata/instances.json",
+		Item:    fixtureInstance{},
+		Relations: map[string]RelationFixture{
+			"fixture_instance_volumes": {Fixture: "testdata/instance_volumes.json", Item: &fixtureVolume{}},
+		},
+		Expected: map[string][]Row{
+			"fixture_instances": {
+				{"id": "i-2", "state": "stopped", "tags": map[string]interface{}{"env": "dev"}},
+				{"id": "i-1", "state": "running", "tags": SubsetOfJSON(map[string]string{"env": "prod"}), "cq_id": AnyUUID()},
+			},
+			"fixture_instance_volumes": {
+				{"instance_id": "i-1", "volume_id": "vol-1", "size": 8},
+				{"instance_id": "i-2", "volume_id": "vol-1", "size": GreaterThan(0)},
+			},
+		},
+	})
+	// the table and its relations aren't modified
+	assert.Error(t, table.Resolver(context.Background(), nil, nil, nil))
+	assert.Error(t, table.Relations[0].Resolver(context.Background(), nil, nil, nil))
+}
+
+func TestFixture_ExpectedDiagnostics(t *testing.T) {
+	TestFixture(t, FixtureTestCase{
+		Table:   fixtureInstancesTable(),
+		Fixture: "testdata/instances.json",
+		Item:    fixtureInstance{},
+		ExpectedDiagnostics: []string{
+			`failed to resolve table "fixture_instance_volumes": the API isn't called by fixture tests`,
+			`failed to resolve table "fixture_instance_volumes": the API isn't called by fixture tests`,
+		},
+		Expected: map[string][]Row{
+			"fixture_instances":        {{"id": "i-1"}, {"id": "i-2"}},
+			"fixture_instance_volumes": {},
+		},
+	})
+}
+
+func TestLoadFixture(t *testing.T) {
+	items, err := LoadFixture("testdata/instances.json", &fixtureInstance{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		&fixtureInstance{Id: "i-1", State: "running", Tags: map[string]string{"env": "prod"}},
+		&fixtureInstance{Id: "i-2", State: "stopped", Tags: map[string]string{"env": "dev"}},
+	}, items)
+
+	items, err = LoadFixture("testdata/instance_volumes.json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"VolumeId": "vol-1", "Size": 8.0}}, items)
+
+	_, err = LoadFixture("testdata/missing.json", nil)
+	assert.Error(t, err)
+}
+
+func TestUnmatchedFixtureRows(t *testing.T) {
+	rows := []Row{{"name": "a"}, {"name": "b"}}
+	// the first expected row matches both rows, it's matched with the row the second one doesn't match
+	assert.Empty(t, unmatchedFixtureRows(rows, []Row{{"name": Any()}, {"name": "a"}}))
+	assert.Empty(t, unmatchedFixtureRows(rows, []Row{{"name": "b"}, {"name": Exists()}}))
+	assert.Equal(t, []int{1}, unmatchedFixtureRows(rows, []Row{{"name": "a"}, {"name": "a"}}))
+	assert.Equal(t, []int{0}, unmatchedFixtureRows(rows, []Row{{"name": "c"}, {"name": "b"}}))
+	assert.Equal(t, []int{0}, unmatchedFixtureRows(nil, []Row{{"name": "a"}}))
+}
@@ -0,0 +1,56 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/database"
+	"github.com/cloudquery/cq-provider-sdk/database/dsn"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+)
+
+// isolatedSchemaPrefix prefixes the names of the schemas created by setupIsolatedSchema
+const isolatedSchemaPrefix = "cq_test_"
+
+// setupIsolatedSchema creates a schema of its own for the test, and connects to the database of DATABASE_URL with
+// search_path set to it, so tables of test cases running in parallel don't interfere. The connection is closed, and
+// the schema dropped with its tables, once the test ends. Returns the DSN the provider connects with.
+func setupIsolatedSchema(t *testing.T) (execution.QueryExecer, string, error) {
+	t.Helper()
+	shared, err := setupDatabase()
+	if err != nil {
+		return nil, "", err
+	}
+	name := isolatedSchemaPrefix + strings.ReplaceAll(uuid.NewString(), "-", "")
+	ctx := context.Background()
+	if err := shared.Exec(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema.QuoteIdentifier(name))); err != nil {
+		return nil, "", fmt.Errorf("failed to create schema %s: %w", name, err)
+	}
+	t.Cleanup(func() {
+		if err := shared.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schema.QuoteIdentifier(name))); err != nil {
+			t.Errorf("failed to drop schema %s: %v", name, err)
+		}
+	})
+	dsnURI, err := isolatedSchemaDSN(getEnv("DATABASE_URL", defaultDatabaseURL), name)
+	if err != nil {
+		return nil, "", err
+	}
+	db, err := database.New(ctx, hclog.NewNullLogger(), dsnURI)
+	if err != nil {
+		return nil, "", err
+	}
+	// registered after the schema's cleanup, so it runs first and the connections don't hold the schema
+	t.Cleanup(db.Close)
+	return db, dsnURI, nil
+}
+
+// isolatedSchemaDSN returns dsnURI with search_path set to the schema, in URL form so postgres.Connect doesn't reset
+// it to public
+func isolatedSchemaDSN(dsnURI, schemaName string) (string, error) {
+	return dsn.SetDSNElement(dsnURI, map[string]string{"search_path": schemaName})
+}
@@ -0,0 +1,19 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+)
+
+// VerifyColumnsMapped fails the test if a column of table has neither a resolver nor a field in item reachable by the
+// default resolving path, see schema.UnmappedColumns. item is an example of the objects the table's resolver sends.
+// Unlike the verifiers, it runs without fetching, in provider unit tests:
+//
+//	providertest.VerifyColumnsMapped(t, Instances(), ec2.Instance{})
+func VerifyColumnsMapped(t *testing.T, table *schema.Table, item interface{}) {
+	t.Helper()
+	if unmapped := schema.UnmappedColumns(table, item); len(unmapped) > 0 {
+		t.Errorf("table %s has columns without resolver or matching field in %T, they will always be null: %v", table.Name, item, unmapped)
+	}
+}
@@ -0,0 +1,206 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+)
+
+// Matcher asserts a column value read from the database. Values are as decoded from JSON, that is numbers are float64,
+// and objects are map[string]interface{}.
+// Matchers can be used as values of the expected Row of VerifyEventuallyContains, or in VerifyRowsMatch.
+type Matcher interface {
+	// Match returns an error describing why the value doesn't match
+	Match(v interface{}) error
+}
+
+// MatcherFunc is a func implementing Matcher
+type MatcherFunc func(v interface{}) error
+
+func (f MatcherFunc) Match(v interface{}) error {
+	return f(v)
+}
+
+// Exists matches any non-null value
+func Exists() Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		if v == nil {
+			return fmt.Errorf("expected a value, got null")
+		}
+		return nil
+	})
+}
+
+// Any matches any value, including null
+func Any() Matcher {
+	return MatcherFunc(func(interface{}) error {
+		return nil
+	})
+}
+
+// AnyUUID matches strings that are UUIDs
+func AnyUUID() Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a uuid, got %v", v)
+		}
+		if _, err := uuid.Parse(s); err != nil {
+			return fmt.Errorf("expected a uuid, got %q", s)
+		}
+		return nil
+	})
+}
+
+// timestampLayouts are the layouts of timestamps read from the database as JSON, with and without time zone
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999Z07:00"}
+
+// AnyTimestamp matches strings that are timestamps
+func AnyTimestamp() Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a timestamp, got %v", v)
+		}
+		for _, layout := range timestampLayouts {
+			if _, err := time.Parse(layout, s); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected a timestamp, got %q", s)
+	})
+}
+
+// Equals matches values equal to expected, after it's normalized to its JSON form
+func Equals(expected interface{}) Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		want, err := normalizeValue(expected)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(v, want) {
+			return fmt.Errorf("expected %v, got %v", want, v)
+		}
+		return nil
+	})
+}
+
+// MatchesRegex matches string values matching the regular expression
+func MatchesRegex(expr string) Matcher {
+	re := regexp.MustCompile(expr)
+	return MatcherFunc(func(v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected a string matching %q, got %v", expr, v)
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("expected a string matching %q, got %q", expr, s)
+		}
+		return nil
+	})
+}
+
+// GreaterThan matches numeric values greater than n
+func GreaterThan(n float64) Matcher {
+	return numericMatcher("greater than", n, func(v float64) bool { return v > n })
+}
+
+// LessThan matches numeric values less than n
+func LessThan(n float64) Matcher {
+	return numericMatcher("less than", n, func(v float64) bool { return v < n })
+}
+
+// Between matches numeric values in the closed range [min, max]
+func Between(min, max float64) Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		f, err := toNumber(v)
+		if err != nil || f < min || f > max {
+			return fmt.Errorf("expected a number between %v and %v, got %v", min, max, v)
+		}
+		return nil
+	})
+}
+
+// SubsetOfJSON matches JSON values containing expected: objects must have all the expected keys with matching values,
+// and arrays must have a matching element for each expected element. Other values must be equal.
+func SubsetOfJSON(expected interface{}) Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		want, err := normalizeValue(expected)
+		if err != nil {
+			return err
+		}
+		if !jsonSubset(want, v) {
+			return fmt.Errorf("expected a superset of %v, got %v", want, v)
+		}
+		return nil
+	})
+}
+
+func numericMatcher(op string, n float64, cmp func(float64) bool) Matcher {
+	return MatcherFunc(func(v interface{}) error {
+		f, err := toNumber(v)
+		if err != nil || !cmp(f) {
+			return fmt.Errorf("expected a number %s %v, got %v", op, n, v)
+		}
+		return nil
+	})
+}
+
+// toNumber returns the numeric value of v, null isn't a number
+func toNumber(v interface{}) (float64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("expected a number, got null")
+	}
+	return cast.ToFloat64E(v)
+}
+
+func jsonSubset(want, got interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if gv, ok := g[k]; !ok || !jsonSubset(v, gv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, v := range w {
+			found := false
+			for _, gv := range g {
+				if jsonSubset(v, gv) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+// normalizeValue round trips v through JSON, so it compares equal to the values read from the database
+func normalizeValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var ret interface{}
+	return ret, json.Unmarshal(b, &ret)
+}
@@ -0,0 +1,93 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchers(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Matcher  Matcher
+		Matching []interface{}
+		Failing  []interface{}
+	}{
+		{
+			Name:     "exists",
+			Matcher:  Exists(),
+			Matching: []interface{}{"", 0.0, false},
+			Failing:  []interface{}{nil},
+		},
+		{
+			Name:     "any",
+			Matcher:  Any(),
+			Matching: []interface{}{nil, "value", 1.0},
+		},
+		{
+			Name:     "any uuid",
+			Matcher:  AnyUUID(),
+			Matching: []interface{}{"a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29"},
+			Failing:  []interface{}{nil, "not-a-uuid", 1.0},
+		},
+		{
+			Name:     "any timestamp",
+			Matcher:  AnyTimestamp(),
+			Matching: []interface{}{"2022-05-01T10:00:00Z", "2022-05-01T10:00:00.123456", "2022-05-01 10:00:00+02:00"},
+			Failing:  []interface{}{nil, "2022-05-01", 1.0},
+		},
+		{
+			Name:     "equals",
+			Matcher:  Equals(3),
+			Matching: []interface{}{3.0},
+			Failing:  []interface{}{nil, 3, "3"},
+		},
+		{
+			Name:     "matches regex",
+			Matcher:  MatchesRegex("^arn:aws:"),
+			Matching: []interface{}{"arn:aws:ec2:us-east-1"},
+			Failing:  []interface{}{nil, "aws:arn", 1.0},
+		},
+		{
+			Name:     "greater than",
+			Matcher:  GreaterThan(1),
+			Matching: []interface{}{2.0, "1.5"},
+			Failing:  []interface{}{nil, 1.0, "a"},
+		},
+		{
+			Name:     "less than",
+			Matcher:  LessThan(1),
+			Matching: []interface{}{0.0},
+			Failing:  []interface{}{nil, 1.0},
+		},
+		{
+			Name:     "between",
+			Matcher:  Between(1, 2),
+			Matching: []interface{}{1.0, 2.0, 1.5},
+			Failing:  []interface{}{nil, 0.5, 2.5},
+		},
+		{
+			Name:    "subset of json",
+			Matcher: SubsetOfJSON(map[string]interface{}{"tags": []string{"a"}, "nested": map[string]int{"n": 1}}),
+			Matching: []interface{}{
+				map[string]interface{}{"tags": []interface{}{"b", "a"}, "nested": map[string]interface{}{"n": 1.0, "m": 2.0}, "other": true},
+			},
+			Failing: []interface{}{
+				nil,
+				map[string]interface{}{"tags": []interface{}{"b"}, "nested": map[string]interface{}{"n": 1.0}},
+				map[string]interface{}{"tags": []interface{}{"a"}, "nested": map[string]interface{}{"n": 2.0}},
+				map[string]interface{}{"tags": []interface{}{"a"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			for _, v := range tc.Matching {
+				assert.NoError(t, tc.Matcher.Match(v), "value %v", v)
+			}
+			for _, v := range tc.Failing {
+				assert.Error(t, tc.Matcher.Match(v), "value %v", v)
+			}
+		})
+	}
+}
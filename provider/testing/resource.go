@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -37,6 +36,22 @@ type ResourceTestCase struct {
 	// If no verifiers specified for resource (resource name is not in key set of map),
 	// non emptiness check of all columns in table and its relations will be performed.
 	Verifiers map[string][]Verifier
+	// TableVerifiers are map from table name, of resources or their relations, to verifiers called with the table. They
+	// are called in addition to Verifiers, i.e VerifyAtLeastOneRow of a relation.
+	TableVerifiers map[string][]Verifier
+	// ExpectedRows are map from table name, of resources or their relations, to the exact amount of rows expected in
+	// the table once fetched, see VerifyRowCount. Requires IsolatedSchema, so rows of other test cases aren't counted.
+	ExpectedRows map[string]int
+	// Clients if set, are the clients each table is multiplexed with instead of its Multiplex, i.e mock clients of
+	// several accounts, so the multiplexing and relation resolving paths are exercised with the configured provider.
+	// The tables of the provider's ResourceMap aren't modified, the test fetches multiplexed copies of them.
+	Clients []schema.ClientMeta
+	// Recorder if set, is the transport the provider's API clients use in the test, see NewRecorder. Its cassette is
+	// saved as interactions are recorded, and once the test ends, if it's recording.
+	Recorder *Recorder
+	// IsolatedSchema if set, the test case's tables are created in a schema of its own, which the provider is
+	// configured with and which is dropped once the test ends, so test cases running in parallel don't interfere
+	IsolatedSchema bool
 }
 
 // Verifier verifies tables specified by table schema (main table and its relations).
@@ -46,6 +61,9 @@ type testResourceSender struct {
 	Errors []string
 }
 
+// defaultDatabaseURL is the database tests connect to if DATABASE_URL isn't set
+const defaultDatabaseURL = "host=localhost user=postgres password=pass DB.name=postgres port=5432"
+
 var (
 	dbConnOnce sync.Once
 	pool       execution.QueryExecer
@@ -63,10 +81,26 @@ func TestResource(t *testing.T, resource ResourceTestCase) {
 	}
 	t.Helper()
 
+	if len(resource.ExpectedRows) > 0 && !resource.IsolatedSchema {
+		t.Fatal("ExpectedRows requires IsolatedSchema, rows of other test cases would be counted")
+	}
+
+	if resource.Recorder != nil {
+		t.Cleanup(func() {
+			if err := resource.Recorder.Save(); err != nil {
+				t.Errorf("failed to save cassette: %v", err)
+			}
+		})
+	}
+
 	// No need for configuration or db connection, get it out of the way first
 	// testTableIdentifiersForProvider(t, resource.Provider)
 
+	dsnURI := getEnv("DATABASE_URL", defaultDatabaseURL)
 	conn, err := setupDatabase()
+	if resource.IsolatedSchema {
+		conn, dsnURI, err = setupIsolatedSchema(t)
+	}
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -78,18 +112,21 @@ func TestResource(t *testing.T, resource ResourceTestCase) {
 	// configure provider
 	if resp, configErr := resource.Provider.ConfigureProvider(context.Background(), &cqproto.ConfigureProviderRequest{
 		CloudQueryVersion: "",
-		Connection: cqproto.ConnectionDetails{DSN: getEnv("DATABASE_URL",
-			"host=localhost user=postgres password=pass DB.name=postgres port=5432")},
-		Config: []byte(resource.Config),
+		Connection:        cqproto.ConnectionDetails{DSN: dsnURI},
+		Config:            []byte(resource.Config),
 	}); configErr != nil {
 		t.Fatal("failed to configure provider", configErr)
 	} else if resp != nil && resp.Diagnostics.HasErrors() {
 		t.Fatal("errors while configuring provider", configErr)
 	}
+	resourceMap := resource.Provider.ResourceMap
+	if len(resource.Clients) > 0 {
+		resourceMap = multiplexResourceMap(resourceMap, resource.Clients)
+	}
 
-	for resourceName, table := range resource.Provider.ResourceMap {
+	for resourceName, table := range resourceMap {
 		t.Run(resourceName, func(t *testing.T) {
-			testErr := testResource(t, resource, resourceName, table, conn)
+			testErr := testResource(t, resource, resourceMap, resourceName, table, conn)
 			if testErr != nil {
 				t.Errorf("Error testing %v: %v", table.Name, testErr)
 			}
@@ -97,7 +134,7 @@ func TestResource(t *testing.T, resource ResourceTestCase) {
 	}
 }
 
-func testResource(t *testing.T, resource ResourceTestCase, name string, table *schema.Table, conn execution.QueryExecer) error {
+func testResource(t *testing.T, resource ResourceTestCase, resourceMap map[string]*schema.Table, name string, table *schema.Table, conn execution.QueryExecer) error {
 	t.Helper()
 
 	if createErr := dropAndCreateTable(context.Background(), conn, table); createErr != nil {
@@ -107,7 +144,7 @@ func testResource(t *testing.T, resource ResourceTestCase, name string, table *s
 	if !resource.SkipIgnoreInTest && table.IgnoreInTests {
 		t.Logf("skipping fetch of resource: %s in tests", name)
 	} else {
-		if err := fetchResource(t, &resource, name); err != nil {
+		if err := fetchResource(t, &resource, resourceMap, name); err != nil {
 			return err
 		}
 	}
@@ -120,12 +157,42 @@ func testResource(t *testing.T, resource ResourceTestCase, name string, table *s
 		// fallback to default verification
 		verifyNoEmptyColumns(t, table, conn, resource.SkipIgnoreInTest)
 	}
+	verifyTables(t, table, func(t *testing.T, tbl *schema.Table) {
+		for _, verifier := range resource.TableVerifiers[tbl.Name] {
+			verifier(t, tbl, conn, resource.SkipIgnoreInTest)
+		}
+		if n, ok := resource.ExpectedRows[tbl.Name]; ok {
+			verifyRowCount(t, tbl, conn, n, resource.SkipIgnoreInTest)
+		}
+	})
 
 	return nil
 }
 
-// fetchResource - fetches a resource from the cloud and puts them into database. database config can be specified via DATABASE_URL env variable
-func fetchResource(t *testing.T, resource *ResourceTestCase, resourceName string) error {
+// verifyTables calls verify with the table and each of its relations, recursively
+func verifyTables(t *testing.T, table *schema.Table, verify func(*testing.T, *schema.Table)) {
+	t.Helper()
+	verify(t, table)
+	for _, rel := range table.Relations {
+		verifyTables(t, rel, verify)
+	}
+}
+
+// multiplexResourceMap returns copies of the tables multiplexed with the clients, the tables of the map aren't modified
+func multiplexResourceMap(resourceMap map[string]*schema.Table, clients []schema.ClientMeta) map[string]*schema.Table {
+	multiplexed := make(map[string]*schema.Table, len(resourceMap))
+	for name, table := range resourceMap {
+		t := *table
+		t.Multiplex = func(schema.ClientMeta) []schema.ClientMeta {
+			return clients
+		}
+		multiplexed[name] = &t
+	}
+	return multiplexed
+}
+
+// fetchResource - fetches a resource of resourceMap from the cloud and puts them into database. database config can be specified via DATABASE_URL env variable
+func fetchResource(t *testing.T, resource *ResourceTestCase, resourceMap map[string]*schema.Table, resourceName string) error {
 	t.Helper()
 
 	t.Logf("fetch resource %v", resourceName)
@@ -134,7 +201,7 @@ func fetchResource(t *testing.T, resource *ResourceTestCase, resourceName string
 		Errors: []string{},
 	}
 
-	if err := resource.Provider.FetchResources(context.Background(),
+	if err := resource.Provider.FetchResources(provider.WithResourceMap(context.Background(), resourceMap),
 		&cqproto.FetchResourcesRequest{
 			Resources:             []string{resourceName},
 			ParallelFetchingLimit: resource.ParallelFetchingLimit,
@@ -161,8 +228,8 @@ func verifyNoEmptyColumns(t *testing.T, table *schema.Table, conn pgxscan.Querie
 		}
 		s := sq.StatementBuilder.
 			PlaceholderFormat(sq.Dollar).
-			Select(fmt.Sprintf("json_agg(%s)", table.Name)).
-			From(table.Name)
+			Select(fmt.Sprintf("json_agg(%s)", schema.QuoteIdentifier(table.Name))).
+			From(schema.QuoteIdentifier(table.Name))
 		query, args, err := s.ToSql()
 		if err != nil {
 			t.Fatal(err)
@@ -230,7 +297,7 @@ func dropAndCreateTable(ctx context.Context, conn execution.QueryExecer, table *
 }
 
 func dropTables(ctx context.Context, db execution.QueryExecer, table *schema.Table) error {
-	if err := db.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", strconv.Quote(table.Name))); err != nil {
+	if err := db.Exec(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", schema.QuoteIdentifier(table.Name))); err != nil {
 		return err
 	}
 	for _, rel := range table.Relations {
@@ -256,7 +323,7 @@ func (f *testResourceSender) Send(r *cqproto.FetchResourcesResponse) error {
 
 func setupDatabase() (execution.QueryExecer, error) {
 	dbConnOnce.Do(func() {
-		pool, dbErr = database.New(context.Background(), hclog.NewNullLogger(), getEnv("DATABASE_URL", "host=localhost user=postgres password=pass DB.name=postgres port=5432"))
+		pool, dbErr = database.New(context.Background(), hclog.NewNullLogger(), getEnv("DATABASE_URL", defaultDatabaseURL))
 		if dbErr != nil {
 			return
 		}
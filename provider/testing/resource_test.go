@@ -0,0 +1,49 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockClient struct {
+	id string
+}
+
+func (mockClient) Logger() hclog.Logger {
+	return hclog.NewNullLogger()
+}
+
+func TestMultiplexResourceMap(t *testing.T) {
+	table := &schema.Table{Name: "test_multiplex"}
+	resourceMap := map[string]*schema.Table{"multiplex": table}
+	clients := []schema.ClientMeta{mockClient{"a"}, mockClient{"b"}}
+
+	multiplexed := multiplexResourceMap(resourceMap, clients)
+	require.Contains(t, multiplexed, "multiplex")
+	require.NotNil(t, multiplexed["multiplex"].Multiplex)
+	assert.Equal(t, clients, multiplexed["multiplex"].Multiplex(nil))
+	assert.Equal(t, "test_multiplex", multiplexed["multiplex"].Name)
+
+	// the tables of the resource map aren't modified
+	assert.Same(t, table, resourceMap["multiplex"])
+	assert.Nil(t, table.Multiplex)
+}
+
+func TestVerifyTables(t *testing.T) {
+	table := &schema.Table{
+		Name: "test_verify",
+		Relations: []*schema.Table{
+			{Name: "test_verify_children", Relations: []*schema.Table{{Name: "test_verify_grandchildren"}}},
+			{Name: "test_verify_others"},
+		},
+	}
+	var visited []string
+	verifyTables(t, table, func(_ *testing.T, tbl *schema.Table) {
+		visited = append(visited, tbl.Name)
+	})
+	assert.Equal(t, []string{"test_verify", "test_verify_children", "test_verify_grandchildren", "test_verify_others"}, visited)
+}
@@ -0,0 +1,236 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// UpdateSnapshotsEnv if set to true, makes VerifySnapshot write the snapshots of the tables instead of verifying them
+const UpdateSnapshotsEnv = "CQ_UPDATE_SNAPSHOTS"
+
+// Snapshot placeholders are the values of snapshots matching volatile values, instead of being compared as is
+const (
+	// PlaceholderAny matches any value, including null
+	PlaceholderAny = "<any>"
+	// PlaceholderNotNull matches any non-null value
+	PlaceholderNotNull = "<not-null>"
+	// PlaceholderUUID matches uuids, i.e of cq_id columns
+	PlaceholderUUID = "<any-uuid>"
+	// PlaceholderTimestamp matches timestamps, i.e of cq_fetch_date columns
+	PlaceholderTimestamp = "<timestamp>"
+	// placeholderRegexPrefix prefixes the regular expression of placeholders matching strings by regex, i.e
+	// "<regex:^arn:aws:>"
+	placeholderRegexPrefix = "<regex:"
+)
+
+// VerifySnapshot verifies the rows of the table match its snapshot, a JSON file of the rows at path. Values of the
+// snapshot can be placeholders matching volatile values, i.e "<any-uuid>", "<timestamp>", "<not-null>", "<any>" or
+// "<regex:EXPR>". Rows are ordered by the table's primary keys, or by all of their columns other than the SDK's if the
+// table has none, volatile columns excluded, so snapshots are stable across fetches.
+//
+// If the snapshot doesn't exist, or CQ_UPDATE_SNAPSHOTS=true, the snapshot is written instead. The SDK's columns and
+// the parent's cq_id are written as placeholders, and so are the columns that are placeholders in the previous snapshot.
+func VerifySnapshot(tableName, path string) Verifier {
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			rows := getRows(t, conn, table, shouldSkipIgnoreInTest)
+			sortSnapshotRows(table, rows)
+			previous, err := readSnapshot(path)
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+			if previous == nil || os.Getenv(UpdateSnapshotsEnv) == "true" {
+				if err := writeSnapshot(path, snapshotRows(table, rows, previous)); err != nil {
+					t.Fatalf("VerifySnapshot failed: failed to write snapshot of table %s: %s", table.Name, err)
+				}
+				t.Logf("VerifySnapshot wrote the snapshot of table %s to %s", table.Name, path)
+			} else if diff := snapshotDiff(rows, previous); len(diff) > 0 {
+				t.Fatalf("VerifySnapshot failed: table %s doesn't match snapshot %s, set %s=true to update it:\n\t%s", table.Name, path, UpdateSnapshotsEnv, strings.Join(diff, "\n\t"))
+			}
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+// snapshotMatcher returns the matcher of a snapshot's value, placeholders match volatile values and other values must
+// be equal
+func snapshotMatcher(v interface{}) Matcher {
+	s, ok := v.(string)
+	if !ok {
+		return Equals(v)
+	}
+	switch {
+	case s == PlaceholderAny:
+		return Any()
+	case s == PlaceholderNotNull:
+		return Exists()
+	case s == PlaceholderUUID:
+		return AnyUUID()
+	case s == PlaceholderTimestamp:
+		return AnyTimestamp()
+	case strings.HasPrefix(s, placeholderRegexPrefix) && strings.HasSuffix(s, ">"):
+		return MatchesRegex(strings.TrimSuffix(strings.TrimPrefix(s, placeholderRegexPrefix), ">"))
+	default:
+		return Equals(v)
+	}
+}
+
+func isPlaceholder(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	return s == PlaceholderAny || s == PlaceholderNotNull || s == PlaceholderUUID || s == PlaceholderTimestamp ||
+		(strings.HasPrefix(s, placeholderRegexPrefix) && strings.HasSuffix(s, ">"))
+}
+
+// snapshotDiff returns the description of the rows that don't match the snapshot, each row is compared with the
+// snapshot's row in the same position
+func snapshotDiff(rows, snapshot []Row) []string {
+	var diff []string
+	if len(rows) != len(snapshot) {
+		diff = append(diff, fmt.Sprintf("expected %d rows, got %d", len(snapshot), len(rows)))
+	}
+	for i := 0; i < len(rows) && i < len(snapshot); i++ {
+		matchers := make(map[string]Matcher, len(snapshot[i]))
+		for k, v := range snapshot[i] {
+			matchers[k] = snapshotMatcher(v)
+		}
+		rowDiffs := rowDiff(rows[i], matchers)
+		for k := range rows[i] {
+			if _, ok := snapshot[i][k]; !ok {
+				rowDiffs = append(rowDiffs, fmt.Sprintf("%s: column isn't in snapshot", k))
+			}
+		}
+		sort.Strings(rowDiffs)
+		for _, d := range rowDiffs {
+			diff = append(diff, fmt.Sprintf("row %d: %s", i, d))
+		}
+	}
+	return diff
+}
+
+// volatileColumns returns the columns whose values change on every fetch, and the placeholders they're written as
+func volatileColumns(table *schema.Table) map[string]string {
+	cols := map[string]string{
+		"cq_id":         PlaceholderUUID,
+		"cq_meta":       PlaceholderAny,
+		"cq_fetch_date": PlaceholderTimestamp,
+	}
+	if c := table.ParentIdColumn(); c != nil {
+		cols[c.Name] = PlaceholderUUID
+	}
+	return cols
+}
+
+// sortSnapshotRows orders rows by the table's primary keys, or by their non-volatile columns if the table doesn't have
+// primary keys. Volatile columns are never part of the order, as they differ on every fetch, so primary keys such as
+// cq_id or the parent's cq_id are skipped.
+func sortSnapshotRows(table *schema.Table, rows []Row) {
+	volatile := volatileColumns(table)
+	var pks []string
+	for _, pk := range table.Options.PrimaryKeys {
+		if _, ok := volatile[pk]; !ok {
+			pks = append(pks, pk)
+		}
+	}
+	keys := make([]string, len(rows))
+	for i, r := range rows {
+		var values []interface{}
+		if len(pks) > 0 {
+			for _, pk := range pks {
+				values = append(values, r[pk])
+			}
+		} else {
+			cols := make([]string, 0, len(r))
+			for c := range r {
+				if _, ok := volatile[c]; !ok {
+					cols = append(cols, c)
+				}
+			}
+			sort.Strings(cols)
+			for _, c := range cols {
+				values = append(values, c, r[c])
+			}
+		}
+		b, _ := json.Marshal(values)
+		keys[i] = string(b)
+	}
+	sort.Stable(snapshotRowsByKey{rows: rows, keys: keys})
+}
+
+type snapshotRowsByKey struct {
+	rows []Row
+	keys []string
+}
+
+func (s snapshotRowsByKey) Len() int           { return len(s.rows) }
+func (s snapshotRowsByKey) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s snapshotRowsByKey) Swap(i, j int) {
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// snapshotRows returns the rows as they're written to the snapshot, with the volatile columns and the columns that are
+// placeholders in the previous snapshot replaced with their placeholders
+func snapshotRows(table *schema.Table, rows, previous []Row) []Row {
+	placeholders := volatileColumns(table)
+	for _, r := range previous {
+		for k, v := range r {
+			if isPlaceholder(v) {
+				placeholders[k] = v.(string)
+			}
+		}
+	}
+	ret := make([]Row, len(rows))
+	for i, r := range rows {
+		row := make(Row, len(r))
+		for k, v := range r {
+			if p, ok := placeholders[k]; ok {
+				row[k] = p
+			} else {
+				row[k] = v
+			}
+		}
+		ret[i] = row
+	}
+	return ret
+}
+
+func readSnapshot(path string) ([]Row, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rows := []Row{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	return rows, nil
+}
+
+func writeSnapshot(path string, rows []Row) error {
+	if rows == nil {
+		rows = []Row{}
+	}
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
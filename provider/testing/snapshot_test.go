@@ -0,0 +1,116 @@
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotMatcher(t *testing.T) {
+	testCases := []struct {
+		Snapshot interface{}
+		Matching interface{}
+		Failing  interface{}
+	}{
+		{Snapshot: PlaceholderAny, Matching: nil},
+		{Snapshot: PlaceholderNotNull, Matching: "value", Failing: nil},
+		{Snapshot: PlaceholderUUID, Matching: "a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29", Failing: "value"},
+		{Snapshot: PlaceholderTimestamp, Matching: "2022-05-01T10:00:00Z", Failing: "value"},
+		{Snapshot: "<regex:^i-[0-9]+$>", Matching: "i-123", Failing: "i-abc"},
+		{Snapshot: "value", Matching: "value", Failing: "other"},
+		{Snapshot: 1.0, Matching: 1.0, Failing: 2.0},
+	}
+	for _, tc := range testCases {
+		m := snapshotMatcher(tc.Snapshot)
+		assert.NoError(t, m.Match(tc.Matching), "snapshot %v", tc.Snapshot)
+		if tc.Snapshot != PlaceholderAny {
+			assert.Error(t, m.Match(tc.Failing), "snapshot %v", tc.Snapshot)
+		}
+		assert.Equal(t, tc.Snapshot != "value" && tc.Snapshot != 1.0, isPlaceholder(tc.Snapshot), "snapshot %v", tc.Snapshot)
+	}
+}
+
+func TestSortSnapshotRows(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Table    *schema.Table
+		Rows     []Row
+		Expected []string
+	}{
+		{
+			Name:  "primary keys",
+			Table: &schema.Table{Name: "sort_pk", Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}}},
+			Rows: []Row{
+				{"id": "b", "name": "a"},
+				{"id": "a", "name": "b"},
+			},
+			Expected: []string{"a", "b"},
+		},
+		{
+			Name:  "volatile primary keys",
+			Table: &schema.Table{Name: "sort_cq_id", Options: schema.TableCreationOptions{PrimaryKeys: []string{"cq_id", "id"}}},
+			Rows: []Row{
+				{"cq_id": "1", "id": "b"},
+				{"cq_id": "2", "id": "a"},
+			},
+			Expected: []string{"a", "b"},
+		},
+		{
+			Name:  "only volatile primary keys",
+			Table: &schema.Table{Name: "sort_only_cq_id", Options: schema.TableCreationOptions{PrimaryKeys: []string{"cq_id"}}},
+			Rows: []Row{
+				{"cq_id": "1", "id": "b", "cq_fetch_date": "1"},
+				{"cq_id": "2", "id": "a", "cq_fetch_date": "2"},
+			},
+			Expected: []string{"a", "b"},
+		},
+		{
+			Name:  "no primary keys",
+			Table: &schema.Table{Name: "sort_no_pk"},
+			Rows: []Row{
+				{"cq_id": "1", "cq_meta": nil, "id": "b"},
+				{"cq_id": "2", "cq_meta": nil, "id": "a"},
+			},
+			Expected: []string{"a", "b"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			sortSnapshotRows(tc.Table, tc.Rows)
+			ids := make([]string, len(tc.Rows))
+			for i, r := range tc.Rows {
+				ids[i] = r["id"].(string)
+			}
+			assert.Equal(t, tc.Expected, ids)
+		})
+	}
+}
+
+func TestSnapshotRows(t *testing.T) {
+	table := &schema.Table{Name: "snapshot_rows"}
+	rows := []Row{{"cq_id": "a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29", "cq_meta": nil, "cq_fetch_date": "2022-05-01T10:00:00Z", "arn": "arn:1", "name": "a"}}
+	previous := []Row{{"arn": "<regex:^arn:>", "name": "b"}}
+	assert.Equal(t, []Row{{
+		"cq_id":         PlaceholderUUID,
+		"cq_meta":       PlaceholderAny,
+		"cq_fetch_date": PlaceholderTimestamp,
+		"arn":           "<regex:^arn:>",
+		"name":          "a",
+	}}, snapshotRows(table, rows, previous))
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	rows := []Row{{"cq_id": "a7d5a7e1-2d4c-4c4f-8c43-2bd1f9bd1a29", "name": "a"}}
+	require.NoError(t, writeSnapshot(path, snapshotRows(&schema.Table{Name: "snapshot_diff"}, rows, nil)))
+	snapshot, err := readSnapshot(path)
+	require.NoError(t, err)
+	assert.Empty(t, snapshotDiff(rows, snapshot))
+
+	assert.Equal(t, []string{"row 0: name: expected b, got a"}, snapshotDiff(rows, []Row{{"cq_id": PlaceholderUUID, "name": "b"}}))
+	assert.Equal(t, []string{"row 0: name: column isn't in snapshot"}, snapshotDiff(rows, []Row{{"cq_id": PlaceholderUUID}}))
+	assert.Equal(t, []string{"expected 0 rows, got 1"}, snapshotDiff(rows, []Row{}))
+}
@@ -2,8 +2,12 @@ package testing
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudquery/cq-provider-sdk/provider/schema"
 	"github.com/cloudquery/faker/v3/support/slice"
@@ -34,13 +38,7 @@ func getRows(t *testing.T, conn pgxscan.Querier, table *schema.Table, shouldSkip
 		t.Skipf("table %s marked as IgnoreInTest. Skipping...", table.Name)
 	}
 
-	var rows []Row
-	err := pgxscan.Get(
-		context.Background(),
-		conn,
-		&rows,
-		fmt.Sprintf("select json_agg(%[1]s) from %[1]s", table.Name),
-	)
+	rows, err := queryRows(conn, table)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,6 +54,17 @@ func getRows(t *testing.T, conn pgxscan.Querier, table *schema.Table, shouldSkip
 	return rows
 }
 
+func queryRows(conn pgxscan.Querier, table *schema.Table) ([]Row, error) {
+	var rows []Row
+	err := pgxscan.Get(
+		context.Background(),
+		conn,
+		&rows,
+		fmt.Sprintf("select json_agg(%[1]s) from %[1]s", schema.QuoteIdentifier(table.Name)),
+	)
+	return rows, err
+}
+
 // VerifyNoEmptyColumnsExcept verifies that for each row in table its columns are not empty except passed
 func VerifyNoEmptyColumnsExcept(tableName string, except ...string) Verifier {
 	return VerifyRowPredicateInTable(tableName, func(t *testing.T, row Row) {
@@ -90,7 +99,7 @@ func VerifyAtMostOneOf(tableName string, oneof ...string) Verifier {
 // VerifyAtLeastOneRow verifies that main table from schema has at least one row
 func VerifyAtLeastOneRow() Verifier {
 	return func(t *testing.T, table *schema.Table, conn pgxscan.Querier, _ bool) {
-		rows, err := conn.Query(context.Background(), fmt.Sprintf("select * from %s;", table.Name))
+		rows, err := conn.Query(context.Background(), fmt.Sprintf("select * from %s;", schema.QuoteIdentifier(table.Name)))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -101,3 +110,246 @@ func VerifyAtLeastOneRow() Verifier {
 		rows.Close()
 	}
 }
+
+// VerifyRowCountAtLeast verifies that table from schema has at least n rows
+func VerifyRowCountAtLeast(tableName string, n int) Verifier {
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			if err := checkRowCountAtLeast(table, getRows(t, conn, table, shouldSkipIgnoreInTest), n); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+func checkRowCountAtLeast(table *schema.Table, rows []Row, n int) error {
+	if len(rows) < n {
+		return fmt.Errorf("VerifyRowCountAtLeast failed: expected at least %d rows in table %s, got %d", n, table.Name, len(rows))
+	}
+	return nil
+}
+
+// VerifyRowCount verifies that table from schema has exactly n rows, i.e a row for each of the multiplexed clients
+func VerifyRowCount(tableName string, n int) Verifier {
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			verifyRowCount(t, table, conn, n, shouldSkipIgnoreInTest)
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+// verifyRowCount verifies the table has exactly n rows, its relations aren't verified
+func verifyRowCount(t *testing.T, table *schema.Table, conn pgxscan.Querier, n int, shouldSkipIgnoreInTest bool) {
+	t.Helper()
+	rows := getRows(t, conn, table, shouldSkipIgnoreInTest)
+	if len(rows) != n {
+		t.Fatalf("VerifyRowCount failed: expected %d rows in table %s, got %d", n, table.Name, len(rows))
+	}
+}
+
+// VerifyUniqueValues verifies that no two rows in table share the same values for the given columns.
+// If no columns are passed the table's primary keys are used.
+func VerifyUniqueValues(tableName string, columns ...string) Verifier {
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			cols := columns
+			if len(cols) == 0 {
+				cols = schema.PostgresDialect{}.PrimaryKeys(table)
+			}
+			if err := checkUniqueValues(table, getRows(t, conn, table, shouldSkipIgnoreInTest), cols); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+func checkUniqueValues(table *schema.Table, rows []Row, cols []string) error {
+	seen := make(map[string]int)
+	for i, row := range rows {
+		values := make([]interface{}, len(cols))
+		for j, c := range cols {
+			v, ok := row[c]
+			if !ok {
+				return fmt.Errorf("VerifyUniqueValues failed: column %s doesn't exist in table %s", c, table.Name)
+			}
+			values[j] = v
+		}
+		key := fmt.Sprintf("%#v", values)
+		if prev, ok := seen[key]; ok {
+			return fmt.Errorf("VerifyUniqueValues failed: rows %d and %d in table %s have the same values %v for columns %v", prev, i, table.Name, values, cols)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// VerifyJSONColumns verifies that the JSON columns of each row in table hold JSON objects or arrays. Strings are
+// reported, as they're usually JSON documents the resolver encoded twice, i.e a json.Marshal result set as a *string.
+func VerifyJSONColumns(tableName string) Verifier {
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			for _, row := range getRows(t, conn, table, shouldSkipIgnoreInTest) {
+				if err := checkJSONColumns(table, row); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+func checkJSONColumns(table *schema.Table, row Row) error {
+	for _, c := range table.Columns {
+		if c.Type != schema.TypeJSON {
+			continue
+		}
+		switch v := row[c.Name].(type) {
+		case nil, map[string]interface{}, []interface{}:
+		case string:
+			if json.Valid([]byte(v)) {
+				return fmt.Errorf("VerifyJSONColumns failed: column %s in table %s holds a JSON document encoded as a string: %s", c.Name, table.Name, v)
+			}
+			return fmt.Errorf("VerifyJSONColumns failed: column %s in table %s holds the string %q instead of a JSON object or array", c.Name, table.Name, v)
+		default:
+			return fmt.Errorf("VerifyJSONColumns failed: column %s in table %s holds %v instead of a JSON object or array", c.Name, table.Name, v)
+		}
+	}
+	return nil
+}
+
+// eventuallyPollInterval is the interval in which VerifyEventuallyContains queries the table
+const eventuallyPollInterval = 500 * time.Millisecond
+
+// maxClosestRows is the number of closest rows VerifyEventuallyContains reports when no row matches
+const maxClosestRows = 3
+
+// VerifyEventuallyContains verifies that table from schema has a row with all the expected column values, polling the
+// table for up to timeout, as the data can lag behind the fetch (i.e with eventually consistent cloud APIs).
+// Expected values can be a Matcher, other values must be equal. If no row matches, the closest rows are reported with
+// the columns that differ.
+func VerifyEventuallyContains(tableName string, expected Row, timeout time.Duration) Verifier {
+	matchers := toMatchers(expected)
+	var verifier Verifier
+	verifier = func(t *testing.T, table *schema.Table, conn pgxscan.Querier, shouldSkipIgnoreInTest bool) {
+		if tableName == table.Name {
+			if shouldSkipIgnoreInTest && table.IgnoreInTests {
+				t.Skipf("table %s marked as IgnoreInTest. Skipping...", table.Name)
+			}
+			if err := waitForRow(conn, table, matchers, timeout, eventuallyPollInterval); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, r := range table.Relations {
+			verifier(t, r, conn, shouldSkipIgnoreInTest)
+		}
+	}
+	return verifier
+}
+
+// waitForRow queries the table every interval until a row matches, or returns an error describing the closest rows
+// once timeout passes
+func waitForRow(conn pgxscan.Querier, table *schema.Table, matchers map[string]Matcher, timeout, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		rows, err := queryRows(conn, table)
+		if err != nil {
+			return err
+		}
+		if containsRow(rows, matchers) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("VerifyEventuallyContains failed: no row in table %s matched after %s%s", table.Name, timeout, closestRows(rows, matchers))
+		}
+		time.Sleep(interval)
+	}
+}
+
+// VerifyRowsMatch verifies that all rows in table match the column matchers, i.e VerifyRowsMatch("t", map[string]Matcher{"arn": MatchesRegex("^arn:")})
+func VerifyRowsMatch(tableName string, matchers map[string]Matcher) Verifier {
+	return VerifyRowPredicateInTable(tableName, func(t *testing.T, row Row) {
+		if diff := rowDiff(row, matchers); len(diff) > 0 {
+			t.Fatalf("VerifyRowsMatch failed: row in table %s doesn't match: %s", tableName, strings.Join(diff, "; "))
+		}
+	})
+}
+
+func toMatchers(expected Row) map[string]Matcher {
+	ret := make(map[string]Matcher, len(expected))
+	for k, v := range expected {
+		if m, ok := v.(Matcher); ok {
+			ret[k] = m
+		} else {
+			ret[k] = Equals(v)
+		}
+	}
+	return ret
+}
+
+func containsRow(rows []Row, matchers map[string]Matcher) bool {
+	for _, r := range rows {
+		if len(rowDiff(r, matchers)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// rowDiff returns the description of the columns in row that don't match, sorted
+func rowDiff(row Row, matchers map[string]Matcher) []string {
+	var diff []string
+	for k, m := range matchers {
+		got, ok := row[k]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("%s: column doesn't exist", k))
+			continue
+		}
+		if err := m.Match(got); err != nil {
+			diff = append(diff, fmt.Sprintf("%s: %s", k, err))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// closestRows describes the rows with the fewest columns that don't match
+func closestRows(rows []Row, matchers map[string]Matcher) string {
+	if len(rows) == 0 {
+		return ", table is empty"
+	}
+	diffs := make([][]string, len(rows))
+	for i, r := range rows {
+		diffs[i] = rowDiff(r, matchers)
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return len(diffs[i]) < len(diffs[j])
+	})
+	if len(diffs) > maxClosestRows {
+		diffs = diffs[:maxClosestRows]
+	}
+	b := &strings.Builder{}
+	b.WriteString(", closest rows:")
+	for _, d := range diffs {
+		b.WriteString("\n\t" + strings.Join(d, "; "))
+	}
+	return b.String()
+}
@@ -0,0 +1,195 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rowsQuerier answers the json_agg queries of the verifiers with its results in order, the last one repeated once the
+// others are returned
+type rowsQuerier struct {
+	results [][]Row
+	queries []string
+}
+
+func (q *rowsQuerier) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	q.queries = append(q.queries, sql)
+	i := len(q.queries) - 1
+	if i >= len(q.results) {
+		i = len(q.results) - 1
+	}
+	data, err := json.Marshal(q.results[i])
+	if err != nil {
+		return nil, err
+	}
+	return &aggRows{data: data}, nil
+}
+
+// aggRows is the single json_agg row of rowsQuerier
+type aggRows struct {
+	pgx.Rows
+	data []byte
+	read bool
+}
+
+func (r *aggRows) FieldDescriptions() []pgproto3.FieldDescription {
+	return []pgproto3.FieldDescription{{Name: []byte("json_agg")}}
+}
+
+func (r *aggRows) Next() bool {
+	if r.read {
+		return false
+	}
+	r.read = true
+	return true
+}
+
+func (r *aggRows) Scan(dest ...interface{}) error {
+	return json.Unmarshal(r.data, dest[0])
+}
+
+func (r *aggRows) Err() error { return nil }
+
+func (r *aggRows) Close() {}
+
+var verifierTable = &schema.Table{
+	Name: "test_verifier_items",
+	Columns: []schema.Column{
+		{Name: "name", Type: schema.TypeString},
+		{Name: "region", Type: schema.TypeString},
+		{Name: "tags", Type: schema.TypeJSON},
+	},
+	Options: schema.TableCreationOptions{PrimaryKeys: []string{"name", "region"}},
+	Relations: []*schema.Table{
+		{Name: "test_verifier_item_children"},
+	},
+}
+
+func verifierRows() []Row {
+	return []Row{
+		{"name": "first", "region": "us-east-1", "tags": map[string]interface{}{"env": "prod"}},
+		{"name": "first", "region": "eu-west-1", "tags": []interface{}{"a"}},
+		{"name": "second", "region": "us-east-1", "tags": nil},
+	}
+}
+
+func TestVerifyRowCountAtLeast(t *testing.T) {
+	q := &rowsQuerier{results: [][]Row{verifierRows()}}
+	VerifyRowCountAtLeast("test_verifier_items", 3)(t, verifierTable, q, false)
+	assert.Equal(t, []string{`select json_agg("test_verifier_items") from "test_verifier_items"`}, q.queries)
+
+	assert.NoError(t, checkRowCountAtLeast(verifierTable, verifierRows(), 3))
+	assert.EqualError(t, checkRowCountAtLeast(verifierTable, verifierRows(), 4),
+		"VerifyRowCountAtLeast failed: expected at least 4 rows in table test_verifier_items, got 3")
+}
+
+func TestVerifyUniqueValues(t *testing.T) {
+	// relations of the table are verified too
+	q := &rowsQuerier{results: [][]Row{verifierRows()}}
+	VerifyUniqueValues("test_verifier_item_children", "name", "region")(t, verifierTable, q, false)
+	assert.Equal(t, []string{`select json_agg("test_verifier_item_children") from "test_verifier_item_children"`}, q.queries)
+
+	// the primary keys are used if no columns are passed
+	VerifyUniqueValues("test_verifier_items")(t, verifierTable, &rowsQuerier{results: [][]Row{verifierRows()}}, false)
+
+	assert.EqualError(t, checkUniqueValues(verifierTable, verifierRows(), []string{"name"}),
+		"VerifyUniqueValues failed: rows 0 and 1 in table test_verifier_items have the same values [first] for columns [name]")
+	assert.EqualError(t, checkUniqueValues(verifierTable, verifierRows(), []string{"account_id"}),
+		"VerifyUniqueValues failed: column account_id doesn't exist in table test_verifier_items")
+}
+
+func TestVerifyJSONColumns(t *testing.T) {
+	VerifyJSONColumns("test_verifier_items")(t, verifierTable, &rowsQuerier{results: [][]Row{verifierRows()}}, false)
+
+	tests := []struct {
+		name string
+		tags interface{}
+		err  string
+	}{
+		{name: "object", tags: map[string]interface{}{"env": "prod"}},
+		{name: "null"},
+		{
+			name: "encoded twice",
+			tags: `{"env":"prod"}`,
+			err:  `VerifyJSONColumns failed: column tags in table test_verifier_items holds a JSON document encoded as a string: {"env":"prod"}`,
+		},
+		{
+			name: "string",
+			tags: "prod",
+			err:  `VerifyJSONColumns failed: column tags in table test_verifier_items holds the string "prod" instead of a JSON object or array`,
+		},
+		{
+			name: "number",
+			tags: float64(1),
+			err:  "VerifyJSONColumns failed: column tags in table test_verifier_items holds 1 instead of a JSON object or array",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkJSONColumns(verifierTable, Row{"name": "first", "tags": tc.tags})
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRowDiff(t *testing.T) {
+	row := Row{"name": "first", "region": "us-east-1"}
+	assert.Empty(t, rowDiff(row, toMatchers(Row{"name": "first", "region": Exists()})))
+	assert.Equal(t, []string{
+		"account_id: column doesn't exist",
+		"name: expected second, got first",
+	}, rowDiff(row, toMatchers(Row{"name": "second", "region": "us-east-1", "account_id": "123"})))
+}
+
+func TestContainsRow(t *testing.T) {
+	assert.True(t, containsRow(verifierRows(), toMatchers(Row{"name": "first", "region": "eu-west-1"})))
+	assert.False(t, containsRow(verifierRows(), toMatchers(Row{"name": "second", "region": "eu-west-1"})))
+	assert.False(t, containsRow(nil, toMatchers(Row{"name": "first"})))
+}
+
+func TestClosestRows(t *testing.T) {
+	assert.Equal(t, ", table is empty", closestRows(nil, toMatchers(Row{"name": "first"})))
+
+	rows := append(verifierRows(), Row{"name": "third", "region": "ap-south-1"})
+	// the rows with the fewest differences are reported, up to maxClosestRows in the order of the table
+	assert.Equal(t, ", closest rows:"+
+		"\n\tname: expected second, got first"+
+		"\n\tregion: expected eu-west-1, got us-east-1"+
+		"\n\tname: expected second, got first; region: expected eu-west-1, got us-east-1",
+		closestRows([]Row{rows[0], rows[1], rows[3], rows[2]}, toMatchers(Row{"name": "second", "region": "eu-west-1"})))
+}
+
+func TestVerifyEventuallyContains(t *testing.T) {
+	// the row appears in the third query
+	q := &rowsQuerier{results: [][]Row{nil, verifierRows()[:1], verifierRows()}}
+	require.NoError(t, waitForRow(q, verifierTable, toMatchers(Row{"name": "second"}), time.Second, time.Millisecond))
+	assert.Len(t, q.queries, 3)
+
+	VerifyEventuallyContains("test_verifier_items", Row{"name": "second"}, time.Second)(t, verifierTable, &rowsQuerier{results: [][]Row{verifierRows()}}, false)
+}
+
+func TestVerifyEventuallyContains_Timeout(t *testing.T) {
+	q := &rowsQuerier{results: [][]Row{verifierRows()}}
+	err := waitForRow(q, verifierTable, toMatchers(Row{"name": "third", "region": "us-east-1"}), 20*time.Millisecond, time.Millisecond)
+	assert.EqualError(t, err, "VerifyEventuallyContains failed: no row in table test_verifier_items matched after 20ms, closest rows:"+
+		"\n\tname: expected third, got first"+
+		"\n\tname: expected third, got second"+
+		"\n\tname: expected third, got first; region: expected us-east-1, got eu-west-1")
+	// the table is polled until the timeout passes
+	assert.Greater(t, len(q.queries), 1)
+
+	err = waitForRow(&rowsQuerier{results: [][]Row{nil}}, verifierTable, toMatchers(Row{"name": "third"}), 0, time.Millisecond)
+	assert.EqualError(t, err, "VerifyEventuallyContains failed: no row in table test_verifier_items matched after 0s, table is empty")
+}
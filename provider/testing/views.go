@@ -16,12 +16,17 @@ type ViewTestCase struct {
 	Provider *provider.Provider
 	// SQLView statement that create that view
 	SQLView string
+	// IsolatedSchema if set, the tables and the view are created in a schema of its own, dropped once the test ends
+	IsolatedSchema bool
 }
 
 func HelperTestView(t *testing.T, resource ViewTestCase) {
 	t.Helper()
 
 	conn, err := setupDatabase()
+	if resource.IsolatedSchema {
+		conn, _, err = setupIsolatedSchema(t)
+	}
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,187 @@
+// Package runner runs a provider in-process, without serving it over gRPC. It's meant for Go programs embedding a
+// single provider, and for tests that don't need the plugin protocol:
+//
+//	result, diags := runner.Run(ctx, provider, config, storage, runner.FetchOptions{Resources: []string{"*"}})
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/cloudquery/cq-provider-sdk/provider"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/execution"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Status is the execution status of a fetched resource
+type Status string
+
+const (
+	// StatusComplete all tables of the resource were fetched without errors
+	StatusComplete Status = "complete"
+	// StatusFailed the resource wasn't fetched, i.e its canary fetch or schema validation failed
+	StatusFailed Status = "failed"
+	// StatusPartial one or more tables or clients of the resource failed to resolve
+	StatusPartial Status = "partial"
+	// StatusCanceled the fetch was canceled before the resource finished
+	StatusCanceled Status = "canceled"
+)
+
+// FetchOptions configure the fetch of Run, zero values use the provider's defaults
+type FetchOptions struct {
+	// Resources to fetch, ["*"] fetches all resources of the provider
+	Resources []string
+	// ParallelFetchingLimit limits the amount of resources fetched at a time if more than 0
+	ParallelFetchingLimit uint64
+	// MaxGoroutines is the approximate maximum amount of goroutines of the fetch
+	MaxGoroutines uint64
+	// Timeout of each parent resource resolve call
+	Timeout time.Duration
+	// Metadata of the fetch, see execution.NewTableExecutor
+	Metadata map[string]interface{}
+	// FeatureFlags the provider is configured with
+	FeatureFlags map[string]bool
+	// Labels if set, only the resources whose table has all the labels are fetched, see schema.Table.Labels
+	Labels map[string]string
+	// ResumeFetchId continues the interrupted fetch with this id, see cqproto.FetchResourcesRequest.ResumeFetchId
+	ResumeFetchId string
+	// DryRun executes the resolvers without writing to the database, see cqproto.FetchResourcesRequest.DryRun
+	DryRun bool
+	// Verify compares the resolved resources to the stored data, see cqproto.FetchResourcesRequest.Verify
+	Verify bool
+	// ErrorPolicy defines how errors of the fetch are handled, see cqproto.FetchResourcesRequest.ErrorPolicy
+	ErrorPolicy cqproto.ErrorPolicy
+	// TenantId owns the fetched resources, see cqproto.FetchResourcesRequest.TenantId
+	TenantId string
+	// FullRefresh fully fetches incremental tables, see cqproto.FetchResourcesRequest.FullRefresh
+	FullRefresh bool
+	// TableFilters limit the resources fetched by table, see cqproto.FetchResourcesRequest.TableFilters
+	TableFilters map[string]string
+	// SkipStaleCleanup keeps and counts stale data, see cqproto.FetchResourcesRequest.SkipStaleCleanup
+	SkipStaleCleanup bool
+	// BatchSize of the resources resolved and inserted together, see cqproto.FetchResourcesRequest.BatchSize
+	BatchSize uint64
+}
+
+// ResourceSummary is the summary of a fetched resource
+type ResourceSummary struct {
+	// Status of the resource's fetch
+	Status Status
+	// ResourceCount is the amount of resources fetched, including relations
+	ResourceCount uint64
+	// Diagnostics of the resource's fetch
+	Diagnostics diag.Diagnostics
+	// TableCounts is the amount of resources resolved per table, set by dry runs
+	TableCounts map[string]uint64
+	// FailFastError is the summary of the error that canceled a fail fast fetch, if it was canceled before the resource
+	// finished
+	FailFastError string
+	// Clients is the completion state of the resource's multiplexed clients, set if the fetch was canceled
+	Clients []cqproto.ClientFetchSummary
+}
+
+// Result is the result of a Run, by resource name
+type Result struct {
+	Resources map[string]ResourceSummary
+}
+
+// Diagnostics returns the diagnostics of all resources
+func (r *Result) Diagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, s := range r.Resources {
+		diags = diags.Add(s.Diagnostics)
+	}
+	return diags
+}
+
+// Run configures the provider with config and fetches the resources of opts into storage, returning once the fetch
+// finishes. storage is owned by the caller and isn't closed, it may be nil with FetchOptions.DryRun. A provider can only
+// be run once, unless it's in debug mode.
+//
+// The returned diagnostics include configuration errors and errors that aborted the fetch, errors of the fetched
+// resources are reported by their summaries in the result.
+func Run(ctx context.Context, p *provider.Provider, config []byte, storage execution.Storage, opts FetchOptions) (*Result, diag.Diagnostics) {
+	if storage == nil && !opts.DryRun {
+		return nil, diag.FromError(fmt.Errorf("provider %s run without storage", p.Name), diag.INTERNAL)
+	}
+	if p.Logger == nil {
+		p.Logger = logging.New(&hclog.LoggerOptions{Name: p.Name, Level: hclog.Info})
+	}
+	p.Storage = storage
+
+	resp, err := p.ConfigureProvider(ctx, &cqproto.ConfigureProviderRequest{
+		Config:       config,
+		FeatureFlags: opts.FeatureFlags,
+	})
+	if err != nil {
+		return nil, diag.FromError(err, diag.INTERNAL)
+	}
+	if resp.Diagnostics.HasErrors() {
+		return nil, resp.Diagnostics
+	}
+
+	sender := &resultSender{result: &Result{Resources: make(map[string]ResourceSummary)}}
+	if err := p.FetchResources(ctx, &cqproto.FetchResourcesRequest{
+		Resources:             opts.Resources,
+		ParallelFetchingLimit: opts.ParallelFetchingLimit,
+		MaxGoroutines:         opts.MaxGoroutines,
+		Timeout:               opts.Timeout,
+		Metadata:              opts.Metadata,
+		Labels:                opts.Labels,
+		ResumeFetchId:         opts.ResumeFetchId,
+		DryRun:                opts.DryRun,
+		Verify:                opts.Verify,
+		ErrorPolicy:           opts.ErrorPolicy,
+		TenantId:              opts.TenantId,
+		FullRefresh:           opts.FullRefresh,
+		TableFilters:          opts.TableFilters,
+		SkipStaleCleanup:      opts.SkipStaleCleanup,
+		BatchSize:             opts.BatchSize,
+	}, sender); err != nil {
+		return sender.result, resp.Diagnostics.Add(diag.FromError(fmt.Errorf("fetch failed: %w", err), diag.INTERNAL))
+	}
+	return sender.result, resp.Diagnostics
+}
+
+// resultSender collects the summaries sent by Provider.FetchResources
+type resultSender struct {
+	mu     sync.Mutex
+	result *Result
+}
+
+func (s *resultSender) Send(resp *cqproto.FetchResourcesResponse) error {
+	if resp.Progress != nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result.Resources[resp.ResourceName] = ResourceSummary{
+		Status:        statusFromProto(resp.Summary.Status),
+		ResourceCount: resp.Summary.ResourceCount,
+		Diagnostics:   resp.Summary.Diagnostics,
+		TableCounts:   resp.Summary.TableCounts,
+		FailFastError: resp.Summary.FailFastError,
+		Clients:       resp.Summary.Clients,
+	}
+	return nil
+}
+
+func statusFromProto(s cqproto.ResourceFetchStatus) Status {
+	switch s {
+	case cqproto.ResourceFetchComplete:
+		return StatusComplete
+	case cqproto.ResourceFetchFailed:
+		return StatusFailed
+	case cqproto.ResourceFetchPartial:
+		return StatusPartial
+	case cqproto.ResourceFetchCanceled:
+		return StatusCanceled
+	default:
+		return Status(s.String())
+	}
+}
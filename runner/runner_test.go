@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/database/memory"
+	"github.com/cloudquery/cq-provider-sdk/provider"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Names []string `yaml:"names"`
+}
+
+func (testConfig) Example() string { return "" }
+
+type testClient struct {
+	names []string
+}
+
+func (c testClient) Logger() hclog.Logger { return hclog.NewNullLogger() }
+
+func testProvider() *provider.Provider {
+	return &provider.Provider{
+		Name:   "test",
+		Config: func() provider.Config { return &testConfig{} },
+		Configure: func(_ hclog.Logger, cfg interface{}) (schema.ClientMeta, diag.Diagnostics) {
+			return testClient{names: cfg.(*testConfig).Names}, nil
+		},
+		ResourceMap: map[string]*schema.Table{
+			"names": {
+				Name:    "test_names",
+				Columns: []schema.Column{{Name: "name", Type: schema.TypeString, Resolver: schema.PathResolver("Name")}},
+				Resolver: func(_ context.Context, meta schema.ClientMeta, _ *schema.Resource, res chan<- interface{}) error {
+					for _, n := range meta.(testClient).names {
+						res <- struct{ Name string }{n}
+					}
+					return nil
+				},
+			},
+			"failing": {
+				Name:    "test_failing",
+				Columns: []schema.Column{{Name: "name", Type: schema.TypeString}},
+				Resolver: func(context.Context, schema.ClientMeta, *schema.Resource, chan<- interface{}) error {
+					return errors.New("failed to list")
+				},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	storage := memory.New()
+	result, diags := Run(context.Background(), testProvider(), []byte("names: [a, b]"), storage, FetchOptions{Resources: []string{"*"}})
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	assert.Equal(t, StatusComplete, result.Resources["names"].Status)
+	assert.Equal(t, uint64(2), result.Resources["names"].ResourceCount)
+	assert.Equal(t, 2, storage.Table("test_names").Count())
+
+	assert.Equal(t, StatusPartial, result.Resources["failing"].Status)
+	assert.True(t, result.Diagnostics().HasErrors())
+}
+
+func TestRun_ConfigureFailed(t *testing.T) {
+	result, diags := Run(context.Background(), testProvider(), []byte("names: {"), memory.New(), FetchOptions{Resources: []string{"*"}})
+	assert.Nil(t, result)
+	assert.True(t, diags.HasErrors())
+}
+
+func TestRun_NoStorage(t *testing.T) {
+	_, diags := Run(context.Background(), testProvider(), nil, nil, FetchOptions{Resources: []string{"*"}})
+	assert.True(t, diags.HasErrors())
+}
@@ -0,0 +1,106 @@
+// Package scaffold generates the skeleton of a new provider project, with the layout the SDK expects: a main serving
+// the provider, the provider definition with the initial migration of its tables, a client with its config, an example
+// table, and the make targets generating the docs and migrations.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+const templatesDir = "templates"
+
+// renamedFiles maps template files to the generated file names that can't be embedded as is
+var renamedFiles = map[string]string{
+	"gitignore": ".gitignore",
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Options of the generated provider
+type Options struct {
+	// Name of the provider, i.e aws. Prefixes the names of the provider's tables.
+	Name string
+	// Module is the go module path of the provider, i.e github.com/cloudquery/cq-provider-aws
+	Module string
+	// Force overwrites existing files
+	Force bool
+}
+
+// Generate writes the provider skeleton to dir, creating it if needed. Generate fails if any of the files exists
+// unless Force is set.
+func Generate(dir string, opts Options) error {
+	if !validName.MatchString(opts.Name) {
+		return fmt.Errorf("invalid provider name %q, must be lower case letters, digits and underscores", opts.Name)
+	}
+	if opts.Module == "" {
+		return fmt.Errorf("missing provider module path")
+	}
+	files, err := render(opts)
+	if err != nil {
+		return err
+	}
+	if !opts.Force {
+		for name := range files {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return fmt.Errorf("file %s already exists", filepath.Join(dir, name))
+			}
+		}
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render executes all templates, returning the generated files by their path relative to the project root
+func render(opts Options) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := fs.WalkDir(templates, templatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := templates.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(path).Parse(string(data))
+		if err != nil {
+			return err
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, opts); err != nil {
+			return fmt.Errorf("failed to render %s: %w", path, err)
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(path, templatesDir+"/"), ".tmpl")
+		if renamed, ok := renamedFiles[name]; ok {
+			name = renamed
+		}
+		out := b.Bytes()
+		if strings.HasSuffix(name, ".go") {
+			if out, err = format.Source(out); err != nil {
+				return fmt.Errorf("failed to format %s: %w", name, err)
+			}
+		}
+		files[filepath.FromSlash(name)] = out
+		return nil
+	})
+	return files, err
+}
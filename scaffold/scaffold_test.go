@@ -0,0 +1,88 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/migration"
+	"github.com/cloudquery/cq-provider-sdk/provider/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildModule builds and vets the generated module, with the SDK replaced by the module under test
+func buildModule(t *testing.T, dir string) {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found, the generated provider isn't built")
+	}
+	sdkDir, err := filepath.Abs("..")
+	require.NoError(t, err)
+	f, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(f, "\nrequire github.com/cloudquery/cq-provider-sdk v0.0.0\n\nreplace github.com/cloudquery/cq-provider-sdk => %s\n", sdkDir)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	sum, err := os.ReadFile(filepath.Join(sdkDir, "go.sum"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0644))
+
+	for _, args := range [][]string{{"build", "./..."}, {"vet", "./..."}} {
+		cmd := exec.Command(goBin, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "go %s failed:\n%s", strings.Join(args, " "), out)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	opts := Options{Name: "example", Module: "github.com/cloudquery/cq-provider-example"}
+	require.NoError(t, Generate(dir, opts))
+
+	for _, f := range []string{
+		"main.go", "go.mod", "Makefile", ".gitignore", "client/client.go", "client/config.go", "resources/provider/provider.go",
+		"resources/services/demo_items.go", "docs/docs.go", "tools/migrations/main.go",
+		"resources/provider/migrations/postgres/1_v0.0.1.up.sql", "resources/provider/migrations/postgres/1_v0.0.1.down.sql",
+	} {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+	makefile, err := os.ReadFile(filepath.Join(dir, "Makefile"))
+	require.NoError(t, err)
+	assert.Contains(t, string(makefile), "gen-migrations:")
+
+	// the initial migration creates the example table as the provider defines it
+	demoTable := &schema.Table{
+		Name:    "example_demo_items",
+		Options: schema.TableCreationOptions{PrimaryKeys: []string{"id"}},
+		Columns: []schema.Column{{Name: "id", Type: schema.TypeString}, {Name: "name", Type: schema.TypeString}, {Name: "tags", Type: schema.TypeJSON}},
+	}
+	expectedUp, err := migration.CreateTableDefinitions(context.Background(), schema.PostgresDialect{}, demoTable, nil)
+	require.NoError(t, err)
+	up, err := os.ReadFile(filepath.Join(dir, "resources/provider/migrations/postgres/1_v0.0.1.up.sql"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(up), "\n"+strings.Join(expectedUp, "\n")+"\n"), string(up))
+
+	table, err := os.ReadFile(filepath.Join(dir, "resources/services/demo_items.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(table), `Name:        "example_demo_items"`)
+
+	// existing files aren't overwritten unless forced
+	assert.Error(t, Generate(dir, opts))
+	opts.Force = true
+	assert.NoError(t, Generate(dir, opts))
+
+	buildModule(t, dir)
+}
+
+func TestGenerateInvalidName(t *testing.T) {
+	assert.Error(t, Generate(t.TempDir(), Options{Name: "Bad-Name", Module: "example.com/bad"}))
+	assert.Error(t, Generate(t.TempDir(), Options{Name: "example"}))
+}
@@ -0,0 +1,67 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/provider"
+	"github.com/cloudquery/cq-provider-sdk/provider/diag"
+)
+
+// selfTestCommand is the argument that runs the provider's SelfTest instead of serving it
+const selfTestCommand = "selftest"
+
+// selfTestResult is a diagnostic returned by SelfTest, in a structured form CI can parse
+type selfTestResult struct {
+	Severity string `json:"severity"`
+	Type     string `json:"type"`
+	Resource string `json:"resource,omitempty"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// runSelfTest runs SelfTest with the given command line arguments, writes the diagnostics to out, one JSON object
+// per line, and returns the exit code of the command. Only a *provider.Provider can be self tested, other servers fail
+// with an error result.
+func runSelfTest(ctx context.Context, server cqproto.CQProviderServer, args []string, out io.Writer) int {
+	fs := flag.NewFlagSet(selfTestCommand, flag.ContinueOnError)
+	fs.SetOutput(out)
+	var opts provider.SelfTestOptions
+	fs.StringVar(&opts.DSN, "dsn", "", "DSN of an empty database to run the migrations against")
+	fs.BoolVar(&opts.Docker, "docker", false, "run the migrations against an ephemeral postgres docker container")
+	fs.StringVar(&opts.Image, "image", "", "postgres image of the docker container")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	enc := json.NewEncoder(out)
+	p, ok := server.(*provider.Provider)
+	if !ok {
+		_ = enc.Encode(selfTestResult{
+			Severity: diag.ERROR.String(),
+			Type:     diag.INTERNAL.String(),
+			Summary:  fmt.Sprintf("self test is only supported for *provider.Provider, got %T", server),
+		})
+		return 1
+	}
+	diags := p.SelfTest(ctx, opts)
+	for _, d := range diags {
+		desc := d.Description()
+		_ = enc.Encode(selfTestResult{
+			Severity: d.Severity().String(),
+			Type:     d.Type().String(),
+			Resource: desc.Resource,
+			Summary:  desc.Summary,
+			Detail:   desc.Detail,
+		})
+	}
+	if diags.HasErrors() {
+		return 1
+	}
+	fmt.Fprintf(out, "%s self test passed\n", p.Name)
+	return 0
+}
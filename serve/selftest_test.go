@@ -0,0 +1,27 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/stretchr/testify/assert"
+)
+
+// wrappedServer is a provider server that isn't a *provider.Provider
+type wrappedServer struct {
+	cqproto.CQProviderServer
+}
+
+func TestRunSelfTest_UnsupportedServer(t *testing.T) {
+	var out bytes.Buffer
+	code := runSelfTest(context.Background(), wrappedServer{}, nil, &out)
+	assert.Equal(t, 1, code)
+	assert.JSONEq(t, `{"severity":"Error","type":"Internal","summary":"self test is only supported for *provider.Provider, got serve.wrappedServer"}`, out.String())
+}
+
+func TestRunSelfTest_InvalidArgs(t *testing.T) {
+	var out bytes.Buffer
+	assert.Equal(t, 2, runSelfTest(context.Background(), wrappedServer{}, []string{"-unknown"}, &out))
+}
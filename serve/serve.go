@@ -7,10 +7,13 @@ import (
 	"os"
 
 	"github.com/cloudquery/cq-provider-sdk/cqproto"
+	"github.com/cloudquery/cq-provider-sdk/logging"
 	"github.com/cloudquery/cq-provider-sdk/provider"
 	"github.com/cloudquery/cq-provider-sdk/stats"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
@@ -24,11 +27,24 @@ type Options struct {
 	// Optional: Logger is the logger that go-plugin will use.
 	Logger hclog.Logger
 
+	// Optional: LogFormat is the format of the logs of the logger created if Logger isn't set, when the provider runs
+	// in debug mode and its logs aren't passed to CloudQuery. Defaults to the CQ_LOG_FORMAT environment variable, or
+	// JSON. Served providers always log JSON, which go-plugin parses to pass the log levels and fields to CloudQuery.
+	LogFormat logging.Format
+
 	// Optional: Set NoLogOutputOverride to not override the log output with an hclog
 	// adapter. This should only be used when running the plugin in
 	// acceptance tests.
 	NoLogOutputOverride bool
 
+	// Optional: Telemetry receives anonymized fetch telemetry (table names, counts, durations and error types).
+	// Telemetry is disabled unless a reporter is set here or on the provider.
+	Telemetry provider.TelemetryReporter
+
+	// Optional: MetricsAddr is the address to serve Prometheus metrics of the provider's fetches on, at the /metrics
+	// path, e.g. "127.0.0.1:9090". Useful for long-running providers, metrics aren't served if empty.
+	MetricsAddr string
+
 	// TestConfig should only be set when the provider is being tested; it
 	// will opt out of go-plugin's lifecycle management and other features,
 	// and will use the supplied configuration options to control the
@@ -66,16 +82,23 @@ func Serve(opts *Options) {
 				// pass it through another hclog.Logger on the client side where it can
 				// be filtered.
 				Level:      hclog.Debug,
-				JSONFormat: true,
+				JSONFormat: opts.jsonLogs(),
 				Name:       opts.Name,
 			})
 		}
+		if p.Telemetry == nil {
+			p.Telemetry = opts.Telemetry
+		}
 		// finally set opts logger to the provider's logger
 		if opts.Logger == nil {
 			opts.Logger = p.Logger
 		}
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == selfTestCommand {
+		os.Exit(runSelfTest(context.Background(), opts.Provider, os.Args[2:], os.Stdout))
+	}
+
 	// Check of CQ_PROVIDER_DEBUG is turned on. In case it's true the plugin is executed in debug mode, allowing for
 	// the CloudQuery main command to connect to this plugin via the .cq_reattach and the CQ_REATTACH_PROVIDERS env var
 	if provider.IsDebug() {
@@ -102,6 +125,26 @@ func Serve(opts *Options) {
 	serve(opts)
 }
 
+// tracerProvider returns the tracer provider of the served provider's gRPC spans, see provider.Provider.TracerProvider
+func tracerProvider(opts *Options) trace.TracerProvider {
+	if p, ok := opts.Provider.(*provider.Provider); ok && p.TracerProvider != nil {
+		return p.TracerProvider
+	}
+	return otel.GetTracerProvider()
+}
+
+// jsonLogs returns true if the created loggers should log JSON, which is required unless the provider is in debug mode
+func (opts *Options) jsonLogs() bool {
+	if !provider.IsDebug() {
+		return true
+	}
+	format := opts.LogFormat
+	if format == "" {
+		format = logging.FormatFromEnv(logging.FormatJSON)
+	}
+	return format == logging.FormatJSON
+}
+
 func serve(opts *Options) {
 	if !opts.NoLogOutputOverride {
 		// In order to allow go-plugin to correctly pass log-levels through to
@@ -122,10 +165,27 @@ func serve(opts *Options) {
 				"provider": &cqproto.CQPlugin{Impl: opts.Provider},
 			},
 		},
-		GRPCServer: func(opts []grpc.ServerOption) *grpc.Server {
-			return grpc.NewServer(opts...)
+		GRPCServer: func(serverOpts []grpc.ServerOption) *grpc.Server {
+			// metrics are served once the plugin is served, they're optional so failing to serve them doesn't stop it
+			go serveMetrics(opts)
+			return grpc.NewServer(append(serverOpts, cqproto.GRPCServerOptions(tracerProvider(opts))...)...)
 		},
 		Logger: opts.Logger,
 		Test:   opts.TestConfig,
 	})
 }
+
+// serveMetrics serves the metrics of the provider's fetches on opts.MetricsAddr, if set, until the test's context is
+// done. Failures are logged as warnings.
+func serveMetrics(opts *Options) {
+	if opts.MetricsAddr == "" {
+		return
+	}
+	ctx := context.Background()
+	if opts.TestConfig != nil && opts.TestConfig.Context != nil {
+		ctx = opts.TestConfig.Context
+	}
+	if err := stats.ServeMetrics(ctx, opts.MetricsAddr, opts.Logger); err != nil {
+		opts.Logger.Warn("failed to serve metrics, they won't be available", "addr", opts.MetricsAddr, "error", err)
+	}
+}
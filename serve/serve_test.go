@@ -0,0 +1,35 @@
+package serve
+
+import (
+	"testing"
+
+	"github.com/cloudquery/cq-provider-sdk/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_JSONLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		debug    string
+		env      string
+		format   logging.Format
+		expected bool
+	}{
+		// the logs are passed to cloudquery as JSON unless the provider runs on its own in debug mode
+		{name: "plugin", expected: true},
+		{name: "plugin ignores text env", env: "text", expected: true},
+		{name: "plugin ignores text option", format: logging.FormatText, expected: true},
+		{name: "debug defaults to json", debug: "1", expected: true},
+		{name: "debug text env", debug: "1", env: "text", expected: false},
+		{name: "debug text option", debug: "1", format: logging.FormatText, expected: false},
+		{name: "debug option overrides env", debug: "1", env: "text", format: logging.FormatJSON, expected: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("CQ_PROVIDER_DEBUG", tc.debug)
+			t.Setenv(logging.EnvLogFormat, tc.env)
+			opts := &Options{LogFormat: tc.format}
+			assert.Equal(t, tc.expected, opts.jsonLogs())
+		})
+	}
+}
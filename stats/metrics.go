@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/stats/v4"
+	"github.com/segmentio/stats/v4/prometheus"
+)
+
+// Metrics of fetches, reported to the registered handlers. Names are measure.field, exposed as measure_field by
+// ServeMetrics.
+const (
+	// MetricResourcesFetched counts the resources resolved by table
+	MetricResourcesFetched = "cq_resources.fetched"
+	// MetricWriteSeconds is the histogram of the latency of writes to the storage by table and method, copy_from or insert
+	MetricWriteSeconds = "cq_storage.write_seconds"
+	// MetricCopyFromFallbacks counts the CopyFrom writes that failed and fell back to inserts, by table
+	MetricCopyFromFallbacks = "cq_storage.copy_from_fallbacks"
+	// MetricResolverPanics counts the panics recovered from resolvers, by table
+	MetricResolverPanics = "cq_resolver.panics"
+	// MetricSemaphoreWaitSeconds is the histogram of the time clients waited for the fetch's goroutines, by table
+	MetricSemaphoreWaitSeconds = "cq_goroutines.wait_seconds"
+)
+
+// metricsShutdownTimeout bounds the shutdown of the metrics server once its context is done
+const metricsShutdownTimeout = 5 * time.Second
+
+// metricBuckets are the buckets of the histogram metrics, in seconds
+var metricBuckets = map[string][]interface{}{
+	MetricWriteSeconds:         {0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	MetricSemaphoreWaitSeconds: {0.01, 0.1, 1, 5, 10, 30, 60, 300, 900},
+}
+
+// AddResourcesFetched adds n to the resources fetched of the table
+func AddResourcesFetched(table string, n int) {
+	stats.Add(MetricResourcesFetched, n, stats.Tag{Name: "table", Value: table})
+}
+
+// ObserveWrite records the latency of a write of the table's resources with the method, copy_from or insert
+func ObserveWrite(table, method string, d time.Duration) {
+	stats.Observe(MetricWriteSeconds, d.Seconds(), stats.Tag{Name: "method", Value: method}, stats.Tag{Name: "table", Value: table})
+}
+
+// IncrCopyFromFallbacks records a CopyFrom of the table's resources that fell back to inserts
+func IncrCopyFromFallbacks(table string) {
+	stats.Incr(MetricCopyFromFallbacks, stats.Tag{Name: "table", Value: table})
+}
+
+// IncrResolverPanics records a panic recovered from a resolver of the table
+func IncrResolverPanics(table string) {
+	stats.Incr(MetricResolverPanics, stats.Tag{Name: "table", Value: table})
+}
+
+// ObserveSemaphoreWait records the time a client of the table waited for the fetch's goroutines
+func ObserveSemaphoreWait(table string, d time.Duration) {
+	stats.Observe(MetricSemaphoreWaitSeconds, d.Seconds(), stats.Tag{Name: "table", Value: table})
+}
+
+// ServeMetrics serves the metrics in the Prometheus format on addr's /metrics path, until ctx is done. Returns once the
+// listener is created, errors of the server after are logged.
+func ServeMetrics(ctx context.Context, addr string, logger hclog.Logger) error {
+	_, err := serveMetrics(ctx, addr, logger)
+	return err
+}
+
+// serveMetrics serves the metrics as ServeMetrics, returning the address of the listener
+func serveMetrics(ctx context.Context, addr string, logger hclog.Logger) (net.Addr, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	handler := newMetricsHandler()
+	stats.Register(handler)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		logger.Info("serving metrics", "addr", l.Addr().String())
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("metrics server failed", "error", err)
+		}
+	}()
+	return l.Addr(), nil
+}
+
+// newMetricsHandler creates a Prometheus handler of the metrics of the default engine. Measures are named with the
+// engine's prefix, so are the keys of the buckets.
+func newMetricsHandler() *prometheus.Handler {
+	prefix := stats.DefaultEngine.Prefix
+	buckets := stats.HistogramBuckets{}
+	for name, b := range metricBuckets {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		buckets.Set(name, b...)
+	}
+	return &prometheus.Handler{TrimPrefix: prefix, Buckets: buckets}
+}
@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/segmentio/stats/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	handler := newMetricsHandler()
+	stats.Register(handler)
+
+	AddResourcesFetched("test_table", 3)
+	AddResourcesFetched("test_table", 2)
+	ObserveWrite("test_table", "copy_from", 20*time.Millisecond)
+	IncrCopyFromFallbacks("test_table")
+	IncrResolverPanics("test_table")
+	ObserveSemaphoreWait("test_table", 2*time.Second)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `cq_resources_fetched{table="test_table"} 5`)
+	assert.Contains(t, body, `cq_storage_copy_from_fallbacks{table="test_table"} 1`)
+	assert.Contains(t, body, `cq_resolver_panics{table="test_table"} 1`)
+	assert.Contains(t, body, `cq_storage_write_seconds_count{method="copy_from",table="test_table"} 1`)
+	assert.Contains(t, body, `cq_goroutines_wait_seconds_bucket{table="test_table",le="5"} 1`)
+}
+
+func TestServeMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	addr, err := serveMetrics(ctx, "127.0.0.1:0", hclog.NewNullLogger())
+	require.NoError(t, err)
+	assert.Error(t, ServeMetrics(ctx, "invalid-addr", hclog.NewNullLogger()))
+	// the address is in use by the first server
+	assert.Error(t, ServeMetrics(ctx, addr.String(), hclog.NewNullLogger()))
+
+	AddResourcesFetched("served_table", 4)
+	resp, err := http.Get("http://" + addr.String() + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `cq_resources_fetched{table="served_table"} 4`)
+
+	// the server is shut down once the context is done
+	cancel()
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get("http://" + addr.String() + "/metrics")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -46,6 +46,10 @@ func (h *durationLogger) HandleMeasures(t time.Time, measures ...stats.Measure)
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	for _, m := range measures {
+		// only clocks are tracked, other measures are metrics
+		if m.Fields[0].Value.Type() != stats.Duration {
+			continue
+		}
 		id, stamp := getMeasurementDetails(m.Fields[0].Name, m.Tags)
 		if stamp {
 			item, ok := h.trackedOperations.Get(id)